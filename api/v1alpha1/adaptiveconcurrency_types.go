@@ -0,0 +1,119 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package v1alpha1
+
+import gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+// AdaptiveConcurrency defines the configuration of the adaptive concurrency limit.
+//
+// The concurrency limit of a route rule is adjusted dynamically by a gradient controller,
+// which compares the latency of the sampled requests with the ideal round-trip time (minRTT)
+// of the backends of the rule. The requests exceeding the concurrency limit are rejected.
+//
+// Envoy doesn't support per-route settings for adaptive concurrency, so an adaptive concurrency
+// filter is added to the listener for each route rule, and each rule has its own limit.
+// The limit isn't computed per backend: the backends of a rule share the same limit.
+//
+// +kubebuilder:validation:XValidation:rule="!has(self.concurrencyLimitExceededStatus) || self.concurrencyLimitExceededStatus >= 400",message="concurrencyLimitExceededStatus must be an error status code."
+type AdaptiveConcurrency struct {
+	// SampleAggregatePercentile is the percentile of the latencies of the sampled requests
+	// that is compared with the minRTT to compute the concurrency limit.
+	// Defaults to 50.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	SampleAggregatePercentile *int32 `json:"sampleAggregatePercentile,omitempty"`
+
+	// ConcurrencyLimit defines how the concurrency limit is updated.
+	//
+	// +optional
+	ConcurrencyLimit *AdaptiveConcurrencyLimit `json:"concurrencyLimit,omitempty"`
+
+	// MinRTT defines how the ideal round-trip time of the backend is calculated.
+	//
+	// +optional
+	MinRTT *AdaptiveConcurrencyMinRTT `json:"minRTT,omitempty"`
+
+	// ConcurrencyLimitExceededStatus is the status code of the response returned
+	// when a request is rejected because the concurrency limit is reached.
+	// Only the status codes known by Envoy are supported, e.g. 429, 500 or 503.
+	// Defaults to 503.
+	//
+	// +optional
+	ConcurrencyLimitExceededStatus *HTTPStatus `json:"concurrencyLimitExceededStatus,omitempty"`
+}
+
+// AdaptiveConcurrencyLimit defines how the adaptive concurrency limit is updated.
+type AdaptiveConcurrencyLimit struct {
+	// MaxConcurrencyLimit is the upper bound of the concurrency limit.
+	// Defaults to 1000.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4294967295
+	// +optional
+	MaxConcurrencyLimit *int64 `json:"maxConcurrencyLimit,omitempty"`
+
+	// UpdateInterval is the interval between two updates of the concurrency limit.
+	// Defaults to 100ms.
+	//
+	// +optional
+	UpdateInterval *gwapiv1.Duration `json:"updateInterval,omitempty"`
+}
+
+// AdaptiveConcurrencyMinRTT defines how the ideal round-trip time (minRTT) of the backend is calculated.
+//
+// The minRTT is measured periodically by temporarily lowering the concurrency limit
+// to MinConcurrency, and sampling the latencies of RequestCount requests.
+//
+// +kubebuilder:validation:XValidation:rule="!(has(self.interval) && has(self.fixedValue))",message="only one of interval or fixedValue can be set."
+type AdaptiveConcurrencyMinRTT struct {
+	// Interval is the time between two minRTT measurements.
+	// Defaults to 60s.
+	//
+	// +optional
+	Interval *gwapiv1.Duration `json:"interval,omitempty"`
+
+	// FixedValue is a fixed minRTT to use instead of measuring it periodically.
+	// When set, the minRTT isn't measured and RequestCount, Jitter and MinConcurrency are ignored.
+	// FixedValue cannot be set together with Interval.
+	//
+	// +optional
+	FixedValue *gwapiv1.Duration `json:"fixedValue,omitempty"`
+
+	// RequestCount is the number of requests sampled to measure the minRTT.
+	// Defaults to 50.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RequestCount *int32 `json:"requestCount,omitempty"`
+
+	// Jitter is a random delay added to the interval between two minRTT
+	// measurements, as a percentage of Interval. It prevents all the Envoy proxies
+	// from measuring the minRTT of the backend at the same time.
+	// Defaults to 15.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Jitter *int32 `json:"jitter,omitempty"`
+
+	// MinConcurrency is the concurrency limit applied while the minRTT is measured.
+	// Defaults to 3.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinConcurrency *int32 `json:"minConcurrency,omitempty"`
+
+	// Buffer is the tolerance added to the minRTT, as a percentage of the minRTT,
+	// before the sampled latencies are considered as degraded.
+	// Defaults to 25.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Buffer *int32 `json:"buffer,omitempty"`
+}
@@ -7,16 +7,53 @@ package v1alpha1
 
 import (
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 const APIKeysSecretKey = "credentials"
 
+// APIKeysExpireAtAnnotation is the annotation of a Secret referenced by CredentialRefs holding
+// the time the API keys of the Secret expire at, in the RFC 3339 format, e.g. "2026-01-01T00:00:00Z".
+// The expired API keys are removed from the configuration of the proxies.
+const APIKeysExpireAtAnnotation = "gateway.envoyproxy.io/api-keys-expire-at"
+
+const (
+	// PolicyConditionAPIKeysExpired indicates that API keys referenced by the policy have expired
+	// and are no longer accepted.
+	//
+	// Possible reasons for this condition to be True are:
+	//
+	// * "Expired"
+	//
+	PolicyConditionAPIKeysExpired gwapiv1a2.PolicyConditionType = "APIKeysExpired"
+
+	// PolicyReasonExpired is used with the "APIKeysExpired" condition when the API keys of a Secret
+	// referenced by the policy have expired.
+	PolicyReasonExpired gwapiv1a2.PolicyConditionReason = "Expired"
+)
+
 // APIKeyAuth defines the configuration for the API Key Authentication.
 type APIKeyAuth struct {
 	// CredentialRefs is the Kubernetes secret which contains the API keys.
 	// This is an Opaque secret.
 	// Each API key is stored in the key representing the client id.
 	// If the secrets have a key for a duplicated client, the first one will be used.
+	//
+	// The value of a key is either the API key, or a JSON object with the API key
+	// and its metadata, for example:
+	// {"key": "$2y$10$...", "tenant": "acme", "plan": "gold", "expiresAt": "2026-01-01T00:00:00Z"}.
+	// The API key is stored as plain text, or hashed with SHA ("{SHA}"), bcrypt
+	// ("$2a$", "$2b$" and "$2y$") or argon2 ("$argon2i$" and "$argon2id$").
+	// An API key is no longer accepted after its expiresAt time.
+	//
+	// Plain text API keys without metadata are verified by Envoy. The other API keys
+	// are verified by Envoy Gateway, which sets the client id, tenant and plan of the
+	// API key as the client_id, tenant and plan dynamic metadata of the request, in the
+	// "envoy.filters.http.ext_authz" namespace.
+	//
+	// The API keys of a secret with the "gateway.envoyproxy.io/api-keys-expire-at"
+	// annotation are no longer accepted after the time of the annotation, in the
+	// RFC 3339 format, and are reported in the APIKeysExpired condition.
 	CredentialRefs []gwapiv1.SecretObjectReference `json:"credentialRefs"`
 
 	// ExtractFrom is where to fetch the key from the coming request.
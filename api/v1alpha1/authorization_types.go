@@ -42,7 +42,7 @@ type AuthorizationRule struct {
 	// Action defines the action to be taken if the rule matches.
 	Action AuthorizationAction `json:"action"`
 
-	// Operation specifies the operation of a request, such as HTTP methods.
+	// Operation specifies the operation of a request, such as HTTP methods, paths, hosts and headers.
 	// If not specified, all operations are matched on.
 	//
 	// +optional
@@ -56,23 +56,66 @@ type AuthorizationRule struct {
 }
 
 // Operation specifies the operation of a request.
+// If multiple operation types are specified, all of them must match for the operation to match.
+// For example, if both methods and paths are specified, the operation will match only if
+// one of the methods and one of the paths match.
+//
+// +kubebuilder:validation:XValidation:rule="(has(self.methods) || has(self.paths) || has(self.hosts) || has(self.headers) || has(self.expression))",message="at least one of methods, paths, hosts, headers, or expression must be specified"
 type Operation struct {
 	// Methods are the HTTP methods of the request.
 	// If multiple methods are specified, all specified methods are allowed or denied, based on the action of the rule.
 	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Methods []gwapiv1.HTTPMethod `json:"methods,omitempty"`
+
+	// Paths are the path matches of the request, for example, an Exact match on "/login",
+	// a Prefix match on "/admin/", or a RegularExpression match on "/users/[0-9]+".
+	// The query string of the request is ignored.
+	// If multiple paths are specified, one of the paths must match.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Paths []StringMatch `json:"paths,omitempty"`
+
+	// Hosts are the hostnames of the request, matched against the Host header of HTTP/1.1
+	// requests and the :authority pseudo-header of HTTP/2 requests, ignoring the port.
+	// A hostname may be prefixed with a wildcard label (`*.`), which matches one or more labels,
+	// for example, "*.example.com" matches "foo.example.com" and "foo.bar.example.com",
+	// but not "example.com".
+	// If multiple hosts are specified, one of the hosts must match.
+	//
+	// +optional
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=16
-	Methods []gwapiv1.HTTPMethod `json:"methods"`
+	Hosts []gwapiv1.Hostname `json:"hosts,omitempty"`
 
-	// Other fields may be supported in the future, such as path or host.
+	// Headers are the header matches of the request.
+	// If multiple headers are specified, all headers must match.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Headers []gwapiv1.HTTPHeaderMatch `json:"headers,omitempty"`
+
+	// Expression is a CEL expression on the attributes of the request, for example,
+	// `request.path.startsWith('/api/') && request.size < 1024`.
+	// The operation matches if the expression evaluates to true. If the evaluation fails, for example,
+	// because a header used by the expression is missing, it doesn't match in an Allow rule,
+	// and matches in a Deny rule, so that the request is denied.
+	//
+	// +optional
+	Expression *CELExpression `json:"expression,omitempty"`
 }
 
 // Principal specifies the client identity of a request.
-// A client identity can be a client IP, a JWT claim, username from the Authorization header,
-// or any other identity that can be extracted from a custom header.
+// A client identity can be a client IP, a JWT claim, the client certificate of an mTLS connection,
+// username from the Authorization header, or any other identity that can be extracted from a custom header.
 // If there are multiple principal types, all principals must match for the rule to match.
 //
-// +kubebuilder:validation:XValidation:rule="(has(self.clientCIDRs) || has(self.jwt) || has(self.headers))",message="at least one of clientCIDRs, jwt, or headers must be specified"
+// +kubebuilder:validation:XValidation:rule="(has(self.clientCIDRs) || has(self.jwt) || has(self.headers) || has(self.clientCertificate) || has(self.expression))",message="at least one of clientCIDRs, jwt, headers, clientCertificate, or expression must be specified"
 type Principal struct {
 	// ClientCIDRs are the IP CIDR ranges of the client.
 	// Valid examples are "192.168.1.0/24" or "2001:db8::/64"
@@ -102,6 +145,77 @@ type Principal struct {
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=256
 	Headers []AuthorizationHeaderMatch `json:"headers,omitempty"`
+
+	// ClientCertificate authorize the request based on the client certificate of the mTLS connection.
+	// Note: in order to use the client certificate for authorization, you must configure the
+	// client validation in the `ClientTrafficPolicy` targeting the same listener.
+	// Requests without a verified client certificate don't match the principal.
+	//
+	// +optional
+	ClientCertificate *ClientCertificatePrincipal `json:"clientCertificate,omitempty"`
+
+	// Expression is a CEL expression on the attributes of the request and the connection, for example,
+	// `connection.subject_peer_certificate.startsWith('CN=admin')`.
+	// The principal matches if the expression evaluates to true. If the evaluation fails, for example,
+	// because a header used by the expression is missing, it doesn't match in an Allow rule,
+	// and matches in a Deny rule, so that the request is denied.
+	//
+	// +optional
+	Expression *CELExpression `json:"expression,omitempty"`
+}
+
+// ClientCertificatePrincipal specifies the client identity of a request based on the
+// verified client certificate of the mTLS connection.
+// Subjects, issuers, URIs, DNS names and emails are And-ed together if more than one is specified.
+//
+// Each SAN of the client certificate is matched separately. SAN matches must not contain a comma,
+// which Envoy uses to join the SANs of a type.
+//
+// +kubebuilder:validation:XValidation:rule="(has(self.subjects) || has(self.issuers) || has(self.uris) || has(self.dnsNames) || has(self.emails))",message="at least one of subjects, issuers, uris, dnsNames, or emails must be specified"
+type ClientCertificatePrincipal struct {
+	// Subjects are the matches of the subject distinguished name of the client certificate,
+	// in the RFC 2253 format, for example, "CN=client,OU=payments,O=example".
+	// If multiple subjects are specified, one of the subjects must match.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Subjects []StringMatch `json:"subjects,omitempty"`
+
+	// Issuers are the matches of the issuer distinguished name of the client certificate,
+	// in the RFC 2253 format, for example, "CN=example-ca,O=example".
+	// If multiple issuers are specified, one of the issuers must match.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Issuers []StringMatch `json:"issuers,omitempty"`
+
+	// URIs are the matches of the URI SANs of the client certificate, such as SPIFFE IDs,
+	// for example, an Exact match on "spiffe://cluster.local/ns/default/sa/client",
+	// or a Prefix match on "spiffe://cluster.local/ns/default/".
+	// If multiple URIs are specified, one of the URIs must match one of the URI SANs.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	URIs []StringMatch `json:"uris,omitempty"`
+
+	// DNSNames are the matches of the DNS SANs of the client certificate.
+	// If multiple DNS names are specified, one of the DNS names must match one of the DNS SANs.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	DNSNames []StringMatch `json:"dnsNames,omitempty"`
+
+	// Emails are the matches of the email address SANs of the client certificate.
+	// If multiple emails are specified, one of the emails must match one of the email SANs.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Emails []StringMatch `json:"emails,omitempty"`
 }
 
 // AuthorizationHeaderMatch specifies how to match against the value of an HTTP header within a authorization rule.
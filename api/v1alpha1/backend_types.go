@@ -82,6 +82,16 @@ type BackendEndpoint struct {
 	//
 	// +optional
 	Zone *string `json:"zone,omitempty"`
+
+	// Region defines the region of the backend endpoint.
+	//
+	// +optional
+	Region *string `json:"region,omitempty"`
+
+	// SubZone defines the sub-zone of the backend endpoint.
+	//
+	// +optional
+	SubZone *string `json:"subZone,omitempty"`
 }
 
 // IPEndpoint describes TCP/UDP socket address, corresponding to Envoy's Socket Address
@@ -107,6 +107,27 @@ type BackendTrafficPolicySpec struct {
 	//
 	// +optional
 	Telemetry *BackendTelemetry `json:"telemetry,omitempty"`
+
+	// CredentialInjection defines the configuration to inject a credential into the requests
+	// forwarded to the backends.
+	// A credential injection configured with an HTTPRouteFilter on the route rule takes precedence.
+	//
+	// +optional
+	CredentialInjection *HTTPCredentialInjectionFilter `json:"credentialInjection,omitempty"`
+
+	// AdaptiveConcurrency enables a concurrency limit that is adjusted dynamically based
+	// on the latency of the requests, instead of the static thresholds of the circuit breaker.
+	// A separate limit is computed for each route rule, and shared by all the backends of the rule.
+	//
+	// +optional
+	AdaptiveConcurrency *AdaptiveConcurrency `json:"adaptiveConcurrency,omitempty"`
+
+	// Failover defines an ordered failover group over the backends of the route,
+	// the traffic fails over to the next priority level when the backends of a
+	// priority level become unhealthy.
+	//
+	// +optional
+	Failover *Failover `json:"failover,omitempty"`
 }
 
 type BackendTelemetry struct {
@@ -9,9 +9,14 @@ import (
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
-const BasicAuthUsersSecretKey = ".htpasswd"
+const (
+	BasicAuthUsersSecretKey   = ".htpasswd"
+	LDAPBindPasswordSecretKey = "password"
+)
 
 // BasicAuth defines the configuration for 	the HTTP Basic Authentication.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.users) != has(self.ldap)",message="exactly one of users or ldap must be specified"
 type BasicAuth struct {
 	// The Kubernetes secret which contains the username-password pairs in
 	// htpasswd format, used to verify user credentials in the "Authorization"
@@ -20,12 +25,33 @@ type BasicAuth struct {
 	// This is an Opaque secret. The username-password pairs should be stored in
 	// the key ".htpasswd". As the key name indicates, the value needs to be the
 	// htpasswd format, for example: "user1:{SHA}hashed_user1_password".
-	// Right now, only SHA hash algorithm is supported.
+	// The SHA, bcrypt ("$2a$", "$2b$" and "$2y$") and argon2 ("$argon2i$" and
+	// "$argon2id$") hash algorithms are supported.
 	// Reference to https://httpd.apache.org/docs/2.4/programs/htpasswd.html
 	// for more details.
 	//
+	// SHA passwords are verified by Envoy. bcrypt and argon2 passwords are
+	// verified by Envoy Gateway, which Envoy calls for each request with
+	// credentials that were not verified successfully in the last minute.
+	//
+	// Only one of Users or LDAP can be specified.
+	//
 	// Note: The secret must be in the same namespace as the SecurityPolicy.
-	Users gwapiv1.SecretObjectReference `json:"users"`
+	//
+	// +optional
+	Users gwapiv1.SecretObjectReference `json:"users,omitzero"`
+
+	// LDAP is the LDAP directory used to verify the user credentials, instead
+	// of a htpasswd file.
+	//
+	// The credentials are verified by Envoy Gateway, which Envoy calls for each
+	// request with credentials that were not verified successfully in the last
+	// minute.
+	//
+	// Only one of Users or LDAP can be specified.
+	//
+	// +optional
+	LDAP *LDAPUserStore `json:"ldap,omitempty"`
 
 	// This field specifies the header name to forward a successfully authenticated user to
 	// the backend. The header will be added to the request with the username as the value.
@@ -35,3 +61,71 @@ type BasicAuth struct {
 	// +optional
 	ForwardUsernameHeader *string `json:"forwardUsernameHeader,omitempty"`
 }
+
+// LDAPUserStore defines an LDAP directory used to verify the user credentials.
+//
+// The user is searched with the bind DN and password, and the credentials are
+// verified by binding with the distinguished name of the user found and the
+// password in the "Authorization" header.
+//
+// +kubebuilder:validation:XValidation:rule="self.url.startsWith('ldaps://') || (has(self.startTLS) && self.startTLS)",message="the connection to the LDAP server must use TLS: an ldaps:// url or startTLS must be specified"
+type LDAPUserStore struct {
+	// URL is the URL of the LDAP server, e.g. "ldaps://ldap.example.com:636".
+	//
+	// An ldap:// server requires StartTLS, so that the credentials are never
+	// sent in clear text.
+	//
+	// +kubebuilder:validation:Pattern=`^ldaps?://[^/?#]+$`
+	URL string `json:"url"`
+
+	// StartTLS upgrades the connection to an ldap:// server to TLS with the
+	// StartTLS operation, before any credentials are sent.
+	//
+	// +optional
+	StartTLS *bool `json:"startTLS,omitempty"`
+
+	// CACertificateRefs contains the references to the Kubernetes objects that
+	// contain the certificates of the Certificate Authorities used to verify the
+	// certificate of the LDAP server.
+	//
+	// References to a Kubernetes ConfigMap or a Kubernetes Secret, with the CA
+	// certificate in a key named `ca.crt`, are supported.
+	//
+	// If not specified, the certificate of the LDAP server is verified with the
+	// trusted CA certificates of Envoy Gateway.
+	//
+	// Note: The ConfigMaps and Secrets must be in the same namespace as the
+	// SecurityPolicy.
+	//
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	CACertificateRefs []gwapiv1.SecretObjectReference `json:"caCertificateRefs,omitempty"`
+
+	// BindDN is the distinguished name used to search for the users, e.g.
+	// "cn=envoy-gateway,ou=services,dc=example,dc=com".
+	//
+	// +kubebuilder:validation:MinLength=1
+	BindDN string `json:"bindDN"`
+
+	// BindPassword is the Kubernetes secret which contains the password of the
+	// bind DN in the key "password".
+	//
+	// Note: The secret must be in the same namespace as the SecurityPolicy.
+	BindPassword gwapiv1.SecretObjectReference `json:"bindPassword"`
+
+	// BaseDN is the distinguished name the users are searched under, e.g.
+	// "ou=users,dc=example,dc=com".
+	//
+	// +kubebuilder:validation:MinLength=1
+	BaseDN string `json:"baseDN"`
+
+	// SearchFilter is the LDAP filter used to search for the user, where "%s"
+	// is replaced by the escaped username from the "Authorization" header.
+	// The filter must match exactly one entry for the user to be authenticated.
+	//
+	// If not specified, defaults to "(uid=%s)".
+	//
+	// +kubebuilder:validation:XValidation:rule="self.contains('%s')",message="searchFilter must contain %s"
+	// +optional
+	SearchFilter *string `json:"searchFilter,omitempty"`
+}
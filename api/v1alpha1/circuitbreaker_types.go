@@ -39,6 +39,15 @@ type CircuitBreaker struct {
 	// +optional
 	MaxParallelRetries *int64 `json:"maxParallelRetries,omitempty"`
 
+	// RetryBudget limits the number of parallel retries to a percentage of the
+	// active requests to the referenced backend defined within a xRoute rule.
+	// Unlike MaxParallelRetries, the limit scales with the traffic, which prevents
+	// retries from amplifying the load on the backend during partial outages.
+	// If set, RetryBudget takes precedence over MaxParallelRetries.
+	//
+	// +optional
+	RetryBudget *RetryBudget `json:"retryBudget,omitempty"`
+
 	// The maximum number of requests that Envoy will make over a single connection to the referenced backend defined within a xRoute rule.
 	// Default: unlimited.
 	//
@@ -63,3 +72,25 @@ type PerEndpointCircuitBreakers struct {
 	// +optional
 	MaxConnections *int64 `json:"maxConnections,omitempty"`
 }
+
+// RetryBudget defines the maximum number of parallel retries as a percentage of
+// the active requests.
+type RetryBudget struct {
+	// Percent is the percentage of the active requests (pending and in-flight)
+	// that can be retries at the same time.
+	// Defaults to 20.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Percent *int32 `json:"percent,omitempty"`
+
+	// MinRetryConcurrency is the minimum number of parallel retries that are
+	// allowed regardless of the number of active requests.
+	// Defaults to 3.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4294967295
+	// +optional
+	MinRetryConcurrency *int64 `json:"minRetryConcurrency,omitempty"`
+}
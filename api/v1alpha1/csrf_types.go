@@ -0,0 +1,39 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package v1alpha1
+
+import gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+// CSRF defines the configuration for Cross-Site Request Forgery (CSRF) protection.
+//
+// Requests with a mutating method (POST, PUT, DELETE and PATCH) are rejected with
+// a 403 if the origin of the request, taken from the Origin header or the Referer
+// header if Origin is absent, matches neither the destination host nor one of
+// the additional origins.
+type CSRF struct {
+	// AdditionalOrigins defines the origins that are allowed to make requests in
+	// addition to the destination origin.
+	// Only the host and the port of the origins are compared, the scheme is ignored.
+	//
+	// +optional
+	AdditionalOrigins []Origin `json:"additionalOrigins,omitempty"`
+
+	// FilterEnabled defines the fraction of requests for which the CSRF protection
+	// is enforced.
+	// If not specified, the CSRF protection is enforced for all requests.
+	//
+	// +optional
+	FilterEnabled *gwapiv1.Fraction `json:"filterEnabled,omitempty"`
+
+	// ShadowEnabled defines the fraction of requests for which the CSRF protection
+	// is evaluated and tracked in the statistics, but not enforced.
+	// Shadow evaluation only takes place for the requests for which the protection
+	// is not enforced. Setting FilterEnabled to zero and ShadowEnabled to 100%
+	// allows validating the additional origins before enforcing the protection.
+	//
+	// +optional
+	ShadowEnabled *gwapiv1.Fraction `json:"shadowEnabled,omitempty"`
+}
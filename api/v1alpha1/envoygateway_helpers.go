@@ -313,6 +313,22 @@ func (r *EnvoyGatewayProvider) GetEnvoyGatewayKubeProvider() *EnvoyGatewayKubern
 	return r.Kubernetes
 }
 
+// GetPrefix returns the key prefix of the resources, or the default one if unset.
+func (r *EnvoyGatewayKVResourceProvider) GetPrefix() string {
+	if r.Prefix != nil {
+		return *r.Prefix
+	}
+	return DefaultKVResourcePrefix
+}
+
+// GetStatusPrefix returns the key prefix of the status, or the default one if unset.
+func (r *EnvoyGatewayKVResourceProvider) GetStatusPrefix() string {
+	if r.StatusPrefix != nil {
+		return *r.StatusPrefix
+	}
+	return DefaultKVStatusPrefix
+}
+
 func (r *EnvoyGatewayProvider) IsRunningOnKubernetes() bool {
 	return r.Type == ProviderTypeKubernetes
 }
@@ -377,17 +377,20 @@ type EnvoyGatewayCustomProvider struct {
 
 // ResourceProviderType defines the types of custom resource providers supported by Envoy Gateway.
 //
-// +kubebuilder:validation:Enum=File
+// +kubebuilder:validation:Enum=File;KV
 type ResourceProviderType string
 
 const (
 	// ResourceProviderTypeFile defines the "File" provider.
 	ResourceProviderTypeFile ResourceProviderType = "File"
+
+	// ResourceProviderTypeKV defines the "KV" provider.
+	ResourceProviderTypeKV ResourceProviderType = "KV"
 )
 
 // EnvoyGatewayResourceProvider defines configuration for the Custom Resource provider.
 type EnvoyGatewayResourceProvider struct {
-	// Type is the type of resource provider to use. Supported types are "File".
+	// Type is the type of resource provider to use. Supported types are "File" and "KV".
 	//
 	// +unionDiscriminator
 	Type ResourceProviderType `json:"type"`
@@ -396,13 +399,82 @@ type EnvoyGatewayResourceProvider struct {
 	//
 	// +optional
 	File *EnvoyGatewayFileResourceProvider `json:"file,omitempty"`
+	// KV defines the configuration of the KV provider. KV provides runtime
+	// configuration stored in a key/value store that implements the etcd v3 API.
+	//
+	// +optional
+	KV *EnvoyGatewayKVResourceProvider `json:"kv,omitempty"`
 }
 
 // EnvoyGatewayFileResourceProvider defines configuration for the File Resource provider.
 type EnvoyGatewayFileResourceProvider struct {
 	// Paths are the paths to a directory or file containing the resource configuration.
-	// Recursive subdirectories are not currently supported.
+	// Subdirectories are only watched when Recursive is enabled.
 	Paths []string `json:"paths"`
+	// Recursive indicates whether the subdirectories of the directories in Paths
+	// are watched recursively. Hidden subdirectories are always ignored.
+	// Defaults to false.
+	//
+	// +optional
+	Recursive *bool `json:"recursive,omitempty"`
+	// Include is a list of glob patterns matched against the name of the files
+	// under the watched directories. Only the files that match any of the patterns
+	// are loaded. If unspecified, all the non-hidden files are loaded.
+	//
+	// +optional
+	Include []string `json:"include,omitempty"`
+	// Exclude is a list of glob patterns matched against the name of the files
+	// under the watched directories. The files that match any of the patterns are
+	// not loaded. Exclude takes precedence over Include.
+	//
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// EnvoyGatewayKVResourceProvider defines configuration for the KV Resource provider.
+type EnvoyGatewayKVResourceProvider struct {
+	// Endpoints are the client endpoints of the key/value store, e.g. "http://127.0.0.1:2379".
+	//
+	// +kubebuilder:validation:MinItems=1
+	Endpoints []string `json:"endpoints"`
+	// Prefix is the key prefix under which the resource configuration is stored.
+	// Every key under the prefix holds one or more YAML documents of Gateway API
+	// and Envoy Gateway resources.
+	// Defaults to "/envoy-gateway/resources/".
+	//
+	// +optional
+	Prefix *string `json:"prefix,omitempty"`
+	// StatusPrefix is the key prefix under which the status of the resources is written back.
+	// The status of a resource is stored under "<StatusPrefix><Kind>/<Namespace>/<Name>".
+	// Defaults to "/envoy-gateway/status/".
+	//
+	// +optional
+	StatusPrefix *string `json:"statusPrefix,omitempty"`
+	// DialTimeout is the timeout for establishing a connection to the key/value store.
+	// Defaults to 5s.
+	//
+	// +optional
+	DialTimeout *gwapiv1.Duration `json:"dialTimeout,omitempty"`
+	// TLS defines the TLS settings used to connect to the key/value store.
+	//
+	// +optional
+	TLS *KVTLSSettings `json:"tls,omitempty"`
+}
+
+// KVTLSSettings defines the TLS settings used by the KV Resource provider.
+type KVTLSSettings struct {
+	// CAFile is the path to the CA certificate bundle used to verify the key/value store.
+	//
+	// +optional
+	CAFile *string `json:"caFile,omitempty"`
+	// CertFile is the path to the client certificate used for mTLS.
+	//
+	// +optional
+	CertFile *string `json:"certFile,omitempty"`
+	// KeyFile is the path to the client private key used for mTLS.
+	//
+	// +optional
+	KeyFile *string `json:"keyFile,omitempty"`
 }
 
 // InfrastructureProviderType defines the types of custom infrastructure providers supported by Envoy Gateway.
@@ -430,7 +502,66 @@ type EnvoyGatewayInfrastructureProvider struct {
 
 // EnvoyGatewayHostInfrastructureProvider defines configuration for the Host Infrastructure provider.
 type EnvoyGatewayHostInfrastructureProvider struct {
-	// TODO: Add config as use cases are better understood.
+	// EnvoyVersion is the version of Envoy to download and run, e.g. "1.35.0".
+	// If unspecified, the latest version known by func-e is used.
+	//
+	// +optional
+	EnvoyVersion *string `json:"envoyVersion,omitempty"`
+	// EnvoyPath is the path to a local Envoy binary to run.
+	// If specified, no Envoy binary is downloaded and EnvoyVersion must be unset.
+	//
+	// +optional
+	EnvoyPath *string `json:"envoyPath,omitempty"`
+	// WorkingDir is the directory where the Envoy binaries are downloaded, and
+	// the Envoy processes are run from. The certificates of the Envoy and rate limit
+	// processes are read from its "certs" subdirectory, which is created by
+	// "envoy-gateway certgen --local" with the same configuration file.
+	// Defaults to "/tmp/envoy-gateway".
+	//
+	// +optional
+	WorkingDir *string `json:"workingDir,omitempty"`
+	// LogFile is the path to the file the Envoy processes write their logs to.
+	// If unspecified, the logs are written to the standard output of Envoy Gateway.
+	//
+	// +optional
+	LogFile *string `json:"logFile,omitempty"`
+	// Admin defines the address the Envoy admin server listens on.
+	// If unspecified, the admin server listens on a random port of the loopback address.
+	//
+	// +optional
+	Admin *HostServerAddress `json:"admin,omitempty"`
+	// Stats defines the address the Envoy Prometheus stats server listens on.
+	// Prometheus stats are only exposed when both Admin and Stats are specified,
+	// and Prometheus is not disabled by the EnvoyProxy telemetry settings.
+	//
+	// Since every Envoy process binds the same addresses, only one Envoy process
+	// can be run when Admin or Stats is specified.
+	//
+	// +optional
+	Stats *HostServerAddress `json:"stats,omitempty"`
+	// RateLimitPath is the path to a local binary of the Envoy rate limit service,
+	// which is run when global rate limiting is enabled with a Redis or Memcached backend.
+	// If unspecified, the "ratelimit" binary is looked up in the PATH.
+	//
+	// With the Local backend, no rate limit process is run since the rate limit
+	// service is served by Envoy Gateway itself.
+	//
+	// +optional
+	RateLimitPath *string `json:"rateLimitPath,omitempty"`
+}
+
+// HostServerAddress defines the address a server of the host Envoy process listens on.
+type HostServerAddress struct {
+	// Host defines the IP address the server binds to.
+	// Defaults to "127.0.0.1" for the admin server, and "0.0.0.0" for the stats server.
+	//
+	// +optional
+	Host *string `json:"host,omitempty"`
+	// Port defines the port the server listens on.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
 }
 
 // RateLimit defines the configuration associated with the Rate Limit Service
@@ -511,6 +642,10 @@ type RateLimitTracingProvider struct {
 type RateLimitDatabaseBackend struct {
 	// Type is the type of database backend to use. Supported types are:
 	//	* Redis: Connects to a Redis database.
+	//	* Memcached: Connects to Memcached servers.
+	//	* Local: Stores the rate limit counters in the memory of Envoy Gateway, which
+	//	  serves the rate limit service itself. The counters are not shared between
+	//	  Envoy Gateway replicas, and are reset when Envoy Gateway restarts.
 	//
 	// +unionDiscriminator
 	Type RateLimitDatabaseBackendType `json:"type"`
@@ -518,16 +653,24 @@ type RateLimitDatabaseBackend struct {
 	//
 	// +optional
 	Redis *RateLimitRedisSettings `json:"redis,omitempty"`
+	// Memcached defines the settings needed to connect to Memcached servers.
+	//
+	// +optional
+	Memcached *RateLimitMemcachedSettings `json:"memcached,omitempty"`
 }
 
 // RateLimitDatabaseBackendType specifies the types of database backend
 // to be used by the rate limit service.
-// +kubebuilder:validation:Enum=Redis
+// +kubebuilder:validation:Enum=Redis;Memcached;Local
 type RateLimitDatabaseBackendType string
 
 const (
 	// RedisBackendType uses a redis database for the rate limit service.
 	RedisBackendType RateLimitDatabaseBackendType = "Redis"
+	// MemcachedBackendType uses memcached servers for the rate limit service.
+	MemcachedBackendType RateLimitDatabaseBackendType = "Memcached"
+	// LocalBackendType stores the rate limit counters in the memory of the rate limit service.
+	LocalBackendType RateLimitDatabaseBackendType = "Local"
 )
 
 // RedisTLSSettings defines the TLS configuration for connecting to redis database.
@@ -540,14 +683,175 @@ type RedisTLSSettings struct {
 
 // RateLimitRedisSettings defines the configuration for connecting to redis database.
 type RateLimitRedisSettings struct {
-	// URL of the Redis Database.
-	// This can reference a single Redis host or a comma delimited list for Sentinel and Cluster deployments of Redis.
-	URL string `json:"url"`
+	// URL of the Redis Database, in the host:port format.
+	// This can reference a single Redis host or, when Type is unset, a comma delimited list
+	// for Sentinel and Cluster deployments of Redis. Prefer the Sentinel and Cluster fields
+	// for these deployments.
+	//
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// Type is the topology of the Redis deployment. Supported types are:
+	//	* Single: A single Redis host, set with the URL field.
+	//	* Sentinel: A Redis Sentinel deployment, set with the Sentinel field.
+	//	* Cluster: A Redis Cluster deployment, set with the Cluster field.
+	// Defaults to Single.
+	//
+	// +optional
+	Type *RedisType `json:"type,omitempty"`
+
+	// Sentinel defines the settings of a Redis Sentinel deployment.
+	// It must be set when Type is Sentinel.
+	//
+	// +optional
+	Sentinel *RedisSentinelSettings `json:"sentinel,omitempty"`
+
+	// Cluster defines the settings of a Redis Cluster deployment.
+	// It must be set when Type is Cluster.
+	//
+	// +optional
+	Cluster *RedisClusterSettings `json:"cluster,omitempty"`
+
+	// PasswordRef references a Secret holding the password used to authenticate to Redis,
+	// in the "password" key. The Secret must be in the same namespace as Envoy Gateway.
+	//
+	// +optional
+	PasswordRef *gwapiv1.SecretObjectReference `json:"passwordRef,omitempty"`
 
 	// TLS defines TLS configuration for connecting to redis database.
 	//
 	// +optional
 	TLS *RedisTLSSettings `json:"tls,omitempty"`
+
+	// PoolSize is the number of connections kept to each Redis host.
+	// If unspecified, the rate limit service keeps 10 connections.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PoolSize *int32 `json:"poolSize,omitempty"`
+
+	// PipelineWindow is the duration after which the buffered commands are flushed to Redis.
+	// Commands are not buffered if unspecified, unless PipelineLimit is set.
+	//
+	// +optional
+	PipelineWindow *gwapiv1.Duration `json:"pipelineWindow,omitempty"`
+
+	// PipelineLimit is the maximum number of buffered commands, after which they are flushed to Redis.
+	// Commands are not buffered if unspecified, unless PipelineWindow is set.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PipelineLimit *int32 `json:"pipelineLimit,omitempty"`
+
+	// PerSecond defines a separate Redis deployment to store the counters of the
+	// rate limits with a unit of a second. Those counters expire quickly and are
+	// hit more often, so keeping them apart reduces the load on the main Redis.
+	//
+	// +optional
+	PerSecond *RedisPerSecondSettings `json:"perSecond,omitempty"`
+}
+
+// RedisType specifies the topology of a Redis deployment.
+// +kubebuilder:validation:Enum=Single;Sentinel;Cluster
+type RedisType string
+
+const (
+	// RedisTypeSingle is a single Redis host.
+	RedisTypeSingle RedisType = "Single"
+	// RedisTypeSentinel is a Redis Sentinel deployment.
+	RedisTypeSentinel RedisType = "Sentinel"
+	// RedisTypeCluster is a Redis Cluster deployment.
+	RedisTypeCluster RedisType = "Cluster"
+)
+
+// RedisSentinelSettings defines the settings of a Redis Sentinel deployment.
+type RedisSentinelSettings struct {
+	// MasterName is the name of the Redis master monitored by the Sentinels.
+	//
+	// +kubebuilder:validation:MinLength=1
+	MasterName string `json:"masterName"`
+
+	// URLs of the Sentinels, in the host:port format.
+	//
+	// +kubebuilder:validation:MinItems=1
+	URLs []string `json:"urls"`
+}
+
+// RedisClusterSettings defines the settings of a Redis Cluster deployment.
+type RedisClusterSettings struct {
+	// URLs of the Redis Cluster nodes used to discover the cluster, in the host:port format.
+	//
+	// +kubebuilder:validation:MinItems=1
+	URLs []string `json:"urls"`
+}
+
+// RedisPerSecondSettings defines the Redis deployment storing the counters
+// of the rate limits with a unit of a second.
+type RedisPerSecondSettings struct {
+	// URL of the Redis Database, in the host:port format.
+	// It must be set when Type is Single.
+	//
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// Type is the topology of the Redis deployment.
+	// Defaults to Single.
+	//
+	// +optional
+	Type *RedisType `json:"type,omitempty"`
+
+	// Sentinel defines the settings of a Redis Sentinel deployment.
+	// It must be set when Type is Sentinel.
+	//
+	// +optional
+	Sentinel *RedisSentinelSettings `json:"sentinel,omitempty"`
+
+	// Cluster defines the settings of a Redis Cluster deployment.
+	// It must be set when Type is Cluster.
+	//
+	// +optional
+	Cluster *RedisClusterSettings `json:"cluster,omitempty"`
+
+	// PasswordRef references a Secret holding the password used to authenticate to Redis,
+	// in the "password" key. The Secret must be in the same namespace as Envoy Gateway.
+	//
+	// +optional
+	PasswordRef *gwapiv1.SecretObjectReference `json:"passwordRef,omitempty"`
+
+	// PoolSize is the number of connections kept to each Redis host.
+	// If unspecified, the rate limit service keeps 10 connections.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PoolSize *int32 `json:"poolSize,omitempty"`
+}
+
+// MemcachedTLSSettings defines the TLS configuration for connecting to memcached servers.
+type MemcachedTLSSettings struct {
+	// CertificateRef defines the client certificate reference for TLS connections.
+	// Currently only a Kubernetes Secret of type TLS is supported.
+	// +optional
+	CertificateRef *gwapiv1.SecretObjectReference `json:"certificateRef,omitempty"`
+}
+
+// RateLimitMemcachedSettings defines the configuration for connecting to memcached servers.
+type RateLimitMemcachedSettings struct {
+	// URLs of the Memcached servers, in the host:port format.
+	//
+	// +kubebuilder:validation:MinItems=1
+	URLs []string `json:"urls"`
+
+	// TLS defines TLS configuration for connecting to memcached servers.
+	//
+	// +optional
+	TLS *MemcachedTLSSettings `json:"tls,omitempty"`
+
+	// MaxIdleConns is the maximum number of idle connections kept to each Memcached server.
+	// If unspecified, the rate limit service keeps 2 idle connections.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxIdleConns *int32 `json:"maxIdleConns,omitempty"`
 }
 
 // ExtensionManager defines the configuration for registering an extension manager to
@@ -0,0 +1,82 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package v1alpha1
+
+import gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+// Failover defines an ordered failover group over the backends of a route.
+//
+// Each backend of the route is assigned to a priority level. The traffic is sent to the backends
+// of the highest priority level, and spills over to the next priority level when the health of
+// the higher priority levels drops. The health of a priority level is the ratio of its healthy hosts,
+// multiplied by the OverprovisioningFactor.
+//
+// It is highly recommended to configure active or passive health checks, so that the unhealthy
+// hosts are detected.
+//
+// The priority levels are the priorities of the endpoints of the cluster of the route, so the failover
+// is rejected when the route needs a cluster for each backendRef: when its backends have different
+// address types, when its backendRefs have filters, when zoneAware preferLocal or localityWeighted is
+// set, or when it has several backendRefs and one of their Services uses topology aware routing.
+type Failover struct {
+	// Priorities is the list of priority levels, ordered from the highest to the lowest priority.
+	// The backends of the route that aren't listed in any priority level are assigned to
+	// an additional priority level, below the listed ones.
+	// Failover takes precedence over the fallback setting of the Backend resources.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	Priorities []FailoverPriority `json:"priorities"`
+
+	// OverprovisioningFactor is the percentage applied to the ratio of healthy hosts of
+	// a priority level to compute its health. A priority level receives all the traffic
+	// as long as its health is 100%.
+	// For example, with the default of 140, the traffic starts spilling over to the next priority
+	// level when less than 72% of the hosts of a priority level are healthy.
+	// The factor applies to all the priority levels, as Envoy only supports a single
+	// overprovisioning factor per cluster.
+	// Defaults to 140.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	OverprovisioningFactor *uint32 `json:"overprovisioningFactor,omitempty"`
+
+	// Failback defines how the health of a priority level is computed, which decides how
+	// the traffic is moved back to a priority level once its backends recover.
+	// Defaults to HealthyHosts.
+	//
+	// +optional
+	Failback *FailbackMode `json:"failback,omitempty"`
+}
+
+// FailoverPriority defines a priority level of a Failover group.
+type FailoverPriority struct {
+	// BackendRefs lists the backends of the route that belong to this priority level.
+	// The namespace defaults to the namespace of the route, and the kind to Service.
+	// If the port is set, only the backendRef of the route with the same port matches.
+	// A backendRef that doesn't match any backendRef of the target routes is reported
+	// in the Accepted condition of the policy.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	BackendRefs []gwapiv1.BackendObjectReference `json:"backendRefs"`
+}
+
+// FailbackMode defines how the health of a priority level is computed.
+//
+// +kubebuilder:validation:Enum=HealthyHosts;HealthyWeight
+type FailbackMode string
+
+const (
+	// FailbackModeHealthyHosts computes the health of a priority level from its number of healthy hosts,
+	// the traffic is moved back to a priority level in proportion to its recovered hosts.
+	FailbackModeHealthyHosts FailbackMode = "HealthyHosts"
+
+	// FailbackModeHealthyWeight computes the health of a priority level from the load balancing weight
+	// of its healthy hosts, so that the recovery of a backend with a higher weight moves back
+	// more traffic to the priority level.
+	FailbackModeHealthyWeight FailbackMode = "HealthyWeight"
+)
@@ -30,6 +30,8 @@ type HealthCheck struct {
 
 // PassiveHealthCheck defines the configuration for passive health checks in the context of Envoy's Outlier Detection,
 // see https://www.envoyproxy.io/docs/envoy/latest/intro/arch_overview/upstream/outlier
+//
+// +kubebuilder:validation:XValidation:rule="!has(self.maxEjectionTime) || duration(self.maxEjectionTime) >= duration(has(self.baseEjectionTime) ? self.baseEjectionTime : '30s')",message="maxEjectionTime must be greater than or equal to baseEjectionTime."
 type PassiveHealthCheck struct {
 	// SplitExternalLocalOriginErrors enables splitting of errors between external and local origin.
 	//
@@ -73,6 +75,106 @@ type PassiveHealthCheck struct {
 	// +kubebuilder:default=10
 	// +optional
 	MaxEjectionPercent *int32 `json:"maxEjectionPercent,omitempty"`
+
+	// MaxEjectionTime defines the maximum duration for which a host can be ejected.
+	// The ejection time of a host grows with the number of times it has been ejected,
+	// and is capped by this value. It must not be lower than BaseEjectionTime.
+	// Defaults to 300s.
+	//
+	// +optional
+	MaxEjectionTime *gwapiv1.Duration `json:"maxEjectionTime,omitempty"`
+
+	// MaxEjectionTimeJitter defines a random duration added to the ejection time of a host,
+	// so that ejected hosts are not brought back into the load balancing pool at the same time.
+	// Defaults to 0s.
+	//
+	// +optional
+	MaxEjectionTimeJitter *gwapiv1.Duration `json:"maxEjectionTimeJitter,omitempty"`
+
+	// SuccessRate configures the ejection of hosts whose success rate is statistically
+	// lower than the success rate of the other hosts of the cluster.
+	//
+	// +optional
+	SuccessRate *PassiveHealthCheckSuccessRate `json:"successRate,omitempty"`
+
+	// FailurePercentage configures the ejection of hosts whose percentage of failed
+	// requests exceeds a fixed threshold.
+	//
+	// +optional
+	FailurePercentage *PassiveHealthCheckFailurePercentage `json:"failurePercentage,omitempty"`
+}
+
+// PassiveHealthCheckSuccessRate defines the configuration of the success rate outlier detection.
+//
+// A host is ejected when its success rate is lower than the mean success rate of the cluster
+// minus StdevFactor times the standard deviation of the success rates of the cluster.
+type PassiveHealthCheckSuccessRate struct {
+	// MinimumHosts is the minimum number of hosts with enough request volume
+	// required to perform the success rate outlier detection.
+	// Defaults to 5.
+	//
+	// +optional
+	MinimumHosts *uint32 `json:"minimumHosts,omitempty"`
+
+	// RequestVolume is the minimum number of requests a host must receive during
+	// an interval to be included in the success rate outlier detection.
+	// Defaults to 100.
+	//
+	// +optional
+	RequestVolume *uint32 `json:"requestVolume,omitempty"`
+
+	// StdevFactor is the factor, multiplied by 1000, applied to the standard deviation
+	// of the success rates to compute the ejection threshold. For example, 1900 means 1.9.
+	// Defaults to 1900.
+	//
+	// +optional
+	StdevFactor *uint32 `json:"stdevFactor,omitempty"`
+
+	// EnforcingPercent is the probability that a host detected as an outlier
+	// by its success rate is actually ejected.
+	// Defaults to 100.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	EnforcingPercent *uint32 `json:"enforcingPercent,omitempty"`
+}
+
+// PassiveHealthCheckFailurePercentage defines the configuration of the failure percentage outlier detection.
+//
+// A host is ejected when the percentage of its requests that failed during an interval
+// is greater than or equal to Threshold.
+type PassiveHealthCheckFailurePercentage struct {
+	// Threshold is the failure percentage of a host above which it is ejected.
+	// Defaults to 85.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Threshold *uint32 `json:"threshold,omitempty"`
+
+	// MinimumHosts is the minimum number of hosts required in the cluster
+	// to perform the failure percentage outlier detection.
+	// Defaults to 5.
+	//
+	// +optional
+	MinimumHosts *uint32 `json:"minimumHosts,omitempty"`
+
+	// RequestVolume is the minimum number of requests a host must receive during
+	// an interval to be included in the failure percentage outlier detection.
+	// Defaults to 50.
+	//
+	// +optional
+	RequestVolume *uint32 `json:"requestVolume,omitempty"`
+
+	// EnforcingPercent is the probability that a host detected as an outlier
+	// by its failure percentage is actually ejected.
+	// Defaults to 100.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	EnforcingPercent *uint32 `json:"enforcingPercent,omitempty"`
 }
 
 // ActiveHealthCheck defines the active health check configuration.
@@ -16,6 +16,10 @@ const (
 
 	// InjectedCredentialKey is the key in the secret where the injected credential is stored.
 	InjectedCredentialKey = "credential"
+
+	// InjectedCredentialClientSecretKey is the key in the secret where the OAuth2 client secret
+	// of an injected credential is stored.
+	InjectedCredentialClientSecretKey = "client-secret"
 )
 
 // +kubebuilder:object:root=true
@@ -150,6 +154,8 @@ type HTTPHostnameModifier struct {
 // This is useful when the backend service requires credentials in the request, and the original
 // request does not contain them. The filter can inject credentials into the request before forwarding
 // it to the backend service.
+//
+// +kubebuilder:validation:XValidation:rule="!has(self.header) || !has(self.credential.oauth2)",message="header cannot be set with an oauth2 credential, the access token is always injected into the Authorization header"
 // +notImplementedHide
 type HTTPCredentialInjectionFilter struct {
 	// Header is the name of the header where the credentials are injected.
@@ -167,6 +173,8 @@ type HTTPCredentialInjectionFilter struct {
 }
 
 // InjectedCredential defines the credential to be injected.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.valueRef) != has(self.oauth2)",message="exactly one of valueRef or oauth2 must be set"
 // +notImplementedHide
 type InjectedCredential struct {
 	// ValueRef is a reference to the secret containing the credentials to be injected.
@@ -175,9 +183,72 @@ type InjectedCredential struct {
 	// For example, for basic authentication, the value should be "Basic <base64 encoded username:password>".
 	// for bearer token, the value should be "Bearer <token>".
 	// Note: The secret must be in the same namespace as the HTTPRouteFilter.
-	ValueRef gwapiv1.SecretObjectReference `json:"valueRef"`
+	// ValueRef is required unless OAuth2 is set.
+	//
+	// +optional
+	ValueRef gwapiv1.SecretObjectReference `json:"valueRef,omitzero"`
+
+	// OAuth2 configures Envoy to retrieve an access token from an OAuth2 token endpoint
+	// with the [Client Credentials Grant](https://datatracker.ietf.org/doc/html/rfc6749#section-4.4) flow,
+	// and to inject it into the Authorization header as a bearer token.
+	//
+	// The access token is cached by Envoy and refreshed before it expires.
+	// Requests are rejected with a 401 response while the access token can't be retrieved.
+	//
+	// +optional
+	OAuth2 *OAuth2ClientCredentials `json:"oauth2,omitempty"`
+}
+
+// OAuth2ClientAuthenticationMethod defines how the client authenticates to the OAuth2 token endpoint.
+// +kubebuilder:validation:Enum=ClientSecretBasic;ClientSecretPost
+type OAuth2ClientAuthenticationMethod string
+
+const (
+	// OAuth2ClientSecretBasic sends the client ID and secret in the Authorization header
+	// with the HTTP Basic authentication scheme.
+	OAuth2ClientSecretBasic OAuth2ClientAuthenticationMethod = "ClientSecretBasic"
+
+	// OAuth2ClientSecretPost sends the client ID and secret in the request body.
+	OAuth2ClientSecretPost OAuth2ClientAuthenticationMethod = "ClientSecretPost"
+)
+
+// OAuth2ClientCredentials defines the configuration to retrieve an access token with the
+// OAuth2 Client Credentials Grant flow.
+type OAuth2ClientCredentials struct {
+	// TokenEndpoint is the URL of the OAuth2 token endpoint, for example, "https://oauth.example.com/token".
+	// The host of the token endpoint must be a domain name, which is resolved with DNS.
+	// The certificate of an HTTPS token endpoint is validated against the system trust store.
+	//
+	// +kubebuilder:validation:MinLength=1
+	TokenEndpoint string `json:"tokenEndpoint"`
+
+	// ClientID is the client ID used to authenticate to the token endpoint.
+	//
+	// +kubebuilder:validation:MinLength=1
+	ClientID string `json:"clientID"`
+
+	// ClientSecret is a reference to the secret containing the client secret used to
+	// authenticate to the token endpoint.
+	// This is an Opaque secret. The client secret should be stored in the key "client-secret".
+	// Note: The secret must be in the same namespace as the referencing resource.
+	ClientSecret gwapiv1.SecretObjectReference `json:"clientSecret"`
+
+	// Scopes are the scopes requested for the access token.
+	//
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
 
-	// EG may support more credential types in the future, for example, OAuth2 access token retrieved by Client Credentials Grant flow.
+	// ClientAuthentication is the method to send the client credentials to the token endpoint.
+	// If not specified, ClientSecretBasic is used.
+	//
+	// +optional
+	ClientAuthentication *OAuth2ClientAuthenticationMethod `json:"clientAuthentication,omitempty"`
+
+	// TokenFetchRetryInterval is the interval between retries when the access token can't be retrieved.
+	// It must be at least 1 second. If not specified, Envoy retries every 2 seconds.
+	//
+	// +optional
+	TokenFetchRetryInterval *gwapiv1.Duration `json:"tokenFetchRetryInterval,omitempty"`
 }
 
 //+kubebuilder:object:root=true
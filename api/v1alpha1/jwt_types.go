@@ -108,6 +108,45 @@ type RemoteJWKS struct {
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=253
 	URI string `json:"uri"`
+
+	// CacheDuration is the duration for which the fetched JWKS is cached before it is fetched again.
+	// If not specified, defaults to 5 minutes.
+	//
+	// +optional
+	CacheDuration *gwapiv1.Duration `json:"cacheDuration,omitempty"`
+
+	// Timeout defines the timeout for the requests to fetch the JWKS.
+	// If not specified, defaults to 10 seconds.
+	//
+	// +optional
+	Timeout *gwapiv1.Duration `json:"timeout,omitempty"`
+
+	// AsyncFetch defines how the JWKS is fetched and refreshed in the background.
+	// The JWKS is always fetched asynchronously, independently of the requests being authenticated.
+	//
+	// The retry policy of the fetch requests can be specified in the BackendSettings.
+	//
+	// +optional
+	AsyncFetch *JWKSAsyncFetch `json:"asyncFetch,omitempty"`
+}
+
+// JWKSAsyncFetch defines how a remote JWKS is fetched and refreshed in the background.
+type JWKSAsyncFetch struct {
+	// FastListener allows the listener to be activated without waiting for the
+	// initial JWKS fetch to complete. Requests that arrive before the JWKS has been
+	// fetched fail the JWT authentication.
+	//
+	// If not specified, defaults to false, and the listener is activated after the initial fetch completes.
+	//
+	// +optional
+	FastListener *bool `json:"fastListener,omitempty"`
+
+	// FailedRefetchDuration is the interval at which the JWKS is fetched again after a failed fetch.
+	// The last successfully fetched JWKS remains in use until a fetch succeeds.
+	// If not specified, defaults to 1 second.
+	//
+	// +optional
+	FailedRefetchDuration *gwapiv1.Duration `json:"failedRefetchDuration,omitempty"`
 }
 
 // LocalJWKSType defines the types of values for Local JWKS.
@@ -151,11 +151,81 @@ type SlowStart struct {
 }
 
 // ZoneAware defines the configuration related to the distribution of requests between locality zones.
+//
+// +kubebuilder:validation:XValidation:rule="!(has(self.preferLocal) && has(self.localityWeighted))",message="only one of preferLocal or localityWeighted can be set."
 type ZoneAware struct {
 	// PreferLocalZone configures zone-aware routing to prefer sending traffic to the local locality zone.
 	//
 	// +optional
 	PreferLocal *PreferLocalZone `json:"preferLocal,omitempty"`
+
+	// LocalityWeighted configures the distribution of requests between the localities
+	// (region, zone and sub-zone) of the endpoints according to their weights.
+	// It cannot be set together with PreferLocal.
+	//
+	// +optional
+	LocalityWeighted *LocalityWeighted `json:"localityWeighted,omitempty"`
+}
+
+// LocalityWeighted configures the distribution of requests between the localities of the endpoints.
+//
+// The endpoints of a backend are grouped by locality, and the requests are distributed between
+// the localities in proportion to their weights. The locality of an endpoint is taken from the
+// region, zone and subZone fields of the Backend endpoints, or from the zone of the EndpointSlice
+// endpoints of a Service and the topology.kubernetes.io/region label of their node.
+//
+// The localities are ordered into priority levels, so LocalityWeighted is not applied to the
+// routes with a fallback Backend.
+type LocalityWeighted struct {
+	// Weights defines the weights of the localities. A locality uses the weight of
+	// the first entry that matches it. The localities that don't match any entry are
+	// weighted by their number of endpoints.
+	//
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	Weights []LocalityWeight `json:"weights,omitempty"`
+
+	// Origin is the locality of the gateway. The localities of the endpoints are ordered into
+	// priority levels by their distance to the origin: the localities in the same region and
+	// zone first, then the localities in the same region, and then the other regions.
+	// The requests fail over to the next priority level when the endpoints of the closer
+	// localities become unhealthy.
+	//
+	// Defaults to the locality of each Envoy proxy, which is taken from the
+	// topology.kubernetes.io/region and topology.kubernetes.io/zone labels of its node.
+	//
+	// +optional
+	Origin *Locality `json:"origin,omitempty"`
+}
+
+// Locality identifies a locality of the endpoints. An unset field matches any value.
+type Locality struct {
+	// Region is the region of the locality, for example the topology.kubernetes.io/region
+	// label of the nodes.
+	//
+	// +optional
+	Region *string `json:"region,omitempty"`
+
+	// Zone is the zone of the locality, for example the topology.kubernetes.io/zone
+	// label of the nodes.
+	//
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// SubZone is the sub-zone of the locality.
+	//
+	// +optional
+	SubZone *string `json:"subZone,omitempty"`
+}
+
+// LocalityWeight defines the weight of the localities matching a Locality.
+type LocalityWeight struct {
+	Locality `json:",inline"`
+
+	// Weight is the relative weight of the matching localities.
+	//
+	// +kubebuilder:validation:Minimum=1
+	Weight uint32 `json:"weight"`
 }
 
 // PreferLocalZone configures zone-aware routing to prefer sending traffic to the local locality zone.
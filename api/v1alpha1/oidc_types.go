@@ -139,6 +139,56 @@ type OIDC struct {
 	// If not specified, defaults to false.
 	// +optional
 	PassThroughAuthHeader *bool `json:"passThroughAuthHeader,omitempty"`
+
+	// ClaimToHeaders is a list of ID token claims that are copied into HTTP request
+	// headers for authenticated requests, so the backend can consume the identity
+	// of the logged-in user without parsing the ID token itself.
+	//
+	// The ID token is validated against the provider's JSON Web Key Set before its
+	// claims are extracted. The JWKS URI is discovered from the provider's
+	// [Well-Known Configuration Endpoint](https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfigurationResponse)
+	// if jwksURI is not specified in the provider.
+	//
+	// The claim headers sent by the clients are removed from all the requests received
+	// by the listener, so that they can't be spoofed.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	ClaimToHeaders []ClaimToHeader `json:"claimToHeaders,omitempty"`
+
+	// UserInfoToHeaders is a list of claims of the OIDC Provider's
+	// [UserInfo response](https://openid.net/specs/openid-connect-core-1_0.html#UserInfoResponse)
+	// that are copied into HTTP request headers for authenticated requests.
+	//
+	// Envoy Gateway queries the UserInfo endpoint with the access token of the session,
+	// and caches the response for up to one minute. The UserInfo endpoint is discovered
+	// from the provider's [Well-Known Configuration Endpoint](https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfigurationResponse)
+	// if userInfoEndpoint is not specified in the provider.
+	//
+	// The claim headers sent by the clients are removed from all the requests received
+	// by the listener, so that they can't be spoofed.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	UserInfoToHeaders []ClaimToHeader `json:"userInfoToHeaders,omitempty"`
+
+	// BackchannelLogoutPath is the path the OIDC Provider sends the
+	// [back-channel logout](https://openid.net/specs/openid-connect-backchannel-1_0.html)
+	// requests to. It must be registered as the back-channel logout URI of the client
+	// in the OIDC Provider.
+	//
+	// Envoy Gateway validates the logout tokens with the provider's JSON Web Key Set, and
+	// ends the sessions they identify: the following requests of these sessions are
+	// redirected to the logout path, which clears the credential cookies.
+	// The ended sessions are held in the memory of the Envoy Gateway replica the proxy
+	// receiving the logout request is connected to.
+	//
+	// If not specified, back-channel logout is disabled.
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^/`
+	BackchannelLogoutPath *string `json:"backchannelLogoutPath,omitempty"`
 }
 
 // OIDCProvider defines the OIDC Provider configuration.
@@ -185,6 +235,49 @@ type OIDCProvider struct {
 	// EG will also try to discover the end session endpoint from the provider's [Well-Known Configuration Endpoint](https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfigurationResponse) when authorizationEndpoint or tokenEndpoint is not provided.
 	// +optional
 	EndSessionEndpoint *string `json:"endSessionEndpoint,omitempty"`
+
+	// The OIDC Provider's [JSON Web Key Set](https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata)
+	// URI, used to validate the ID token when claimToHeaders is set, and the logout tokens
+	// when backchannelLogoutPath is set.
+	// If not provided, EG will try to discover it from the provider's [Well-Known Configuration Endpoint](https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfigurationResponse).
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	JWKSURI *string `json:"jwksURI,omitempty"`
+
+	// The OIDC Provider's [UserInfo endpoint](https://openid.net/specs/openid-connect-core-1_0.html#UserInfo),
+	// used when userInfoToHeaders is set.
+	// If not provided, EG will try to discover it from the provider's [Well-Known Configuration Endpoint](https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfigurationResponse).
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	UserInfoEndpoint *string `json:"userInfoEndpoint,omitempty"`
+
+	// Introspection enables the introspection of the access tokens issued by the OIDC
+	// Provider, so that the sessions whose access token is no longer active, for example
+	// because it was revoked, are ended.
+	//
+	// If not specified, the access tokens are not introspected.
+	//
+	// +optional
+	Introspection *OIDCIntrospection `json:"introspection,omitempty"`
+}
+
+// OIDCIntrospection defines the introspection of the access tokens with the
+// [OAuth 2.0 Token Introspection](https://datatracker.ietf.org/doc/html/rfc7662) endpoint
+// of the OIDC Provider.
+//
+// Envoy Gateway introspects the access token of each session with the client credentials,
+// and caches an active result for up to one minute. The requests of the sessions whose
+// access token isn't active are redirected to the logout path, which clears the
+// credential cookies.
+type OIDCIntrospection struct {
+	// The OIDC Provider's introspection endpoint.
+	// If not provided, EG will try to discover it from the provider's [Well-Known Configuration Endpoint](https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfigurationResponse).
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Endpoint *string `json:"endpoint,omitempty"`
 }
 
 // OIDCDenyRedirect defines headers to match against the request to deny redirect to the OIDC Provider.
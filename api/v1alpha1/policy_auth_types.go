@@ -0,0 +1,56 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package v1alpha1
+
+import (
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// PolicyAuthType defines the languages of the policies supported by the policy authorization.
+// +kubebuilder:validation:Enum=CEL
+type PolicyAuthType string
+
+const (
+	// PolicyAuthTypeCEL defines the "CEL" policy language.
+	PolicyAuthTypeCEL PolicyAuthType = "CEL"
+)
+
+// PolicyAuth defines the configuration for the authorization with a policy, which is compiled by
+// Envoy Gateway and evaluated by Envoy for every request, without any external authorization service.
+//
+// The policy is a CEL expression which must evaluate to a bool. The request is allowed if the expression
+// evaluates to true, and denied with a 403 response otherwise, including when the evaluation fails,
+// for example, because a header used by the expression is missing.
+// The expression has access to the attributes of the request and the connection provided by Envoy,
+// for example, `request.headers`, `request.path`, `request.method`, and `connection.subject_peer_certificate`,
+// as well as the JWT claims in `metadata.filter_metadata['envoy.filters.http.jwt_authn']`, keyed by the JWT provider name.
+// See https://www.envoyproxy.io/docs/envoy/latest/intro/arch_overview/advanced/attributes for all the attributes.
+//
+// If Authorization is also specified in the same SecurityPolicy, the request must be allowed by both.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.inline) != has(self.valueRef)",message="exactly one of inline or valueRef must be set"
+type PolicyAuth struct {
+	// Type is the language of the policy.
+	// Only CEL is supported for now.
+	//
+	// +kubebuilder:default=CEL
+	// +optional
+	Type *PolicyAuthType `json:"type,omitempty"`
+
+	// Inline contains the policy as an inline string.
+	//
+	// +optional
+	Inline *CELExpression `json:"inline,omitempty"`
+
+	// ValueRef is a reference to a local ConfigMap that contains the policy.
+	//
+	// The value of key `policy` in the ConfigMap will be used.
+	// If the key is not found, the first value in the ConfigMap will be used.
+	//
+	// +kubebuilder:validation:XValidation:rule="self.kind == 'ConfigMap' && (self.group == 'v1' || self.group == '')",message="Only a reference to an object of kind ConfigMap belonging to default v1 API group is supported."
+	// +optional
+	ValueRef *gwapiv1.LocalObjectReference `json:"valueRef,omitempty"`
+}
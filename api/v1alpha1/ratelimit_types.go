@@ -5,6 +5,8 @@
 
 package v1alpha1
 
+import gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
 // RateLimitSpec defines the desired state of RateLimitSpec.
 // +union
 type RateLimitSpec struct {
@@ -22,6 +24,18 @@ type RateLimitSpec struct {
 	//
 	// +optional
 	Local *LocalRateLimit `json:"local,omitempty"`
+
+	// ShadowMode evaluates the rate limits without enforcing them. The matching
+	// requests are still counted towards the limits, and the rate limit headers
+	// and stats are reported as usual, but the requests exceeding the limits are
+	// not rejected. This is useful to try out new limits on live traffic before
+	// enforcing them.
+	//
+	// With Global rate limits, it can be overridden for each rule by the
+	// rule ShadowMode field.
+	//
+	// +optional
+	ShadowMode *bool `json:"shadowMode,omitempty"`
 }
 
 // RateLimitType specifies the types of RateLimiting.
@@ -63,6 +77,7 @@ type LocalRateLimit struct {
 	// +optional
 	// +kubebuilder:validation:MaxItems=16
 	// +kubebuilder:validation:XValidation:rule="self.all(foo, !has(foo.cost) || !has(foo.cost.response))", message="response cost is not supported for Local Rate Limits"
+	// +kubebuilder:validation:XValidation:rule="self.all(foo, !has(foo.shadowMode))", message="shadowMode is not supported for Local Rate Limit rules, set it on the rateLimit instead"
 	Rules []RateLimitRule `json:"rules"`
 }
 
@@ -105,8 +120,17 @@ type RateLimitRule struct {
 	//
 	// +optional
 	Shared *bool `json:"shared,omitempty"`
+	// ShadowMode evaluates the limit of this rule without enforcing it: the matching
+	// requests are counted towards the limit, but the requests exceeding it are not
+	// rejected. It overrides the ShadowMode of the rateLimit for this rule.
+	//
+	// Currently, this is only supported for Global Rate Limits.
+	//
+	// +optional
+	ShadowMode *bool `json:"shadowMode,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="!has(self.request) || !has(self.request.header)",message="header is only supported for the response cost"
 type RateLimitCost struct {
 	// Request specifies the number to reduce the rate limit counters
 	// on the request path. If this is not specified, the default behavior
@@ -116,6 +140,8 @@ type RateLimitCost struct {
 	// rate limit counters by the specified number. If the counter doesn't have
 	// enough capacity, the request is rate limited.
 	//
+	// The Header source is not supported for the request cost.
+	//
 	// +optional
 	Request *RateLimitCostSpecifier `json:"request,omitempty"`
 	// Response specifies the number to reduce the rate limit counters
@@ -137,8 +163,10 @@ type RateLimitCost struct {
 // RateLimitCostSpecifier specifies where the Envoy retrieves the number to reduce the rate limit counters.
 //
 // +kubebuilder:validation:XValidation:rule="!(has(self.number) && has(self.metadata))",message="only one of number or metadata can be specified"
+// +kubebuilder:validation:XValidation:rule="!(has(self.header) && (has(self.number) || has(self.metadata)))",message="header cannot be specified with number or metadata"
+// +kubebuilder:validation:XValidation:rule="self.from != 'Header' || has(self.header)",message="header must be specified when from is Header"
 type RateLimitCostSpecifier struct {
-	// From specifies where to get the rate limit cost. Currently, only "Number" and "Metadata" are supported.
+	// From specifies where to get the rate limit cost. Currently, only "Number", "Metadata" and "Header" are supported.
 	//
 	// +kubebuilder:validation:Required
 	From RateLimitCostFrom `json:"from"`
@@ -151,12 +179,17 @@ type RateLimitCostSpecifier struct {
 	//
 	// +optional
 	Metadata *RateLimitCostMetadata `json:"metadata,omitempty"`
+	// Header specifies the response header to retrieve the usage number from.
+	// It is only supported for the response cost.
+	//
+	// +optional
+	Header *RateLimitCostHeader `json:"header,omitempty"`
 }
 
 // RateLimitCostFrom specifies the source of the rate limit cost.
-// Valid RateLimitCostType values are "Number" and "Metadata".
+// Valid RateLimitCostType values are "Number", "Metadata" and "Header".
 //
-// +kubebuilder:validation:Enum=Number;Metadata
+// +kubebuilder:validation:Enum=Number;Metadata;Header
 type RateLimitCostFrom string
 
 const (
@@ -164,7 +197,9 @@ const (
 	RateLimitCostFromNumber RateLimitCostFrom = "Number"
 	// RateLimitCostFromMetadata specifies the rate limit cost to be retrieved from the per-request dynamic metadata.
 	RateLimitCostFromMetadata RateLimitCostFrom = "Metadata"
-	// TODO: add headers, etc. Anything that can be represented in "Format" can be added here.
+	// RateLimitCostFromHeader specifies the rate limit cost to be retrieved from a response header.
+	RateLimitCostFromHeader RateLimitCostFrom = "Header"
+	// TODO: add request headers, etc. Anything that can be represented in "Format" can be added here.
 	// 	https://www.envoyproxy.io/docs/envoy/latest/configuration/observability/access_log/usage#config-access-log-format
 )
 
@@ -180,23 +215,85 @@ type RateLimitCostMetadata struct {
 	Key string `json:"key"`
 }
 
+// RateLimitCostHeader specifies the response header to retrieve the usage number from.
+type RateLimitCostHeader struct {
+	// Name is the name of the response header, for example "x-usage-tokens".
+	//
+	// The value of the header must be a non-negative integer.
+	// The responses without the header, or with a value that is not such an integer,
+	// don't reduce the rate limit counters.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9!#$&'*+\-.^_|~]+$`
+	Name string `json:"name"`
+	// Max is the maximum number a single response can reduce the rate limit counters by.
+	// The greater values of the header are capped to it, so that a backend can't exhaust
+	// the rate limit budget of a client with a single response.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000000000
+	Max uint64 `json:"max"`
+}
+
 // RateLimitSelectCondition specifies the attributes within the traffic flow that can
 // be used to select a subset of clients to be ratelimited.
 // All the individual conditions must hold True for the overall condition to hold True.
 type RateLimitSelectCondition struct {
 	// Headers is a list of request headers to match. Multiple header values are ANDed together,
 	// meaning, a request MUST match all the specified headers.
-	// At least one of headers or sourceCIDR condition must be specified.
+	// At least one condition must be specified.
 	//
 	// +optional
 	// +kubebuilder:validation:MaxItems=16
 	Headers []HeaderMatch `json:"headers,omitempty"`
 
+	// QueryParams is a list of request query parameters to match. Multiple query parameters
+	// are ANDed together, meaning, a request MUST match all the specified query parameters.
+	// At least one condition must be specified.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	QueryParams []QueryParamMatch `json:"queryParams,omitempty"`
+
+	// JWTClaims is a list of claims of the JWT of the request to match. Multiple claims
+	// are ANDed together, meaning, a request MUST match all the specified claims.
+	// The JWT must be validated by the JWT authentication of a SecurityPolicy targeting
+	// the same route, and the claims must have string values.
+	// At least one condition must be specified.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	JWTClaims []JWTClaimMatch `json:"jwtClaims,omitempty"`
+
+	// Path is the request path to match, with the same semantics as the path match of
+	// an HTTPRoute rule. The query string of the request is ignored.
+	// At least one condition must be specified.
+	//
+	// +optional
+	Path *gwapiv1.HTTPPathMatch `json:"path,omitempty"`
+
+	// Methods is a list of HTTP methods to match. A request MUST match one of the specified methods.
+	// At least one condition must be specified.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=9
+	Methods []gwapiv1.HTTPMethod `json:"methods,omitempty"`
+
 	// SourceCIDR is the client IP Address range to match on.
-	// At least one of headers or sourceCIDR condition must be specified.
+	// At least one condition must be specified.
 	//
 	// +optional
 	SourceCIDR *SourceMatch `json:"sourceCIDR,omitempty"`
+
+	// Expression is a CEL expression on the attributes of the request, for example,
+	// `request.headers['x-tier'] == 'free' && request.method == 'POST'`.
+	// The condition holds if the expression evaluates to true, and the request is not
+	// rate limited by the rule if the evaluation fails.
+	// At least one condition must be specified.
+	//
+	// +optional
+	Expression *CELExpression `json:"expression,omitempty"`
 }
 
 // +kubebuilder:validation:Enum=Exact;Distinct
@@ -257,6 +354,112 @@ type HeaderMatch struct {
 	Invert *bool `json:"invert,omitempty"`
 }
 
+// QueryParamMatch defines the match attributes within the query parameters of the request.
+//
+// +kubebuilder:validation:XValidation:rule="self.type == 'Distinct' ? !has(self.value) : has(self.value)",message="value must be set for Exact and RegularExpression types, and must not be set for Distinct type"
+type QueryParamMatch struct {
+	// Type specifies how to match against the value of the query parameter.
+	//
+	// +optional
+	// +kubebuilder:default=Exact
+	Type *QueryParamMatchType `json:"type,omitempty"`
+
+	// Name of the query parameter.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Name string `json:"name"`
+
+	// Value of the query parameter.
+	// Do not set this field when Type="Distinct", implying matching on any/all unique
+	// values of the query parameter.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=1024
+	Value *string `json:"value,omitempty"`
+
+	// Invert specifies whether the value match result will be inverted.
+	// Do not set this field when Type="Distinct".
+	//
+	// +optional
+	// +kubebuilder:default=false
+	Invert *bool `json:"invert,omitempty"`
+}
+
+// QueryParamMatchType specifies the semantics of how query parameter values should be compared.
+// Valid QueryParamMatchType values are "Exact", "RegularExpression", and "Distinct".
+//
+// +kubebuilder:validation:Enum=Exact;RegularExpression;Distinct
+type QueryParamMatchType string
+
+// QueryParamMatchType constants.
+const (
+	// QueryParamMatchExact matches the exact value of the Value field against the value of
+	// the specified query parameter.
+	QueryParamMatchExact QueryParamMatchType = "Exact"
+	// QueryParamMatchRegularExpression matches a regular expression against the value of the
+	// specified query parameter. The regex string must adhere to the syntax documented in
+	// https://github.com/google/re2/wiki/Syntax.
+	QueryParamMatchRegularExpression QueryParamMatchType = "RegularExpression"
+	// QueryParamMatchDistinct matches any and all possible unique values encountered in the
+	// specified query parameter. Note that each unique value will receive its own rate limit
+	// bucket. A request without the query parameter is not matched.
+	QueryParamMatchDistinct QueryParamMatchType = "Distinct"
+)
+
+// JWTClaimMatch defines the match attributes within the claims of the JWT of the request.
+//
+// +kubebuilder:validation:XValidation:rule="self.type == 'Distinct' ? !has(self.value) : has(self.value)",message="value must be set for Exact type, and must not be set for Distinct type"
+type JWTClaimMatch struct {
+	// Provider is the name of the JWT provider that validated the JWT of the request,
+	// as configured in the JWT authentication of the SecurityPolicy.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Provider string `json:"provider"`
+
+	// Name is the name of the claim.
+	// If it is a nested claim, use a dot (.) separated string as the name to
+	// represent the full path to the claim.
+	// For example, if the claim is in the "tenant" field in the "organization" field,
+	// the name should be "organization.tenant".
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+
+	// Type specifies how to match against the value of the claim.
+	//
+	// +optional
+	// +kubebuilder:default=Exact
+	Type *JWTClaimMatchType `json:"type,omitempty"`
+
+	// Value of the claim.
+	// Do not set this field when Type="Distinct", implying matching on any/all unique
+	// values of the claim.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=1024
+	Value *string `json:"value,omitempty"`
+}
+
+// JWTClaimMatchType specifies the semantics of how JWT claim values should be compared.
+// Valid JWTClaimMatchType values are "Exact" and "Distinct".
+//
+// +kubebuilder:validation:Enum=Exact;Distinct
+type JWTClaimMatchType string
+
+// JWTClaimMatchType constants.
+const (
+	// JWTClaimMatchExact matches the exact value of the Value field against the value of
+	// the specified claim.
+	JWTClaimMatchExact JWTClaimMatchType = "Exact"
+	// JWTClaimMatchDistinct matches any and all possible unique values of the specified claim.
+	// Note that each unique value will receive its own rate limit bucket.
+	// A request without the claim is not matched.
+	JWTClaimMatchDistinct JWTClaimMatchType = "Distinct"
+)
+
 // HeaderMatchType specifies the semantics of how HTTP header values should be compared.
 // Valid HeaderMatchType values are "Exact", "RegularExpression", and "Distinct".
 //
@@ -92,6 +92,7 @@ const (
 	Unavailable TriggerEnum = "unavailable"
 )
 
+// +kubebuilder:validation:XValidation:rule="has(self.hedgeOnTimeout) && self.hedgeOnTimeout ? has(self.timeout) : true",message="timeout must be set when hedgeOnTimeout is enabled."
 type PerRetryPolicy struct {
 	// Timeout is the timeout per retry attempt.
 	//
@@ -103,6 +104,14 @@ type PerRetryPolicy struct {
 	//
 	// +optional
 	BackOff *BackOffPolicy `json:"backOff,omitempty"`
+	// HedgeOnTimeout enables request hedging when the timeout of an attempt is reached.
+	// Instead of canceling the attempt that timed out, a retry is sent while the
+	// original attempt is kept running, and the first response received is used.
+	// This reduces tail latency when some endpoints of the backend are slow.
+	// Timeout must be set to use hedging.
+	//
+	// +optional
+	HedgeOnTimeout *bool `json:"hedgeOnTimeout,omitempty"`
 }
 
 type BackOffPolicy struct {
@@ -115,5 +124,57 @@ type BackOffPolicy struct {
 	//
 	// +optional
 	MaxInterval *gwapiv1.Duration `json:"maxInterval,omitempty"`
-	// we can add rate limited based backoff config here if we want to.
+	// RateLimited defines a backoff policy driven by the rate limit headers returned by the backend,
+	// such as Retry-After or X-RateLimit-Reset.
+	// When a retried response contains one of the reset headers, the retry is sent after the
+	// interval specified by the header instead of the interval computed by the backoff algorithm.
+	// It only applies to the retries triggered by the retriable-status-codes trigger, for example
+	// with the 429 and 503 status codes.
+	//
+	// +optional
+	RateLimited *RateLimitedBackOff `json:"rateLimited,omitempty"`
+}
+
+// RateLimitedBackOff defines a retry backoff policy driven by the headers of the responses
+// returned by a rate limited backend.
+type RateLimitedBackOff struct {
+	// ResetHeaders defines the headers that specify when the rate limit of the backend is reset.
+	// The headers are tried in order, and the first valid one found in the response is used.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	ResetHeaders []RetryResetHeader `json:"resetHeaders"`
+	// MaxInterval is the maximum interval between retries when the interval is taken from a reset header.
+	// If the interval specified by a header is greater than MaxInterval, the header is discarded
+	// and the next one is tried.
+	// Defaults to 300s.
+	//
+	// +optional
+	MaxInterval *gwapiv1.Duration `json:"maxInterval,omitempty"`
 }
+
+// RetryResetHeader defines a response header that specifies when the rate limit of the backend is reset.
+type RetryResetHeader struct {
+	// Name is the name of the header, for example Retry-After or X-RateLimit-Reset.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Format is the format of the header value.
+	//
+	// +kubebuilder:default=Seconds
+	// +optional
+	Format *RetryResetHeaderFormat `json:"format,omitempty"`
+}
+
+// RetryResetHeaderFormat defines the format of a reset header value.
+// +kubebuilder:validation:Enum=Seconds;UnixTimestamp
+type RetryResetHeaderFormat string
+
+const (
+	// RetryResetHeaderFormatSeconds indicates that the header value is the number of seconds
+	// to wait before retrying, for example "Retry-After: 30".
+	RetryResetHeaderFormatSeconds RetryResetHeaderFormat = "Seconds"
+	// RetryResetHeaderFormatUnixTimestamp indicates that the header value is the Unix timestamp
+	// at which the rate limit is reset, for example "X-RateLimit-Reset: 1700000000".
+	RetryResetHeaderFormatUnixTimestamp RetryResetHeaderFormat = "UnixTimestamp"
+)
@@ -55,6 +55,11 @@ type SecurityPolicySpec struct {
 	// +optional
 	CORS *CORS `json:"cors,omitempty"`
 
+	// CSRF defines the configuration for Cross-Site Request Forgery (CSRF) protection.
+	//
+	// +optional
+	CSRF *CSRF `json:"csrf,omitempty"`
+
 	// BasicAuth defines the configuration for the HTTP Basic Authentication.
 	//
 	// +optional
@@ -79,6 +84,11 @@ type SecurityPolicySpec struct {
 	//
 	// +optional
 	Authorization *Authorization `json:"authorization,omitempty"`
+
+	// Policy defines the configuration for the authorization with a policy evaluated by Envoy.
+	//
+	// +optional
+	Policy *PolicyAuth `json:"policy,omitempty"`
 }
 
 //+kubebuilder:object:root=true
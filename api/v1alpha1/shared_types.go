@@ -33,6 +33,10 @@ const (
 	DefaultShutdownManagerImage = "docker.io/envoyproxy/gateway-dev:latest"
 	// DefaultRateLimitImage is the default image used by ratelimit.
 	DefaultRateLimitImage = "docker.io/envoyproxy/ratelimit:master"
+	// DefaultKVResourcePrefix is the default key prefix of the resources stored in the KV resource provider.
+	DefaultKVResourcePrefix = "/envoy-gateway/resources/"
+	// DefaultKVStatusPrefix is the default key prefix of the status written back by the KV resource provider.
+	DefaultKVStatusPrefix = "/envoy-gateway/status/"
 	// HTTPProtocol is the common-used http protocol.
 	HTTPProtocol = "http"
 	// GRPCProtocol is the common-used grpc protocol.
@@ -697,13 +701,34 @@ type ResponseOverride struct {
 }
 
 // CustomResponseMatch defines the configuration for matching a user response to return a custom one.
+// If both status codes and expression are specified, both must match.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.statusCodes) || has(self.expression)",message="at least one of statusCodes or expression must be specified"
 type CustomResponseMatch struct {
 	// Status code to match on. The match evaluates to true if any of the matches are successful.
+	//
+	// +optional
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=50
-	StatusCodes []StatusCodeMatch `json:"statusCodes"`
+	StatusCodes []StatusCodeMatch `json:"statusCodes,omitempty"`
+
+	// Expression is a CEL expression on the attributes of the request and the response, for example,
+	// `response.code == 403 && response.headers['x-reason'] == 'quota'`.
+	// The match evaluates to true if the expression evaluates to true.
+	//
+	// +optional
+	Expression *CELExpression `json:"expression,omitempty"`
 }
 
+// CELExpression is a Common Expression Language (CEL) expression which must evaluate to a bool.
+// It's evaluated by Envoy against the attributes of the request, the response and the connection,
+// see https://www.envoyproxy.io/docs/envoy/latest/intro/arch_overview/advanced/attributes for the attributes.
+// The expression is compiled by Envoy Gateway, and an invalid expression is reported in the status of the policy.
+//
+// +kubebuilder:validation:MinLength=1
+// +kubebuilder:validation:MaxLength=4096
+type CELExpression string
+
 // StatusCodeValueType defines the types of values for the status code match supported by Envoy Gateway.
 // +kubebuilder:validation:Enum=Value;Range
 type StatusCodeValueType string
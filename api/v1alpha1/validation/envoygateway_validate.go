@@ -7,10 +7,15 @@ package validation
 
 import (
 	"fmt"
+	"net"
 	"net/url"
+	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 )
@@ -50,7 +55,7 @@ func ValidateEnvoyGateway(eg *egv1a1.EnvoyGateway) error {
 		return err
 	}
 
-	if err := validateEnvoyGatewayRateLimit(eg.RateLimit); err != nil {
+	if err := validateEnvoyGatewayRateLimit(eg.Provider, eg.RateLimit); err != nil {
 		return err
 	}
 
@@ -112,6 +117,33 @@ func validateEnvoyGatewayCustomResourceProvider(resource egv1a1.EnvoyGatewayReso
 		if len(resource.File.Paths) == 0 {
 			return fmt.Errorf("no paths were assigned for file resource provider to watch")
 		}
+
+		for _, pattern := range slices.Concat(resource.File.Include, resource.File.Exclude) {
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid file resource provider pattern %q: %w", pattern, err)
+			}
+		}
+	case egv1a1.ResourceProviderTypeKV:
+		if resource.KV == nil {
+			return fmt.Errorf("field 'kv' should be specified when resource type is 'KV'")
+		}
+
+		if len(resource.KV.Endpoints) == 0 {
+			return fmt.Errorf("no endpoints were assigned for kv resource provider to connect")
+		}
+
+		if resource.KV.DialTimeout != nil {
+			if _, err := time.ParseDuration(string(*resource.KV.DialTimeout)); err != nil {
+				return fmt.Errorf("invalid kv resource provider dial timeout: %w", err)
+			}
+		}
+
+		// The status written back under the status prefix must not be loaded as
+		// resources, nor the resources be overwritten by the status.
+		prefix, statusPrefix := resource.KV.GetPrefix(), resource.KV.GetStatusPrefix()
+		if strings.HasPrefix(prefix, statusPrefix) || strings.HasPrefix(statusPrefix, prefix) {
+			return fmt.Errorf("kv resource provider prefix %q and status prefix %q must not overlap", prefix, statusPrefix)
+		}
 	default:
 		return fmt.Errorf("unsupported resource provider: %s", resource.Type)
 	}
@@ -128,6 +160,22 @@ func validateEnvoyGatewayCustomInfrastructureProvider(infra *egv1a1.EnvoyGateway
 		if infra.Host == nil {
 			return fmt.Errorf("field 'host' should be specified when infrastructure type is 'Host'")
 		}
+
+		if infra.Host.EnvoyPath != nil && infra.Host.EnvoyVersion != nil {
+			return fmt.Errorf("only one of 'envoyPath' and 'envoyVersion' can be specified for host infrastructure")
+		}
+
+		for name, addr := range map[string]*egv1a1.HostServerAddress{"admin": infra.Host.Admin, "stats": infra.Host.Stats} {
+			if addr == nil {
+				continue
+			}
+			if addr.Port < 1 || addr.Port > 65535 {
+				return fmt.Errorf("invalid host infrastructure %s port %d", name, addr.Port)
+			}
+			if addr.Host != nil && net.ParseIP(*addr.Host) == nil {
+				return fmt.Errorf("invalid host infrastructure %s host %s", name, *addr.Host)
+			}
+		}
 	default:
 		return fmt.Errorf("unsupported infrastructure provdier: %s", infra.Type)
 	}
@@ -161,19 +209,130 @@ func validateEnvoyGatewayLogging(logging *egv1a1.EnvoyGatewayLogging) error {
 	return nil
 }
 
-func validateEnvoyGatewayRateLimit(rateLimit *egv1a1.RateLimit) error {
+func validateEnvoyGatewayRateLimit(provider *egv1a1.EnvoyGatewayProvider, rateLimit *egv1a1.RateLimit) error {
 	if rateLimit == nil {
 		return nil
 	}
-	if rateLimit.Backend.Type != egv1a1.RedisBackendType {
+	switch rateLimit.Backend.Type {
+	case egv1a1.RedisBackendType:
+		redis := rateLimit.Backend.Redis
+		if redis == nil {
+			return fmt.Errorf("empty ratelimit redis settings")
+		}
+		if err := validateRateLimitRedisTopology(redis.Type, redis.URL, redis.Sentinel, redis.Cluster); err != nil {
+			return err
+		}
+		if redis.PoolSize != nil && *redis.PoolSize < 1 {
+			return fmt.Errorf("ratelimit redis poolSize must be greater than 0")
+		}
+		if redis.PipelineLimit != nil && *redis.PipelineLimit < 1 {
+			return fmt.Errorf("ratelimit redis pipelineLimit must be greater than 0")
+		}
+		if redis.PipelineWindow != nil {
+			if d, err := time.ParseDuration(string(*redis.PipelineWindow)); err != nil || d <= 0 {
+				return fmt.Errorf("invalid ratelimit redis pipelineWindow %q", *redis.PipelineWindow)
+			}
+		}
+		if perSecond := redis.PerSecond; perSecond != nil {
+			if err := validateRateLimitRedisTopology(perSecond.Type, perSecond.URL, perSecond.Sentinel, perSecond.Cluster); err != nil {
+				return fmt.Errorf("invalid ratelimit redis perSecond settings: %w", err)
+			}
+			if perSecond.PoolSize != nil && *perSecond.PoolSize < 1 {
+				return fmt.Errorf("ratelimit redis perSecond poolSize must be greater than 0")
+			}
+		}
+		if provider.IsRunningOnHost() {
+			if redis.TLS != nil && redis.TLS.CertificateRef != nil {
+				return fmt.Errorf("ratelimit redis tls certificateRef is not supported for host infrastructure")
+			}
+			if redis.PasswordRef != nil || (redis.PerSecond != nil && redis.PerSecond.PasswordRef != nil) {
+				return fmt.Errorf("ratelimit redis passwordRef is not supported for host infrastructure")
+			}
+		}
+		if rateLimit.Backend.Memcached != nil {
+			return fmt.Errorf("ratelimit memcached settings must be unset for the redis backend")
+		}
+	case egv1a1.MemcachedBackendType:
+		if rateLimit.Backend.Memcached == nil || len(rateLimit.Backend.Memcached.URLs) == 0 {
+			return fmt.Errorf("empty ratelimit memcached settings")
+		}
+		for _, u := range rateLimit.Backend.Memcached.URLs {
+			if _, _, err := net.SplitHostPort(u); err != nil {
+				return fmt.Errorf("unknown ratelimit memcached url format: %w", err)
+			}
+		}
+		if provider.IsRunningOnHost() && rateLimit.Backend.Memcached.TLS != nil && rateLimit.Backend.Memcached.TLS.CertificateRef != nil {
+			return fmt.Errorf("ratelimit memcached tls certificateRef is not supported for host infrastructure")
+		}
+		if rateLimit.Backend.Redis != nil {
+			return fmt.Errorf("ratelimit redis settings must be unset for the memcached backend")
+		}
+	case egv1a1.LocalBackendType:
+		if rateLimit.Backend.Redis != nil || rateLimit.Backend.Memcached != nil {
+			return fmt.Errorf("ratelimit redis and memcached settings must be unset for the local backend")
+		}
+	default:
 		return fmt.Errorf("unsupported ratelimit backend %v", rateLimit.Backend.Type)
 	}
-	if rateLimit.Backend.Redis == nil || rateLimit.Backend.Redis.URL == "" {
-		return fmt.Errorf("empty ratelimit redis settings")
+	return nil
+}
+
+// validateRateLimitRedisTopology validates that the settings of a Redis deployment match its type.
+func validateRateLimitRedisTopology(redisType *egv1a1.RedisType, redisURL string,
+	sentinel *egv1a1.RedisSentinelSettings, cluster *egv1a1.RedisClusterSettings,
+) error {
+	switch ptr.Deref(redisType, "") {
+	case "":
+		// The url can be a comma delimited list for Sentinel and Cluster deployments if the type is unset.
+		if redisURL == "" {
+			return fmt.Errorf("empty ratelimit redis settings")
+		}
+		redisHosts := strings.Split(redisURL, ",")
+		for _, host := range redisHosts {
+			if _, err := url.Parse(host); err != nil {
+				return fmt.Errorf("unknown ratelimit redis url format: %w", err)
+			}
+		}
+	case egv1a1.RedisTypeSingle:
+		if redisURL == "" {
+			return fmt.Errorf("ratelimit redis url must be set for the Single type")
+		}
+		if _, _, err := net.SplitHostPort(redisURL); err != nil {
+			return fmt.Errorf("unknown ratelimit redis url format: %w", err)
+		}
+	case egv1a1.RedisTypeSentinel:
+		if sentinel == nil || sentinel.MasterName == "" || len(sentinel.URLs) == 0 {
+			return fmt.Errorf("ratelimit redis sentinel masterName and urls must be set for the Sentinel type")
+		}
+		if err := validateRateLimitRedisURLs(sentinel.URLs); err != nil {
+			return err
+		}
+	case egv1a1.RedisTypeCluster:
+		if cluster == nil || len(cluster.URLs) == 0 {
+			return fmt.Errorf("ratelimit redis cluster urls must be set for the Cluster type")
+		}
+		if err := validateRateLimitRedisURLs(cluster.URLs); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported ratelimit redis type %v", *redisType)
+	}
+
+	if redisType == nil || *redisType == egv1a1.RedisTypeSingle {
+		if sentinel != nil || cluster != nil {
+			return fmt.Errorf("ratelimit redis sentinel and cluster settings must be unset for the Single type")
+		}
+	} else if redisURL != "" ||
+		(*redisType == egv1a1.RedisTypeSentinel && cluster != nil) ||
+		(*redisType == egv1a1.RedisTypeCluster && sentinel != nil) {
+		return fmt.Errorf("ratelimit redis settings of other types must be unset for the %s type", *redisType)
 	}
-	redisHosts := strings.Split(rateLimit.Backend.Redis.URL, ",")
-	for _, host := range redisHosts {
-		if _, err := url.Parse(host); err != nil {
+	return nil
+}
+
+func validateRateLimitRedisURLs(urls []string) error {
+	for _, u := range urls {
+		if _, _, err := net.SplitHostPort(u); err != nil {
 			return fmt.Errorf("unknown ratelimit redis url format: %w", err)
 		}
 	}
@@ -201,6 +201,111 @@ func (in *ActiveHealthCheckPayload) DeepCopy() *ActiveHealthCheckPayload {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdaptiveConcurrency) DeepCopyInto(out *AdaptiveConcurrency) {
+	*out = *in
+	if in.SampleAggregatePercentile != nil {
+		in, out := &in.SampleAggregatePercentile, &out.SampleAggregatePercentile
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConcurrencyLimit != nil {
+		in, out := &in.ConcurrencyLimit, &out.ConcurrencyLimit
+		*out = new(AdaptiveConcurrencyLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinRTT != nil {
+		in, out := &in.MinRTT, &out.MinRTT
+		*out = new(AdaptiveConcurrencyMinRTT)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConcurrencyLimitExceededStatus != nil {
+		in, out := &in.ConcurrencyLimitExceededStatus, &out.ConcurrencyLimitExceededStatus
+		*out = new(HTTPStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdaptiveConcurrency.
+func (in *AdaptiveConcurrency) DeepCopy() *AdaptiveConcurrency {
+	if in == nil {
+		return nil
+	}
+	out := new(AdaptiveConcurrency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdaptiveConcurrencyLimit) DeepCopyInto(out *AdaptiveConcurrencyLimit) {
+	*out = *in
+	if in.MaxConcurrencyLimit != nil {
+		in, out := &in.MaxConcurrencyLimit, &out.MaxConcurrencyLimit
+		*out = new(int64)
+		**out = **in
+	}
+	if in.UpdateInterval != nil {
+		in, out := &in.UpdateInterval, &out.UpdateInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdaptiveConcurrencyLimit.
+func (in *AdaptiveConcurrencyLimit) DeepCopy() *AdaptiveConcurrencyLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(AdaptiveConcurrencyLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdaptiveConcurrencyMinRTT) DeepCopyInto(out *AdaptiveConcurrencyMinRTT) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FixedValue != nil {
+		in, out := &in.FixedValue, &out.FixedValue
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RequestCount != nil {
+		in, out := &in.RequestCount, &out.RequestCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Jitter != nil {
+		in, out := &in.Jitter, &out.Jitter
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinConcurrency != nil {
+		in, out := &in.MinConcurrency, &out.MinConcurrency
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Buffer != nil {
+		in, out := &in.Buffer, &out.Buffer
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdaptiveConcurrencyMinRTT.
+func (in *AdaptiveConcurrencyMinRTT) DeepCopy() *AdaptiveConcurrencyMinRTT {
+	if in == nil {
+		return nil
+	}
+	out := new(AdaptiveConcurrencyMinRTT)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Authorization) DeepCopyInto(out *Authorization) {
 	*out = *in
@@ -287,6 +392,11 @@ func (in *BackOffPolicy) DeepCopyInto(out *BackOffPolicy) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.RateLimited != nil {
+		in, out := &in.RateLimited, &out.RateLimited
+		*out = new(RateLimitedBackOff)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackOffPolicy.
@@ -411,6 +521,16 @@ func (in *BackendEndpoint) DeepCopyInto(out *BackendEndpoint) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(string)
+		**out = **in
+	}
+	if in.SubZone != nil {
+		in, out := &in.SubZone, &out.SubZone
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendEndpoint.
@@ -738,6 +858,21 @@ func (in *BackendTrafficPolicySpec) DeepCopyInto(out *BackendTrafficPolicySpec)
 		*out = new(BackendTelemetry)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CredentialInjection != nil {
+		in, out := &in.CredentialInjection, &out.CredentialInjection
+		*out = new(HTTPCredentialInjectionFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdaptiveConcurrency != nil {
+		in, out := &in.AdaptiveConcurrency, &out.AdaptiveConcurrency
+		*out = new(AdaptiveConcurrency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(Failover)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendTrafficPolicySpec.
@@ -754,6 +889,11 @@ func (in *BackendTrafficPolicySpec) DeepCopy() *BackendTrafficPolicySpec {
 func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
 	*out = *in
 	in.Users.DeepCopyInto(&out.Users)
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(LDAPUserStore)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ForwardUsernameHeader != nil {
 		in, out := &in.ForwardUsernameHeader, &out.ForwardUsernameHeader
 		*out = new(string)
@@ -846,6 +986,36 @@ func (in *CORS) DeepCopy() *CORS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSRF) DeepCopyInto(out *CSRF) {
+	*out = *in
+	if in.AdditionalOrigins != nil {
+		in, out := &in.AdditionalOrigins, &out.AdditionalOrigins
+		*out = make([]Origin, len(*in))
+		copy(*out, *in)
+	}
+	if in.FilterEnabled != nil {
+		in, out := &in.FilterEnabled, &out.FilterEnabled
+		*out = new(v1.Fraction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ShadowEnabled != nil {
+		in, out := &in.ShadowEnabled, &out.ShadowEnabled
+		*out = new(v1.Fraction)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSRF.
+func (in *CSRF) DeepCopy() *CSRF {
+	if in == nil {
+		return nil
+	}
+	out := new(CSRF)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CircuitBreaker) DeepCopyInto(out *CircuitBreaker) {
 	*out = *in
@@ -869,6 +1039,11 @@ func (in *CircuitBreaker) DeepCopyInto(out *CircuitBreaker) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.RetryBudget != nil {
+		in, out := &in.RetryBudget, &out.RetryBudget
+		*out = new(RetryBudget)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MaxRequestsPerConnection != nil {
 		in, out := &in.MaxRequestsPerConnection, &out.MaxRequestsPerConnection
 		*out = new(int64)
@@ -906,6 +1081,56 @@ func (in *ClaimToHeader) DeepCopy() *ClaimToHeader {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientCertificatePrincipal) DeepCopyInto(out *ClientCertificatePrincipal) {
+	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]StringMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Issuers != nil {
+		in, out := &in.Issuers, &out.Issuers
+		*out = make([]StringMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.URIs != nil {
+		in, out := &in.URIs, &out.URIs
+		*out = make([]StringMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]StringMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Emails != nil {
+		in, out := &in.Emails, &out.Emails
+		*out = make([]StringMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientCertificatePrincipal.
+func (in *ClientCertificatePrincipal) DeepCopy() *ClientCertificatePrincipal {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientCertificatePrincipal)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientConnection) DeepCopyInto(out *ClientConnection) {
 	*out = *in
@@ -1531,6 +1756,11 @@ func (in *CustomResponseMatch) DeepCopyInto(out *CustomResponseMatch) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Expression != nil {
+		in, out := &in.Expression, &out.Expression
+		*out = new(CELExpression)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomResponseMatch.
@@ -1850,6 +2080,21 @@ func (in *EnvoyGatewayFileResourceProvider) DeepCopyInto(out *EnvoyGatewayFileRe
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Recursive != nil {
+		in, out := &in.Recursive, &out.Recursive
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayFileResourceProvider.
@@ -1865,6 +2110,41 @@ func (in *EnvoyGatewayFileResourceProvider) DeepCopy() *EnvoyGatewayFileResource
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvoyGatewayHostInfrastructureProvider) DeepCopyInto(out *EnvoyGatewayHostInfrastructureProvider) {
 	*out = *in
+	if in.EnvoyVersion != nil {
+		in, out := &in.EnvoyVersion, &out.EnvoyVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.EnvoyPath != nil {
+		in, out := &in.EnvoyPath, &out.EnvoyPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.WorkingDir != nil {
+		in, out := &in.WorkingDir, &out.WorkingDir
+		*out = new(string)
+		**out = **in
+	}
+	if in.LogFile != nil {
+		in, out := &in.LogFile, &out.LogFile
+		*out = new(string)
+		**out = **in
+	}
+	if in.Admin != nil {
+		in, out := &in.Admin, &out.Admin
+		*out = new(HostServerAddress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Stats != nil {
+		in, out := &in.Stats, &out.Stats
+		*out = new(HostServerAddress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimitPath != nil {
+		in, out := &in.RateLimitPath, &out.RateLimitPath
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayHostInfrastructureProvider.
@@ -1883,7 +2163,7 @@ func (in *EnvoyGatewayInfrastructureProvider) DeepCopyInto(out *EnvoyGatewayInfr
 	if in.Host != nil {
 		in, out := &in.Host, &out.Host
 		*out = new(EnvoyGatewayHostInfrastructureProvider)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -1897,6 +2177,46 @@ func (in *EnvoyGatewayInfrastructureProvider) DeepCopy() *EnvoyGatewayInfrastruc
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewayKVResourceProvider) DeepCopyInto(out *EnvoyGatewayKVResourceProvider) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.StatusPrefix != nil {
+		in, out := &in.StatusPrefix, &out.StatusPrefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.DialTimeout != nil {
+		in, out := &in.DialTimeout, &out.DialTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(KVTLSSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayKVResourceProvider.
+func (in *EnvoyGatewayKVResourceProvider) DeepCopy() *EnvoyGatewayKVResourceProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewayKVResourceProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvoyGatewayKubernetesProvider) DeepCopyInto(out *EnvoyGatewayKubernetesProvider) {
 	*out = *in
@@ -2099,6 +2419,11 @@ func (in *EnvoyGatewayResourceProvider) DeepCopyInto(out *EnvoyGatewayResourcePr
 		*out = new(EnvoyGatewayFileResourceProvider)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.KV != nil {
+		in, out := &in.KV, &out.KV
+		*out = new(EnvoyGatewayKVResourceProvider)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayResourceProvider.
@@ -2884,6 +3209,60 @@ func (in *FQDNEndpoint) DeepCopy() *FQDNEndpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Failover) DeepCopyInto(out *Failover) {
+	*out = *in
+	if in.Priorities != nil {
+		in, out := &in.Priorities, &out.Priorities
+		*out = make([]FailoverPriority, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OverprovisioningFactor != nil {
+		in, out := &in.OverprovisioningFactor, &out.OverprovisioningFactor
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Failback != nil {
+		in, out := &in.Failback, &out.Failback
+		*out = new(FailbackMode)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Failover.
+func (in *Failover) DeepCopy() *Failover {
+	if in == nil {
+		return nil
+	}
+	out := new(Failover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverPriority) DeepCopyInto(out *FailoverPriority) {
+	*out = *in
+	if in.BackendRefs != nil {
+		in, out := &in.BackendRefs, &out.BackendRefs
+		*out = make([]v1.BackendObjectReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverPriority.
+func (in *FailoverPriority) DeepCopy() *FailoverPriority {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverPriority)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FaultInjection) DeepCopyInto(out *FaultInjection) {
 	*out = *in
@@ -3753,12 +4132,32 @@ func (in *HealthCheckSettings) DeepCopy() *HealthCheckSettings {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IPEndpoint) DeepCopyInto(out *IPEndpoint) {
+func (in *HostServerAddress) DeepCopyInto(out *HostServerAddress) {
 	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPEndpoint.
-func (in *IPEndpoint) DeepCopy() *IPEndpoint {
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostServerAddress.
+func (in *HostServerAddress) DeepCopy() *HostServerAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(HostServerAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPEndpoint) DeepCopyInto(out *IPEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPEndpoint.
+func (in *IPEndpoint) DeepCopy() *IPEndpoint {
 	if in == nil {
 		return nil
 	}
@@ -3801,6 +4200,11 @@ func (in *ImageWasmCodeSource) DeepCopy() *ImageWasmCodeSource {
 func (in *InjectedCredential) DeepCopyInto(out *InjectedCredential) {
 	*out = *in
 	in.ValueRef.DeepCopyInto(&out.ValueRef)
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(OAuth2ClientCredentials)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectedCredential.
@@ -3848,6 +4252,31 @@ func (in *JSONPatchOperation) DeepCopy() *JSONPatchOperation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWKSAsyncFetch) DeepCopyInto(out *JWKSAsyncFetch) {
+	*out = *in
+	if in.FastListener != nil {
+		in, out := &in.FastListener, &out.FastListener
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FailedRefetchDuration != nil {
+		in, out := &in.FailedRefetchDuration, &out.FailedRefetchDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWKSAsyncFetch.
+func (in *JWKSAsyncFetch) DeepCopy() *JWKSAsyncFetch {
+	if in == nil {
+		return nil
+	}
+	out := new(JWKSAsyncFetch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JWT) DeepCopyInto(out *JWT) {
 	*out = *in
@@ -3900,6 +4329,31 @@ func (in *JWTClaim) DeepCopy() *JWTClaim {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTClaimMatch) DeepCopyInto(out *JWTClaimMatch) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(JWTClaimMatchType)
+		**out = **in
+	}
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTClaimMatch.
+func (in *JWTClaimMatch) DeepCopy() *JWTClaimMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTClaimMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JWTExtractor) DeepCopyInto(out *JWTExtractor) {
 	*out = *in
@@ -4024,6 +4478,36 @@ func (in *JWTProvider) DeepCopy() *JWTProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KVTLSSettings) DeepCopyInto(out *KVTLSSettings) {
+	*out = *in
+	if in.CAFile != nil {
+		in, out := &in.CAFile, &out.CAFile
+		*out = new(string)
+		**out = **in
+	}
+	if in.CertFile != nil {
+		in, out := &in.CertFile, &out.CertFile
+		*out = new(string)
+		**out = **in
+	}
+	if in.KeyFile != nil {
+		in, out := &in.KeyFile, &out.KeyFile
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KVTLSSettings.
+func (in *KVTLSSettings) DeepCopy() *KVTLSSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(KVTLSSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesClient) DeepCopyInto(out *KubernetesClient) {
 	*out = *in
@@ -4519,6 +5003,39 @@ func (in *KubernetesWatchMode) DeepCopy() *KubernetesWatchMode {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAPUserStore) DeepCopyInto(out *LDAPUserStore) {
+	*out = *in
+	if in.StartTLS != nil {
+		in, out := &in.StartTLS, &out.StartTLS
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CACertificateRefs != nil {
+		in, out := &in.CACertificateRefs, &out.CACertificateRefs
+		*out = make([]v1.SecretObjectReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.BindPassword.DeepCopyInto(&out.BindPassword)
+	if in.SearchFilter != nil {
+		in, out := &in.SearchFilter, &out.SearchFilter
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPUserStore.
+func (in *LDAPUserStore) DeepCopy() *LDAPUserStore {
+	if in == nil {
+		return nil
+	}
+	out := new(LDAPUserStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LeaderElection) DeepCopyInto(out *LeaderElection) {
 	*out = *in
@@ -4676,6 +5193,79 @@ func (in *LocalRateLimit) DeepCopy() *LocalRateLimit {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Locality) DeepCopyInto(out *Locality) {
+	*out = *in
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(string)
+		**out = **in
+	}
+	if in.Zone != nil {
+		in, out := &in.Zone, &out.Zone
+		*out = new(string)
+		**out = **in
+	}
+	if in.SubZone != nil {
+		in, out := &in.SubZone, &out.SubZone
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Locality.
+func (in *Locality) DeepCopy() *Locality {
+	if in == nil {
+		return nil
+	}
+	out := new(Locality)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalityWeight) DeepCopyInto(out *LocalityWeight) {
+	*out = *in
+	in.Locality.DeepCopyInto(&out.Locality)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalityWeight.
+func (in *LocalityWeight) DeepCopy() *LocalityWeight {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalityWeight)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalityWeighted) DeepCopyInto(out *LocalityWeighted) {
+	*out = *in
+	if in.Weights != nil {
+		in, out := &in.Weights, &out.Weights
+		*out = make([]LocalityWeight, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Origin != nil {
+		in, out := &in.Origin, &out.Origin
+		*out = new(Locality)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalityWeighted.
+func (in *LocalityWeighted) DeepCopy() *LocalityWeighted {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalityWeighted)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Lua) DeepCopyInto(out *Lua) {
 	*out = *in
@@ -4701,6 +5291,57 @@ func (in *Lua) DeepCopy() *Lua {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemcachedTLSSettings) DeepCopyInto(out *MemcachedTLSSettings) {
+	*out = *in
+	if in.CertificateRef != nil {
+		in, out := &in.CertificateRef, &out.CertificateRef
+		*out = new(v1.SecretObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemcachedTLSSettings.
+func (in *MemcachedTLSSettings) DeepCopy() *MemcachedTLSSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(MemcachedTLSSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2ClientCredentials) DeepCopyInto(out *OAuth2ClientCredentials) {
+	*out = *in
+	in.ClientSecret.DeepCopyInto(&out.ClientSecret)
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientAuthentication != nil {
+		in, out := &in.ClientAuthentication, &out.ClientAuthentication
+		*out = new(OAuth2ClientAuthenticationMethod)
+		**out = **in
+	}
+	if in.TokenFetchRetryInterval != nil {
+		in, out := &in.TokenFetchRetryInterval, &out.TokenFetchRetryInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2ClientCredentials.
+func (in *OAuth2ClientCredentials) DeepCopy() *OAuth2ClientCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2ClientCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OIDC) DeepCopyInto(out *OIDC) {
 	*out = *in
@@ -4781,6 +5422,21 @@ func (in *OIDC) DeepCopyInto(out *OIDC) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ClaimToHeaders != nil {
+		in, out := &in.ClaimToHeaders, &out.ClaimToHeaders
+		*out = make([]ClaimToHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.UserInfoToHeaders != nil {
+		in, out := &in.UserInfoToHeaders, &out.UserInfoToHeaders
+		*out = make([]ClaimToHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.BackchannelLogoutPath != nil {
+		in, out := &in.BackchannelLogoutPath, &out.BackchannelLogoutPath
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDC.
@@ -4876,6 +5532,26 @@ func (in *OIDCDenyRedirectHeader) DeepCopy() *OIDCDenyRedirectHeader {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCIntrospection) DeepCopyInto(out *OIDCIntrospection) {
+	*out = *in
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCIntrospection.
+func (in *OIDCIntrospection) DeepCopy() *OIDCIntrospection {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCIntrospection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OIDCProvider) DeepCopyInto(out *OIDCProvider) {
 	*out = *in
@@ -4895,6 +5571,21 @@ func (in *OIDCProvider) DeepCopyInto(out *OIDCProvider) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.JWKSURI != nil {
+		in, out := &in.JWKSURI, &out.JWKSURI
+		*out = new(string)
+		**out = **in
+	}
+	if in.UserInfoEndpoint != nil {
+		in, out := &in.UserInfoEndpoint, &out.UserInfoEndpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.Introspection != nil {
+		in, out := &in.Introspection, &out.Introspection
+		*out = new(OIDCIntrospection)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCProvider.
@@ -4943,6 +5634,30 @@ func (in *Operation) DeepCopyInto(out *Operation) {
 		*out = make([]v1.HTTPMethod, len(*in))
 		copy(*out, *in)
 	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]StringMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]v1.Hostname, len(*in))
+		copy(*out, *in)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]v1.HTTPHeaderMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Expression != nil {
+		in, out := &in.Expression, &out.Expression
+		*out = new(CELExpression)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Operation.
@@ -5009,6 +5724,26 @@ func (in *PassiveHealthCheck) DeepCopyInto(out *PassiveHealthCheck) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MaxEjectionTime != nil {
+		in, out := &in.MaxEjectionTime, &out.MaxEjectionTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxEjectionTimeJitter != nil {
+		in, out := &in.MaxEjectionTimeJitter, &out.MaxEjectionTimeJitter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SuccessRate != nil {
+		in, out := &in.SuccessRate, &out.SuccessRate
+		*out = new(PassiveHealthCheckSuccessRate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailurePercentage != nil {
+		in, out := &in.FailurePercentage, &out.FailurePercentage
+		*out = new(PassiveHealthCheckFailurePercentage)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PassiveHealthCheck.
@@ -5021,6 +5756,76 @@ func (in *PassiveHealthCheck) DeepCopy() *PassiveHealthCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PassiveHealthCheckFailurePercentage) DeepCopyInto(out *PassiveHealthCheckFailurePercentage) {
+	*out = *in
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MinimumHosts != nil {
+		in, out := &in.MinimumHosts, &out.MinimumHosts
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.RequestVolume != nil {
+		in, out := &in.RequestVolume, &out.RequestVolume
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.EnforcingPercent != nil {
+		in, out := &in.EnforcingPercent, &out.EnforcingPercent
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PassiveHealthCheckFailurePercentage.
+func (in *PassiveHealthCheckFailurePercentage) DeepCopy() *PassiveHealthCheckFailurePercentage {
+	if in == nil {
+		return nil
+	}
+	out := new(PassiveHealthCheckFailurePercentage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PassiveHealthCheckSuccessRate) DeepCopyInto(out *PassiveHealthCheckSuccessRate) {
+	*out = *in
+	if in.MinimumHosts != nil {
+		in, out := &in.MinimumHosts, &out.MinimumHosts
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.RequestVolume != nil {
+		in, out := &in.RequestVolume, &out.RequestVolume
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.StdevFactor != nil {
+		in, out := &in.StdevFactor, &out.StdevFactor
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.EnforcingPercent != nil {
+		in, out := &in.EnforcingPercent, &out.EnforcingPercent
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PassiveHealthCheckSuccessRate.
+func (in *PassiveHealthCheckSuccessRate) DeepCopy() *PassiveHealthCheckSuccessRate {
+	if in == nil {
+		return nil
+	}
+	out := new(PassiveHealthCheckSuccessRate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PathSettings) DeepCopyInto(out *PathSettings) {
 	*out = *in
@@ -5074,19 +5879,54 @@ func (in *PerRetryPolicy) DeepCopyInto(out *PerRetryPolicy) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
-	if in.BackOff != nil {
-		in, out := &in.BackOff, &out.BackOff
-		*out = new(BackOffPolicy)
-		(*in).DeepCopyInto(*out)
+	if in.BackOff != nil {
+		in, out := &in.BackOff, &out.BackOff
+		*out = new(BackOffPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HedgeOnTimeout != nil {
+		in, out := &in.HedgeOnTimeout, &out.HedgeOnTimeout
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerRetryPolicy.
+func (in *PerRetryPolicy) DeepCopy() *PerRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PerRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyAuth) DeepCopyInto(out *PolicyAuth) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(PolicyAuthType)
+		**out = **in
+	}
+	if in.Inline != nil {
+		in, out := &in.Inline, &out.Inline
+		*out = new(CELExpression)
+		**out = **in
+	}
+	if in.ValueRef != nil {
+		in, out := &in.ValueRef, &out.ValueRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerRetryPolicy.
-func (in *PerRetryPolicy) DeepCopy() *PerRetryPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyAuth.
+func (in *PolicyAuth) DeepCopy() *PolicyAuth {
 	if in == nil {
 		return nil
 	}
-	out := new(PerRetryPolicy)
+	out := new(PolicyAuth)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -5170,6 +6010,16 @@ func (in *Principal) DeepCopyInto(out *Principal) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(ClientCertificatePrincipal)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expression != nil {
+		in, out := &in.Expression, &out.Expression
+		*out = new(CELExpression)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Principal.
@@ -5615,6 +6465,36 @@ func (in *ProxyTracing) DeepCopy() *ProxyTracing {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryParamMatch) DeepCopyInto(out *QueryParamMatch) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(QueryParamMatchType)
+		**out = **in
+	}
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+	if in.Invert != nil {
+		in, out := &in.Invert, &out.Invert
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryParamMatch.
+func (in *QueryParamMatch) DeepCopy() *QueryParamMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryParamMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RateLimit) DeepCopyInto(out *RateLimit) {
 	*out = *in
@@ -5666,6 +6546,21 @@ func (in *RateLimitCost) DeepCopy() *RateLimitCost {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitCostHeader) DeepCopyInto(out *RateLimitCostHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitCostHeader.
+func (in *RateLimitCostHeader) DeepCopy() *RateLimitCostHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitCostHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RateLimitCostMetadata) DeepCopyInto(out *RateLimitCostMetadata) {
 	*out = *in
@@ -5694,6 +6589,11 @@ func (in *RateLimitCostSpecifier) DeepCopyInto(out *RateLimitCostSpecifier) {
 		*out = new(RateLimitCostMetadata)
 		**out = **in
 	}
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(RateLimitCostHeader)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitCostSpecifier.
@@ -5714,6 +6614,11 @@ func (in *RateLimitDatabaseBackend) DeepCopyInto(out *RateLimitDatabaseBackend)
 		*out = new(RateLimitRedisSettings)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Memcached != nil {
+		in, out := &in.Memcached, &out.Memcached
+		*out = new(RateLimitMemcachedSettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitDatabaseBackend.
@@ -5726,6 +6631,36 @@ func (in *RateLimitDatabaseBackend) DeepCopy() *RateLimitDatabaseBackend {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitMemcachedSettings) DeepCopyInto(out *RateLimitMemcachedSettings) {
+	*out = *in
+	if in.URLs != nil {
+		in, out := &in.URLs, &out.URLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(MemcachedTLSSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxIdleConns != nil {
+		in, out := &in.MaxIdleConns, &out.MaxIdleConns
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitMemcachedSettings.
+func (in *RateLimitMemcachedSettings) DeepCopy() *RateLimitMemcachedSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitMemcachedSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RateLimitMetrics) DeepCopyInto(out *RateLimitMetrics) {
 	*out = *in
@@ -5764,11 +6699,51 @@ func (in *RateLimitMetricsPrometheusProvider) DeepCopy() *RateLimitMetricsPromet
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RateLimitRedisSettings) DeepCopyInto(out *RateLimitRedisSettings) {
 	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(RedisType)
+		**out = **in
+	}
+	if in.Sentinel != nil {
+		in, out := &in.Sentinel, &out.Sentinel
+		*out = new(RedisSentinelSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(RedisClusterSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordRef != nil {
+		in, out := &in.PasswordRef, &out.PasswordRef
+		*out = new(v1.SecretObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TLS != nil {
 		in, out := &in.TLS, &out.TLS
 		*out = new(RedisTLSSettings)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PoolSize != nil {
+		in, out := &in.PoolSize, &out.PoolSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PipelineWindow != nil {
+		in, out := &in.PipelineWindow, &out.PipelineWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PipelineLimit != nil {
+		in, out := &in.PipelineLimit, &out.PipelineLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PerSecond != nil {
+		in, out := &in.PerSecond, &out.PerSecond
+		*out = new(RedisPerSecondSettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitRedisSettings.
@@ -5802,6 +6777,11 @@ func (in *RateLimitRule) DeepCopyInto(out *RateLimitRule) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ShadowMode != nil {
+		in, out := &in.ShadowMode, &out.ShadowMode
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitRule.
@@ -5824,11 +6804,40 @@ func (in *RateLimitSelectCondition) DeepCopyInto(out *RateLimitSelectCondition)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.QueryParams != nil {
+		in, out := &in.QueryParams, &out.QueryParams
+		*out = make([]QueryParamMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.JWTClaims != nil {
+		in, out := &in.JWTClaims, &out.JWTClaims
+		*out = make([]JWTClaimMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(v1.HTTPPathMatch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]v1.HTTPMethod, len(*in))
+		copy(*out, *in)
+	}
 	if in.SourceCIDR != nil {
 		in, out := &in.SourceCIDR, &out.SourceCIDR
 		*out = new(SourceMatch)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Expression != nil {
+		in, out := &in.Expression, &out.Expression
+		*out = new(CELExpression)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitSelectCondition.
@@ -5854,6 +6863,11 @@ func (in *RateLimitSpec) DeepCopyInto(out *RateLimitSpec) {
 		*out = new(LocalRateLimit)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ShadowMode != nil {
+		in, out := &in.ShadowMode, &out.ShadowMode
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitSpec.
@@ -5951,6 +6965,113 @@ func (in *RateLimitValue) DeepCopy() *RateLimitValue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitedBackOff) DeepCopyInto(out *RateLimitedBackOff) {
+	*out = *in
+	if in.ResetHeaders != nil {
+		in, out := &in.ResetHeaders, &out.ResetHeaders
+		*out = make([]RetryResetHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxInterval != nil {
+		in, out := &in.MaxInterval, &out.MaxInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitedBackOff.
+func (in *RateLimitedBackOff) DeepCopy() *RateLimitedBackOff {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitedBackOff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisClusterSettings) DeepCopyInto(out *RedisClusterSettings) {
+	*out = *in
+	if in.URLs != nil {
+		in, out := &in.URLs, &out.URLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisClusterSettings.
+func (in *RedisClusterSettings) DeepCopy() *RedisClusterSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisClusterSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisPerSecondSettings) DeepCopyInto(out *RedisPerSecondSettings) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(RedisType)
+		**out = **in
+	}
+	if in.Sentinel != nil {
+		in, out := &in.Sentinel, &out.Sentinel
+		*out = new(RedisSentinelSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(RedisClusterSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordRef != nil {
+		in, out := &in.PasswordRef, &out.PasswordRef
+		*out = new(v1.SecretObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PoolSize != nil {
+		in, out := &in.PoolSize, &out.PoolSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisPerSecondSettings.
+func (in *RedisPerSecondSettings) DeepCopy() *RedisPerSecondSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisPerSecondSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisSentinelSettings) DeepCopyInto(out *RedisSentinelSettings) {
+	*out = *in
+	if in.URLs != nil {
+		in, out := &in.URLs, &out.URLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisSentinelSettings.
+func (in *RedisSentinelSettings) DeepCopy() *RedisSentinelSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisSentinelSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedisTLSSettings) DeepCopyInto(out *RedisTLSSettings) {
 	*out = *in
@@ -5975,6 +7096,21 @@ func (in *RedisTLSSettings) DeepCopy() *RedisTLSSettings {
 func (in *RemoteJWKS) DeepCopyInto(out *RemoteJWKS) {
 	*out = *in
 	in.BackendCluster.DeepCopyInto(&out.BackendCluster)
+	if in.CacheDuration != nil {
+		in, out := &in.CacheDuration, &out.CacheDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.AsyncFetch != nil {
+		in, out := &in.AsyncFetch, &out.AsyncFetch
+		*out = new(JWKSAsyncFetch)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteJWKS.
@@ -6099,6 +7235,31 @@ func (in *Retry) DeepCopy() *Retry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryBudget) DeepCopyInto(out *RetryBudget) {
+	*out = *in
+	if in.Percent != nil {
+		in, out := &in.Percent, &out.Percent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinRetryConcurrency != nil {
+		in, out := &in.MinRetryConcurrency, &out.MinRetryConcurrency
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryBudget.
+func (in *RetryBudget) DeepCopy() *RetryBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RetryOn) DeepCopyInto(out *RetryOn) {
 	*out = *in
@@ -6124,6 +7285,26 @@ func (in *RetryOn) DeepCopy() *RetryOn {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryResetHeader) DeepCopyInto(out *RetryResetHeader) {
+	*out = *in
+	if in.Format != nil {
+		in, out := &in.Format, &out.Format
+		*out = new(RetryResetHeaderFormat)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryResetHeader.
+func (in *RetryResetHeader) DeepCopy() *RetryResetHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryResetHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteTranslationConfig) DeepCopyInto(out *RouteTranslationConfig) {
 	*out = *in
@@ -6262,6 +7443,11 @@ func (in *SecurityPolicySpec) DeepCopyInto(out *SecurityPolicySpec) {
 		*out = new(CORS)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CSRF != nil {
+		in, out := &in.CSRF, &out.CSRF
+		*out = new(CSRF)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.BasicAuth != nil {
 		in, out := &in.BasicAuth, &out.BasicAuth
 		*out = new(BasicAuth)
@@ -6287,6 +7473,11 @@ func (in *SecurityPolicySpec) DeepCopyInto(out *SecurityPolicySpec) {
 		*out = new(Authorization)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(PolicyAuth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPolicySpec.
@@ -7101,6 +8292,11 @@ func (in *ZoneAware) DeepCopyInto(out *ZoneAware) {
 		*out = new(PreferLocalZone)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LocalityWeighted != nil {
+		in, out := &in.LocalityWeighted, &out.LocalityWeighted
+		*out = new(LocalityWeighted)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneAware.
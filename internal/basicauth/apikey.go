@@ -0,0 +1,255 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package basicauth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/utils/ptr"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// APIKeyContextExtensionKey is the key of the context extension holding the name of the
+	// API key auth configuration a request is verified with.
+	APIKeyContextExtensionKey = "api-key-auth"
+
+	// APIKeyClientIDMetadataKey is the key of the dynamic metadata holding the client id of the API key.
+	APIKeyClientIDMetadataKey = "client_id"
+	// APIKeyTenantMetadataKey is the key of the dynamic metadata holding the tenant of the API key.
+	APIKeyTenantMetadataKey = "tenant"
+	// APIKeyPlanMetadataKey is the key of the dynamic metadata holding the plan of the API key.
+	APIKeyPlanMetadataKey = "plan"
+)
+
+type apiKeyConfig struct {
+	*ir.APIKeyAuth
+	// clients holds the client ids by plain text API key.
+	clients map[string]string
+	// hashes holds the hashes of the hashed API keys by client id.
+	hashes map[string]passwordHash
+	// hashedClients holds the client ids of the hashed API keys, sorted so that the keys are
+	// always verified in the same order.
+	hashedClients []string
+	// digest identifies the configuration in the cache keys, so that the verifications cached
+	// with a previous version of the configuration are not used.
+	digest [sha256.Size]byte
+}
+
+func newAPIKeyConfig(apiKeyAuth *ir.APIKeyAuth) (*apiKeyConfig, error) {
+	cfg := &apiKeyConfig{
+		APIKeyAuth: apiKeyAuth,
+		clients:    map[string]string{},
+		hashes:     map[string]passwordHash{},
+	}
+	h := sha256.New()
+	writeField := func(field string) {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+
+	for _, client := range sortedKeys(apiKeyAuth.Credentials) {
+		key := string(apiKeyAuth.Credentials[client])
+		cfg.clients[key] = client
+		writeField(client)
+		writeField(key)
+	}
+	for _, client := range sortedKeys(apiKeyAuth.HashedCredentials) {
+		hash, err := parsePasswordHash(string(apiKeyAuth.HashedCredentials[client]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid API key of client %s: %w", client, err)
+		}
+		cfg.hashes[client] = hash
+		cfg.hashedClients = append(cfg.hashedClients, client)
+		writeField(client)
+		writeField(string(apiKeyAuth.HashedCredentials[client]))
+	}
+	for _, client := range sortedKeys(apiKeyAuth.Metadata) {
+		metadata := apiKeyAuth.Metadata[client]
+		writeField(client)
+		writeField(metadata.Tenant)
+		writeField(metadata.Plan)
+		if metadata.ExpiresAt != nil {
+			writeField(metadata.ExpiresAt.UTC().String())
+		}
+	}
+	copy(cfg.digest[:], h.Sum(nil))
+	return cfg, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// checkAPIKey verifies the API key of the request with the API key auth configuration of the given name.
+func (s *Service) checkAPIKey(name string, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	s.mu.RLock()
+	cfg := s.apiKeyConfigs[name]
+	s.mu.RUnlock()
+	if cfg == nil {
+		return nil, status.Errorf(codes.NotFound, "api key auth %q not found", name)
+	}
+
+	httpReq := req.GetAttributes().GetRequest().GetHttp()
+	source, ok := cfg.extractKey(httpReq)
+	if !ok {
+		return apiKeyUnauthorized(), nil
+	}
+
+	key := cacheKey(cfg.Name, cfg.digest, "", source.key)
+	client, ok := s.cached(key)
+	if !ok {
+		if client, ok = cfg.verify(source.key); !ok {
+			return apiKeyUnauthorized(), nil
+		}
+		s.store(key, client)
+	}
+
+	// The expired API keys are removed from the configuration when they expire, they are also
+	// checked here so that they are rejected in the meantime.
+	metadata := cfg.Metadata[client]
+	if metadata != nil && metadata.ExpiresAt != nil && !s.now().Before(metadata.ExpiresAt.Time) {
+		return apiKeyUnauthorized(), nil
+	}
+
+	resp := &authv3.OkHttpResponse{}
+	if header := ptr.Deref(cfg.ForwardClientIDHeader, ""); header != "" {
+		resp.Headers = append(resp.Headers, &corev3.HeaderValueOption{
+			Header:       &corev3.HeaderValue{Key: header, Value: client},
+			AppendAction: corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+		})
+	}
+	if ptr.Deref(cfg.Sanitize, false) {
+		source.sanitize(httpReq, resp)
+	}
+
+	dynamicMetadata := map[string]any{APIKeyClientIDMetadataKey: client}
+	if metadata != nil && metadata.Tenant != "" {
+		dynamicMetadata[APIKeyTenantMetadataKey] = metadata.Tenant
+	}
+	if metadata != nil && metadata.Plan != "" {
+		dynamicMetadata[APIKeyPlanMetadataKey] = metadata.Plan
+	}
+	dynamicMetadataStruct, err := structpb.NewStruct(dynamicMetadata)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to build the dynamic metadata: %v", err)
+	}
+
+	return &authv3.CheckResponse{
+		Status:          &rpcstatus.Status{Code: int32(codes.OK)},
+		HttpResponse:    &authv3.CheckResponse_OkResponse{OkResponse: resp},
+		DynamicMetadata: dynamicMetadataStruct,
+	}, nil
+}
+
+// verify returns the client id of the API key, and whether the API key is valid.
+func (c *apiKeyConfig) verify(key string) (string, bool) {
+	for plain, client := range c.clients {
+		if subtle.ConstantTimeCompare([]byte(plain), []byte(key)) == 1 {
+			return client, true
+		}
+	}
+	for _, client := range c.hashedClients {
+		if c.hashes[client].verify(key) {
+			return client, true
+		}
+	}
+	return "", false
+}
+
+// apiKeySource is where the API key of a request was found.
+type apiKeySource struct {
+	key    string
+	header string
+	param  string
+	cookie string
+}
+
+// extractKey returns the API key from the first source of the configuration that has a key, in
+// the same order as the api_key_auth filter of Envoy.
+func (c *apiKeyConfig) extractKey(httpReq *authv3.AttributeContext_HttpRequest) (*apiKeySource, bool) {
+	headers := httpReq.GetHeaders()
+	var query url.Values
+	if _, rawQuery, found := strings.Cut(httpReq.GetPath(), "?"); found {
+		query, _ = url.ParseQuery(rawQuery)
+	}
+	cookies := &http.Request{Header: http.Header{"Cookie": []string{headers["cookie"]}}}
+
+	for _, e := range c.ExtractFrom {
+		for _, header := range e.Headers {
+			if key := headers[strings.ToLower(header)]; key != "" {
+				return &apiKeySource{key: key, header: header}, true
+			}
+		}
+		for _, param := range e.Params {
+			if key := query.Get(param); key != "" {
+				return &apiKeySource{key: key, param: param}, true
+			}
+		}
+		for _, cookie := range e.Cookies {
+			if value, err := cookies.Cookie(cookie); err == nil && value.Value != "" {
+				return &apiKeySource{key: value.Value, cookie: cookie}, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// sanitize removes the API key from the request forwarded to the backend.
+func (s *apiKeySource) sanitize(httpReq *authv3.AttributeContext_HttpRequest, resp *authv3.OkHttpResponse) {
+	switch {
+	case s.header != "":
+		resp.HeadersToRemove = append(resp.HeadersToRemove, s.header)
+	case s.param != "":
+		resp.QueryParametersToRemove = append(resp.QueryParametersToRemove, s.param)
+	case s.cookie != "":
+		var cookies []string
+		for _, cookie := range strings.Split(httpReq.GetHeaders()["cookie"], ";") {
+			name, _, _ := strings.Cut(strings.TrimSpace(cookie), "=")
+			if name != s.cookie && strings.TrimSpace(cookie) != "" {
+				cookies = append(cookies, strings.TrimSpace(cookie))
+			}
+		}
+		if len(cookies) == 0 {
+			resp.HeadersToRemove = append(resp.HeadersToRemove, "cookie")
+			return
+		}
+		resp.Headers = append(resp.Headers, &corev3.HeaderValueOption{
+			Header:       &corev3.HeaderValue{Key: "cookie", Value: strings.Join(cookies, "; ")},
+			AppendAction: corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+		})
+	}
+}
+
+// apiKeyUnauthorized returns a response denying the request in the same way as the api_key_auth filter of Envoy.
+func apiKeyUnauthorized() *authv3.CheckResponse {
+	return &authv3.CheckResponse{
+		Status: &rpcstatus.Status{Code: int32(codes.Unauthenticated)},
+		HttpResponse: &authv3.CheckResponse_DeniedResponse{DeniedResponse: &authv3.DeniedHttpResponse{
+			Status: &typev3.HttpStatus{Code: typev3.StatusCode_Unauthorized},
+			Body:   "Client authentication failed.",
+		}},
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package basicauth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func apiKeyCheckRequest(name, path string, headers map[string]string) *authv3.CheckRequest {
+	return &authv3.CheckRequest{Attributes: &authv3.AttributeContext{
+		ContextExtensions: map[string]string{APIKeyContextExtensionKey: name},
+		Request: &authv3.AttributeContext_Request{Http: &authv3.AttributeContext_HttpRequest{
+			Host:    "www.example.com",
+			Path:    path,
+			Headers: headers,
+		}},
+	}}
+}
+
+func TestCheckAPIKey(t *testing.T) {
+	s := New()
+	now := time.Unix(1700000000, 0)
+	s.now = func() time.Time { return now }
+
+	apiKeyAuth := &ir.APIKeyAuth{
+		Name: "securitypolicy/default/policy-for-route",
+		Credentials: map[string]ir.PrivateBytes{
+			"plain-client": []byte("plain-key"),
+		},
+		HashedCredentials: map[string]ir.PrivateBytes{
+			"bcrypt-client": []byte(bcryptPassword(t, "bcrypt-key")),
+			"argon2-client": []byte(argon2Password(true, "argon2-key")),
+		},
+		Metadata: map[string]*ir.APIKeyMetadata{
+			"bcrypt-client": {Tenant: "acme", Plan: "gold"},
+			"argon2-client": {Plan: "trial", ExpiresAt: ptr.To(metav1.NewTime(now.Add(time.Hour)))},
+		},
+		ExtractFrom: []*ir.ExtractFrom{
+			{Headers: []string{"X-API-KEY"}},
+			{Params: []string{"api-key"}},
+			{Cookies: []string{"api-key"}},
+		},
+		ForwardClientIDHeader: ptr.To("x-client-id"),
+		Sanitize:              ptr.To(true),
+	}
+	plainOnly := &ir.APIKeyAuth{
+		Name:        "securitypolicy/default/plain-only",
+		Credentials: map[string]ir.PrivateBytes{"client": []byte("key")},
+	}
+	xds := &ir.Xds{HTTP: []*ir.HTTPListener{{
+		Routes: []*ir.HTTPRoute{
+			{Security: &ir.SecurityFeatures{APIKeyAuth: apiKeyAuth}},
+			{Security: &ir.SecurityFeatures{APIKeyAuth: apiKeyAuth}},
+			{Security: &ir.SecurityFeatures{APIKeyAuth: plainOnly}},
+		},
+	}}}
+	require.NoError(t, s.Update("default/eg", xds))
+	require.Len(t, s.irConfigs["default/eg"].apiKeyAuth, 1)
+
+	ctx := context.Background()
+
+	resp, err := s.Check(ctx, apiKeyCheckRequest(apiKeyAuth.Name, "/", map[string]string{"x-api-key": "bcrypt-key"}))
+	require.NoError(t, err)
+	require.NotNil(t, resp.GetOkResponse())
+	require.Len(t, resp.GetOkResponse().Headers, 1)
+	assert.Equal(t, "x-client-id", resp.GetOkResponse().Headers[0].Header.Key)
+	assert.Equal(t, "bcrypt-client", resp.GetOkResponse().Headers[0].Header.Value)
+	assert.Equal(t, []string{"X-API-KEY"}, resp.GetOkResponse().HeadersToRemove)
+	assert.Equal(t, map[string]any{"client_id": "bcrypt-client", "tenant": "acme", "plan": "gold"},
+		resp.DynamicMetadata.AsMap())
+	assert.Len(t, s.cache, 1)
+
+	resp, err = s.Check(ctx, apiKeyCheckRequest(apiKeyAuth.Name, "/foo?bar=baz&api-key=plain-key", nil))
+	require.NoError(t, err)
+	require.NotNil(t, resp.GetOkResponse())
+	assert.Equal(t, []string{"api-key"}, resp.GetOkResponse().QueryParametersToRemove)
+	assert.Equal(t, map[string]any{"client_id": "plain-client"}, resp.DynamicMetadata.AsMap())
+
+	resp, err = s.Check(ctx, apiKeyCheckRequest(apiKeyAuth.Name, "/",
+		map[string]string{"cookie": "session=abc; api-key=argon2-key; theme=dark"}))
+	require.NoError(t, err)
+	require.NotNil(t, resp.GetOkResponse())
+	require.Len(t, resp.GetOkResponse().Headers, 2)
+	assert.Equal(t, "cookie", resp.GetOkResponse().Headers[1].Header.Key)
+	assert.Equal(t, "session=abc; theme=dark", resp.GetOkResponse().Headers[1].Header.Value)
+	assert.Equal(t, map[string]any{"client_id": "argon2-client", "plan": "trial"}, resp.DynamicMetadata.AsMap())
+
+	for _, headers := range []map[string]string{
+		nil,
+		{"x-api-key": "wrong"},
+		{"x-api-key": string(apiKeyAuth.HashedCredentials["bcrypt-client"])},
+	} {
+		resp, err = s.Check(ctx, apiKeyCheckRequest(apiKeyAuth.Name, "/", headers))
+		require.NoError(t, err)
+		require.NotNil(t, resp.GetDeniedResponse(), headers)
+		assert.Equal(t, typev3.StatusCode_Unauthorized, resp.GetDeniedResponse().Status.Code)
+	}
+
+	// The expired API keys are rejected, even if their verification is cached.
+	now = now.Add(time.Hour)
+	resp, err = s.Check(ctx, apiKeyCheckRequest(apiKeyAuth.Name, "/", map[string]string{"cookie": "api-key=argon2-key"}))
+	require.NoError(t, err)
+	require.NotNil(t, resp.GetDeniedResponse())
+
+	// The configurations only verified by Envoy are not served.
+	_, err = s.Check(ctx, apiKeyCheckRequest(plainOnly.Name, "/", map[string]string{"x-api-key": "key"}))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	s.Delete("default/eg")
+	_, err = s.Check(ctx, apiKeyCheckRequest(apiKeyAuth.Name, "/", map[string]string{"x-api-key": "plain-key"}))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,170 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package basicauth
+
+import (
+	"crypto/sha1" // nolint: gosec
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// passwordHash is the hash of the password of a htpasswd user.
+type passwordHash interface {
+	// verify returns whether the password matches the hash.
+	verify(password string) bool
+}
+
+// ValidateHtpasswd returns an error if the htpasswd data can't be verified by the service:
+// a line is malformed, a user is duplicated, or a password is not hashed with one of the
+// supported algorithms: SHA, bcrypt and argon2.
+func ValidateHtpasswd(data []byte) error {
+	_, err := parseHtpasswd(data)
+	return err
+}
+
+// ValidatePasswordHash returns an error if the password hash can't be verified by the service:
+// it is not hashed with one of the supported algorithms, SHA, bcrypt and argon2, or is malformed.
+func ValidatePasswordHash(hash string) error {
+	_, err := parsePasswordHash(hash)
+	return err
+}
+
+// parseHtpasswd returns the password hashes of the users in htpasswd data.
+func parseHtpasswd(data []byte) (map[string]passwordHash, error) {
+	users := map[string]passwordHash{}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		username, password, found := strings.Cut(line, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid htpasswd format: each line must be in the format 'username:password'")
+		}
+		if _, ok := users[username]; ok {
+			return nil, fmt.Errorf("invalid htpasswd format: user %s is duplicated", username)
+		}
+		hash, err := parsePasswordHash(password)
+		if err != nil {
+			return nil, fmt.Errorf("invalid password of user %s: %w", username, err)
+		}
+		users[username] = hash
+	}
+	return users, nil
+}
+
+func parsePasswordHash(password string) (passwordHash, error) {
+	switch {
+	case strings.HasPrefix(password, "{SHA}"):
+		return shaHash(strings.TrimPrefix(password, "{SHA}")), nil
+	case strings.HasPrefix(password, "$2a$"),
+		strings.HasPrefix(password, "$2b$"),
+		strings.HasPrefix(password, "$2y$"):
+		if _, err := bcrypt.Cost([]byte(password)); err != nil {
+			return nil, err
+		}
+		return bcryptHash(password), nil
+	case strings.HasPrefix(password, "$argon2i$"),
+		strings.HasPrefix(password, "$argon2id$"):
+		return parseArgon2Hash(password)
+	default:
+		return nil, fmt.Errorf("unsupported hash algorithm")
+	}
+}
+
+// shaHash is the base64 encoded SHA-1 hash of a password.
+type shaHash string
+
+func (h shaHash) verify(password string) bool {
+	sum := sha1.Sum([]byte(password)) // nolint: gosec
+	return subtle.ConstantTimeCompare([]byte(base64.StdEncoding.EncodeToString(sum[:])), []byte(h)) == 1
+}
+
+// bcryptHash is a bcrypt hash in the modular crypt format, e.g. "$2y$10$...".
+type bcryptHash string
+
+func (h bcryptHash) verify(password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(h), []byte(password)) == nil
+}
+
+// argon2Hash is an argon2i or argon2id hash.
+type argon2Hash struct {
+	id      bool
+	memory  uint32
+	time    uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+// parseArgon2Hash parses an argon2 hash in the PHC string format,
+// e.g. "$argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>".
+func parseArgon2Hash(password string) (*argon2Hash, error) {
+	parts := strings.Split(password, "$")
+	if len(parts) != 6 {
+		return nil, fmt.Errorf("invalid argon2 hash format")
+	}
+	if parts[2] != "v="+strconv.Itoa(argon2.Version) {
+		return nil, fmt.Errorf("unsupported argon2 version %s", parts[2])
+	}
+
+	h := &argon2Hash{id: parts[1] == "argon2id"}
+	for _, param := range strings.Split(parts[3], ",") {
+		name, value, _ := strings.Cut(param, "=")
+		var (
+			v   uint64
+			err error
+		)
+		switch name {
+		case "m":
+			v, err = strconv.ParseUint(value, 10, 32)
+			h.memory = uint32(v)
+		case "t":
+			v, err = strconv.ParseUint(value, 10, 32)
+			h.time = uint32(v)
+		case "p":
+			v, err = strconv.ParseUint(value, 10, 8)
+			h.threads = uint8(v)
+		default:
+			err = fmt.Errorf("unknown parameter %s", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid argon2 parameters: %w", err)
+		}
+	}
+	if h.memory == 0 || h.time == 0 || h.threads == 0 {
+		return nil, fmt.Errorf("invalid argon2 parameters: m, t and p must be greater than 0")
+	}
+
+	var err error
+	if h.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, fmt.Errorf("invalid argon2 salt: %w", err)
+	}
+	if h.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return nil, fmt.Errorf("invalid argon2 hash: %w", err)
+	}
+	if len(h.key) == 0 {
+		return nil, fmt.Errorf("invalid argon2 hash: the hash must not be empty")
+	}
+	return h, nil
+}
+
+func (h *argon2Hash) verify(password string) bool {
+	var key []byte
+	if h.id {
+		key = argon2.IDKey([]byte(password), h.salt, h.time, h.memory, h.threads, uint32(len(h.key)))
+	} else {
+		key = argon2.Key([]byte(password), h.salt, h.time, h.memory, h.threads, uint32(len(h.key)))
+	}
+	return subtle.ConstantTimeCompare(key, h.key) == 1
+}
@@ -0,0 +1,100 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package basicauth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// ldapTimeout is the timeout of the connection and of each request to an LDAP server.
+const ldapTimeout = 3 * time.Second
+
+// verifyLDAP returns whether the username and password are the credentials of a user of the LDAP directory.
+// It returns an error if the directory can't be queried, which must not be mistaken for invalid credentials.
+func verifyLDAP(store *ir.LDAPUserStore, username, password string) (bool, error) {
+	// An empty password would be an unauthenticated bind, which most LDAP servers accept for any DN.
+	if username == "" || password == "" {
+		return false, nil
+	}
+
+	tlsConfig, err := ldapTLSConfig(store)
+	if err != nil {
+		return false, err
+	}
+
+	conn, err := ldap.DialURL(store.URL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: ldapTimeout}), ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	conn.SetTimeout(ldapTimeout)
+
+	if store.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			return false, err
+		}
+	}
+
+	if err := conn.Bind(store.BindDN, string(store.BindPassword)); err != nil {
+		return false, fmt.Errorf("failed to bind with %s: %w", store.BindDN, err)
+	}
+
+	// Two entries are enough to know that the filter doesn't match exactly one user.
+	result, err := conn.Search(ldap.NewSearchRequest(
+		store.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, int(ldapTimeout.Seconds()), false,
+		ldapSearchFilter(store.SearchFilter, username), []string{"dn"}, nil))
+	switch {
+	case ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded), ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("failed to search for user: %w", err)
+	case len(result.Entries) != 1:
+		return false, nil
+	}
+
+	if err := conn.Bind(result.Entries[0].DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to bind with %s: %w", result.Entries[0].DN, err)
+	}
+	return true, nil
+}
+
+// ldapTLSConfig returns the TLS configuration of the connections to the LDAP server, which verifies
+// the certificate of the server with the CA certificates of the store if any, or with the trusted
+// CA certificates of Envoy Gateway otherwise.
+func ldapTLSConfig(store *ir.LDAPUserStore) (*tls.Config, error) {
+	u, err := url.Parse(store.URL)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+	if len(store.CACertificate) > 0 {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(store.CACertificate) {
+			return nil, errors.New("failed to parse the CA certificates of the LDAP server")
+		}
+	}
+	return tlsConfig, nil
+}
+
+// ldapSearchFilter returns the search filter with "%s" replaced by the escaped username.
+func ldapSearchFilter(filter, username string) string {
+	return strings.ReplaceAll(filter, "%s", ldap.EscapeFilter(username))
+}
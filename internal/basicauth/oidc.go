@@ -0,0 +1,475 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package basicauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// OIDCContextExtensionKey is the key of the context extension holding the name of the
+	// OIDC configuration the session of a request is checked with.
+	OIDCContextExtensionKey = "oidc"
+
+	// backchannelLogoutEvent is the member of the events claim identifying a logout token.
+	backchannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+	// logoutRetention is how long the sessions ended by a back-channel logout are remembered,
+	// which is the default lifetime of the refresh tokens of the oauth filter.
+	logoutRetention = 7 * 24 * time.Hour
+	// jwksRefreshInterval is the minimum interval between two fetches of the JWKS of a provider,
+	// so that logout tokens with unknown key ids can't make Envoy Gateway flood the provider.
+	jwksRefreshInterval = time.Minute
+	// oidcRequestTimeout is the timeout of each request to the OIDC Provider. A session may
+	// need two requests, which must complete within the timeout of the ext_authz filter.
+	oidcRequestTimeout = 4 * time.Second
+	// maxOIDCResponseSize is the maximum size of the responses of the OIDC Provider.
+	maxOIDCResponseSize = 1 << 20
+)
+
+// signatureAlgorithms are the algorithms the logout tokens can be signed with.
+var signatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// errInactiveToken is returned when the OIDC Provider rejects the access token of a session.
+var errInactiveToken = errors.New("the access token is not active")
+
+type oidcConfig struct {
+	*ir.OIDC
+	// client sends the requests to the OIDC Provider.
+	client *http.Client
+	// digest identifies the configuration in the cache keys, so that the sessions cached
+	// with a previous version of the configuration are not used.
+	digest [sha256.Size]byte
+
+	jwksMu sync.Mutex
+	// jwks holds the keys of the OIDC Provider the logout tokens are validated with.
+	jwks *jose.JSONWebKeySet
+	// jwksFetched is the last time the JWKS was fetched at.
+	jwksFetched time.Time
+}
+
+func newOIDCConfig(oidc *ir.OIDC) (*oidcConfig, error) {
+	cfg := &oidcConfig{
+		OIDC:   oidc,
+		client: &http.Client{Timeout: oidcRequestTimeout},
+	}
+	if oidc.Provider.Destination != nil {
+		for _, setting := range oidc.Provider.Destination.Settings {
+			if setting.TLS == nil {
+				continue
+			}
+			tlsConfig, err := setting.TLS.ToTLSConfig()
+			if err != nil {
+				return nil, fmt.Errorf("invalid TLS configuration of the provider: %w", err)
+			}
+			transport := http.DefaultTransport.(*http.Transport).Clone()
+			transport.TLSClientConfig = tlsConfig
+			cfg.client.Transport = transport
+			break
+		}
+	}
+
+	h := sha256.New()
+	session := oidc.Session
+	for _, field := range []string{
+		oidc.ClientID, string(oidc.ClientSecret), oidc.AccessTokenCookieName(), oidc.IDTokenCookieName(),
+		session.Issuer, session.JWKSURI, session.IntrospectionEndpoint, session.UserInfoEndpoint,
+	} {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+	copy(cfg.digest[:], h.Sum(nil))
+	return cfg, nil
+}
+
+// checkOIDC checks the session of the request with the OIDC configuration of the given name, or
+// handles the back-channel logout request.
+func (s *Service) checkOIDC(ctx context.Context, name string, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	s.mu.RLock()
+	cfg := s.oidcConfigs[name]
+	s.mu.RUnlock()
+	if cfg == nil {
+		return nil, status.Errorf(codes.NotFound, "oidc %q not found", name)
+	}
+
+	httpReq := req.GetAttributes().GetRequest().GetHttp()
+	path, _, _ := strings.Cut(httpReq.GetPath(), "?")
+	if cfg.Session.BackchannelLogoutPath != "" && path == cfg.Session.BackchannelLogoutPath {
+		return s.backchannelLogout(ctx, cfg, httpReq), nil
+	}
+
+	cookies := &http.Request{Header: http.Header{"Cookie": []string{httpReq.GetHeaders()["cookie"]}}}
+	if idToken, err := cookies.Cookie(cfg.IDTokenCookieName()); err == nil && s.sessionEnded(cfg.Name, idToken.Value) {
+		return cfg.endSession(), nil
+	}
+
+	// The requests without an access token, e.g. the requests with a bearer token the oauth
+	// filter passes through, don't have a session to check.
+	accessToken, err := cookies.Cookie(cfg.AccessTokenCookieName())
+	if err != nil || accessToken.Value == "" ||
+		(cfg.Session.IntrospectionEndpoint == "" && len(cfg.Session.UserInfoToHeaders) == 0) {
+		return oidcOK(nil), nil
+	}
+
+	key := cacheKey(cfg.Name, cfg.digest, "", accessToken.Value)
+	entry, ok := s.cachedEntry(key)
+	if !ok {
+		ttl := cacheTTL
+		if cfg.Session.IntrospectionEndpoint != "" {
+			expiry, err := cfg.introspect(ctx, accessToken.Value)
+			if errors.Is(err, errInactiveToken) {
+				return cfg.endSession(), nil
+			}
+			if err != nil {
+				return nil, status.Errorf(codes.Unavailable, "failed to introspect the access token: %v", err)
+			}
+			// An active result is not cached beyond the expiry of the access token.
+			if expiry != nil && expiry.Sub(s.now()) < ttl {
+				ttl = expiry.Sub(s.now())
+			}
+		}
+		if len(cfg.Session.UserInfoToHeaders) > 0 {
+			if entry.claims, err = cfg.userInfo(ctx, accessToken.Value); errors.Is(err, errInactiveToken) {
+				return cfg.endSession(), nil
+			} else if err != nil {
+				return nil, status.Errorf(codes.Unavailable, "failed to get the user info: %v", err)
+			}
+		}
+		if ttl > 0 {
+			s.storeEntry(key, entry, ttl)
+		}
+	}
+
+	var headers []*corev3.HeaderValueOption
+	for _, claimToHeader := range cfg.Session.UserInfoToHeaders {
+		if value, ok := claimValue(entry.claims, claimToHeader.Claim); ok {
+			headers = append(headers, &corev3.HeaderValueOption{
+				Header:       &corev3.HeaderValue{Key: claimToHeader.Header, Value: value},
+				AppendAction: corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+			})
+		}
+	}
+	return oidcOK(headers), nil
+}
+
+func oidcOK(headers []*corev3.HeaderValueOption) *authv3.CheckResponse {
+	return &authv3.CheckResponse{
+		Status:       &rpcstatus.Status{Code: int32(codes.OK)},
+		HttpResponse: &authv3.CheckResponse_OkResponse{OkResponse: &authv3.OkHttpResponse{Headers: headers}},
+	}
+}
+
+// endSession returns a response redirecting the request of an ended session to the logout path,
+// where the oauth filter clears the credential cookies.
+func (c *oidcConfig) endSession() *authv3.CheckResponse {
+	return &authv3.CheckResponse{
+		Status: &rpcstatus.Status{Code: int32(codes.Unauthenticated)},
+		HttpResponse: &authv3.CheckResponse_DeniedResponse{DeniedResponse: &authv3.DeniedHttpResponse{
+			Status: &typev3.HttpStatus{Code: typev3.StatusCode_Found},
+			Headers: []*corev3.HeaderValueOption{{
+				Header: &corev3.HeaderValue{Key: "location", Value: c.LogoutPath},
+			}},
+		}},
+	}
+}
+
+// idTokenClaims are the claims of the ID token identifying its session.
+type idTokenClaims struct {
+	Subject   string           `json:"sub,omitempty"`
+	SessionID string           `json:"sid,omitempty"`
+	IssuedAt  *jwt.NumericDate `json:"iat,omitempty"`
+}
+
+// sessionEnded returns true if the session of the ID token was ended by a back-channel logout.
+// The signature of the ID token isn't verified, as the oauth filter verifies the HMAC of the
+// cookies, which covers the ID token.
+func (s *Service) sessionEnded(name, rawIDToken string) bool {
+	token, err := jwt.ParseSigned(rawIDToken, signatureAlgorithms)
+	if err != nil {
+		return false
+	}
+	var claims idTokenClaims
+	if err := token.UnsafeClaimsWithoutVerification(&claims); err != nil {
+		return false
+	}
+
+	s.logoutsMu.Lock()
+	defer s.logoutsMu.Unlock()
+	logouts := s.logouts[name]
+	if _, ok := logouts[sessionLogoutKey(claims.SessionID)]; ok && claims.SessionID != "" {
+		return true
+	}
+	// A logout of the subject ends the sessions that were established before it.
+	at, ok := logouts[subjectLogoutKey(claims.Subject)]
+	return ok && claims.Subject != "" && claims.IssuedAt != nil && !claims.IssuedAt.Time().After(at)
+}
+
+func sessionLogoutKey(sid string) string {
+	return "sid:" + sid
+}
+
+func subjectLogoutKey(sub string) string {
+	return "sub:" + sub
+}
+
+// logoutTokenClaims are the claims of a back-channel logout token.
+type logoutTokenClaims struct {
+	jwt.Claims
+	SessionID string                     `json:"sid,omitempty"`
+	Events    map[string]json.RawMessage `json:"events,omitempty"`
+	Nonce     json.RawMessage            `json:"nonce,omitempty"`
+}
+
+// backchannelLogout ends the sessions identified by the logout token of the request, and returns
+// the response to the OIDC Provider.
+func (s *Service) backchannelLogout(ctx context.Context, cfg *oidcConfig, httpReq *authv3.AttributeContext_HttpRequest) *authv3.CheckResponse {
+	if httpReq.GetMethod() != http.MethodPost {
+		return logoutResponse(typev3.StatusCode_MethodNotAllowed, "the logout token must be posted")
+	}
+	form, err := url.ParseQuery(httpReq.GetBody())
+	if err != nil || form.Get("logout_token") == "" {
+		return logoutResponse(typev3.StatusCode_BadRequest, "missing logout token")
+	}
+	claims, err := cfg.verifyLogoutToken(ctx, form.Get("logout_token"), s.now())
+	if err != nil {
+		return logoutResponse(typev3.StatusCode_BadRequest, err.Error())
+	}
+
+	s.logoutsMu.Lock()
+	defer s.logoutsMu.Unlock()
+	now := s.now()
+	for name, logouts := range s.logouts {
+		for key, at := range logouts {
+			if now.Sub(at) > logoutRetention {
+				delete(logouts, key)
+			}
+		}
+		if len(logouts) == 0 {
+			delete(s.logouts, name)
+		}
+	}
+	if s.logouts[cfg.Name] == nil {
+		s.logouts[cfg.Name] = map[string]time.Time{}
+	}
+	// The session id identifies the session to end, otherwise all the sessions of the subject are ended.
+	if claims.SessionID != "" {
+		s.logouts[cfg.Name][sessionLogoutKey(claims.SessionID)] = claims.IssuedAt.Time()
+	} else {
+		s.logouts[cfg.Name][subjectLogoutKey(claims.Subject)] = claims.IssuedAt.Time()
+	}
+	return logoutResponse(typev3.StatusCode_OK, "")
+}
+
+// logoutResponse returns the response to a back-channel logout request, which isn't forwarded
+// to the backend.
+func logoutResponse(code typev3.StatusCode, message string) *authv3.CheckResponse {
+	resp := &authv3.DeniedHttpResponse{
+		Status: &typev3.HttpStatus{Code: code},
+		Headers: []*corev3.HeaderValueOption{{
+			Header: &corev3.HeaderValue{Key: "cache-control", Value: "no-store"},
+		}},
+	}
+	if message != "" {
+		body, _ := json.Marshal(map[string]string{"error": "invalid_request", "error_description": message})
+		resp.Body = string(body)
+		resp.Headers = append(resp.Headers, &corev3.HeaderValueOption{
+			Header: &corev3.HeaderValue{Key: "content-type", Value: "application/json"},
+		})
+	}
+	return &authv3.CheckResponse{
+		Status:       &rpcstatus.Status{Code: int32(codes.PermissionDenied)},
+		HttpResponse: &authv3.CheckResponse_DeniedResponse{DeniedResponse: resp},
+	}
+}
+
+// verifyLogoutToken validates the logout token as specified by
+// https://openid.net/specs/openid-connect-backchannel-1_0.html#Validation.
+func (c *oidcConfig) verifyLogoutToken(ctx context.Context, rawToken string, now time.Time) (*logoutTokenClaims, error) {
+	token, err := jwt.ParseSigned(rawToken, signatureAlgorithms)
+	if err != nil {
+		return nil, fmt.Errorf("invalid logout token: %w", err)
+	}
+	keys, err := c.keys(ctx, token.Headers[0].KeyID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims *logoutTokenClaims
+	for _, key := range keys {
+		verified := &logoutTokenClaims{}
+		if err := token.Claims(key, verified); err == nil {
+			claims = verified
+			break
+		}
+	}
+	switch {
+	case claims == nil:
+		return nil, errors.New("invalid signature of the logout token")
+	case claims.ValidateWithLeeway(jwt.Expected{
+		Issuer:      c.Session.Issuer,
+		AnyAudience: jwt.Audience{c.ClientID},
+		Time:        now,
+	}, jwt.DefaultLeeway) != nil:
+		return nil, errors.New("invalid issuer, audience or time of the logout token")
+	case claims.IssuedAt == nil:
+		return nil, errors.New("missing iat claim in the logout token")
+	case claims.Events[backchannelLogoutEvent] == nil:
+		return nil, errors.New("missing back-channel logout event in the logout token")
+	case claims.SessionID == "" && claims.Subject == "":
+		return nil, errors.New("missing sid or sub claim in the logout token")
+	case claims.Nonce != nil:
+		return nil, errors.New("nonce claim not allowed in the logout token")
+	}
+	return claims, nil
+}
+
+// keys returns the keys of the OIDC Provider with the given key id, or all the keys if the key
+// id is empty. The JWKS is fetched again if it doesn't have the key id, e.g. after a key rotation.
+func (c *oidcConfig) keys(ctx context.Context, kid string, now time.Time) ([]jose.JSONWebKey, error) {
+	c.jwksMu.Lock()
+	defer c.jwksMu.Unlock()
+	if c.jwks == nil || (kid != "" && len(c.jwks.Key(kid)) == 0 && now.Sub(c.jwksFetched) >= jwksRefreshInterval) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.Session.JWKSURI, nil)
+		if err != nil {
+			return nil, err
+		}
+		jwks := &jose.JSONWebKeySet{}
+		if _, err := c.do(req, jwks); err != nil {
+			return nil, fmt.Errorf("failed to fetch the JWKS: %w", err)
+		}
+		c.jwks, c.jwksFetched = jwks, now
+	}
+	if kid != "" {
+		return c.jwks.Key(kid), nil
+	}
+	return c.jwks.Keys, nil
+}
+
+// introspectionResponse is the response of the
+// [introspection endpoint](https://datatracker.ietf.org/doc/html/rfc7662#section-2.2).
+type introspectionResponse struct {
+	Active bool             `json:"active"`
+	Expiry *jwt.NumericDate `json:"exp,omitempty"`
+}
+
+// introspect returns the expiry of the access token, if the OIDC Provider has one, or
+// errInactiveToken if the access token is not active.
+func (c *oidcConfig) introspect(ctx context.Context, accessToken string) (*time.Time, error) {
+	form := url.Values{"token": {accessToken}, "token_type_hint": {"access_token"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Session.IntrospectionEndpoint,
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// The client credentials are form-urlencoded, see https://datatracker.ietf.org/doc/html/rfc6749#section-2.3.1.
+	req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(string(c.ClientSecret)))
+
+	var resp introspectionResponse
+	if _, err := c.do(req, &resp); err != nil {
+		return nil, err
+	}
+	if !resp.Active {
+		return nil, errInactiveToken
+	}
+	if resp.Expiry == nil {
+		return nil, nil
+	}
+	expiry := resp.Expiry.Time()
+	return &expiry, nil
+}
+
+// userInfo returns the claims of the UserInfo response for the access token, or errInactiveToken
+// if the OIDC Provider rejects the access token.
+func (c *oidcConfig) userInfo(ctx context.Context, accessToken string) (map[string]any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.Session.UserInfoEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	claims := map[string]any{}
+	code, err := c.do(req, &claims)
+	if code == http.StatusUnauthorized {
+		return nil, errInactiveToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// do sends the request to the OIDC Provider, and decodes the JSON response into out.
+// It returns the status code of the response.
+func (c *oidcConfig) do(req *http.Request, out any) (int, error) {
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, req.URL.Redacted())
+	}
+	decoder := json.NewDecoder(io.LimitReader(resp.Body, maxOIDCResponseSize))
+	// The numbers are kept as is, so that the number claims are copied into the headers unchanged.
+	decoder.UseNumber()
+	if err := decoder.Decode(out); err != nil {
+		return resp.StatusCode, fmt.Errorf("invalid response from %s: %w", req.URL.Redacted(), err)
+	}
+	return resp.StatusCode, nil
+}
+
+// claimValue returns the value of the string, number or boolean claim, which can be a nested claim
+// whose names are separated by dots, e.g. "address.country".
+func claimValue(claims map[string]any, claim string) (string, bool) {
+	var value any = claims
+	for _, name := range strings.Split(claim, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return "", false
+		}
+		if value, ok = object[name]; !ok {
+			return "", false
+		}
+	}
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
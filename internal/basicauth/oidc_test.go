@@ -0,0 +1,235 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package basicauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func oidcCheckRequest(name, method, path, cookie, body string) *authv3.CheckRequest {
+	return &authv3.CheckRequest{Attributes: &authv3.AttributeContext{
+		ContextExtensions: map[string]string{OIDCContextExtensionKey: name},
+		Request: &authv3.AttributeContext_Request{Http: &authv3.AttributeContext_HttpRequest{
+			Method:  method,
+			Host:    "www.example.com",
+			Path:    path,
+			Headers: map[string]string{"cookie": cookie},
+			Body:    body,
+		}},
+	}}
+}
+
+func newSigner(t *testing.T, kid string) (jose.Signer, jose.JSONWebKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: key, KeyID: kid}},
+		(&jose.SignerOptions{}).WithType("JWT"))
+	require.NoError(t, err)
+	return signer, jose.JSONWebKey{Key: &key.PublicKey, KeyID: kid, Algorithm: string(jose.RS256), Use: "sig"}
+}
+
+func signToken(t *testing.T, signer jose.Signer, claims map[string]any) string {
+	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	require.NoError(t, err)
+	return token
+}
+
+func TestCheckOIDC(t *testing.T) {
+	signer, publicKey := newSigner(t, "key-1")
+	otherSigner, _ := newSigner(t, "key-1")
+
+	var introspections atomic.Int32
+	now := time.Unix(1700000000, 0)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{publicKey}})
+	})
+	mux.HandleFunc("/introspect", func(w http.ResponseWriter, r *http.Request) {
+		introspections.Add(1)
+		if id, secret, ok := r.BasicAuth(); !ok || id != "client+1" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		active := r.PostFormValue("token") == "active-token"
+		_ = json.NewEncoder(w).Encode(map[string]any{"active": active, "exp": now.Add(30 * time.Second).Unix()})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer active-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"sub":"user-1","email":"user@example.com","email_verified":true,"age":42,"address":{"country":"FR"},"groups":["admin"]}`))
+	})
+	provider := httptest.NewServer(mux)
+	defer provider.Close()
+
+	oidc := &ir.OIDC{
+		Name:         "securitypolicy/default/policy-for-route",
+		ClientID:     "client 1",
+		ClientSecret: []byte("secret"),
+		LogoutPath:   "/logout",
+		CookieSuffix: "1234abcd",
+		Session: &ir.OIDCSession{
+			Issuer:                provider.URL,
+			BackchannelLogoutPath: "/backchannel-logout",
+			JWKSURI:               provider.URL + "/jwks",
+			IntrospectionEndpoint: provider.URL + "/introspect",
+			UserInfoEndpoint:      provider.URL + "/userinfo",
+			UserInfoToHeaders: []egv1a1.ClaimToHeader{
+				{Header: "x-email", Claim: "email"},
+				{Header: "x-email-verified", Claim: "email_verified"},
+				{Header: "x-age", Claim: "age"},
+				{Header: "x-country", Claim: "address.country"},
+				{Header: "x-groups", Claim: "groups"},
+				{Header: "x-name", Claim: "name"},
+			},
+		},
+	}
+	s := New()
+	s.now = func() time.Time { return now }
+	require.NoError(t, s.Update("default/eg", &ir.Xds{HTTP: []*ir.HTTPListener{{
+		Routes: []*ir.HTTPRoute{
+			{Security: &ir.SecurityFeatures{OIDC: oidc}},
+			{Security: &ir.SecurityFeatures{OIDC: oidc}},
+		},
+	}}}))
+	require.Len(t, s.irConfigs["default/eg"].oidc, 1)
+
+	ctx := context.Background()
+	idToken := func(claims map[string]any) string {
+		return signToken(t, signer, claims)
+	}
+	cookie := func(accessToken, idToken string) string {
+		return "AccessToken-1234abcd=" + accessToken + "; IdToken-1234abcd=" + idToken
+	}
+	check := func(path, cookie string) *authv3.CheckResponse {
+		resp, err := s.Check(ctx, oidcCheckRequest(oidc.Name, http.MethodGet, path, cookie, ""))
+		require.NoError(t, err)
+		return resp
+	}
+	requireEnded := func(resp *authv3.CheckResponse) {
+		require.NotNil(t, resp.GetDeniedResponse())
+		assert.Equal(t, typev3.StatusCode_Found, resp.GetDeniedResponse().Status.Code)
+		assert.Equal(t, "/logout", resp.GetDeniedResponse().Headers[0].Header.Value)
+	}
+	session1 := cookie("active-token", idToken(map[string]any{"sub": "user-1", "sid": "session-1", "iat": now.Add(-time.Hour).Unix()}))
+
+	// The UserInfo claims are extracted into the headers, the active access tokens are cached.
+	for range 2 {
+		resp := check("/foo", session1)
+		require.NotNil(t, resp.GetOkResponse())
+		headers := map[string]string{}
+		for _, header := range resp.GetOkResponse().Headers {
+			headers[header.Header.Key] = header.Header.Value
+		}
+		assert.Equal(t, map[string]string{
+			"x-email":          "user@example.com",
+			"x-email-verified": "true",
+			"x-age":            "42",
+			"x-country":        "FR",
+		}, headers)
+	}
+	assert.Equal(t, int32(1), introspections.Load())
+
+	// The active result isn't cached beyond the expiry of the access token.
+	now = now.Add(31 * time.Second)
+	require.NotNil(t, check("/foo", session1).GetOkResponse())
+	assert.Equal(t, int32(2), introspections.Load())
+
+	// The sessions whose access token isn't active are ended.
+	requireEnded(check("/foo", cookie("revoked-token", "")))
+
+	// The requests without a session are passed through.
+	resp := check("/foo", "")
+	require.NotNil(t, resp.GetOkResponse())
+	assert.Empty(t, resp.GetOkResponse().Headers)
+
+	logout := func(method, body string) *authv3.DeniedHttpResponse {
+		resp, err := s.Check(ctx, oidcCheckRequest(oidc.Name, method, "/backchannel-logout", "", body))
+		require.NoError(t, err)
+		require.NotNil(t, resp.GetDeniedResponse())
+		return resp.GetDeniedResponse()
+	}
+	logoutToken := func(signer jose.Signer, claims map[string]any) string {
+		token := map[string]any{
+			"iss":    provider.URL,
+			"aud":    "client 1",
+			"iat":    now.Unix(),
+			"jti":    "logout-1",
+			"events": map[string]any{backchannelLogoutEvent: map[string]any{}},
+		}
+		for k, v := range claims {
+			if v == nil {
+				delete(token, k)
+			} else {
+				token[k] = v
+			}
+		}
+		return url.Values{"logout_token": {signToken(t, signer, token)}}.Encode()
+	}
+
+	assert.Equal(t, typev3.StatusCode_MethodNotAllowed, logout(http.MethodGet, "").Status.Code)
+	for name, body := range map[string]string{
+		"missing token":   "",
+		"invalid token":   "logout_token=invalid",
+		"other signer":    logoutToken(otherSigner, map[string]any{"sid": "session-1"}),
+		"other issuer":    logoutToken(signer, map[string]any{"sid": "session-1", "iss": "https://other.example.com"}),
+		"other audience":  logoutToken(signer, map[string]any{"sid": "session-1", "aud": "other"}),
+		"missing iat":     logoutToken(signer, map[string]any{"sid": "session-1", "iat": nil}),
+		"missing event":   logoutToken(signer, map[string]any{"sid": "session-1", "events": map[string]any{}}),
+		"missing sid/sub": logoutToken(signer, map[string]any{}),
+		"nonce":           logoutToken(signer, map[string]any{"sid": "session-1", "nonce": "n"}),
+	} {
+		resp := logout(http.MethodPost, body)
+		assert.Equal(t, typev3.StatusCode_BadRequest, resp.Status.Code, name)
+		assert.Contains(t, resp.Body, "invalid_request", name)
+	}
+	require.NotNil(t, check("/foo", session1).GetOkResponse())
+
+	// A logout with a session id ends the session.
+	resp200 := logout(http.MethodPost, logoutToken(signer, map[string]any{"sub": "user-1", "sid": "session-1"}))
+	assert.Equal(t, typev3.StatusCode_OK, resp200.Status.Code)
+	requireEnded(check("/foo", session1))
+	require.NotNil(t, check("/foo", cookie("active-token",
+		idToken(map[string]any{"sub": "user-1", "sid": "session-2", "iat": now.Unix()}))).GetOkResponse())
+
+	// A logout without a session id ends the sessions of the subject established before it.
+	assert.Equal(t, typev3.StatusCode_OK, logout(http.MethodPost, logoutToken(signer, map[string]any{"sub": "user-2"})).Status.Code)
+	requireEnded(check("/foo", cookie("active-token",
+		idToken(map[string]any{"sub": "user-2", "iat": now.Add(-time.Minute).Unix()}))))
+	require.NotNil(t, check("/foo", cookie("active-token",
+		idToken(map[string]any{"sub": "user-2", "iat": now.Add(time.Minute).Unix()}))).GetOkResponse())
+
+	// The logouts are forgotten after the retention.
+	now = now.Add(logoutRetention + time.Minute)
+	assert.Equal(t, typev3.StatusCode_OK, logout(http.MethodPost, logoutToken(signer, map[string]any{"sub": "user-3"})).Status.Code)
+	assert.Len(t, s.logouts[oidc.Name], 1)
+
+	s.Delete("default/eg")
+	_, err := s.Check(ctx, oidcCheckRequest(oidc.Name, http.MethodGet, "/foo", session1, ""))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,341 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package basicauth implements the Envoy external authorization service verifying the
+// credentials that the basic_auth and api_key_auth filters of Envoy can't verify: the bcrypt
+// and argon2 htpasswd passwords, the users of LDAP directories, and the hashed API keys or
+// API keys with metadata. It also checks the OIDC sessions that the oauth2 filter of Envoy
+// can't check: it handles the back-channel logouts, introspects the access tokens and extracts
+// the UserInfo claims into headers.
+package basicauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// ContextExtensionKey is the key of the context extension holding the name of the
+	// basic auth configuration a request is verified with.
+	ContextExtensionKey = "basic-auth"
+	// cacheTTL is how long a successful verification is cached, so that the password isn't
+	// hashed, or the LDAP directory queried, for each request.
+	cacheTTL = time.Minute
+	// maxCacheSize is the maximum number of cached verifications.
+	maxCacheSize = 10000
+)
+
+// Service implements the Envoy external authorization service with the basic auth, API key
+// auth and OIDC configurations of the xds IRs that are verified by Envoy Gateway.
+type Service struct {
+	authv3.UnimplementedAuthorizationServer
+
+	mu sync.RWMutex
+	// irConfigs holds the configurations of each xds IR by the IR key.
+	irConfigs map[string]*irConfig
+	// configs holds the basic auth configurations of all the xds IRs by their name.
+	configs map[string]*config
+	// apiKeyConfigs holds the API key auth configurations of all the xds IRs by their name.
+	apiKeyConfigs map[string]*apiKeyConfig
+	// oidcConfigs holds the OIDC configurations of all the xds IRs by their name.
+	oidcConfigs map[string]*oidcConfig
+
+	cacheMu sync.Mutex
+	// cache holds the successful verifications by their cache key.
+	cache map[[sha256.Size]byte]cacheEntry
+	now   func() time.Time
+
+	logoutsMu sync.Mutex
+	// logouts holds the times of the back-channel logouts by the session or subject they ended,
+	// for each OIDC configuration name.
+	logouts map[string]map[string]time.Time
+}
+
+// irConfig holds the configurations verified by Envoy Gateway of an xds IR.
+type irConfig struct {
+	basicAuth  []*config
+	apiKeyAuth []*apiKeyConfig
+	oidc       []*oidcConfig
+}
+
+// cacheEntry is a successful verification of credentials.
+type cacheEntry struct {
+	// expiry is the time the verification must be done again at.
+	expiry time.Time
+	// client is the username or the client id of the verified credentials.
+	client string
+	// claims holds the UserInfo claims of the access token of an OIDC session.
+	claims map[string]any
+}
+
+type config struct {
+	*ir.BasicAuth
+	// users holds the password hashes of the htpasswd users, if the LDAP directory isn't used.
+	users map[string]passwordHash
+	// digest identifies the configuration in the cache keys, so that the verifications cached
+	// with a previous version of the configuration are not used.
+	digest [sha256.Size]byte
+}
+
+// New returns a new Service without any basic auth configuration.
+func New() *Service {
+	return &Service{
+		irConfigs:     map[string]*irConfig{},
+		configs:       map[string]*config{},
+		apiKeyConfigs: map[string]*apiKeyConfig{},
+		oidcConfigs:   map[string]*oidcConfig{},
+		cache:         map[[sha256.Size]byte]cacheEntry{},
+		now:           time.Now,
+		logouts:       map[string]map[string]time.Time{},
+	}
+}
+
+// Update replaces the basic auth, API key auth and OIDC configurations of the xds IR with the given key.
+func (s *Service) Update(key string, xds *ir.Xds) error {
+	var (
+		configs    = &irConfig{}
+		seen       = sets.New[string]()
+		seenAPIKey = sets.New[string]()
+		seenOIDC   = sets.New[string]()
+		errs       error
+	)
+	for _, listener := range xds.HTTP {
+		for _, route := range listener.Routes {
+			if route.Security == nil {
+				continue
+			}
+			if basicAuth := route.Security.BasicAuth; basicAuth != nil &&
+				basicAuth.VerifiedByEnvoyGateway() && !seen.Has(basicAuth.Name) {
+				seen.Insert(basicAuth.Name)
+				cfg, err := newConfig(basicAuth)
+				if err != nil {
+					errs = errors.Join(errs, fmt.Errorf("invalid basic auth %s: %w", basicAuth.Name, err))
+				} else {
+					configs.basicAuth = append(configs.basicAuth, cfg)
+				}
+			}
+			if apiKeyAuth := route.Security.APIKeyAuth; apiKeyAuth != nil &&
+				apiKeyAuth.VerifiedByEnvoyGateway() && !seenAPIKey.Has(apiKeyAuth.Name) {
+				seenAPIKey.Insert(apiKeyAuth.Name)
+				cfg, err := newAPIKeyConfig(apiKeyAuth)
+				if err != nil {
+					errs = errors.Join(errs, fmt.Errorf("invalid api key auth %s: %w", apiKeyAuth.Name, err))
+				} else {
+					configs.apiKeyAuth = append(configs.apiKeyAuth, cfg)
+				}
+			}
+			if oidc := route.Security.OIDC; oidc != nil && oidc.Session != nil && !seenOIDC.Has(oidc.Name) {
+				seenOIDC.Insert(oidc.Name)
+				cfg, err := newOIDCConfig(oidc)
+				if err != nil {
+					errs = errors.Join(errs, fmt.Errorf("invalid oidc %s: %w", oidc.Name, err))
+				} else {
+					configs.oidc = append(configs.oidc, cfg)
+				}
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.irConfigs[key] = configs
+	s.index()
+	return errs
+}
+
+// Delete removes the configurations of the xds IR with the given key.
+func (s *Service) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.irConfigs, key)
+	s.index()
+}
+
+// index rebuilds the configurations by name. The caller must hold the lock.
+func (s *Service) index() {
+	configs := map[string]*config{}
+	apiKeyConfigs := map[string]*apiKeyConfig{}
+	oidcConfigs := map[string]*oidcConfig{}
+	for _, irConfig := range s.irConfigs {
+		for _, cfg := range irConfig.basicAuth {
+			configs[cfg.Name] = cfg
+		}
+		for _, cfg := range irConfig.apiKeyAuth {
+			apiKeyConfigs[cfg.Name] = cfg
+		}
+		for _, cfg := range irConfig.oidc {
+			oidcConfigs[cfg.Name] = cfg
+		}
+	}
+	s.configs = configs
+	s.apiKeyConfigs = apiKeyConfigs
+	s.oidcConfigs = oidcConfigs
+}
+
+func newConfig(basicAuth *ir.BasicAuth) (*config, error) {
+	cfg := &config{BasicAuth: basicAuth}
+	h := sha256.New()
+	if basicAuth.LDAP != nil {
+		ldap := basicAuth.LDAP
+		for _, field := range []string{ldap.URL, fmt.Sprint(ldap.StartTLS), ldap.BindDN, string(ldap.BindPassword), ldap.BaseDN, ldap.SearchFilter} {
+			h.Write([]byte(field))
+			h.Write([]byte{0})
+		}
+	} else {
+		users, err := parseHtpasswd(basicAuth.Users)
+		if err != nil {
+			return nil, err
+		}
+		cfg.users = users
+		h.Write(basicAuth.Users)
+	}
+	copy(cfg.digest[:], h.Sum(nil))
+	return cfg, nil
+}
+
+// Check implements the AuthorizationServer interface.
+func (s *Service) Check(ctx context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	if name, ok := req.GetAttributes().GetContextExtensions()[APIKeyContextExtensionKey]; ok {
+		return s.checkAPIKey(name, req)
+	}
+	if name, ok := req.GetAttributes().GetContextExtensions()[OIDCContextExtensionKey]; ok {
+		return s.checkOIDC(ctx, name, req)
+	}
+
+	name := req.GetAttributes().GetContextExtensions()[ContextExtensionKey]
+	s.mu.RLock()
+	cfg := s.configs[name]
+	s.mu.RUnlock()
+	if cfg == nil {
+		return nil, status.Errorf(codes.NotFound, "basic auth %q not found", name)
+	}
+
+	httpReq := req.GetAttributes().GetRequest().GetHttp()
+	username, password, ok := (&http.Request{Header: http.Header{
+		"Authorization": []string{httpReq.GetHeaders()["authorization"]},
+	}}).BasicAuth()
+	if !ok {
+		return unauthorized(httpReq.GetHost(), "User authentication failed. Missing username and password."), nil
+	}
+
+	key := cacheKey(cfg.Name, cfg.digest, username, password)
+	if _, ok := s.cached(key); !ok {
+		valid, err := cfg.verify(username, password)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to verify the credentials: %v", err)
+		}
+		if !valid {
+			return unauthorized(httpReq.GetHost(), "User authentication failed. Invalid username/password combination."), nil
+		}
+		s.store(key, username)
+	}
+
+	resp := &authv3.OkHttpResponse{}
+	if cfg.ForwardUsernameHeader != nil && *cfg.ForwardUsernameHeader != "" {
+		resp.Headers = []*corev3.HeaderValueOption{{
+			Header:       &corev3.HeaderValue{Key: *cfg.ForwardUsernameHeader, Value: username},
+			AppendAction: corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+		}}
+	}
+	return &authv3.CheckResponse{
+		Status:       &rpcstatus.Status{Code: int32(codes.OK)},
+		HttpResponse: &authv3.CheckResponse_OkResponse{OkResponse: resp},
+	}, nil
+}
+
+func (c *config) verify(username, password string) (bool, error) {
+	if c.LDAP != nil {
+		return verifyLDAP(c.LDAP, username, password)
+	}
+	hash, ok := c.users[username]
+	return ok && hash.verify(password), nil
+}
+
+// unauthorized returns a response denying the request in the same way as the basic_auth filter of Envoy.
+func unauthorized(host, message string) *authv3.CheckResponse {
+	return &authv3.CheckResponse{
+		Status: &rpcstatus.Status{Code: int32(codes.Unauthenticated)},
+		HttpResponse: &authv3.CheckResponse_DeniedResponse{DeniedResponse: &authv3.DeniedHttpResponse{
+			Status: &typev3.HttpStatus{Code: typev3.StatusCode_Unauthorized},
+			Headers: []*corev3.HeaderValueOption{{
+				Header: &corev3.HeaderValue{Key: "www-authenticate", Value: fmt.Sprintf("Basic realm=\"http://%s\"", host)},
+			}},
+			Body: message,
+		}},
+	}
+}
+
+// cacheKey returns the key of the verification of the credentials with the configuration of the
+// given name and digest. The credentials are hashed, so that the cache doesn't hold any password.
+func cacheKey(name string, digest [sha256.Size]byte, username, password string) [sha256.Size]byte {
+	h := sha256.New()
+	h.Write([]byte(name))
+	h.Write([]byte{0})
+	h.Write(digest[:])
+	h.Write([]byte(username))
+	h.Write([]byte{0})
+	h.Write([]byte(password))
+	var key [sha256.Size]byte
+	copy(key[:], h.Sum(nil))
+	return key
+}
+
+// cached returns the username or client id of the credentials, and whether they were verified
+// successfully in the last cacheTTL.
+func (s *Service) cached(key [sha256.Size]byte) (string, bool) {
+	entry, ok := s.cachedEntry(key)
+	return entry.client, ok
+}
+
+// cachedEntry returns the cached verification, and whether it is still valid.
+func (s *Service) cachedEntry(key [sha256.Size]byte) (cacheEntry, bool) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	entry, ok := s.cache[key]
+	if !ok || !s.now().Before(entry.expiry) {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// store caches the successful verification of the credentials of the username or client id.
+func (s *Service) store(key [sha256.Size]byte, client string) {
+	s.storeEntry(key, cacheEntry{client: client}, cacheTTL)
+}
+
+// storeEntry caches the successful verification for the given ttl.
+func (s *Service) storeEntry(key [sha256.Size]byte, entry cacheEntry, ttl time.Duration) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	now := s.now()
+	if len(s.cache) >= maxCacheSize {
+		for k, entry := range s.cache {
+			if !now.Before(entry.expiry) {
+				delete(s.cache, k)
+			}
+		}
+		// All the verifications are still valid, start over rather than evicting them one by one.
+		if len(s.cache) >= maxCacheSize {
+			s.cache = map[[sha256.Size]byte]cacheEntry{}
+		}
+	}
+	entry.expiry = now.Add(ttl)
+	s.cache[key] = entry
+}
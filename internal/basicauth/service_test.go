@@ -0,0 +1,212 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package basicauth
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/utils/ptr"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func bcryptPassword(t *testing.T, password string) string {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	return string(hash)
+}
+
+func argon2Password(id bool, password string) string {
+	salt := []byte("0123456789abcdef")
+	if id {
+		key := argon2.IDKey([]byte(password), salt, 1, 64, 1, 32)
+		return fmt.Sprintf("$argon2id$v=19$m=64,t=1,p=1$%s$%s",
+			base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+	}
+	key := argon2.Key([]byte(password), salt, 1, 64, 1, 32)
+	return fmt.Sprintf("$argon2i$v=19$m=64,t=1,p=1$%s$%s",
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+}
+
+func TestParseHtpasswd(t *testing.T) {
+	htpasswd := fmt.Sprintf("sha:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\nbcrypt:%s\n\nargon2i:%s\nargon2id:%s\n",
+		bcryptPassword(t, "password"), argon2Password(false, "password"), argon2Password(true, "password"))
+
+	users, err := parseHtpasswd([]byte(htpasswd))
+	require.NoError(t, err)
+	require.Len(t, users, 4)
+	for username, hash := range users {
+		assert.True(t, hash.verify("password"), username)
+		assert.False(t, hash.verify("wrong"), username)
+		assert.False(t, hash.verify(""), username)
+	}
+
+	invalid := []struct {
+		name     string
+		htpasswd string
+	}{
+		{name: "missing colon", htpasswd: "user1{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g="},
+		{name: "duplicated user", htpasswd: "user1:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\nuser1:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g="},
+		{name: "MD5", htpasswd: "user1:$apr1$salt$hash"},
+		{name: "plain text", htpasswd: "user1:password"},
+		{name: "truncated bcrypt", htpasswd: "user1:$2y$10$"},
+		{name: "argon2d", htpasswd: "user1:$argon2d$v=19$m=64,t=1,p=1$c2FsdA$aGFzaA"},
+		{name: "argon2 without version", htpasswd: "user1:$argon2id$m=64,t=1,p=1$c2FsdA$aGFzaA"},
+		{name: "argon2 with unknown parameter", htpasswd: "user1:$argon2id$v=19$m=64,t=1,p=1,x=1$c2FsdA$aGFzaA"},
+		{name: "argon2 with zero parameter", htpasswd: "user1:$argon2id$v=19$m=64,t=0,p=1$c2FsdA$aGFzaA"},
+		{name: "argon2 with empty hash", htpasswd: "user1:$argon2id$v=19$m=64,t=1,p=1$c2FsdA$"},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Error(t, ValidateHtpasswd([]byte(tc.htpasswd)))
+		})
+	}
+}
+
+func TestLDAPSearchFilter(t *testing.T) {
+	assert.Equal(t, "(uid=alice)", ldapSearchFilter("(uid=%s)", "alice"))
+	assert.Equal(t, `(&(objectClass=person)(|(uid=\2a\29\28uid=\2a)(mail=\2a\29\28uid=\2a)))`,
+		ldapSearchFilter("(&(objectClass=person)(|(uid=%s)(mail=%s)))", "*)(uid=*"))
+}
+
+func TestVerifyLDAPRejectsEmptyPassword(t *testing.T) {
+	// The LDAP server isn't dialed, an empty password must never reach an unauthenticated bind.
+	valid, err := verifyLDAP(&ir.LDAPUserStore{URL: "ldap://127.0.0.1:1"}, "alice", "")
+	require.NoError(t, err)
+	assert.False(t, valid)
+}
+
+func TestLDAPTLSConfig(t *testing.T) {
+	tlsConfig, err := ldapTLSConfig(&ir.LDAPUserStore{URL: "ldaps://ldap.example.com:636"})
+	require.NoError(t, err)
+	assert.Equal(t, "ldap.example.com", tlsConfig.ServerName)
+	assert.Nil(t, tlsConfig.RootCAs)
+
+	_, err = ldapTLSConfig(&ir.LDAPUserStore{URL: "ldap://ldap.example.com", CACertificate: []byte("invalid")})
+	require.EqualError(t, err, "failed to parse the CA certificates of the LDAP server")
+}
+
+func checkRequest(name, authorization string) *authv3.CheckRequest {
+	headers := map[string]string{}
+	if authorization != "" {
+		headers["authorization"] = authorization
+	}
+	return &authv3.CheckRequest{Attributes: &authv3.AttributeContext{
+		ContextExtensions: map[string]string{ContextExtensionKey: name},
+		Request: &authv3.AttributeContext_Request{Http: &authv3.AttributeContext_HttpRequest{
+			Host:    "www.example.com",
+			Headers: headers,
+		}},
+	}}
+}
+
+func basicCredentials(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+func TestCheck(t *testing.T) {
+	s := New()
+	now := time.Unix(1700000000, 0)
+	s.now = func() time.Time { return now }
+
+	basicAuth := &ir.BasicAuth{
+		Name:                  "securitypolicy/default/policy-for-route",
+		Users:                 []byte("alice:" + bcryptPassword(t, "secret")),
+		ForwardUsernameHeader: ptr.To("x-username"),
+	}
+	shaOnly := &ir.BasicAuth{
+		Name:  "securitypolicy/default/sha-only",
+		Users: []byte("bob:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g="),
+	}
+	xds := &ir.Xds{HTTP: []*ir.HTTPListener{{
+		Routes: []*ir.HTTPRoute{
+			{Security: &ir.SecurityFeatures{BasicAuth: basicAuth}},
+			{Security: &ir.SecurityFeatures{BasicAuth: basicAuth}},
+			{Security: &ir.SecurityFeatures{BasicAuth: shaOnly}},
+			{},
+		},
+	}}}
+	require.NoError(t, s.Update("default/eg", xds))
+	require.Len(t, s.irConfigs["default/eg"].basicAuth, 1)
+
+	ctx := context.Background()
+
+	resp, err := s.Check(ctx, checkRequest(basicAuth.Name, basicCredentials("alice", "secret")))
+	require.NoError(t, err)
+	require.NotNil(t, resp.GetOkResponse())
+	require.Len(t, resp.GetOkResponse().Headers, 1)
+	assert.Equal(t, "x-username", resp.GetOkResponse().Headers[0].Header.Key)
+	assert.Equal(t, "alice", resp.GetOkResponse().Headers[0].Header.Value)
+	assert.Len(t, s.cache, 1)
+
+	for _, authorization := range []string{
+		"",
+		"Bearer token",
+		basicCredentials("alice", "wrong"),
+		basicCredentials("mallory", "secret"),
+	} {
+		resp, err = s.Check(ctx, checkRequest(basicAuth.Name, authorization))
+		require.NoError(t, err)
+		require.NotNil(t, resp.GetDeniedResponse(), authorization)
+		assert.Equal(t, typev3.StatusCode_Unauthorized, resp.GetDeniedResponse().Status.Code)
+		assert.Equal(t, `Basic realm="http://www.example.com"`, resp.GetDeniedResponse().Headers[0].Header.Value)
+	}
+	assert.Len(t, s.cache, 1)
+
+	// The configurations only verified by Envoy are not served.
+	_, err = s.Check(ctx, checkRequest(shaOnly.Name, basicCredentials("bob", "password")))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// A changed password invalidates the cached verification.
+	changed := basicAuth.DeepCopy()
+	changed.Users = []byte("alice:" + bcryptPassword(t, "changed"))
+	xds.HTTP[0].Routes[0].Security.BasicAuth = changed
+	xds.HTTP[0].Routes[1].Security.BasicAuth = changed
+	require.NoError(t, s.Update("default/eg", xds))
+	resp, err = s.Check(ctx, checkRequest(basicAuth.Name, basicCredentials("alice", "secret")))
+	require.NoError(t, err)
+	require.NotNil(t, resp.GetDeniedResponse())
+
+	// The cached verifications expire.
+	resp, err = s.Check(ctx, checkRequest(basicAuth.Name, basicCredentials("alice", "changed")))
+	require.NoError(t, err)
+	require.NotNil(t, resp.GetOkResponse())
+	now = now.Add(cacheTTL)
+	cfg := s.configs[basicAuth.Name]
+	_, ok := s.cached(cacheKey(cfg.Name, cfg.digest, "alice", "changed"))
+	assert.False(t, ok)
+
+	s.Delete("default/eg")
+	_, err = s.Check(ctx, checkRequest(basicAuth.Name, basicCredentials("alice", "changed")))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestUpdateInvalidHtpasswd(t *testing.T) {
+	s := New()
+	xds := &ir.Xds{HTTP: []*ir.HTTPListener{{
+		Routes: []*ir.HTTPRoute{
+			{Security: &ir.SecurityFeatures{BasicAuth: &ir.BasicAuth{
+				Name:  "securitypolicy/default/invalid",
+				Users: []byte("alice:$2y$10$"),
+			}}},
+		},
+	}}}
+	require.Error(t, s.Update("default/eg", xds))
+	assert.Empty(t, s.configs)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/envoyproxy/gateway/internal/crypto"
 	"github.com/envoyproxy/gateway/internal/envoygateway"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/infrastructure/host"
 	"github.com/envoyproxy/gateway/internal/provider/kubernetes"
 	"github.com/envoyproxy/gateway/internal/utils/file"
 )
@@ -32,11 +33,7 @@ var overwriteControlPlaneCerts bool
 
 var disableTopologyInjector bool
 
-// TODO: make this path configurable or use server config directly.
-const (
-	defaultLocalCertPath      = "/tmp/envoy-gateway/certs"
-	topologyWebhookNamePrefix = "envoy-gateway-topology-injector"
-)
+const topologyWebhookNamePrefix = "envoy-gateway-topology-injector"
 
 // GetCertGenCommand returns the certGen cobra command to be executed.
 func GetCertGenCommand() *cobra.Command {
@@ -52,6 +49,8 @@ func GetCertGenCommand() *cobra.Command {
 
 	cmd.PersistentFlags().BoolVarP(&local, "local", "l", false,
 		"Generate all the certificates locally.")
+	cmd.PersistentFlags().StringVarP(&cfgPath, "config-path", "c", "",
+		"The path to the configuration file, the local certificates are generated in the working directory of its Host infrastructure provider.")
 	cmd.PersistentFlags().BoolVarP(&overwriteControlPlaneCerts, "overwrite", "o", false,
 		"Updates the secrets containing the control plane certs.")
 	cmd.PersistentFlags().BoolVar(&disableTopologyInjector, "disable-topology-injector", false,
@@ -61,7 +60,7 @@ func GetCertGenCommand() *cobra.Command {
 
 // certGen generates control plane certificates.
 func certGen(ctx context.Context, logOut io.Writer, local bool) error {
-	cfg, err := config.New(logOut)
+	cfg, err := getConfig(logOut)
 	if err != nil {
 		return err
 	}
@@ -86,8 +85,9 @@ func certGen(ctx context.Context, logOut io.Writer, local bool) error {
 			return fmt.Errorf("failed to patch webhook: %w", err)
 		}
 	} else {
-		log.Info("generated certificates", "path", defaultLocalCertPath)
-		if err = outputCertsForLocal(defaultLocalCertPath, certs); err != nil {
+		localCertPath := host.LocalCertsDir(host.WorkingDir(cfg.EnvoyGateway))
+		log.Info("generated certificates", "path", localCertPath)
+		if err = outputCertsForLocal(localCertPath, certs); err != nil {
 			return fmt.Errorf("failed to output certificates locally: %w", err)
 		}
 	}
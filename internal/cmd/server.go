@@ -8,6 +8,7 @@ package cmd
 import (
 	"context"
 	"io"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/envoyproxy/gateway/internal/extension/types"
 	gatewayapirunner "github.com/envoyproxy/gateway/internal/gatewayapi/runner"
 	ratelimitrunner "github.com/envoyproxy/gateway/internal/globalratelimit/runner"
+	"github.com/envoyproxy/gateway/internal/infrastructure/host"
 	infrarunner "github.com/envoyproxy/gateway/internal/infrastructure/runner"
 	"github.com/envoyproxy/gateway/internal/logging"
 	"github.com/envoyproxy/gateway/internal/message"
@@ -153,6 +155,16 @@ func startRunners(ctx context.Context, cfg *config.Server) (err error) {
 		return err
 	}
 
+	// The proxies run on the same host as Envoy Gateway with the Host infrastructure provider, and
+	// connect to the control plane components with the local certificates.
+	var localCertsDir, localEnvoyCertsDir, rateLimitServiceURL, rateLimitCACertFilepath string
+	if cfg.EnvoyGateway.Provider.IsRunningOnHost() {
+		localCertsDir = host.LocalCertsDir(host.WorkingDir(cfg.EnvoyGateway))
+		localEnvoyCertsDir = host.LocalEnvoyCertsDir(host.WorkingDir(cfg.EnvoyGateway))
+		rateLimitCACertFilepath = filepath.Join(localEnvoyCertsDir, host.XdsTLSCaFilename)
+		rateLimitServiceURL = host.GetRateLimitServiceURL(cfg.EnvoyGateway.RateLimit)
+	}
+
 	runners := []struct {
 		runner Runner
 	}{
@@ -172,11 +184,13 @@ func startRunners(ctx context.Context, cfg *config.Server) (err error) {
 			// It subscribes to the provider resources, translates it to xDS IR
 			// and infra IR resources and publishes them.
 			runner: gatewayapirunner.New(&gatewayapirunner.Config{
-				Server:            *cfg,
-				ProviderResources: channels.pResources,
-				XdsIR:             channels.xdsIR,
-				InfraIR:           channels.infraIR,
-				ExtensionManager:  extMgr,
+				Server:             *cfg,
+				ProviderResources:  channels.pResources,
+				XdsIR:              channels.xdsIR,
+				InfraIR:            channels.infraIR,
+				ExtensionManager:   extMgr,
+				LocalEnvoyCertsDir: localEnvoyCertsDir,
+				LocalCertsDir:      localCertsDir,
 			}),
 		},
 		{
@@ -185,19 +199,24 @@ func startRunners(ctx context.Context, cfg *config.Server) (err error) {
 			// and publishes it into the xDS Cache.
 			// It also computes the EnvoyPatchPolicy statuses and publishes it.
 			runner: xdsrunner.New(&xdsrunner.Config{
-				Server:            *cfg,
-				XdsIR:             channels.xdsIR,
-				ExtensionManager:  extMgr,
-				ProviderResources: channels.pResources,
+				Server:                  *cfg,
+				XdsIR:                   channels.xdsIR,
+				ExtensionManager:        extMgr,
+				ProviderResources:       channels.pResources,
+				RateLimitServiceURL:     rateLimitServiceURL,
+				RateLimitCACertFilepath: rateLimitCACertFilepath,
+				LocalCertsDir:           localCertsDir,
 			}),
 		},
 		{
 			// Start the Infra Manager Runner
 			// It subscribes to the infraIR, translates it into Envoy Proxy infrastructure
 			// resources such as K8s deployment and services.
+			// It also publishes the status of the host Envoy processes.
 			runner: infrarunner.New(&infrarunner.Config{
-				Server:  *cfg,
-				InfraIR: channels.infraIR,
+				Server:            *cfg,
+				InfraIR:           channels.infraIR,
+				ProviderResources: channels.pResources,
 			}),
 		},
 		{
@@ -226,8 +245,9 @@ func startRunners(ctx context.Context, cfg *config.Server) (err error) {
 		// Start the Global RateLimit xDS Server
 		// It subscribes to the xds Resources and translates it to Envoy Ratelimit configuration.
 		rateLimitRunner := ratelimitrunner.New(&ratelimitrunner.Config{
-			Server: *cfg,
-			XdsIR:  channels.xdsIR,
+			Server:        *cfg,
+			XdsIR:         channels.xdsIR,
+			LocalCertsDir: localCertsDir,
 		})
 		if err = startRunner(ctx, cfg, rateLimitRunner); err != nil {
 			return err
@@ -9,16 +9,19 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	perr "github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
@@ -418,7 +421,9 @@ func (t *Translator) translateBackendTrafficPolicyForRoute(
 			// Skip if not the gateway wanted
 			continue
 		}
-		applyTrafficFeatureToRoute(route, tf, errs, policy, x)
+		if err := applyTrafficFeatureToRoute(route, tf, errs, policy, x); err != nil {
+			errs = errors.Join(errs, err)
+		}
 	}
 
 	return errs
@@ -479,20 +484,23 @@ func (t *Translator) translateBackendTrafficPolicyForRouteWithMerge(
 		// should not happen.
 		return nil
 	}
-	applyTrafficFeatureToRoute(route, tf, errs, mergedPolicy, x)
-
-	return nil
+	return applyTrafficFeatureToRoute(route, tf, errs, mergedPolicy, x)
 }
 
+// applyTrafficFeatureToRoute applies the traffic features to the IR routes of the route,
+// and returns the errors of the load balancer and failover of the policy.
 func applyTrafficFeatureToRoute(route RouteContext,
 	tf *ir.TrafficFeatures, errs error,
 	policy *egv1a1.BackendTrafficPolicy, x *ir.Xds,
-) {
+) error {
+	var routeErrs error
 	prefix := irRoutePrefix(route)
 	for _, tcp := range x.TCP {
 		for _, r := range tcp.Routes {
 			if strings.HasPrefix(r.Destination.Name, prefix) {
-				r.LoadBalancer = tf.LoadBalancer
+				lb, err := validateLocalityWeighted(r.Name, tf.LoadBalancer, r.Destination)
+				routeErrs = errors.Join(routeErrs, err)
+				r.LoadBalancer = lb
 				r.ProxyProtocol = tf.ProxyProtocol
 				r.HealthCheck = tf.HealthCheck
 				r.CircuitBreaker = tf.CircuitBreaker
@@ -508,12 +516,18 @@ func applyTrafficFeatureToRoute(route RouteContext,
 		if udp.Route != nil {
 			r := udp.Route
 			if strings.HasPrefix(r.Destination.Name, prefix) {
-				r.LoadBalancer = tf.LoadBalancer
+				lb, err := validateLocalityWeighted(r.Name, tf.LoadBalancer, r.Destination)
+				routeErrs = errors.Join(routeErrs, err)
+				r.LoadBalancer = lb
 				r.DNS = tf.DNS
 			}
 		}
 	}
 
+	var (
+		failoverMatched = sets.New[failoverBackendRef]()
+		failoverApplied bool
+	)
 	for _, http := range x.HTTP {
 		for _, r := range http.Routes {
 			// Apply if there is a match
@@ -538,9 +552,23 @@ func applyTrafficFeatureToRoute(route RouteContext,
 				if policy.Spec.UseClientProtocol != nil {
 					r.UseClientProtocol = policy.Spec.UseClientProtocol
 				}
+
+				lb, err := validateLocalityWeighted(r.Name, r.Traffic.LoadBalancer, r.Destination)
+				routeErrs = errors.Join(routeErrs, err)
+				r.Traffic.LoadBalancer = lb
+
+				if err := applyFailoverPriorities(r, policy.Spec.Failover, failoverMatched); err != nil {
+					routeErrs = errors.Join(routeErrs, err)
+				}
+				failoverApplied = true
 			}
 		}
 	}
+
+	if failoverApplied {
+		routeErrs = errors.Join(routeErrs, unmatchedFailoverBackendRefs(policy.Spec.Failover, failoverMatched))
+	}
+	return routeErrs
 }
 
 func mergeBackendTrafficPolicy(routePolicy, gwPolicy *egv1a1.BackendTrafficPolicy) (*egv1a1.BackendTrafficPolicy, error) {
@@ -567,6 +595,9 @@ func (t *Translator) buildTrafficFeatures(policy *egv1a1.BackendTrafficPolicy, r
 		h2          *ir.HTTP2Settings
 		ro          *ir.ResponseOverride
 		rb          *ir.RequestBuffer
+		ci          *ir.CredentialInjection
+		ac          *ir.AdaptiveConcurrency
+		fo          *ir.Failover
 		cp          []*ir.Compression
 		httpUpgrade []ir.HTTPUpgradeConfig
 		err, errs   error
@@ -583,7 +614,10 @@ func (t *Translator) buildTrafficFeatures(policy *egv1a1.BackendTrafficPolicy, r
 		errs = errors.Join(errs, err)
 	}
 	pp = buildProxyProtocol(policy.Spec.ClusterSettings)
-	hc = buildHealthCheck(policy.Spec.ClusterSettings)
+	if hc, err = buildHealthCheck(policy.Spec.ClusterSettings); err != nil {
+		err = perr.WithMessage(err, "HealthCheck")
+		errs = errors.Join(errs, err)
+	}
 	if cb, err = buildCircuitBreaker(policy.Spec.ClusterSettings); err != nil {
 		err = perr.WithMessage(err, "CircuitBreaker")
 		errs = errors.Join(errs, err)
@@ -626,29 +660,54 @@ func (t *Translator) buildTrafficFeatures(policy *egv1a1.BackendTrafficPolicy, r
 		errs = errors.Join(errs, err)
 	}
 
+	if policy.Spec.CredentialInjection != nil {
+		if ci, err = t.buildCredentialInjection(
+			irConfigName(policy),
+			policy.Spec.CredentialInjection,
+			crossNamespaceFrom{
+				group:     egv1a1.GroupName,
+				kind:      resource.KindBackendTrafficPolicy,
+				namespace: policy.Namespace,
+			},
+			resources); err != nil {
+			err = perr.WithMessage(err, "CredentialInjection")
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	if ac, err = buildAdaptiveConcurrency(policy.Spec.AdaptiveConcurrency); err != nil {
+		err = perr.WithMessage(err, "AdaptiveConcurrency")
+		errs = errors.Join(errs, err)
+	}
+
+	fo = buildFailover(policy.Spec.Failover)
+
 	cp = buildCompression(policy.Spec.Compression)
 	httpUpgrade = buildHTTPProtocolUpgradeConfig(policy.Spec.HTTPUpgrade)
 
 	ds = translateDNS(policy.Spec.ClusterSettings)
 
 	return &ir.TrafficFeatures{
-		RateLimit:         rl,
-		LoadBalancer:      lb,
-		ProxyProtocol:     pp,
-		HealthCheck:       hc,
-		CircuitBreaker:    cb,
-		FaultInjection:    fi,
-		TCPKeepalive:      ka,
-		Retry:             rt,
-		BackendConnection: bc,
-		HTTP2:             h2,
-		DNS:               ds,
-		Timeout:           to,
-		ResponseOverride:  ro,
-		RequestBuffer:     rb,
-		Compression:       cp,
-		HTTPUpgrade:       httpUpgrade,
-		Telemetry:         policy.Spec.Telemetry,
+		RateLimit:           rl,
+		LoadBalancer:        lb,
+		ProxyProtocol:       pp,
+		HealthCheck:         hc,
+		CircuitBreaker:      cb,
+		FaultInjection:      fi,
+		TCPKeepalive:        ka,
+		Retry:               rt,
+		BackendConnection:   bc,
+		HTTP2:               h2,
+		DNS:                 ds,
+		Timeout:             to,
+		ResponseOverride:    ro,
+		RequestBuffer:       rb,
+		Compression:         cp,
+		HTTPUpgrade:         httpUpgrade,
+		Telemetry:           policy.Spec.Telemetry,
+		CredentialInjection: ci,
+		AdaptiveConcurrency: ac,
+		Failover:            fo,
 	}, errs
 }
 
@@ -671,6 +730,8 @@ func (t *Translator) translateBackendTrafficPolicyForGateway(
 
 	policyTarget := irStringKey(policy.Namespace, string(target.Name))
 
+	// The errors of the routes don't turn the other routes into direct responses.
+	var routeErrs error
 	for _, tcp := range x.TCP {
 		gatewayName := tcp.Name[0:strings.LastIndex(tcp.Name, "/")]
 		if t.MergeGateways && gatewayName != policyTarget {
@@ -680,7 +741,11 @@ func (t *Translator) translateBackendTrafficPolicyForGateway(
 		for _, r := range tcp.Routes {
 			// only set attributes which weren't already set by a more
 			// specific policy
-			setIfNil(&r.LoadBalancer, tf.LoadBalancer)
+			if r.LoadBalancer == nil {
+				lb, err := validateLocalityWeighted(r.Name, tf.LoadBalancer, r.Destination)
+				routeErrs = errors.Join(routeErrs, err)
+				r.LoadBalancer = lb
+			}
 			setIfNil(&r.ProxyProtocol, tf.ProxyProtocol)
 			setIfNil(&r.HealthCheck, tf.HealthCheck)
 			setIfNil(&r.CircuitBreaker, tf.CircuitBreaker)
@@ -704,10 +769,18 @@ func (t *Translator) translateBackendTrafficPolicyForGateway(
 
 		// only set attributes which weren't already set by a more
 		// specific policy
-		setIfNil(&route.LoadBalancer, tf.LoadBalancer)
+		if route.LoadBalancer == nil {
+			lb, err := validateLocalityWeighted(route.Name, tf.LoadBalancer, route.Destination)
+			routeErrs = errors.Join(routeErrs, err)
+			route.LoadBalancer = lb
+		}
 		setIfNil(&route.DNS, tf.DNS)
 	}
 
+	var (
+		failoverMatched = sets.New[failoverBackendRef]()
+		failoverApplied bool
+	)
 	for _, http := range x.HTTP {
 		gatewayName := http.Name[0:strings.LastIndex(http.Name, "/")]
 		if t.MergeGateways && gatewayName != policyTarget {
@@ -743,10 +816,22 @@ func (t *Translator) translateBackendTrafficPolicyForGateway(
 			if policy.Spec.UseClientProtocol != nil {
 				setIfNil(&r.UseClientProtocol, policy.Spec.UseClientProtocol)
 			}
+
+			lb, err := validateLocalityWeighted(r.Name, r.Traffic.LoadBalancer, r.Destination)
+			routeErrs = errors.Join(routeErrs, err)
+			r.Traffic.LoadBalancer = lb
+
+			if err := applyFailoverPriorities(r, policy.Spec.Failover, failoverMatched); err != nil {
+				routeErrs = errors.Join(routeErrs, err)
+			}
+			failoverApplied = true
 		}
 	}
 
-	return errs
+	if failoverApplied {
+		routeErrs = errors.Join(routeErrs, unmatchedFailoverBackendRefs(policy.Spec.Failover, failoverMatched))
+	}
+	return errors.Join(errs, routeErrs)
 }
 
 func (t *Translator) buildRateLimit(policy *egv1a1.BackendTrafficPolicy) (*ir.RateLimit, error) {
@@ -823,8 +908,9 @@ func (t *Translator) buildLocalRateLimit(policy *egv1a1.BackendTrafficPolicy) (*
 
 	rateLimit := &ir.RateLimit{
 		Local: &ir.LocalRateLimit{
-			Default: *defaultLimit,
-			Rules:   irRules,
+			Default:    *defaultLimit,
+			Rules:      irRules,
+			ShadowMode: ptr.Deref(policy.Spec.RateLimit.ShadowMode, false),
 		},
 	}
 
@@ -855,6 +941,8 @@ func (t *Translator) buildGlobalRateLimit(policy *egv1a1.BackendTrafficPolicy) (
 		}
 		// Set the Name field as <policy-ns>/<policy-name>/rule/<rule-index>
 		irRules[i].Name = irRuleName(policy.Namespace, policy.Name, i)
+		// The rule shadow mode takes precedence over the one of the policy.
+		irRules[i].ShadowMode = ptr.Deref(rule.ShadowMode, ptr.Deref(policy.Spec.RateLimit.ShadowMode, false))
 	}
 
 	return rateLimit, nil
@@ -871,10 +959,11 @@ func buildRateLimitRule(rule egv1a1.RateLimitRule) (*ir.RateLimitRule, error) {
 	}
 
 	for _, match := range rule.ClientSelectors {
-		if len(match.Headers) == 0 && match.SourceCIDR == nil {
+		if len(match.Headers) == 0 && len(match.QueryParams) == 0 && len(match.JWTClaims) == 0 &&
+			match.Path == nil && len(match.Methods) == 0 && match.SourceCIDR == nil && match.Expression == nil {
 			return nil, fmt.Errorf(
 				"unable to translate rateLimit. At least one of the" +
-					" header or sourceCIDR must be specified")
+					" header, queryParam, jwtClaim, path, method, sourceCIDR or expression must be specified")
 		}
 		for _, header := range match.Headers {
 			switch {
@@ -914,6 +1003,48 @@ func buildRateLimitRule(rule egv1a1.RateLimitRule) (*ir.RateLimitRule, error) {
 			}
 		}
 
+		// The path and methods are matched with the :path and :method pseudo-headers.
+		if match.Path != nil {
+			m, err := buildRateLimitPathMatch(match.Path)
+			if err != nil {
+				return nil, err
+			}
+			irRule.HeaderMatches = append(irRule.HeaderMatches, m)
+		}
+		if len(match.Methods) > 0 {
+			irRule.HeaderMatches = append(irRule.HeaderMatches, buildRateLimitMethodMatch(match.Methods))
+		}
+
+		for _, queryParam := range match.QueryParams {
+			m, err := buildRateLimitQueryParamMatch(queryParam)
+			if err != nil {
+				return nil, err
+			}
+			irRule.QueryParamMatches = append(irRule.QueryParamMatches, m)
+		}
+
+		for _, claim := range match.JWTClaims {
+			m := &ir.MetadataMatch{
+				Namespace: egv1a1.EnvoyFilterJWTAuthn.String(),
+				// The name of the JWT provider is used as the `payload_in_metadata` in the JWT Authn filter.
+				Path: append([]string{claim.Provider}, strings.Split(claim.Name, ".")...),
+			}
+			if claim.Type == nil || *claim.Type == egv1a1.JWTClaimMatchExact {
+				if claim.Value == nil {
+					return nil, fmt.Errorf("unable to translate rateLimit. The jwtClaim %s is missing a value", claim.Name)
+				}
+				m.Exact = claim.Value
+			}
+			irRule.MetadataMatches = append(irRule.MetadataMatches, m)
+		}
+
+		if match.Expression != nil {
+			if err := validateCELExpression(match.Expression); err != nil {
+				return nil, fmt.Errorf("unable to translate rateLimit: %w", err)
+			}
+			irRule.ExpressionMatches = append(irRule.ExpressionMatches, *match.Expression)
+		}
+
 		if match.SourceCIDR != nil {
 			// distinct means that each IP Address within the specified Source IP CIDR is treated as a
 			// distinct client selector and uses a separate rate limit bucket/counter.
@@ -934,6 +1065,10 @@ func buildRateLimitRule(rule egv1a1.RateLimitRule) (*ir.RateLimitRule, error) {
 
 	if cost := rule.Cost; cost != nil {
 		if cost.Request != nil {
+			// The response headers are not available when the request cost is applied.
+			if cost.Request.Header != nil {
+				return nil, fmt.Errorf("unable to translate rateLimit. Header is only supported for the response cost")
+			}
 			irRule.RequestCost = translateRateLimitCost(cost.Request)
 		}
 		if cost.Response != nil {
@@ -943,6 +1078,73 @@ func buildRateLimitRule(rule egv1a1.RateLimitRule) (*ir.RateLimitRule, error) {
 	return irRule, nil
 }
 
+// buildRateLimitPathMatch builds the match on the :path pseudo-header for the path match of a rate limit rule.
+func buildRateLimitPathMatch(path *gwapiv1.HTTPPathMatch) (*ir.StringMatch, error) {
+	pattern, err := regex.PathMatch(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to translate rateLimit: %w", err)
+	}
+	return &ir.StringMatch{
+		Name:      ":path",
+		SafeRegex: &pattern,
+	}, nil
+}
+
+// buildRateLimitMethodMatch builds the match on the :method pseudo-header for the method match of a rate limit rule.
+func buildRateLimitMethodMatch(methods []gwapiv1.HTTPMethod) *ir.StringMatch {
+	if len(methods) == 1 {
+		return &ir.StringMatch{
+			Name:  ":method",
+			Exact: ptr.To(string(methods[0])),
+		}
+	}
+	alternatives := make([]string, 0, len(methods))
+	for _, method := range methods {
+		alternatives = append(alternatives, regexp.QuoteMeta(string(method)))
+	}
+	return &ir.StringMatch{
+		Name:      ":method",
+		SafeRegex: ptr.To(strings.Join(alternatives, "|")),
+	}
+}
+
+func buildRateLimitQueryParamMatch(queryParam egv1a1.QueryParamMatch) (*ir.StringMatch, error) {
+	switch ptr.Deref(queryParam.Type, egv1a1.QueryParamMatchExact) {
+	case egv1a1.QueryParamMatchExact:
+		if queryParam.Value != nil {
+			return &ir.StringMatch{
+				Name:   queryParam.Name,
+				Exact:  queryParam.Value,
+				Invert: queryParam.Invert,
+			}, nil
+		}
+	case egv1a1.QueryParamMatchRegularExpression:
+		if queryParam.Value != nil {
+			if err := regex.Validate(*queryParam.Value); err != nil {
+				return nil, err
+			}
+			return &ir.StringMatch{
+				Name:      queryParam.Name,
+				SafeRegex: queryParam.Value,
+				Invert:    queryParam.Invert,
+			}, nil
+		}
+	case egv1a1.QueryParamMatchDistinct:
+		if queryParam.Value == nil {
+			if ptr.Deref(queryParam.Invert, false) {
+				return nil, fmt.Errorf("unable to translate rateLimit. " +
+					"Invert is not applicable for distinct query parameter match type")
+			}
+			return &ir.StringMatch{
+				Name:     queryParam.Name,
+				Distinct: true,
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("unable to translate rateLimit. Either the queryParam.Type "+
+		"is not valid or the queryParam %s is missing a value", queryParam.Name)
+}
+
 func translateRateLimitCost(cost *egv1a1.RateLimitCostSpecifier) *ir.RateLimitCost {
 	ret := &ir.RateLimitCost{}
 	if cost.Number != nil {
@@ -952,6 +1154,12 @@ func translateRateLimitCost(cost *egv1a1.RateLimitCostSpecifier) *ir.RateLimitCo
 		ret.Format = ptr.To(fmt.Sprintf("%%DYNAMIC_METADATA(%s:%s)%%",
 			cost.Metadata.Namespace, cost.Metadata.Key))
 	}
+	if cost.Header != nil {
+		ret.Header = &ir.RateLimitCostHeader{
+			Name: cost.Header.Name,
+			Max:  cost.Header.Max,
+		}
+	}
 	return ret
 }
 
@@ -1039,6 +1247,232 @@ func buildRequestBuffer(spec *egv1a1.RequestBuffer) (*ir.RequestBuffer, error) {
 	}, nil
 }
 
+func buildAdaptiveConcurrency(spec *egv1a1.AdaptiveConcurrency) (*ir.AdaptiveConcurrency, error) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	ac := &ir.AdaptiveConcurrency{}
+	if spec.SampleAggregatePercentile != nil {
+		ac.SampleAggregatePercentile = ptr.To(uint32(*spec.SampleAggregatePercentile))
+	}
+	if spec.ConcurrencyLimitExceededStatus != nil {
+		// Envoy only accepts the status codes of its StatusCode enum, and would reject the whole listener.
+		if _, ok := typev3.StatusCode_name[int32(*spec.ConcurrencyLimitExceededStatus)]; !ok {
+			return nil, fmt.Errorf("unsupported concurrencyLimitExceededStatus %d", *spec.ConcurrencyLimitExceededStatus)
+		}
+		ac.ConcurrencyLimitExceededStatus = ptr.To(uint32(*spec.ConcurrencyLimitExceededStatus))
+	}
+
+	if cl := spec.ConcurrencyLimit; cl != nil {
+		if cl.MaxConcurrencyLimit != nil {
+			if ui32, ok := int64ToUint32(*cl.MaxConcurrencyLimit); ok {
+				ac.MaxConcurrencyLimit = &ui32
+			} else {
+				return nil, fmt.Errorf("invalid MaxConcurrencyLimit value %d", *cl.MaxConcurrencyLimit)
+			}
+		}
+		d, err := parseAdaptiveConcurrencyDuration("updateInterval", cl.UpdateInterval)
+		if err != nil {
+			return nil, err
+		}
+		ac.ConcurrencyUpdateInterval = d
+	}
+
+	if minRTT := spec.MinRTT; minRTT != nil {
+		var err error
+		if ac.MinRTTInterval, err = parseAdaptiveConcurrencyDuration("minRTT interval", minRTT.Interval); err != nil {
+			return nil, err
+		}
+		if ac.MinRTTFixedValue, err = parseAdaptiveConcurrencyDuration("minRTT fixedValue", minRTT.FixedValue); err != nil {
+			return nil, err
+		}
+		if ac.MinRTTInterval != nil && ac.MinRTTFixedValue != nil {
+			return nil, errors.New("only one of minRTT interval or fixedValue can be set")
+		}
+		if minRTT.RequestCount != nil {
+			ac.MinRTTRequestCount = ptr.To(uint32(*minRTT.RequestCount))
+		}
+		if minRTT.Jitter != nil {
+			ac.MinRTTJitter = ptr.To(uint32(*minRTT.Jitter))
+		}
+		if minRTT.MinConcurrency != nil {
+			ac.MinConcurrency = ptr.To(uint32(*minRTT.MinConcurrency))
+		}
+		if minRTT.Buffer != nil {
+			ac.MinRTTBuffer = ptr.To(uint32(*minRTT.Buffer))
+		}
+	}
+
+	return ac, nil
+}
+
+// parseAdaptiveConcurrencyDuration parses an optional duration of the adaptive concurrency
+// settings, which must be positive.
+func parseAdaptiveConcurrencyDuration(field string, d *gwapiv1.Duration) (*metav1.Duration, error) {
+	if d == nil {
+		return nil, nil
+	}
+	duration, err := time.ParseDuration(string(*d))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", field, err)
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("%s must be greater than 0", field)
+	}
+	return ir.MetaV1DurationPtr(duration), nil
+}
+
+func buildFailover(spec *egv1a1.Failover) *ir.Failover {
+	if spec == nil {
+		return nil
+	}
+
+	return &ir.Failover{
+		OverprovisioningFactor: spec.OverprovisioningFactor,
+		WeightedPriorityHealth: ptr.Deref(spec.Failback, egv1a1.FailbackModeHealthyHosts) == egv1a1.FailbackModeHealthyWeight,
+	}
+}
+
+// failoverBackendRef identifies a backendRef of a failover priority level by its indexes.
+type failoverBackendRef struct {
+	priority, backendRef int
+}
+
+// applyFailoverPriorities sets the priority of the destination settings of the route
+// according to the priority levels of the failover, and adds the backendRefs of the
+// failover that match a destination setting to matched.
+// The settings which don't match any priority level are assigned to the lowest priority,
+// and the priorities are then renumbered so that there's no gap between the levels.
+//
+// The priority levels are the priorities of the endpoints of a single cluster, so the
+// failover is rejected if the route needs a cluster for each destination setting.
+func applyFailoverPriorities(r *ir.HTTPRoute, failover *egv1a1.Failover, matched sets.Set[failoverBackendRef]) error {
+	if failover == nil || r.Destination == nil {
+		return nil
+	}
+
+	routeNamespace := ""
+	if r.Metadata != nil {
+		routeNamespace = r.Metadata.Namespace
+	}
+
+	priorities := make([]uint32, len(r.Destination.Settings))
+	levels := sets.New[uint32]()
+	for i, ds := range r.Destination.Settings {
+		priorities[i] = uint32(len(failover.Priorities))
+		found := false
+		for level, fp := range failover.Priorities {
+			for j, ref := range fp.BackendRefs {
+				if failoverBackendRefMatches(ref, ds, routeNamespace) {
+					matched.Insert(failoverBackendRef{priority: level, backendRef: j})
+					if !found {
+						priorities[i] = uint32(level)
+						found = true
+					}
+				}
+			}
+		}
+		levels.Insert(priorities[i])
+	}
+
+	if reason := clusterPerSettingReason(r); reason != "" {
+		r.Traffic.Failover = nil
+		return fmt.Errorf("failover can't be applied to %s: %s, which requires a cluster for each backendRef", r.Name, reason)
+	}
+
+	sortedLevels := sets.List(levels)
+	for i, ds := range r.Destination.Settings {
+		ds.Priority = ptr.To(uint32(sort.Search(len(sortedLevels), func(j int) bool {
+			return sortedLevels[j] >= priorities[i]
+		})))
+	}
+	return nil
+}
+
+// validateLocalityWeighted returns the load balancer to use for the destination, and an error if
+// the localityWeighted of the load balancer can't be applied to the destination.
+// The priority levels of the endpoints order the localities by their distance to the proxy, so
+// localityWeighted is dropped if a backend of the destination is a fallback backend.
+func validateLocalityWeighted(name string, lb *ir.LoadBalancer, dest *ir.RouteDestination) (*ir.LoadBalancer, error) {
+	if lb == nil || lb.LocalityWeighted == nil || dest == nil {
+		return lb, nil
+	}
+
+	for _, ds := range dest.Settings {
+		if ptr.Deref(ds.Priority, 0) > 0 {
+			lb = lb.DeepCopy()
+			lb.LocalityWeighted = nil
+			return lb, fmt.Errorf("zoneAware localityWeighted can't be applied to %s: a Backend of its backendRefs is a fallback backend", name)
+		}
+	}
+	return lb, nil
+}
+
+// clusterPerSettingReason returns why the route needs a cluster for each destination setting,
+// or an empty string if its destination settings share a single cluster.
+func clusterPerSettingReason(r *ir.HTTPRoute) string {
+	if r.Traffic != nil && r.Traffic.LoadBalancer != nil {
+		switch {
+		case r.Traffic.LoadBalancer.PreferLocal != nil:
+			return "zoneAware preferLocal is set"
+		case r.Traffic.LoadBalancer.LocalityWeighted != nil:
+			return "zoneAware localityWeighted is set"
+		}
+	}
+	switch {
+	case r.Destination.HasMixedEndpoints():
+		return "its backends have different address types"
+	case r.Destination.HasFiltersInSettings():
+		return "its backendRefs have filters"
+	case len(r.Destination.Settings) > 1 && r.Destination.HasPreferLocalZone():
+		return "a Service of its backendRefs uses topology aware routing"
+	}
+	return ""
+}
+
+// unmatchedFailoverBackendRefs returns an error listing the backendRefs of the failover that
+// don't match any backendRef of the routes the failover was applied to.
+func unmatchedFailoverBackendRefs(failover *egv1a1.Failover, matched sets.Set[failoverBackendRef]) error {
+	if failover == nil {
+		return nil
+	}
+
+	var unmatched []string
+	for i, fp := range failover.Priorities {
+		for j, ref := range fp.BackendRefs {
+			if !matched.Has(failoverBackendRef{priority: i, backendRef: j}) {
+				unmatched = append(unmatched, fmt.Sprintf("priorities[%d].backendRefs[%d] (%s)", i, j, ref.Name))
+			}
+		}
+	}
+	if len(unmatched) == 0 {
+		return nil
+	}
+	return fmt.Errorf("failover %s don't match any backendRef of the routes", strings.Join(unmatched, ", "))
+}
+
+// failoverBackendRefMatches returns true if the destination setting is the backendRef of a priority level.
+func failoverBackendRefMatches(ref gwapiv1.BackendObjectReference, ds *ir.DestinationSetting, routeNamespace string) bool {
+	if ds.Metadata == nil {
+		return false
+	}
+
+	kind := string(ptr.Deref(ref.Kind, resource.KindService))
+	if ds.Metadata.Kind != "" && ds.Metadata.Kind != kind {
+		return false
+	}
+	if ds.Metadata.Name != string(ref.Name) ||
+		ds.Metadata.Namespace != NamespaceDerefOr(ref.Namespace, routeNamespace) {
+		return false
+	}
+	if ref.Port != nil && ds.Metadata.SectionName != "" &&
+		ds.Metadata.SectionName != strconv.Itoa(int(*ref.Port)) {
+		return false
+	}
+	return true
+}
+
 func buildResponseOverride(policy *egv1a1.BackendTrafficPolicy, resources *resource.Resources) (*ir.ResponseOverride, error) {
 	if len(policy.Spec.ResponseOverride) == 0 {
 		return nil, nil
@@ -1046,8 +1480,12 @@ func buildResponseOverride(policy *egv1a1.BackendTrafficPolicy, resources *resou
 
 	rules := make([]ir.ResponseOverrideRule, 0, len(policy.Spec.ResponseOverride))
 	for index, ro := range policy.Spec.ResponseOverride {
+		if err := validateCELExpression(ro.Match.Expression); err != nil {
+			return nil, err
+		}
 		match := ir.CustomResponseMatch{
 			StatusCodes: make([]ir.StatusCodeMatch, 0, len(ro.Match.StatusCodes)),
+			Expression:  ro.Match.Expression,
 		}
 
 		for _, code := range ro.Match.StatusCodes {
@@ -24,6 +24,9 @@ import (
 	"github.com/envoyproxy/gateway/internal/ir"
 )
 
+// defaultBaseEjectionTime is the default base ejection time of the passive health checks.
+const defaultBaseEjectionTime = 30 * time.Second
+
 func translateTrafficFeatures(policy *egv1a1.ClusterSettings) (*ir.TrafficFeatures, error) {
 	if policy == nil {
 		return nil, nil
@@ -62,7 +65,11 @@ func translateTrafficFeatures(policy *egv1a1.ClusterSettings) (*ir.TrafficFeatur
 
 	ret.ProxyProtocol = buildProxyProtocol(*policy)
 
-	ret.HealthCheck = buildHealthCheck(*policy)
+	if hc, err := buildHealthCheck(*policy); err != nil {
+		return nil, err
+	} else {
+		ret.HealthCheck = hc
+	}
 
 	ret.DNS = translateDNS(*policy)
 
@@ -251,6 +258,21 @@ func buildCircuitBreaker(policy egv1a1.ClusterSettings) (*ir.CircuitBreaker, err
 			}
 		}
 
+		if pcb.RetryBudget != nil {
+			rb := &ir.RetryBudget{}
+			if pcb.RetryBudget.Percent != nil {
+				rb.Percent = ptr.To(uint32(*pcb.RetryBudget.Percent))
+			}
+			if pcb.RetryBudget.MinRetryConcurrency != nil {
+				if ui32, ok := int64ToUint32(*pcb.RetryBudget.MinRetryConcurrency); ok {
+					rb.MinRetryConcurrency = &ui32
+				} else {
+					return nil, fmt.Errorf("invalid MinRetryConcurrency value %d", *pcb.RetryBudget.MinRetryConcurrency)
+				}
+			}
+			cb.RetryBudget = rb
+		}
+
 		if pcb.MaxRequestsPerConnection != nil {
 			if ui32, ok := int64ToUint32(*pcb.MaxRequestsPerConnection); ok {
 				cb.MaxRequestsPerConnection = &ui32
@@ -333,6 +355,10 @@ func buildLoadBalancer(policy egv1a1.ClusterSettings) (*ir.LoadBalancer, error)
 		}
 	}
 
+	if policy.LoadBalancer.ZoneAware != nil && policy.LoadBalancer.ZoneAware.LocalityWeighted != nil {
+		lb.LocalityWeighted = buildLocalityWeighted(policy.LoadBalancer.ZoneAware.LocalityWeighted)
+	}
+
 	// Add EndpointOverride if specified
 	if policy.LoadBalancer.EndpointOverride != nil {
 		lb.EndpointOverride = buildEndpointOverride(*policy.LoadBalancer.EndpointOverride)
@@ -341,6 +367,28 @@ func buildLoadBalancer(policy egv1a1.ClusterSettings) (*ir.LoadBalancer, error)
 	return lb, nil
 }
 
+func buildLocalityWeighted(lw *egv1a1.LocalityWeighted) *ir.LocalityWeighted {
+	irLW := &ir.LocalityWeighted{}
+	for _, w := range lw.Weights {
+		irLW.Weights = append(irLW.Weights, ir.LocalityWeight{
+			Locality: buildLocality(w.Locality),
+			Weight:   w.Weight,
+		})
+	}
+	if lw.Origin != nil {
+		irLW.Origin = ptr.To(buildLocality(*lw.Origin))
+	}
+	return irLW
+}
+
+func buildLocality(l egv1a1.Locality) ir.Locality {
+	return ir.Locality{
+		Region:  ptr.Deref(l.Region, ""),
+		Zone:    ptr.Deref(l.Zone, ""),
+		SubZone: ptr.Deref(l.SubZone, ""),
+	}
+}
+
 func buildConsistentHashLoadBalancer(policy egv1a1.LoadBalancer) (*ir.ConsistentHash, error) {
 	consistentHash := &ir.ConsistentHash{}
 
@@ -404,21 +452,25 @@ func buildProxyProtocol(policy egv1a1.ClusterSettings) *ir.ProxyProtocol {
 	return pp
 }
 
-func buildHealthCheck(policy egv1a1.ClusterSettings) *ir.HealthCheck {
+func buildHealthCheck(policy egv1a1.ClusterSettings) (*ir.HealthCheck, error) {
 	if policy.HealthCheck == nil {
-		return nil
+		return nil, nil
 	}
 
 	irhc := &ir.HealthCheck{}
-	irhc.Passive = buildPassiveHealthCheck(*policy.HealthCheck)
+	passive, err := buildPassiveHealthCheck(*policy.HealthCheck)
+	if err != nil {
+		return nil, err
+	}
+	irhc.Passive = passive
 	irhc.Active = buildActiveHealthCheck(*policy.HealthCheck)
 	irhc.PanicThreshold = policy.HealthCheck.PanicThreshold
-	return irhc
+	return irhc, nil
 }
 
-func buildPassiveHealthCheck(policy egv1a1.HealthCheck) *ir.OutlierDetection {
+func buildPassiveHealthCheck(policy egv1a1.HealthCheck) (*ir.OutlierDetection, error) {
 	if policy.Passive == nil {
-		return nil
+		return nil, nil
 	}
 
 	hc := policy.Passive
@@ -430,22 +482,77 @@ func buildPassiveHealthCheck(policy egv1a1.HealthCheck) *ir.OutlierDetection {
 		MaxEjectionPercent:             hc.MaxEjectionPercent,
 	}
 
+	var errs error
 	if hc.Interval != nil {
 		d, err := time.ParseDuration(string(*hc.Interval))
 		if err != nil {
-			return nil
+			errs = errors.Join(errs, fmt.Errorf("invalid Interval value %s", *hc.Interval))
+		} else {
+			irOD.Interval = ir.MetaV1DurationPtr(d)
 		}
-		irOD.Interval = ir.MetaV1DurationPtr(d)
 	}
 
 	if hc.BaseEjectionTime != nil {
 		d, err := time.ParseDuration(string(*hc.BaseEjectionTime))
 		if err != nil {
-			return nil
+			errs = errors.Join(errs, fmt.Errorf("invalid BaseEjectionTime value %s", *hc.BaseEjectionTime))
+		} else {
+			irOD.BaseEjectionTime = ir.MetaV1DurationPtr(d)
+		}
+	}
+
+	if hc.MaxEjectionTime != nil {
+		d, err := time.ParseDuration(string(*hc.MaxEjectionTime))
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid MaxEjectionTime value %s", *hc.MaxEjectionTime))
+		} else {
+			irOD.MaxEjectionTime = ir.MetaV1DurationPtr(d)
 		}
-		irOD.BaseEjectionTime = ir.MetaV1DurationPtr(d)
 	}
-	return irOD
+
+	if hc.MaxEjectionTimeJitter != nil {
+		d, err := time.ParseDuration(string(*hc.MaxEjectionTimeJitter))
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid MaxEjectionTimeJitter value %s", *hc.MaxEjectionTimeJitter))
+		} else {
+			irOD.MaxEjectionTimeJitter = ir.MetaV1DurationPtr(d)
+		}
+	}
+
+	if irOD.MaxEjectionTime != nil {
+		// Envoy rejects the cluster if the max ejection time is lower than the base ejection time.
+		baseEjectionTime := defaultBaseEjectionTime
+		if irOD.BaseEjectionTime != nil {
+			baseEjectionTime = irOD.BaseEjectionTime.Duration
+		}
+		if irOD.MaxEjectionTime.Duration < baseEjectionTime {
+			errs = errors.Join(errs, fmt.Errorf("MaxEjectionTime %s must not be lower than BaseEjectionTime %s",
+				irOD.MaxEjectionTime.Duration, baseEjectionTime))
+		}
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	if sr := hc.SuccessRate; sr != nil {
+		irOD.SuccessRate = &ir.OutlierDetectionSuccessRate{
+			MinimumHosts:     sr.MinimumHosts,
+			RequestVolume:    sr.RequestVolume,
+			StdevFactor:      sr.StdevFactor,
+			EnforcingPercent: sr.EnforcingPercent,
+		}
+	}
+
+	if fp := hc.FailurePercentage; fp != nil {
+		irOD.FailurePercentage = &ir.OutlierDetectionFailurePercentage{
+			Threshold:        fp.Threshold,
+			MinimumHosts:     fp.MinimumHosts,
+			RequestVolume:    fp.RequestVolume,
+			EnforcingPercent: fp.EnforcingPercent,
+		}
+	}
+	return irOD, nil
 }
 
 func buildActiveHealthCheck(policy egv1a1.HealthCheck) *ir.ActiveHealthCheck {
@@ -612,8 +719,17 @@ func buildRetry(r *egv1a1.Retry) (*ir.Retry, error) {
 			bpr = true
 		}
 
+		if ptr.Deref(r.PerRetry.HedgeOnTimeout, false) {
+			if pr.Timeout == nil {
+				return nil, fmt.Errorf("timeout must be set when hedgeOnTimeout is enabled")
+			}
+			pr.HedgeOnTimeout = true
+			bpr = true
+		}
+
 		if r.PerRetry.BackOff != nil {
-			if r.PerRetry.BackOff.MaxInterval != nil || r.PerRetry.BackOff.BaseInterval != nil {
+			if r.PerRetry.BackOff.MaxInterval != nil || r.PerRetry.BackOff.BaseInterval != nil ||
+				r.PerRetry.BackOff.RateLimited != nil {
 				bop := &ir.BackOffPolicy{}
 				if r.PerRetry.BackOff.BaseInterval != nil {
 					if d, err := time.ParseDuration(string(*r.PerRetry.BackOff.BaseInterval)); err == nil {
@@ -634,6 +750,13 @@ func buildRetry(r *egv1a1.Retry) (*ir.Retry, error) {
 						}
 					}
 				}
+				if r.PerRetry.BackOff.RateLimited != nil {
+					rlb, err := buildRateLimitedBackOff(r.PerRetry.BackOff.RateLimited)
+					if err != nil {
+						return nil, err
+					}
+					bop.RateLimited = rlb
+				}
 
 				pr.BackOff = bop
 				bpr = true
@@ -647,3 +770,24 @@ func buildRetry(r *egv1a1.Retry) (*ir.Retry, error) {
 
 	return rt, nil
 }
+
+func buildRateLimitedBackOff(r *egv1a1.RateLimitedBackOff) (*ir.RateLimitedBackOff, error) {
+	rlb := &ir.RateLimitedBackOff{}
+	for _, h := range r.ResetHeaders {
+		rlb.ResetHeaders = append(rlb.ResetHeaders, ir.RetryResetHeader{
+			Name:   h.Name,
+			Format: ptr.Deref(h.Format, egv1a1.RetryResetHeaderFormatSeconds),
+		})
+	}
+	if r.MaxInterval != nil {
+		d, err := time.ParseDuration(string(*r.MaxInterval))
+		if err != nil {
+			return nil, err
+		}
+		if d == 0 {
+			return nil, fmt.Errorf("rateLimited maxInterval cannot be set to 0s")
+		}
+		rlb.MaxInterval = ir.MetaV1DurationPtr(d)
+	}
+	return rlb, nil
+}
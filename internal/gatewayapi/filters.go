@@ -6,7 +6,9 @@
 package gatewayapi
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
 	"time"
@@ -740,6 +742,105 @@ func (t *Translator) processResponseHeaderModifierFilter(
 	}
 }
 
+// buildCredentialInjection translates the credential injection configuration of an HTTPRouteFilter
+// or a BackendTrafficPolicy to IR.
+func (t *Translator) buildCredentialInjection(
+	name string,
+	injection *egv1a1.HTTPCredentialInjectionFilter,
+	from crossNamespaceFrom,
+	resources *resource.Resources,
+) (*ir.CredentialInjection, error) {
+	irInjection := &ir.CredentialInjection{
+		Name:      name,
+		Header:    injection.Header,
+		Overwrite: injection.Overwrite,
+	}
+
+	credential := injection.Credential
+	hasValueRef := credential.ValueRef.Name != ""
+	switch {
+	case hasValueRef && credential.OAuth2 != nil:
+		return nil, errors.New("only one of valueRef or oauth2 can be specified for the credential")
+	case hasValueRef:
+		secret, err := t.validateSecretRef(false, from, credential.ValueRef, resources)
+		if err != nil {
+			return nil, err
+		}
+
+		secretBytes, ok := secret.Data[egv1a1.InjectedCredentialKey]
+		if !ok || len(secretBytes) == 0 {
+			return nil, fmt.Errorf(
+				"credential key %s not found in secret %s/%s",
+				egv1a1.InjectedCredentialKey, secret.Namespace,
+				secret.Name)
+		}
+		irInjection.Credential = secretBytes
+	case credential.OAuth2 != nil:
+		if injection.Header != nil {
+			return nil, errors.New("header cannot be set with an oauth2 credential")
+		}
+		oauth2, err := t.buildOAuth2ClientCredentials(credential.OAuth2, from, resources)
+		if err != nil {
+			return nil, err
+		}
+		irInjection.OAuth2 = oauth2
+	default:
+		return nil, errors.New("one of valueRef or oauth2 must be specified for the credential")
+	}
+
+	return irInjection, nil
+}
+
+func (t *Translator) buildOAuth2ClientCredentials(
+	oauth2 *egv1a1.OAuth2ClientCredentials,
+	from crossNamespaceFrom,
+	resources *resource.Resources,
+) (*ir.OAuth2ClientCredentials, error) {
+	u, err := url.Parse(oauth2.TokenEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing token endpoint URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("token endpoint URL must use the http or https scheme: %s", oauth2.TokenEndpoint)
+	}
+	if err = validateTokenEndpoint(oauth2.TokenEndpoint); err != nil {
+		return nil, err
+	}
+
+	secret, err := t.validateSecretRef(false, from, oauth2.ClientSecret, resources)
+	if err != nil {
+		return nil, err
+	}
+	clientSecret, ok := secret.Data[egv1a1.InjectedCredentialClientSecretKey]
+	if !ok || len(clientSecret) == 0 {
+		return nil, fmt.Errorf(
+			"client secret key %s not found in secret %s/%s",
+			egv1a1.InjectedCredentialClientSecretKey, secret.Namespace,
+			secret.Name)
+	}
+
+	irOAuth2 := &ir.OAuth2ClientCredentials{
+		TokenEndpoint:    oauth2.TokenEndpoint,
+		ClientID:         oauth2.ClientID,
+		ClientSecret:     clientSecret,
+		Scopes:           oauth2.Scopes,
+		ClientSecretPost: ptr.Deref(oauth2.ClientAuthentication, egv1a1.OAuth2ClientSecretBasic) == egv1a1.OAuth2ClientSecretPost,
+	}
+
+	if oauth2.TokenFetchRetryInterval != nil {
+		d, err := time.ParseDuration(string(*oauth2.TokenFetchRetryInterval))
+		if err != nil {
+			return nil, fmt.Errorf("invalid token fetch retry interval: %w", err)
+		}
+		if d < time.Second {
+			return nil, fmt.Errorf("token fetch retry interval must be at least 1s: %s", *oauth2.TokenFetchRetryInterval)
+		}
+		irOAuth2.TokenFetchRetryInterval = ir.MetaV1DurationPtr(d)
+	}
+
+	return irOAuth2, nil
+}
+
 func (t *Translator) processExtensionRefHTTPFilter(extFilter *gwapiv1.LocalObjectReference, filterContext *HTTPFiltersContext, resources *resource.Resources) {
 	// Make sure the config actually exists.
 	if extFilter == nil {
@@ -863,35 +964,19 @@ func (t *Translator) processExtensionRefHTTPFilter(extFilter *gwapiv1.LocalObjec
 				}
 
 				if hrf.Spec.CredentialInjection != nil {
-					secret, err := t.validateSecretRef(
-						false,
+					injection, err := t.buildCredentialInjection(
+						irConfigName(hrf),
+						hrf.Spec.CredentialInjection,
 						crossNamespaceFrom{
 							group:     egv1a1.GroupName,
 							kind:      resource.KindHTTPRouteFilter,
 							namespace: filterNs,
 						},
-						hrf.Spec.CredentialInjection.Credential.ValueRef, resources)
+						resources)
 					if err != nil {
 						t.processInvalidHTTPFilter(string(extFilter.Kind), filterContext, err)
 						return
 					}
-
-					secretBytes, ok := secret.Data[egv1a1.InjectedCredentialKey]
-					if !ok || len(secretBytes) == 0 {
-						err := fmt.Errorf(
-							"credential key %s not found in secret %s/%s",
-							egv1a1.InjectedCredentialKey, secret.Namespace,
-							secret.Name)
-						t.processInvalidHTTPFilter(string(extFilter.Kind), filterContext, err)
-						return
-					}
-
-					injection := &ir.CredentialInjection{
-						Name:       irConfigName(hrf),
-						Header:     hrf.Spec.CredentialInjection.Header,
-						Overwrite:  hrf.Spec.CredentialInjection.Overwrite,
-						Credential: secretBytes,
-					}
 					filterContext.CredentialInjection = injection
 				}
 			}
@@ -42,6 +42,9 @@ func (t *Translator) ProcessGlobalResources(resources *resource.Resources, xdsIR
 	// Get the envoy client TLS secret. It is used for envoy to establish a TLS connection with control plane components,
 	// including the rate limit server and the wasm HTTP server.
 	envoyTLSSecret := resources.GetSecret(t.ControllerNamespace, envoyTLSSecretName)
+	if envoyTLSSecret == nil {
+		envoyTLSSecret = t.EnvoyTLSSecret
+	}
 	if envoyTLSSecret == nil {
 		return fmt.Errorf("envoy TLS secret %s/%s not found", t.ControllerNamespace, envoyTLSSecretName)
 	}
@@ -0,0 +1,80 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func TestProcessGlobalResourcesEnvoyTLSSecret(t *testing.T) {
+	secret := func(cert string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "envoy-gateway-system", Name: "envoy"},
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       []byte(cert),
+				corev1.TLSPrivateKeyKey: []byte("key"),
+			},
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		secrets        []*corev1.Secret
+		envoyTLSSecret *corev1.Secret
+		expectCert     string
+		expectErr      bool
+	}{
+		{
+			name:       "secret from resources",
+			secrets:    []*corev1.Secret{secret("resources")},
+			expectCert: "resources",
+		},
+		{
+			name:           "resources take precedence",
+			secrets:        []*corev1.Secret{secret("resources")},
+			envoyTLSSecret: secret("local"),
+			expectCert:     "resources",
+		},
+		{
+			name:           "secret from translator",
+			envoyTLSSecret: secret("local"),
+			expectCert:     "local",
+		},
+		{
+			name:      "no secret",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := &Translator{ControllerNamespace: "envoy-gateway-system", EnvoyTLSSecret: tc.envoyTLSSecret}
+			xdsIR := &ir.Xds{
+				HTTP: []*ir.HTTPListener{{
+					Routes: []*ir.HTTPRoute{{
+						Traffic: &ir.TrafficFeatures{RateLimit: &ir.RateLimit{Global: &ir.GlobalRateLimit{}}},
+					}},
+				}},
+			}
+
+			err := tr.ProcessGlobalResources(&resource.Resources{Secrets: tc.secrets}, resource.XdsIRMap{"default/eg": xdsIR}, nil)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "envoy-gateway-system/envoy", xdsIR.GlobalResources.EnvoyClientCertificate.Name)
+			require.Equal(t, []byte(tc.expectCert), xdsIR.GlobalResources.EnvoyClientCertificate.Certificate)
+		})
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/utils"
+	"github.com/envoyproxy/gateway/internal/utils/cel"
 )
 
 const (
@@ -782,3 +783,12 @@ func getOverriddenTargetsMessageForGateway(
 	}
 	return ""
 }
+
+// validateCELExpression validates that the CEL expression, if specified, compiles and evaluates to a bool.
+func validateCELExpression(expr *egv1a1.CELExpression) error {
+	if expr == nil {
+		return nil
+	}
+	_, err := cel.Compile(string(*expr))
+	return err
+}
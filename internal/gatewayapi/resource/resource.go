@@ -41,18 +41,21 @@ type Resources struct {
 	// EnvoyProxiesForGateways holds EnvoyProxiesForGateways attached to Gateways
 	EnvoyProxiesForGateways []*egv1a1.EnvoyProxy `json:"envoyProxiesForGateways,omitempty" yaml:"envoyProxiesForGateways,omitempty"`
 
-	GatewayClass            *gwapiv1.GatewayClass          `json:"gatewayClass,omitempty" yaml:"gatewayClass,omitempty"`
-	Gateways                []*gwapiv1.Gateway             `json:"gateways,omitempty" yaml:"gateways,omitempty"`
-	HTTPRoutes              []*gwapiv1.HTTPRoute           `json:"httpRoutes,omitempty" yaml:"httpRoutes,omitempty"`
-	GRPCRoutes              []*gwapiv1.GRPCRoute           `json:"grpcRoutes,omitempty" yaml:"grpcRoutes,omitempty"`
-	TLSRoutes               []*gwapiv1a2.TLSRoute          `json:"tlsRoutes,omitempty" yaml:"tlsRoutes,omitempty"`
-	TCPRoutes               []*gwapiv1a2.TCPRoute          `json:"tcpRoutes,omitempty" yaml:"tcpRoutes,omitempty"`
-	UDPRoutes               []*gwapiv1a2.UDPRoute          `json:"udpRoutes,omitempty" yaml:"udpRoutes,omitempty"`
-	ReferenceGrants         []*gwapiv1b1.ReferenceGrant    `json:"referenceGrants,omitempty" yaml:"referenceGrants,omitempty"`
-	Namespaces              []*corev1.Namespace            `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
-	Services                []*corev1.Service              `json:"services,omitempty" yaml:"services,omitempty"`
-	ServiceImports          []*mcsapiv1a1.ServiceImport    `json:"serviceImports,omitempty" yaml:"serviceImports,omitempty"`
-	EndpointSlices          []*discoveryv1.EndpointSlice   `json:"endpointSlices,omitempty" yaml:"endpointSlices,omitempty"`
+	GatewayClass    *gwapiv1.GatewayClass        `json:"gatewayClass,omitempty" yaml:"gatewayClass,omitempty"`
+	Gateways        []*gwapiv1.Gateway           `json:"gateways,omitempty" yaml:"gateways,omitempty"`
+	HTTPRoutes      []*gwapiv1.HTTPRoute         `json:"httpRoutes,omitempty" yaml:"httpRoutes,omitempty"`
+	GRPCRoutes      []*gwapiv1.GRPCRoute         `json:"grpcRoutes,omitempty" yaml:"grpcRoutes,omitempty"`
+	TLSRoutes       []*gwapiv1a2.TLSRoute        `json:"tlsRoutes,omitempty" yaml:"tlsRoutes,omitempty"`
+	TCPRoutes       []*gwapiv1a2.TCPRoute        `json:"tcpRoutes,omitempty" yaml:"tcpRoutes,omitempty"`
+	UDPRoutes       []*gwapiv1a2.UDPRoute        `json:"udpRoutes,omitempty" yaml:"udpRoutes,omitempty"`
+	ReferenceGrants []*gwapiv1b1.ReferenceGrant  `json:"referenceGrants,omitempty" yaml:"referenceGrants,omitempty"`
+	Namespaces      []*corev1.Namespace          `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+	Services        []*corev1.Service            `json:"services,omitempty" yaml:"services,omitempty"`
+	ServiceImports  []*mcsapiv1a1.ServiceImport  `json:"serviceImports,omitempty" yaml:"serviceImports,omitempty"`
+	EndpointSlices  []*discoveryv1.EndpointSlice `json:"endpointSlices,omitempty" yaml:"endpointSlices,omitempty"`
+	// Nodes holds the Nodes of the endpoints of the EndpointSlices, with only their name
+	// and topology labels, to set the region of the endpoints.
+	Nodes                   []*corev1.Node                 `json:"nodes,omitempty" yaml:"nodes,omitempty"`
 	Secrets                 []*corev1.Secret               `json:"secrets,omitempty" yaml:"secrets,omitempty"`
 	ConfigMaps              []*corev1.ConfigMap            `json:"configMaps,omitempty" yaml:"configMaps,omitempty"`
 	ExtensionRefFilters     []unstructured.Unstructured    `json:"extensionRefFilters,omitempty" yaml:"extensionRefFilters,omitempty"`
@@ -188,6 +191,17 @@ func (r *Resources) GetConfigMap(namespace, name string) *corev1.ConfigMap {
 	return nil
 }
 
+// GetNodeRegions returns the topology region of the Nodes by their name.
+func (r *Resources) GetNodeRegions() map[string]string {
+	regions := make(map[string]string, len(r.Nodes))
+	for _, node := range r.Nodes {
+		if region, ok := node.Labels[corev1.LabelTopologyRegion]; ok {
+			regions[node.Name] = region
+		}
+	}
+	return regions
+}
+
 func (r *Resources) GetEndpointSlicesForBackend(svcNamespace, svcName, backendKind string) []*discoveryv1.EndpointSlice {
 	var endpointSlices []*discoveryv1.EndpointSlice
 	for _, endpointSlice := range r.EndpointSlices {
@@ -352,6 +366,11 @@ func (r *Resources) Sort() {
 		return r.EndpointSlices[i].CreationTimestamp.Before(&(r.EndpointSlices[j].CreationTimestamp))
 	})
 
+	// Sort Nodes by name
+	sort.Slice(r.Nodes, func(i, j int) bool {
+		return r.Nodes[i].Name < r.Nodes[j].Name
+	})
+
 	// Sort Secrets by creation timestamp, then namespace/name
 	sort.Slice(r.Secrets, func(i, j int) bool {
 		if r.Secrets[i].CreationTimestamp.Equal(&(r.Secrets[j].CreationTimestamp)) {
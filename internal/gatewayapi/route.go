@@ -1545,7 +1545,7 @@ func (t *Translator) processServiceImportDestinationSetting(
 	// Route to endpoints by default
 	if !t.IsEnvoyServiceRouting(envoyProxy) {
 		endpointSlices := resources.GetEndpointSlicesForBackend(backendNamespace, string(backendRef.Name), resource.KindServiceImport)
-		endpoints, addrType = getIREndpointsFromEndpointSlices(endpointSlices, servicePort.Name, servicePort.Protocol)
+		endpoints, addrType = getIREndpointsFromEndpointSlices(endpointSlices, servicePort.Name, servicePort.Protocol, resources.GetNodeRegions())
 	} else {
 		// Fall back to Service ClusterIP routing
 		backendIps := resources.GetServiceImport(backendNamespace, string(backendRef.Name)).Spec.IPs
@@ -1594,7 +1594,7 @@ func (t *Translator) processServiceDestinationSetting(
 	// Route to endpoints by default
 	if !t.IsEnvoyServiceRouting(envoyProxy) {
 		endpointSlices := resources.GetEndpointSlicesForBackend(backendNamespace, string(backendRef.Name), KindDerefOr(backendRef.Kind, resource.KindService))
-		endpoints, addrType = getIREndpointsFromEndpointSlices(endpointSlices, servicePort.Name, servicePort.Protocol)
+		endpoints, addrType = getIREndpointsFromEndpointSlices(endpointSlices, servicePort.Name, servicePort.Protocol, resources.GetNodeRegions())
 	} else {
 		// Fall back to Service ClusterIP routing
 		ep := ir.NewDestEndpoint(nil, service.Spec.ClusterIP, uint32(*backendRef.Port), false, nil)
@@ -1808,7 +1808,9 @@ func (t *Translator) processAllowedListenersForParentRefs(routeContext RouteCont
 	return relevantRoute
 }
 
-func getIREndpointsFromEndpointSlices(endpointSlices []*discoveryv1.EndpointSlice, portName string, portProtocol corev1.Protocol) ([]*ir.DestinationEndpoint, *ir.DestinationAddressType) {
+// getIREndpointsFromEndpointSlices returns the endpoints of the EndpointSlices for the port, the region
+// of an endpoint is the region of its Node in nodeRegions.
+func getIREndpointsFromEndpointSlices(endpointSlices []*discoveryv1.EndpointSlice, portName string, portProtocol corev1.Protocol, nodeRegions map[string]string) ([]*ir.DestinationEndpoint, *ir.DestinationAddressType) {
 	var (
 		dstEndpoints []*ir.DestinationEndpoint
 		dstAddrType  *ir.DestinationAddressType
@@ -1821,7 +1823,7 @@ func getIREndpointsFromEndpointSlices(endpointSlices []*discoveryv1.EndpointSlic
 		} else {
 			addrTypeMap[ir.IP]++
 		}
-		endpoints := getIREndpointsFromEndpointSlice(endpointSlice, portName, portProtocol)
+		endpoints := getIREndpointsFromEndpointSlice(endpointSlice, portName, portProtocol, nodeRegions)
 		dstEndpoints = append(dstEndpoints, endpoints...)
 	}
 
@@ -1839,9 +1841,13 @@ func getIREndpointsFromEndpointSlices(endpointSlices []*discoveryv1.EndpointSlic
 	return dstEndpoints, dstAddrType
 }
 
-func getIREndpointsFromEndpointSlice(endpointSlice *discoveryv1.EndpointSlice, portName string, portProtocol corev1.Protocol) []*ir.DestinationEndpoint {
+func getIREndpointsFromEndpointSlice(endpointSlice *discoveryv1.EndpointSlice, portName string, portProtocol corev1.Protocol, nodeRegions map[string]string) []*ir.DestinationEndpoint {
 	var endpoints []*ir.DestinationEndpoint
 	for _, endpoint := range endpointSlice.Endpoints {
+		var region *string
+		if r, ok := nodeRegions[ptr.Deref(endpoint.NodeName, "")]; ok {
+			region = &r
+		}
 		for _, endpointPort := range endpointSlice.Ports {
 			// Check if the endpoint port matches the service port
 			if *endpointPort.Name != portName || *endpointPort.Protocol != portProtocol {
@@ -1858,11 +1864,13 @@ func getIREndpointsFromEndpointSlice(endpointSlice *discoveryv1.EndpointSlice, p
 				draining := *conditions.Terminating
 				for _, address := range endpoint.Addresses {
 					ep := ir.NewDestEndpoint(nil, address, uint32(*endpointPort.Port), draining, endpoint.Zone)
+					ep.Region = region
 					endpoints = append(endpoints, ep)
 				}
 			} else if conditions.Ready == nil || *conditions.Ready {
 				for _, address := range endpoint.Addresses {
 					ep := ir.NewDestEndpoint(nil, address, uint32(*endpointPort.Port), false, endpoint.Zone)
+					ep.Region = region
 					endpoints = append(endpoints, ep)
 				}
 			}
@@ -2009,6 +2017,10 @@ func (t *Translator) processBackendDestinationSetting(
 				Zone: bep.Zone,
 			}
 		}
+		if irde != nil {
+			irde.Region = bep.Region
+			irde.SubZone = bep.SubZone
+		}
 
 		dstEndpoints = append(dstEndpoints, irde)
 	}
@@ -208,7 +208,7 @@ func TestGetIREndpointsFromEndpointSlices(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			endpoints, addrType := getIREndpointsFromEndpointSlices(tt.endpointSlices, tt.portName, tt.portProtocol)
+			endpoints, addrType := getIREndpointsFromEndpointSlices(tt.endpointSlices, tt.portName, tt.portProtocol, nil)
 
 			fmt.Printf("Test case: %s\n", tt.name)
 			fmt.Printf("Number of endpoints: %d\n", len(endpoints))
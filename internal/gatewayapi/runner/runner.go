@@ -12,10 +12,14 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 	"reflect"
+	"sync"
+	"time"
 
 	"github.com/docker/docker/pkg/fileutils"
 	"github.com/telepresenceio/watchable"
+	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -43,15 +47,8 @@ const (
 	serveTLSKeyFilepath  = "/certs/tls.key"
 	serveTLSCaFilepath   = "/certs/ca.crt"
 
-	// TODO: Make these path configurable.
-	// Default certificates path for envoy-gateway with Host infrastructure provider.
-	localTLSCertFilepath = "/tmp/envoy-gateway/certs/envoy-gateway/tls.crt"
-	localTLSKeyFilepath  = "/tmp/envoy-gateway/certs/envoy-gateway/tls.key"
-	localTLSCaFilepath   = "/tmp/envoy-gateway/certs/envoy-gateway/ca.crt"
-
 	hmacSecretName = "envoy-oidc-hmac" // nolint: gosec
 	hmacSecretKey  = "hmac-secret"
-	hmacSecretPath = "/tmp/envoy-gateway/certs/envoy-oidc-hmac/hmac-secret" // nolint: gosec
 )
 
 type Config struct {
@@ -60,11 +57,24 @@ type Config struct {
 	XdsIR             *message.XdsIR
 	InfraIR           *message.InfraIR
 	ExtensionManager  extension.Manager
+	// LocalEnvoyCertsDir is the directory of the envoy client certificate, which is loaded as the
+	// envoy client TLS secret when set, e.g. with the Host infrastructure provider.
+	LocalEnvoyCertsDir string
+	// LocalCertsDir is the directory of the local certificates and HMAC secret, which are loaded
+	// with the Host infrastructure provider.
+	LocalCertsDir string
 }
 
 type Runner struct {
 	Config
 	wasmCache wasm.Cache
+	// mu serializes the translations of the provider resources updates and of the API keys expiry.
+	mu sync.Mutex
+	// apiKeysExpiryTimer translates the last provider resources again when API keys expire.
+	apiKeysExpiryTimer *time.Timer
+	// envoyTLSSecret is the envoy client TLS secret loaded from the local certificates
+	// with the Host infrastructure provider.
+	envoyTLSSecret *corev1.Secret
 }
 
 func New(cfg *Config) *Runner {
@@ -86,6 +96,14 @@ func (r *Runner) Start(ctx context.Context) (err error) {
 	r.Logger = r.Logger.WithName(r.Name()).WithValues("runner", r.Name())
 
 	go r.startWasmCache(ctx)
+
+	// There is no envoy client TLS secret in the resources with the Host infrastructure provider,
+	// so it's loaded from the local certificates.
+	if r.LocalEnvoyCertsDir != "" {
+		if r.envoyTLSSecret, err = r.loadLocalEnvoyTLSSecret(); err != nil {
+			r.Logger.Error(err, "failed to load envoy TLS secret")
+		}
+	}
 	// Do not call .Subscribe() inside Goroutine since it is supposed to be called from the same
 	// Goroutine where Close() is called.
 	c := r.ProviderResources.GatewayAPIResources.Subscribe(ctx)
@@ -128,252 +146,313 @@ func (r *Runner) startWasmCache(ctx context.Context) {
 func (r *Runner) subscribeAndTranslate(sub <-chan watchable.Snapshot[string, *resource.ControllerResources]) {
 	message.HandleSubscription(message.Metadata{Runner: r.Name(), Message: message.ProviderResourcesMessageName}, sub,
 		func(update message.Update[string, *resource.ControllerResources], errChan chan error) {
-			r.Logger.Info("received an update")
-			val := update.Value
-			// There is only 1 key which is the controller name
-			// so when a delete is triggered, delete all IR keys
-			if update.Delete || val == nil {
-				r.deleteAllIRKeys()
-				r.deleteAllStatusKeys()
-				return
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			// Scheduled before the translation, which modifies the resources.
+			r.scheduleAPIKeysExpiry(update)
+			r.translate(update, errChan)
+		},
+	)
+	r.mu.Lock()
+	if r.apiKeysExpiryTimer != nil {
+		r.apiKeysExpiryTimer.Stop()
+	}
+	r.mu.Unlock()
+	r.Logger.Info("shutting down")
+}
+
+// scheduleAPIKeysExpiry schedules the translation of a copy of the provider resources again when the next
+// API keys expire, so that they are removed from the xds IR and reported in the SecurityPolicy status.
+// The caller must hold the lock.
+func (r *Runner) scheduleAPIKeysExpiry(update message.Update[string, *resource.ControllerResources]) {
+	if r.apiKeysExpiryTimer != nil {
+		r.apiKeysExpiryTimer.Stop()
+		r.apiKeysExpiryTimer = nil
+	}
+	if update.Delete || update.Value == nil {
+		return
+	}
+
+	now := time.Now()
+	var next *time.Time
+	for _, resources := range *update.Value {
+		if expiry := gatewayapi.NextAPIKeysExpiry(resources, now); expiry != nil && (next == nil || expiry.Before(*next)) {
+			next = expiry
+		}
+	}
+	if next == nil {
+		return
+	}
+
+	update.Value = update.Value.DeepCopy()
+	var timer *time.Timer
+	timer = time.AfterFunc(next.Sub(now), func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		// The timer was replaced by a newer update.
+		if r.apiKeysExpiryTimer != timer {
+			return
+		}
+		r.Logger.Info("translating again for expired API keys")
+		errChan := make(chan error)
+		go func() {
+			for err := range errChan {
+				r.Logger.Error(err, "observed an error")
 			}
+		}()
+		r.scheduleAPIKeysExpiry(update)
+		r.translate(message.Update[string, *resource.ControllerResources]{Key: update.Key, Value: update.Value.DeepCopy()}, errChan)
+		close(errChan)
+	})
+	r.apiKeysExpiryTimer = timer
+}
+
+// translate translates the provider resources and publishes the IRs and statuses.
+func (r *Runner) translate(update message.Update[string, *resource.ControllerResources], errChan chan error) {
+	r.Logger.Info("received an update")
+	val := update.Value
+	// There is only 1 key which is the controller name
+	// so when a delete is triggered, delete all IR keys
+	if update.Delete || val == nil {
+		r.deleteAllIRKeys()
+		r.deleteAllStatusKeys()
+		return
+	}
 
-			// IR keys for watchable
-			var curIRKeys, newIRKeys []string
+	// IR keys for watchable
+	var curIRKeys, newIRKeys []string
 
-			// Get current IR keys
-			for key := range r.InfraIR.LoadAll() {
-				curIRKeys = append(curIRKeys, key)
+	// Get current IR keys
+	for key := range r.InfraIR.LoadAll() {
+		curIRKeys = append(curIRKeys, key)
+	}
+
+	// Get all status keys from watchable and save them in this StatusesToDelete structure.
+	// Iterating through the controller resources, any valid keys will be removed from statusesToDelete.
+	// Remaining keys will be deleted from watchable before we exit this function.
+	statusesToDelete := r.getAllStatuses()
+
+	for _, resources := range *val {
+		// Translate and publish IRs.
+		t := &gatewayapi.Translator{
+			GatewayControllerName:     r.EnvoyGateway.Gateway.ControllerName,
+			GatewayClassName:          gwapiv1.ObjectName(resources.GatewayClass.Name),
+			GlobalRateLimitEnabled:    r.EnvoyGateway.RateLimit != nil,
+			EnvoyPatchPolicyEnabled:   r.EnvoyGateway.ExtensionAPIs != nil && r.EnvoyGateway.ExtensionAPIs.EnableEnvoyPatchPolicy,
+			BackendEnabled:            r.EnvoyGateway.ExtensionAPIs != nil && r.EnvoyGateway.ExtensionAPIs.EnableBackend,
+			ControllerNamespace:       r.ControllerNamespace,
+			GatewayNamespaceMode:      r.EnvoyGateway.GatewayNamespaceMode(),
+			MergeGateways:             gatewayapi.IsMergeGatewaysEnabled(resources),
+			WasmCache:                 r.wasmCache,
+			ListenerPortShiftDisabled: r.EnvoyGateway.Provider != nil && r.EnvoyGateway.Provider.IsRunningOnHost(),
+			EnvoyTLSSecret:            r.envoyTLSSecret,
+		}
+
+		// If an extension is loaded, pass its supported groups/kinds to the translator
+		if r.EnvoyGateway.ExtensionManager != nil {
+			var extGKs []schema.GroupKind
+			for _, gvk := range r.EnvoyGateway.ExtensionManager.Resources {
+				extGKs = append(extGKs, schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind})
 			}
+			// Include backend resources in extension group kinds for custom backend support
+			for _, gvk := range r.EnvoyGateway.ExtensionManager.BackendResources {
+				extGKs = append(extGKs, schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind})
+			}
+			t.ExtensionGroupKinds = extGKs
+			r.Logger.Info("extension resources", "GVKs count", len(extGKs))
+		}
+		// Translate to IR
+		result, err := t.Translate(resources)
+		if err != nil {
+			// Currently all errors that Translate returns should just be logged
+			r.Logger.Error(err, "errors detected during translation", "gateway-class", resources.GatewayClass.Name)
+		}
 
-			// Get all status keys from watchable and save them in this StatusesToDelete structure.
-			// Iterating through the controller resources, any valid keys will be removed from statusesToDelete.
-			// Remaining keys will be deleted from watchable before we exit this function.
-			statusesToDelete := r.getAllStatuses()
-
-			for _, resources := range *val {
-				// Translate and publish IRs.
-				t := &gatewayapi.Translator{
-					GatewayControllerName:     r.EnvoyGateway.Gateway.ControllerName,
-					GatewayClassName:          gwapiv1.ObjectName(resources.GatewayClass.Name),
-					GlobalRateLimitEnabled:    r.EnvoyGateway.RateLimit != nil,
-					EnvoyPatchPolicyEnabled:   r.EnvoyGateway.ExtensionAPIs != nil && r.EnvoyGateway.ExtensionAPIs.EnableEnvoyPatchPolicy,
-					BackendEnabled:            r.EnvoyGateway.ExtensionAPIs != nil && r.EnvoyGateway.ExtensionAPIs.EnableBackend,
-					ControllerNamespace:       r.ControllerNamespace,
-					GatewayNamespaceMode:      r.EnvoyGateway.GatewayNamespaceMode(),
-					MergeGateways:             gatewayapi.IsMergeGatewaysEnabled(resources),
-					WasmCache:                 r.wasmCache,
-					ListenerPortShiftDisabled: r.EnvoyGateway.Provider != nil && r.EnvoyGateway.Provider.IsRunningOnHost(),
-				}
-
-				// If an extension is loaded, pass its supported groups/kinds to the translator
-				if r.EnvoyGateway.ExtensionManager != nil {
-					var extGKs []schema.GroupKind
-					for _, gvk := range r.EnvoyGateway.ExtensionManager.Resources {
-						extGKs = append(extGKs, schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind})
-					}
-					// Include backend resources in extension group kinds for custom backend support
-					for _, gvk := range r.EnvoyGateway.ExtensionManager.BackendResources {
-						extGKs = append(extGKs, schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind})
-					}
-					t.ExtensionGroupKinds = extGKs
-					r.Logger.Info("extension resources", "GVKs count", len(extGKs))
-				}
-				// Translate to IR
-				result, err := t.Translate(resources)
-				if err != nil {
-					// Currently all errors that Translate returns should just be logged
-					r.Logger.Error(err, "errors detected during translation", "gateway-class", resources.GatewayClass.Name)
-				}
-
-				// Publish the IRs.
-				// Also validate the ir before sending it.
-				for key, val := range result.InfraIR {
-					r.Logger.V(1).WithValues(string(message.InfraIRMessageName), key).Info(val.JSONString())
-					if err := val.Validate(); err != nil {
-						r.Logger.Error(err, "unable to validate infra ir, skipped sending it")
-						errChan <- err
-					} else {
-						r.InfraIR.Store(key, val)
-						message.PublishMetric(message.Metadata{
-							Runner:  r.Name(),
-							Message: message.InfraIRMessageName,
-						})
-						newIRKeys = append(newIRKeys, key)
-					}
-				}
-
-				for key, val := range result.XdsIR {
-					r.Logger.V(1).WithValues(string(message.XDSIRMessageName), key).Info(val.JSONString())
-					if err := val.Validate(); err != nil {
-						r.Logger.Error(err, "unable to validate xds ir, skipped sending it")
-						errChan <- err
-					} else {
-						r.XdsIR.Store(key, val)
-						message.PublishMetric(message.Metadata{
-							Runner:  r.Name(),
-							Message: message.XDSIRMessageName,
-						})
-					}
-				}
-
-				// Update Status
-				for _, gateway := range result.Gateways {
-					key := utils.NamespacedName(gateway)
-					r.ProviderResources.GatewayStatuses.Store(key, &gateway.Status)
-					message.PublishMetric(message.Metadata{
-						Runner:  r.Name(),
-						Message: message.GatewayStatusMessageName,
-					})
-					delete(statusesToDelete.GatewayStatusKeys, key)
-				}
-				for _, httpRoute := range result.HTTPRoutes {
-					key := utils.NamespacedName(httpRoute)
-					r.ProviderResources.HTTPRouteStatuses.Store(key, &httpRoute.Status)
-					message.PublishMetric(message.Metadata{
-						Runner:  r.Name(),
-						Message: message.HTTPRouteStatusMessageName,
-					})
-					delete(statusesToDelete.HTTPRouteStatusKeys, key)
-				}
-				for _, grpcRoute := range result.GRPCRoutes {
-					key := utils.NamespacedName(grpcRoute)
-					r.ProviderResources.GRPCRouteStatuses.Store(key, &grpcRoute.Status)
-					message.PublishMetric(message.Metadata{
-						Runner:  r.Name(),
-						Message: message.GRPCRouteStatusMessageName,
-					})
-					delete(statusesToDelete.GRPCRouteStatusKeys, key)
-				}
-				for _, tlsRoute := range result.TLSRoutes {
-					key := utils.NamespacedName(tlsRoute)
-					r.ProviderResources.TLSRouteStatuses.Store(key, &tlsRoute.Status)
-					message.PublishMetric(message.Metadata{
-						Runner:  r.Name(),
-						Message: message.TLSRouteStatusMessageName,
-					})
-					delete(statusesToDelete.TLSRouteStatusKeys, key)
-				}
-				for _, tcpRoute := range result.TCPRoutes {
-					key := utils.NamespacedName(tcpRoute)
-					r.ProviderResources.TCPRouteStatuses.Store(key, &tcpRoute.Status)
-					message.PublishMetric(message.Metadata{
-						Runner:  r.Name(),
-						Message: message.TCPRouteStatusMessageName,
-					})
-					delete(statusesToDelete.TCPRouteStatusKeys, key)
-				}
-				for _, udpRoute := range result.UDPRoutes {
-					key := utils.NamespacedName(udpRoute)
-					r.ProviderResources.UDPRouteStatuses.Store(key, &udpRoute.Status)
-					message.PublishMetric(message.Metadata{
-						Runner:  r.Name(),
-						Message: message.UDPRouteStatusMessageName,
-					})
-					delete(statusesToDelete.UDPRouteStatusKeys, key)
-				}
-
-				// Skip updating status for policies with empty status
-				// They may have been skipped in this translation because
-				// their target is not found (not relevant)
-
-				for _, backendTLSPolicy := range result.BackendTLSPolicies {
-					key := utils.NamespacedName(backendTLSPolicy)
-					if !(reflect.ValueOf(backendTLSPolicy.Status).IsZero()) {
-						r.ProviderResources.BackendTLSPolicyStatuses.Store(key, &backendTLSPolicy.Status)
-						message.PublishMetric(message.Metadata{
-							Runner:  r.Name(),
-							Message: message.BackendTLSPolicyStatusMessageName,
-						})
-					}
-					delete(statusesToDelete.BackendTLSPolicyStatusKeys, key)
-				}
-
-				for _, clientTrafficPolicy := range result.ClientTrafficPolicies {
-					key := utils.NamespacedName(clientTrafficPolicy)
-					if !(reflect.ValueOf(clientTrafficPolicy.Status).IsZero()) {
-						r.ProviderResources.ClientTrafficPolicyStatuses.Store(key, &clientTrafficPolicy.Status)
-						message.PublishMetric(message.Metadata{
-							Runner:  r.Name(),
-							Message: message.ClientTrafficPolicyStatusMessageName,
-						})
-					}
-					delete(statusesToDelete.ClientTrafficPolicyStatusKeys, key)
-				}
-				for _, backendTrafficPolicy := range result.BackendTrafficPolicies {
-					key := utils.NamespacedName(backendTrafficPolicy)
-					if !(reflect.ValueOf(backendTrafficPolicy.Status).IsZero()) {
-						r.ProviderResources.BackendTrafficPolicyStatuses.Store(key, &backendTrafficPolicy.Status)
-						message.PublishMetric(message.Metadata{
-							Runner:  r.Name(),
-							Message: message.BackendTrafficPolicyStatusMessageName,
-						})
-					}
-					delete(statusesToDelete.BackendTrafficPolicyStatusKeys, key)
-				}
-				for _, securityPolicy := range result.SecurityPolicies {
-					key := utils.NamespacedName(securityPolicy)
-					if !(reflect.ValueOf(securityPolicy.Status).IsZero()) {
-						r.ProviderResources.SecurityPolicyStatuses.Store(key, &securityPolicy.Status)
-						message.PublishMetric(message.Metadata{
-							Runner:  r.Name(),
-							Message: message.SecurityPolicyStatusMessageName,
-						})
-					}
-					delete(statusesToDelete.SecurityPolicyStatusKeys, key)
-				}
-				for _, envoyExtensionPolicy := range result.EnvoyExtensionPolicies {
-					key := utils.NamespacedName(envoyExtensionPolicy)
-					if !(reflect.ValueOf(envoyExtensionPolicy.Status).IsZero()) {
-						r.ProviderResources.EnvoyExtensionPolicyStatuses.Store(key, &envoyExtensionPolicy.Status)
-						message.PublishMetric(message.Metadata{
-							Runner:  r.Name(),
-							Message: message.EnvoyExtensionPolicyStatusMessageName,
-						})
-					}
-					delete(statusesToDelete.EnvoyExtensionPolicyStatusKeys, key)
-				}
-				for _, backend := range result.Backends {
-					key := utils.NamespacedName(backend)
-					if !(reflect.ValueOf(backend.Status).IsZero()) {
-						r.ProviderResources.BackendStatuses.Store(key, &backend.Status)
-						message.PublishMetric(message.Metadata{
-							Runner:  r.Name(),
-							Message: message.BackendStatusMessageName,
-						})
-					}
-					delete(statusesToDelete.BackendStatusKeys, key)
-				}
-				for _, extServerPolicy := range result.ExtensionServerPolicies {
-					key := message.NamespacedNameAndGVK{
-						NamespacedName:   utils.NamespacedName(&extServerPolicy),
-						GroupVersionKind: extServerPolicy.GroupVersionKind(),
-					}
-					if !(reflect.ValueOf(extServerPolicy.Object["status"]).IsZero()) {
-						policyStatus := unstructuredToPolicyStatus(extServerPolicy.Object["status"].(map[string]any))
-						r.ProviderResources.ExtensionPolicyStatuses.Store(key, &policyStatus)
-						message.PublishMetric(message.Metadata{
-							Runner:  r.Name(),
-							Message: message.ExtensionServerPoliciesStatusMessageName,
-						})
-					}
-					delete(statusesToDelete.ExtensionServerPolicyStatusKeys, key)
-				}
+		// Publish the IRs.
+		// Also validate the ir before sending it.
+		for key, val := range result.InfraIR {
+			r.Logger.V(1).WithValues(string(message.InfraIRMessageName), key).Info(val.JSONString())
+			if err := val.Validate(); err != nil {
+				r.Logger.Error(err, "unable to validate infra ir, skipped sending it")
+				errChan <- err
+			} else {
+				r.InfraIR.Store(key, val)
+				message.PublishMetric(message.Metadata{
+					Runner:  r.Name(),
+					Message: message.InfraIRMessageName,
+				})
+				newIRKeys = append(newIRKeys, key)
 			}
+		}
 
-			// Delete IR keys
-			// There is a 1:1 mapping between infra and xds IR keys
-			delKeys := getIRKeysToDelete(curIRKeys, newIRKeys)
-			for _, key := range delKeys {
-				r.InfraIR.Delete(key)
-				r.XdsIR.Delete(key)
+		for key, val := range result.XdsIR {
+			r.Logger.V(1).WithValues(string(message.XDSIRMessageName), key).Info(val.JSONString())
+			if err := val.Validate(); err != nil {
+				r.Logger.Error(err, "unable to validate xds ir, skipped sending it")
+				errChan <- err
+			} else {
+				r.XdsIR.Store(key, val)
+				message.PublishMetric(message.Metadata{
+					Runner:  r.Name(),
+					Message: message.XDSIRMessageName,
+				})
 			}
+		}
 
-			// Delete status keys
-			r.deleteStatusKeys(statusesToDelete)
-		},
-	)
-	r.Logger.Info("shutting down")
+		// Update Status
+		for _, gateway := range result.Gateways {
+			key := utils.NamespacedName(gateway)
+			r.ProviderResources.GatewayStatuses.Store(key, &gateway.Status)
+			message.PublishMetric(message.Metadata{
+				Runner:  r.Name(),
+				Message: message.GatewayStatusMessageName,
+			})
+			delete(statusesToDelete.GatewayStatusKeys, key)
+		}
+		for _, httpRoute := range result.HTTPRoutes {
+			key := utils.NamespacedName(httpRoute)
+			r.ProviderResources.HTTPRouteStatuses.Store(key, &httpRoute.Status)
+			message.PublishMetric(message.Metadata{
+				Runner:  r.Name(),
+				Message: message.HTTPRouteStatusMessageName,
+			})
+			delete(statusesToDelete.HTTPRouteStatusKeys, key)
+		}
+		for _, grpcRoute := range result.GRPCRoutes {
+			key := utils.NamespacedName(grpcRoute)
+			r.ProviderResources.GRPCRouteStatuses.Store(key, &grpcRoute.Status)
+			message.PublishMetric(message.Metadata{
+				Runner:  r.Name(),
+				Message: message.GRPCRouteStatusMessageName,
+			})
+			delete(statusesToDelete.GRPCRouteStatusKeys, key)
+		}
+		for _, tlsRoute := range result.TLSRoutes {
+			key := utils.NamespacedName(tlsRoute)
+			r.ProviderResources.TLSRouteStatuses.Store(key, &tlsRoute.Status)
+			message.PublishMetric(message.Metadata{
+				Runner:  r.Name(),
+				Message: message.TLSRouteStatusMessageName,
+			})
+			delete(statusesToDelete.TLSRouteStatusKeys, key)
+		}
+		for _, tcpRoute := range result.TCPRoutes {
+			key := utils.NamespacedName(tcpRoute)
+			r.ProviderResources.TCPRouteStatuses.Store(key, &tcpRoute.Status)
+			message.PublishMetric(message.Metadata{
+				Runner:  r.Name(),
+				Message: message.TCPRouteStatusMessageName,
+			})
+			delete(statusesToDelete.TCPRouteStatusKeys, key)
+		}
+		for _, udpRoute := range result.UDPRoutes {
+			key := utils.NamespacedName(udpRoute)
+			r.ProviderResources.UDPRouteStatuses.Store(key, &udpRoute.Status)
+			message.PublishMetric(message.Metadata{
+				Runner:  r.Name(),
+				Message: message.UDPRouteStatusMessageName,
+			})
+			delete(statusesToDelete.UDPRouteStatusKeys, key)
+		}
+
+		// Skip updating status for policies with empty status
+		// They may have been skipped in this translation because
+		// their target is not found (not relevant)
+
+		for _, backendTLSPolicy := range result.BackendTLSPolicies {
+			key := utils.NamespacedName(backendTLSPolicy)
+			if !(reflect.ValueOf(backendTLSPolicy.Status).IsZero()) {
+				r.ProviderResources.BackendTLSPolicyStatuses.Store(key, &backendTLSPolicy.Status)
+				message.PublishMetric(message.Metadata{
+					Runner:  r.Name(),
+					Message: message.BackendTLSPolicyStatusMessageName,
+				})
+			}
+			delete(statusesToDelete.BackendTLSPolicyStatusKeys, key)
+		}
+
+		for _, clientTrafficPolicy := range result.ClientTrafficPolicies {
+			key := utils.NamespacedName(clientTrafficPolicy)
+			if !(reflect.ValueOf(clientTrafficPolicy.Status).IsZero()) {
+				r.ProviderResources.ClientTrafficPolicyStatuses.Store(key, &clientTrafficPolicy.Status)
+				message.PublishMetric(message.Metadata{
+					Runner:  r.Name(),
+					Message: message.ClientTrafficPolicyStatusMessageName,
+				})
+			}
+			delete(statusesToDelete.ClientTrafficPolicyStatusKeys, key)
+		}
+		for _, backendTrafficPolicy := range result.BackendTrafficPolicies {
+			key := utils.NamespacedName(backendTrafficPolicy)
+			if !(reflect.ValueOf(backendTrafficPolicy.Status).IsZero()) {
+				r.ProviderResources.BackendTrafficPolicyStatuses.Store(key, &backendTrafficPolicy.Status)
+				message.PublishMetric(message.Metadata{
+					Runner:  r.Name(),
+					Message: message.BackendTrafficPolicyStatusMessageName,
+				})
+			}
+			delete(statusesToDelete.BackendTrafficPolicyStatusKeys, key)
+		}
+		for _, securityPolicy := range result.SecurityPolicies {
+			key := utils.NamespacedName(securityPolicy)
+			if !(reflect.ValueOf(securityPolicy.Status).IsZero()) {
+				r.ProviderResources.SecurityPolicyStatuses.Store(key, &securityPolicy.Status)
+				message.PublishMetric(message.Metadata{
+					Runner:  r.Name(),
+					Message: message.SecurityPolicyStatusMessageName,
+				})
+			}
+			delete(statusesToDelete.SecurityPolicyStatusKeys, key)
+		}
+		for _, envoyExtensionPolicy := range result.EnvoyExtensionPolicies {
+			key := utils.NamespacedName(envoyExtensionPolicy)
+			if !(reflect.ValueOf(envoyExtensionPolicy.Status).IsZero()) {
+				r.ProviderResources.EnvoyExtensionPolicyStatuses.Store(key, &envoyExtensionPolicy.Status)
+				message.PublishMetric(message.Metadata{
+					Runner:  r.Name(),
+					Message: message.EnvoyExtensionPolicyStatusMessageName,
+				})
+			}
+			delete(statusesToDelete.EnvoyExtensionPolicyStatusKeys, key)
+		}
+		for _, backend := range result.Backends {
+			key := utils.NamespacedName(backend)
+			if !(reflect.ValueOf(backend.Status).IsZero()) {
+				r.ProviderResources.BackendStatuses.Store(key, &backend.Status)
+				message.PublishMetric(message.Metadata{
+					Runner:  r.Name(),
+					Message: message.BackendStatusMessageName,
+				})
+			}
+			delete(statusesToDelete.BackendStatusKeys, key)
+		}
+		for _, extServerPolicy := range result.ExtensionServerPolicies {
+			key := message.NamespacedNameAndGVK{
+				NamespacedName:   utils.NamespacedName(&extServerPolicy),
+				GroupVersionKind: extServerPolicy.GroupVersionKind(),
+			}
+			if !(reflect.ValueOf(extServerPolicy.Object["status"]).IsZero()) {
+				policyStatus := unstructuredToPolicyStatus(extServerPolicy.Object["status"].(map[string]any))
+				r.ProviderResources.ExtensionPolicyStatuses.Store(key, &policyStatus)
+				message.PublishMetric(message.Metadata{
+					Runner:  r.Name(),
+					Message: message.ExtensionServerPoliciesStatusMessageName,
+				})
+			}
+			delete(statusesToDelete.ExtensionServerPolicyStatusKeys, key)
+		}
+	}
+
+	// Delete IR keys
+	// There is a 1:1 mapping between infra and xds IR keys
+	delKeys := getIRKeysToDelete(curIRKeys, newIRKeys)
+	for _, key := range delKeys {
+		r.InfraIR.Delete(key)
+		r.XdsIR.Delete(key)
+	}
+
+	// Delete status keys
+	r.deleteStatusKeys(statusesToDelete)
 }
 
 func (r *Runner) loadTLSConfig(ctx context.Context) (tlsConfig *tls.Config, salt []byte, err error) {
@@ -390,12 +469,15 @@ func (r *Runner) loadTLSConfig(ctx context.Context) (tlsConfig *tls.Config, salt
 		}
 
 	case r.EnvoyGateway.Provider.IsRunningOnHost():
-		salt, err = os.ReadFile(hmacSecretPath)
+		salt, err = os.ReadFile(filepath.Join(r.LocalCertsDir, hmacSecretName, hmacSecretKey))
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to get hmac secret: %w", err)
 		}
 
-		tlsConfig, err = crypto.LoadTLSConfig(localTLSCertFilepath, localTLSKeyFilepath, localTLSCaFilepath)
+		tlsConfig, err = crypto.LoadTLSConfig(
+			filepath.Join(r.LocalCertsDir, "envoy-gateway", "tls.crt"),
+			filepath.Join(r.LocalCertsDir, "envoy-gateway", "tls.key"),
+			filepath.Join(r.LocalCertsDir, "envoy-gateway", "ca.crt"))
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to create tls config: %w", err)
 		}
@@ -406,6 +488,29 @@ func (r *Runner) loadTLSConfig(ctx context.Context) (tlsConfig *tls.Config, salt
 	return
 }
 
+// loadLocalEnvoyTLSSecret builds the envoy client TLS secret from the local certificates.
+func (r *Runner) loadLocalEnvoyTLSSecret() (*corev1.Secret, error) {
+	cert, err := os.ReadFile(filepath.Join(r.LocalEnvoyCertsDir, corev1.TLSCertKey))
+	if err != nil {
+		return nil, err
+	}
+	key, err := os.ReadFile(filepath.Join(r.LocalEnvoyCertsDir, corev1.TLSPrivateKeyKey))
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.ControllerNamespace,
+			Name:      "envoy",
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       cert,
+			corev1.TLSPrivateKeyKey: key,
+		},
+	}, nil
+}
+
 func unstructuredToPolicyStatus(policyStatus map[string]any) gwapiv1a2.PolicyStatus {
 	var ret gwapiv1a2.PolicyStatus
 	// No need to check the json marshal/unmarshal error, the policyStatus was
@@ -14,6 +14,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -21,6 +23,7 @@ import (
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/extension/registry"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/message"
 	pb "github.com/envoyproxy/gateway/proto/extension"
@@ -305,3 +308,56 @@ func TestDeleteAllStatusKeys(t *testing.T) {
 	require.Equal(t, 0, r.ProviderResources.UDPRouteStatuses.Len())
 	require.Equal(t, 0, r.ProviderResources.BackendStatuses.Len())
 }
+
+func TestScheduleAPIKeysExpiry(t *testing.T) {
+	cfg, err := config.New(os.Stdout)
+	require.NoError(t, err)
+	r := New(&Config{
+		Server:            *cfg,
+		ProviderResources: new(message.ProviderResources),
+		XdsIR:             new(message.XdsIR),
+		InfraIR:           new(message.InfraIR),
+	})
+
+	resourcesWithExpiry := func(expiry time.Time) *resource.ControllerResources {
+		res := resource.NewResources()
+		res.SecurityPolicies = []*egv1a1.SecurityPolicy{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "apikey-auth"},
+			Spec: egv1a1.SecurityPolicySpec{
+				APIKeyAuth: &egv1a1.APIKeyAuth{
+					CredentialRefs: []gwapiv1.SecretObjectReference{{Name: "apikeys"}},
+				},
+			},
+		}}
+		res.Secrets = []*corev1.Secret{{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "apikeys",
+				Annotations: map[string]string{egv1a1.APIKeysExpireAtAnnotation: expiry.Format(time.RFC3339)},
+			},
+			Data: map[string][]byte{"client1": []byte("key1")},
+		}}
+		return &resource.ControllerResources{res}
+	}
+
+	// API keys expiring later are translated again when they expire.
+	r.mu.Lock()
+	r.scheduleAPIKeysExpiry(message.Update[string, *resource.ControllerResources]{
+		Key: "test", Value: resourcesWithExpiry(time.Now().Add(time.Hour)),
+	})
+	require.NotNil(t, r.apiKeysExpiryTimer)
+
+	// API keys that already expired were removed by the translation.
+	r.scheduleAPIKeysExpiry(message.Update[string, *resource.ControllerResources]{
+		Key: "test", Value: resourcesWithExpiry(time.Now().Add(-time.Hour)),
+	})
+	require.Nil(t, r.apiKeysExpiryTimer)
+
+	r.scheduleAPIKeysExpiry(message.Update[string, *resource.ControllerResources]{
+		Key: "test", Value: resourcesWithExpiry(time.Now().Add(time.Hour)),
+	})
+	require.NotNil(t, r.apiKeysExpiryTimer)
+	r.scheduleAPIKeysExpiry(message.Update[string, *resource.ControllerResources]{Key: "test", Delete: true})
+	require.Nil(t, r.apiKeysExpiryTimer)
+	r.mu.Unlock()
+}
@@ -6,6 +6,7 @@
 package gatewayapi
 
 import (
+	"bytes"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
@@ -15,6 +16,7 @@ import (
 	"net/mail"
 	"net/netip"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -31,10 +33,12 @@ import (
 	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/basicauth"
 	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
 	"github.com/envoyproxy/gateway/internal/gatewayapi/status"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/utils"
+	"github.com/envoyproxy/gateway/internal/utils/regex"
 )
 
 const (
@@ -44,6 +48,10 @@ const (
 	defaultForwardAccessToken    = false
 	defaultRefreshToken          = false
 	defaultPassThroughAuthHeader = false
+	defaultLDAPSearchFilter      = "(uid=%s)"
+
+	// htpasswdUnknownHashAlgorithm is the name of the hash algorithm of a crypt or plain text password.
+	htpasswdUnknownHashAlgorithm = "crypt or plain text"
 
 	// nolint: gosec
 	oidcHMACSecretName = "envoy-oidc-hmac"
@@ -260,6 +268,19 @@ func (t *Translator) processSecurityPolicyForHTTPRoute(
 	// Set Accepted condition if it is unset
 	status.SetAcceptedForPolicyAncestors(&policy.Status, parentGateways, t.GatewayControllerName, policy.Generation)
 
+	// Report the expired API keys, which are no longer accepted
+	if expiredAPIKeysMessage := expiredAPIKeysMessage(policy, resources); expiredAPIKeysMessage != "" {
+		status.SetConditionForPolicyAncestors(&policy.Status,
+			parentGateways,
+			t.GatewayControllerName,
+			egv1a1.PolicyConditionAPIKeysExpired,
+			metav1.ConditionTrue,
+			egv1a1.PolicyReasonExpired,
+			expiredAPIKeysMessage,
+			policy.Generation,
+		)
+	}
+
 	// Check if this policy is overridden by other policies targeting at route rule levels
 	key := policyTargetRouteKey{
 		Kind:      string(currTarget.Kind),
@@ -332,6 +353,19 @@ func (t *Translator) processSecurityPolicyForGateway(
 	// Set Accepted condition if it is unset
 	status.SetAcceptedForPolicyAncestors(&policy.Status, parentGateways, t.GatewayControllerName, policy.Generation)
 
+	// Report the expired API keys, which are no longer accepted
+	if expiredAPIKeysMessage := expiredAPIKeysMessage(policy, resources); expiredAPIKeysMessage != "" {
+		status.SetConditionForPolicyAncestors(&policy.Status,
+			parentGateways,
+			t.GatewayControllerName,
+			egv1a1.PolicyConditionAPIKeysExpired,
+			metav1.ConditionTrue,
+			egv1a1.PolicyReasonExpired,
+			expiredAPIKeysMessage,
+			policy.Generation,
+		)
+	}
+
 	// Check if this policy is overridden by other policies targeting at route and listener levels
 	overriddenTargetsMessage := getOverriddenTargetsMessageForGateway(
 		gatewayMap[gatewayNN], gatewayRouteMap[gatewayNN.String()], currTarget.SectionName)
@@ -539,9 +573,11 @@ func (t *Translator) translateSecurityPolicyForRoute(
 	// Build IR
 	var (
 		cors               *ir.CORS
+		csrf               *ir.CSRF
 		apiKeyAuth         *ir.APIKeyAuth
 		basicAuth          *ir.BasicAuth
 		authorization      *ir.Authorization
+		policyAuth         *ir.PolicyAuth
 		err, errs          error
 		hasNonExtAuthError bool
 	)
@@ -550,6 +586,10 @@ func (t *Translator) translateSecurityPolicyForRoute(
 		cors = t.buildCORS(policy.Spec.CORS)
 	}
 
+	if policy.Spec.CSRF != nil {
+		csrf = t.buildCSRF(policy.Spec.CSRF)
+	}
+
 	if policy.Spec.BasicAuth != nil {
 		if basicAuth, err = t.buildBasicAuth(
 			policy,
@@ -575,6 +615,13 @@ func (t *Translator) translateSecurityPolicyForRoute(
 		}
 	}
 
+	if policy.Spec.Policy != nil {
+		if policyAuth, err = t.buildPolicyAuth(policy, resources); err != nil {
+			err = perr.WithMessage(err, "Policy")
+			errs = errors.Join(errs, err)
+		}
+	}
+
 	hasNonExtAuthError = errs != nil
 
 	// Apply IR to all relevant routes
@@ -643,12 +690,14 @@ func (t *Translator) translateSecurityPolicyForRoute(
 
 						r.Security = &ir.SecurityFeatures{
 							CORS:          cors,
+							CSRF:          csrf,
 							JWT:           jwt,
 							OIDC:          oidc,
 							APIKeyAuth:    apiKeyAuth,
 							BasicAuth:     basicAuth,
 							ExtAuth:       extAuth,
 							Authorization: authorization,
+							PolicyAuth:    policyAuth,
 						}
 						if errs != nil {
 							// If there is only error for ext auth and ext auth is set to fail open, then skip the ext auth
@@ -680,12 +729,14 @@ func (t *Translator) translateSecurityPolicyForGateway(
 	// Build IR
 	var (
 		cors                  *ir.CORS
+		csrf                  *ir.CSRF
 		jwt                   *ir.JWT
 		oidc                  *ir.OIDC
 		apiKeyAuth            *ir.APIKeyAuth
 		basicAuth             *ir.BasicAuth
 		extAuth               *ir.ExtAuth
 		authorization         *ir.Authorization
+		policyAuth            *ir.PolicyAuth
 		extAuthErr, err, errs error
 		hasNonExtAuthError    bool
 	)
@@ -694,6 +745,10 @@ func (t *Translator) translateSecurityPolicyForGateway(
 		cors = t.buildCORS(policy.Spec.CORS)
 	}
 
+	if policy.Spec.CSRF != nil {
+		csrf = t.buildCSRF(policy.Spec.CSRF)
+	}
+
 	if policy.Spec.JWT != nil {
 		if jwt, err = t.buildJWT(
 			policy,
@@ -738,6 +793,13 @@ func (t *Translator) translateSecurityPolicyForGateway(
 		}
 	}
 
+	if policy.Spec.Policy != nil {
+		if policyAuth, err = t.buildPolicyAuth(policy, resources); err != nil {
+			err = perr.WithMessage(err, "Policy")
+			errs = errors.Join(errs, err)
+		}
+	}
+
 	hasNonExtAuthError = errs != nil
 
 	if policy.Spec.ExtAuth != nil {
@@ -782,12 +844,14 @@ func (t *Translator) translateSecurityPolicyForGateway(
 			}
 			r.Security = &ir.SecurityFeatures{
 				CORS:          cors,
+				CSRF:          csrf,
 				JWT:           jwt,
 				OIDC:          oidc,
 				APIKeyAuth:    apiKeyAuth,
 				BasicAuth:     basicAuth,
 				ExtAuth:       extAuth,
 				Authorization: authorization,
+				PolicyAuth:    policyAuth,
 			}
 			if errs != nil {
 				// If there is only error for ext auth and ext auth is set to fail open, then skip the ext auth
@@ -838,6 +902,34 @@ func (t *Translator) buildCORS(cors *egv1a1.CORS) *ir.CORS {
 	return irCORS
 }
 
+func (t *Translator) buildCSRF(csrf *egv1a1.CSRF) *ir.CSRF {
+	var additionalOrigins []*ir.StringMatch
+
+	// Envoy compares the host and port of the source origin, so the scheme is stripped.
+	for _, origin := range csrf.AdditionalOrigins {
+		hostPort := string(origin)
+		if i := strings.Index(hostPort, "://"); i >= 0 {
+			hostPort = hostPort[i+3:]
+		}
+		if containsWildcard(hostPort) {
+			regexStr := wildcard2regex(hostPort)
+			additionalOrigins = append(additionalOrigins, &ir.StringMatch{
+				SafeRegex: &regexStr,
+			})
+		} else {
+			additionalOrigins = append(additionalOrigins, &ir.StringMatch{
+				Exact: &hostPort,
+			})
+		}
+	}
+
+	return &ir.CSRF{
+		AdditionalOrigins: additionalOrigins,
+		FilterEnabled:     csrf.FilterEnabled,
+		ShadowEnabled:     csrf.ShadowEnabled,
+	}
+}
+
 func containsWildcard(s string) bool {
 	return strings.ContainsAny(s, "*")
 }
@@ -1011,11 +1103,42 @@ func (t *Translator) buildRemoteJWKS(
 		}
 	}
 
-	return &ir.RemoteJWKS{
+	irRemoteJWKS := &ir.RemoteJWKS{
 		Destination: rd,
 		Traffic:     traffic,
 		URI:         remoteJWKS.URI,
-	}, nil
+	}
+
+	if irRemoteJWKS.CacheDuration, err = parseRemoteJWKSDuration("cacheDuration", remoteJWKS.CacheDuration); err != nil {
+		return nil, err
+	}
+	if irRemoteJWKS.Timeout, err = parseRemoteJWKSDuration("timeout", remoteJWKS.Timeout); err != nil {
+		return nil, err
+	}
+	if remoteJWKS.AsyncFetch != nil {
+		irRemoteJWKS.FastListener = ptr.Deref(remoteJWKS.AsyncFetch.FastListener, false)
+		if irRemoteJWKS.FailedRefetchDuration, err = parseRemoteJWKSDuration(
+			"failedRefetchDuration", remoteJWKS.AsyncFetch.FailedRefetchDuration); err != nil {
+			return nil, err
+		}
+	}
+
+	return irRemoteJWKS, nil
+}
+
+// parseRemoteJWKSDuration parses a duration of the remote JWKS, which must be positive.
+func parseRemoteJWKSDuration(field string, duration *gwapiv1.Duration) (*metav1.Duration, error) {
+	if duration == nil {
+		return nil, nil
+	}
+	d, err := time.ParseDuration(string(*duration))
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote JWKS %s: %w", field, err)
+	}
+	if d <= 0 {
+		return nil, fmt.Errorf("invalid remote JWKS %s: %s, must be positive", field, *duration)
+	}
+	return ir.MetaV1DurationPtr(d), nil
 }
 
 func (t *Translator) buildLocalJWKS(
@@ -1069,10 +1192,11 @@ func (t *Translator) buildOIDC(
 		forwardAccessToken    = defaultForwardAccessToken
 		refreshToken          = defaultRefreshToken
 		passThroughAuthHeader = defaultPassThroughAuthHeader
+		endpoints             *oidcEndpoints
 		err                   error
 	)
 
-	if provider, err = t.buildOIDCProvider(policy, resources, envoyProxy); err != nil {
+	if provider, endpoints, err = t.buildOIDCProvider(policy, resources, envoyProxy); err != nil {
 		return nil, err
 	}
 
@@ -1126,6 +1250,11 @@ func (t *Translator) buildOIDC(
 	if oidc.LogoutPath != nil {
 		logoutPath = *oidc.LogoutPath
 	}
+	if oidc.BackchannelLogoutPath != nil &&
+		(*oidc.BackchannelLogoutPath == logoutPath || *oidc.BackchannelLogoutPath == redirectPath) {
+		return nil, fmt.Errorf("backchannel logout path %s must be different from the logout path and the redirect path",
+			*oidc.BackchannelLogoutPath)
+	}
 	if oidc.ForwardAccessToken != nil {
 		forwardAccessToken = *oidc.ForwardAccessToken
 	}
@@ -1189,10 +1318,57 @@ func (t *Translator) buildOIDC(
 		}
 	}
 
+	if len(oidc.ClaimToHeaders) > 0 {
+		irOIDC.IDTokenClaims = &ir.OIDCIDTokenClaims{
+			Issuer: oidc.Provider.Issuer,
+			RemoteJWKS: ir.RemoteJWKS{
+				Destination: provider.Destination,
+				Traffic:     provider.Traffic,
+				URI:         endpoints.jwksURI,
+			},
+			ClaimToHeaders: oidc.ClaimToHeaders,
+		}
+	}
+
+	if oidcSessionCheckedByEnvoyGateway(oidc) {
+		irOIDC.Session = &ir.OIDCSession{
+			Issuer:                oidc.Provider.Issuer,
+			BackchannelLogoutPath: ptr.Deref(oidc.BackchannelLogoutPath, ""),
+			IntrospectionEndpoint: endpoints.introspectionEndpoint,
+			UserInfoEndpoint:      endpoints.userInfoEndpoint,
+			UserInfoToHeaders:     oidc.UserInfoToHeaders,
+		}
+		if oidc.BackchannelLogoutPath != nil {
+			irOIDC.Session.JWKSURI = endpoints.jwksURI
+		}
+	}
+
 	return irOIDC, nil
 }
 
-func (t *Translator) buildOIDCProvider(policy *egv1a1.SecurityPolicy, resources *resource.Resources, envoyProxy *egv1a1.EnvoyProxy) (*ir.OIDCProvider, error) {
+// oidcSessionCheckedByEnvoyGateway returns true if the sessions of the OIDC configuration are
+// checked by Envoy Gateway for each request, which is needed by the back-channel logout, the
+// introspection of the access tokens and the UserInfo claims.
+func oidcSessionCheckedByEnvoyGateway(oidc *egv1a1.OIDC) bool {
+	return oidc.BackchannelLogoutPath != nil ||
+		oidc.Provider.Introspection != nil ||
+		len(oidc.UserInfoToHeaders) > 0
+}
+
+// oidcEndpoints holds the endpoints of the OIDC Provider that are only needed by some features.
+type oidcEndpoints struct {
+	// jwksURI is used to validate the ID tokens whose claims are extracted into headers, and the logout tokens.
+	jwksURI string
+	// introspectionEndpoint is used to introspect the access tokens.
+	introspectionEndpoint string
+	// userInfoEndpoint is used to extract the UserInfo claims into headers.
+	userInfoEndpoint string
+}
+
+// buildOIDCProvider builds the IR OIDC Provider from the SecurityPolicy.
+// It also returns the endpoints of the provider that are needed by the features
+// enabled in the SecurityPolicy.
+func (t *Translator) buildOIDCProvider(policy *egv1a1.SecurityPolicy, resources *resource.Resources, envoyProxy *egv1a1.EnvoyProxy) (*ir.OIDCProvider, *oidcEndpoints, error) {
 	var (
 		provider              = policy.Spec.OIDC.Provider
 		tokenEndpoint         string
@@ -1202,6 +1378,7 @@ func (t *Translator) buildOIDCProvider(policy *egv1a1.SecurityPolicy, resources
 		rd                    *ir.RouteDestination
 		traffic               *ir.TrafficFeatures
 		providerTLS           *ir.TLSUpstreamConfig
+		endpoints             = &oidcEndpoints{}
 		err                   error
 	)
 
@@ -1213,7 +1390,7 @@ func (t *Translator) buildOIDCProvider(policy *egv1a1.SecurityPolicy, resources
 	}
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if u.Scheme == "https" {
@@ -1224,7 +1401,7 @@ func (t *Translator) buildOIDCProvider(policy *egv1a1.SecurityPolicy, resources
 
 	if len(provider.BackendRefs) > 0 {
 		if rd, err = t.translateExtServiceBackendRefs(policy, provider.BackendRefs, protocol, resources, envoyProxy, "oidc", 0); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
@@ -1240,10 +1417,10 @@ func (t *Translator) buildOIDCProvider(policy *egv1a1.SecurityPolicy, resources
 	// Discover the token and authorization endpoints from the issuer's well-known url if not explicitly specified.
 	// EG assumes that the issuer url uses the same protocol and CA as the token endpoint.
 	// If we need to support different protocols or CAs, we need to add more fields to the OIDCProvider CRD.
+	var discoveredConfig *OpenIDConfig
 	if provider.TokenEndpoint == nil || provider.AuthorizationEndpoint == nil {
-		discoveredConfig, err := fetchEndpointsFromIssuer(provider.Issuer, providerTLS)
-		if err != nil {
-			return nil, fmt.Errorf("error fetching endpoints from issuer: %w", err)
+		if discoveredConfig, err = fetchEndpointsFromIssuer(provider.Issuer, providerTLS); err != nil {
+			return nil, nil, fmt.Errorf("error fetching endpoints from issuer: %w", err)
 		}
 		tokenEndpoint = discoveredConfig.TokenEndpoint
 		authorizationEndpoint = discoveredConfig.AuthorizationEndpoint
@@ -1258,11 +1435,51 @@ func (t *Translator) buildOIDCProvider(policy *egv1a1.SecurityPolicy, resources
 	}
 
 	if err = validateTokenEndpoint(tokenEndpoint); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// endpoint returns the explicitly specified endpoint, or discovers it from the issuer's well-known url.
+	endpoint := func(name string, specified *string, discovered func(*OpenIDConfig) string) (string, error) {
+		var e string
+		if specified != nil {
+			e = *specified
+		} else {
+			if discoveredConfig == nil {
+				if discoveredConfig, err = fetchEndpointsFromIssuer(provider.Issuer, providerTLS); err != nil {
+					return "", fmt.Errorf("error fetching endpoints from issuer: %w", err)
+				}
+			}
+			e = discovered(discoveredConfig)
+		}
+		if err := validateOIDCEndpoint(name, e); err != nil {
+			return "", err
+		}
+		return e, nil
+	}
+
+	// The JWKS is only needed to validate the ID token when its claims are extracted into headers,
+	// and the logout tokens of the back-channel logout.
+	if len(policy.Spec.OIDC.ClaimToHeaders) > 0 || policy.Spec.OIDC.BackchannelLogoutPath != nil {
+		if endpoints.jwksURI, err = endpoint("jwks uri", provider.JWKSURI,
+			func(c *OpenIDConfig) string { return c.JWKSURI }); err != nil {
+			return nil, nil, err
+		}
+	}
+	if provider.Introspection != nil {
+		if endpoints.introspectionEndpoint, err = endpoint("introspection endpoint", provider.Introspection.Endpoint,
+			func(c *OpenIDConfig) string { return c.IntrospectionEndpoint }); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(policy.Spec.OIDC.UserInfoToHeaders) > 0 {
+		if endpoints.userInfoEndpoint, err = endpoint("userinfo endpoint", provider.UserInfoEndpoint,
+			func(c *OpenIDConfig) string { return c.UserInfoEndpoint }); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	if traffic, err = translateTrafficFeatures(provider.BackendSettings); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	return &ir.OIDCProvider{
@@ -1271,7 +1488,22 @@ func (t *Translator) buildOIDCProvider(policy *egv1a1.SecurityPolicy, resources
 		AuthorizationEndpoint: authorizationEndpoint,
 		TokenEndpoint:         tokenEndpoint,
 		EndSessionEndpoint:    endSessionEndpoint,
-	}, nil
+	}, endpoints, nil
+}
+
+// validateOIDCEndpoint validates an endpoint of the OIDC Provider, e.g. the JWKS URI.
+func validateOIDCEndpoint(name, endpoint string) error {
+	if endpoint == "" {
+		return fmt.Errorf("%s not found in the well-known configuration of the issuer", name)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid %s %s: %w", name, endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid %s %s: scheme must be http or https", name, endpoint)
+	}
+	return nil
 }
 
 func extractRedirectPath(redirectURL string) (string, error) {
@@ -1317,6 +1549,9 @@ type OpenIDConfig struct {
 	TokenEndpoint         string  `json:"token_endpoint"`
 	AuthorizationEndpoint string  `json:"authorization_endpoint"`
 	EndSessionEndpoint    *string `json:"end_session_endpoint,omitempty"`
+	JWKSURI               string  `json:"jwks_uri,omitempty"`
+	IntrospectionEndpoint string  `json:"introspection_endpoint,omitempty"`
+	UserInfoEndpoint      string  `json:"userinfo_endpoint,omitempty"`
 }
 
 func fetchEndpointsFromIssuer(issuerURL string, providerTLS *ir.TLSUpstreamConfig) (*OpenIDConfig, error) {
@@ -1390,6 +1625,9 @@ func (t *Translator) buildAPIKeyAuth(
 	}
 
 	credentials := make(map[string]ir.PrivateBytes)
+	hashedCredentials := make(map[string]ir.PrivateBytes)
+	metadata := make(map[string]*ir.APIKeyMetadata)
+	seenClients := make(sets.Set[string])
 	seenKeys := make(sets.Set[string])
 
 	for _, ref := range policy.Spec.APIKeyAuth.CredentialRefs {
@@ -1398,18 +1636,58 @@ func (t *Translator) buildAPIKeyAuth(
 		if err != nil {
 			return nil, err
 		}
-		for clientid, key := range credentialsSecret.Data {
-			if _, ok := credentials[clientid]; ok {
+
+		// The expired API keys are reported in the status of the policy.
+		expiry, err := apiKeysExpiry(credentialsSecret)
+		if err != nil {
+			return nil, err
+		}
+		if expiry != nil && !time.Now().Before(*expiry) {
+			continue
+		}
+
+		for clientid, value := range credentialsSecret.Data {
+			if seenClients.Has(clientid) {
 				continue
 			}
+			seenClients.Insert(clientid)
 
-			keyString := string(key)
-			if seenKeys.Has(keyString) {
-				return nil, errors.New("duplicated API key")
+			entry, err := parseAPIKeyEntry(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid API key of client %s in secret %s/%s: %w",
+					clientid, credentialsSecret.Namespace, credentialsSecret.Name, err)
+			}
+			// The expired API keys are reported in the status of the policy.
+			if entry.ExpiresAt != nil && !time.Now().Before(entry.ExpiresAt.Time) {
+				continue
+			}
+			if entry.Tenant != "" || entry.Plan != "" || entry.ExpiresAt != nil {
+				metadata[clientid] = &ir.APIKeyMetadata{
+					Tenant:    entry.Tenant,
+					Plan:      entry.Plan,
+					ExpiresAt: entry.ExpiresAt,
+				}
 			}
 
-			seenKeys.Insert(keyString)
-			credentials[clientid] = key
+			// The hashed API keys are verified by Envoy Gateway.
+			switch algorithm, hashed := hashedAPIKeyAlgorithm(entry.Key); {
+			case algorithm == "SHA", algorithm == "bcrypt", algorithm == "argon2":
+				if err := basicauth.ValidatePasswordHash(entry.Key); err != nil {
+					return nil, fmt.Errorf("invalid %s hash of the API key of client %s in secret %s/%s: %w",
+						algorithm, clientid, credentialsSecret.Namespace, credentialsSecret.Name, err)
+				}
+				hashedCredentials[clientid] = []byte(entry.Key)
+			case hashed:
+				return nil, fmt.Errorf("the API key of client %s in secret %s/%s is hashed with %s, "+
+					"please use {SHA}, bcrypt or argon2",
+					clientid, credentialsSecret.Namespace, credentialsSecret.Name, algorithm)
+			default:
+				if seenKeys.Has(entry.Key) {
+					return nil, errors.New("duplicated API key")
+				}
+				seenKeys.Insert(entry.Key)
+				credentials[clientid] = []byte(entry.Key)
+			}
 		}
 	}
 
@@ -1423,13 +1701,153 @@ func (t *Translator) buildAPIKeyAuth(
 	}
 
 	return &ir.APIKeyAuth{
+		Name:                  irConfigName(policy),
 		Credentials:           credentials,
+		HashedCredentials:     hashedCredentials,
+		Metadata:              metadata,
 		ExtractFrom:           extractFrom,
 		ForwardClientIDHeader: policy.Spec.APIKeyAuth.ForwardClientIDHeader,
 		Sanitize:              policy.Spec.APIKeyAuth.Sanitize,
 	}, nil
 }
 
+// apiKeyEntry is an API key with its metadata, stored as a JSON object in the value of the
+// client id key of a Secret referenced by CredentialRefs.
+type apiKeyEntry struct {
+	// Key is the API key, as plain text or hashed.
+	Key string `json:"key"`
+	// Tenant is the tenant of the client.
+	Tenant string `json:"tenant,omitempty"`
+	// Plan is the plan of the client.
+	Plan string `json:"plan,omitempty"`
+	// ExpiresAt is the time the API key is no longer accepted at.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// parseAPIKeyEntry returns the API key entry of the value of a client id key of a Secret, which is
+// either a JSON object with the API key and its metadata, or the API key itself.
+func parseAPIKeyEntry(value []byte) (*apiKeyEntry, error) {
+	trimmed := bytes.TrimSpace(value)
+	if !bytes.HasPrefix(trimmed, []byte("{")) || !json.Valid(trimmed) {
+		return &apiKeyEntry{Key: string(value)}, nil
+	}
+
+	entry := &apiKeyEntry{}
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(entry); err != nil {
+		return nil, err
+	}
+	if entry.Key == "" {
+		return nil, errors.New("the key of the API key entry must not be empty")
+	}
+	return entry, nil
+}
+
+// expiredAPIKeyClients returns the client ids of the API key entries of the secret that expired
+// at or before now.
+func expiredAPIKeyClients(secret *corev1.Secret, now time.Time) []string {
+	var clients []string
+	for clientid, value := range secret.Data {
+		entry, err := parseAPIKeyEntry(value)
+		if err == nil && entry.ExpiresAt != nil && !now.Before(entry.ExpiresAt.Time) {
+			clients = append(clients, clientid)
+		}
+	}
+	sort.Strings(clients)
+	return clients
+}
+
+// apiKeysExpiry returns the time the API keys of the secret expire at, or nil if they don't expire.
+func apiKeysExpiry(secret *corev1.Secret) (*time.Time, error) {
+	value, ok := secret.Annotations[egv1a1.APIKeysExpireAtAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	expiry, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s annotation of secret %s/%s: %w",
+			egv1a1.APIKeysExpireAtAnnotation, secret.Namespace, secret.Name, err)
+	}
+	return &expiry, nil
+}
+
+// NextAPIKeysExpiry returns the earliest time after now that the API keys referenced by the
+// SecurityPolicies expire at, or nil if none expires after now. The resources must be translated
+// again at that time to remove the expired API keys.
+func NextAPIKeysExpiry(resources *resource.Resources, now time.Time) *time.Time {
+	var next *time.Time
+	for _, policy := range resources.SecurityPolicies {
+		if policy.Spec.APIKeyAuth == nil {
+			continue
+		}
+		for _, ref := range policy.Spec.APIKeyAuth.CredentialRefs {
+			secret := resources.GetSecret(NamespaceDerefOr(ref.Namespace, policy.Namespace), string(ref.Name))
+			if secret == nil {
+				continue
+			}
+			if expiry, err := apiKeysExpiry(secret); err == nil && expiry != nil && expiry.After(now) &&
+				(next == nil || expiry.Before(*next)) {
+				next = expiry
+			}
+			for _, value := range secret.Data {
+				entry, err := parseAPIKeyEntry(value)
+				if err != nil || entry.ExpiresAt == nil || !entry.ExpiresAt.After(now) {
+					continue
+				}
+				if next == nil || entry.ExpiresAt.Time.Before(*next) {
+					next = &entry.ExpiresAt.Time
+				}
+			}
+		}
+	}
+	return next
+}
+
+// hashedAPIKeyAlgorithm returns the name of the hash algorithm and true if the API key is a password hash,
+// which Envoy would compare as is with the key of the request.
+// The SHA, bcrypt and argon2 hashes are verified by Envoy Gateway instead.
+func hashedAPIKeyAlgorithm(key string) (string, bool) {
+	if strings.HasPrefix(key, "{SSHA}") {
+		return "salted SHA", true
+	}
+	if algorithm := htpasswdHashAlgorithm(key); algorithm != htpasswdUnknownHashAlgorithm {
+		return algorithm, true
+	}
+	return "", false
+}
+
+// expiredAPIKeysMessage returns a message listing the expired API keys of the policy,
+// or an empty string if none has expired.
+func expiredAPIKeysMessage(policy *egv1a1.SecurityPolicy, resources *resource.Resources) string {
+	if policy.Spec.APIKeyAuth == nil {
+		return ""
+	}
+
+	var messages []string
+	for _, ref := range policy.Spec.APIKeyAuth.CredentialRefs {
+		secret := resources.GetSecret(NamespaceDerefOr(ref.Namespace, policy.Namespace), string(ref.Name))
+		if secret == nil {
+			continue
+		}
+		expiry, err := apiKeysExpiry(secret)
+		if err == nil && expiry != nil && !time.Now().Before(*expiry) {
+			clients := sets.List(sets.KeySet(secret.Data))
+			messages = append(messages, fmt.Sprintf("the API keys of secret %s/%s expired at %s: %s",
+				secret.Namespace, secret.Name, expiry.Format(time.RFC3339), strings.Join(clients, ", ")))
+			continue
+		}
+		if clients := expiredAPIKeyClients(secret, time.Now()); len(clients) > 0 {
+			messages = append(messages, fmt.Sprintf("the API keys of secret %s/%s have expired: %s",
+				secret.Namespace, secret.Name, strings.Join(clients, ", ")))
+		}
+	}
+	if len(messages) == 0 {
+		return ""
+	}
+	return "API keys are no longer accepted, " + strings.Join(messages, "; ") + "."
+}
+
 func (t *Translator) buildBasicAuth(
 	policy *egv1a1.SecurityPolicy,
 	resources *resource.Resources,
@@ -1445,6 +1863,18 @@ func (t *Translator) buildBasicAuth(
 		kind:      resource.KindSecurityPolicy,
 		namespace: policy.Namespace,
 	}
+	if basicAuth.LDAP != nil {
+		ldap, err := t.buildLDAPUserStore(basicAuth.LDAP, from, resources)
+		if err != nil {
+			return nil, err
+		}
+		return &ir.BasicAuth{
+			Name:                  irConfigName(policy),
+			LDAP:                  ldap,
+			ForwardUsernameHeader: basicAuth.ForwardUsernameHeader,
+		}, nil
+	}
+
 	if usersSecret, err = t.validateSecretRef(
 		false, from, basicAuth.Users, resources); err != nil {
 		return nil, err
@@ -1469,8 +1899,95 @@ func (t *Translator) buildBasicAuth(
 	}, nil
 }
 
+func (t *Translator) buildLDAPUserStore(
+	ldap *egv1a1.LDAPUserStore,
+	from crossNamespaceFrom,
+	resources *resource.Resources,
+) (*ir.LDAPUserStore, error) {
+	bindPasswordSecret, err := t.validateSecretRef(false, from, ldap.BindPassword, resources)
+	if err != nil {
+		return nil, err
+	}
+
+	bindPassword, ok := bindPasswordSecret.Data[egv1a1.LDAPBindPasswordSecretKey]
+	if !ok || len(bindPassword) == 0 {
+		return nil, fmt.Errorf(
+			"password not found in secret %s/%s",
+			bindPasswordSecret.Namespace, bindPasswordSecret.Name)
+	}
+
+	searchFilter := defaultLDAPSearchFilter
+	if ldap.SearchFilter != nil {
+		searchFilter = *ldap.SearchFilter
+	}
+
+	caCertificate, err := t.buildLDAPCACertificate(ldap.CACertificateRefs, from, resources)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ir.LDAPUserStore{
+		URL:           ldap.URL,
+		StartTLS:      ptr.Deref(ldap.StartTLS, false),
+		CACertificate: caCertificate,
+		BindDN:        ldap.BindDN,
+		BindPassword:  bindPassword,
+		BaseDN:        ldap.BaseDN,
+		SearchFilter:  searchFilter,
+	}, nil
+}
+
+// buildLDAPCACertificate returns the CA certificates of the ConfigMaps and Secrets used to verify
+// the certificate of the LDAP server.
+func (t *Translator) buildLDAPCACertificate(
+	caCertRefs []gwapiv1.SecretObjectReference,
+	from crossNamespaceFrom,
+	resources *resource.Resources,
+) ([]byte, error) {
+	var caCertificate []byte
+	for _, caCertRef := range caCertRefs {
+		caCertRefKind := string(ptr.Deref(caCertRef.Kind, resource.KindSecret))
+		var caCertBytes []byte
+		switch caCertRefKind {
+		case resource.KindSecret:
+			secret, err := t.validateSecretRef(false, from, caCertRef, resources)
+			if err != nil {
+				return nil, err
+			}
+
+			secretCertBytes, ok := getCaCertFromSecret(secret)
+			if !ok || len(secretCertBytes) == 0 {
+				return nil, fmt.Errorf(
+					"caCertificateRef secret [%s] not found", caCertRef.Name)
+			}
+			caCertBytes = secretCertBytes
+		case resource.KindConfigMap:
+			configMap, err := t.validateConfigMapRef(false, from, caCertRef, resources)
+			if err != nil {
+				return nil, err
+			}
+
+			configMapData, ok := getCaCertFromConfigMap(configMap)
+			if !ok || len(configMapData) == 0 {
+				return nil, fmt.Errorf(
+					"caCertificateRef configmap [%s] not found", caCertRef.Name)
+			}
+			caCertBytes = []byte(configMapData)
+		default:
+			return nil, fmt.Errorf("unsupported caCertificateRef kind:%s", caCertRefKind)
+		}
+
+		if err := validateCertificate(caCertBytes); err != nil {
+			return nil, fmt.Errorf(
+				"invalid certificate in %s %s: %w", caCertRefKind, caCertRef.Name, err)
+		}
+		caCertificate = append(caCertificate, caCertBytes...)
+	}
+	return caCertificate, nil
+}
+
 // validateHtpasswdFormat validates that the htpasswd data is in the correct format.
-// Currently, only the SHA format is supported by Envoy.
+// The SHA passwords are verified by Envoy, and the bcrypt and argon2 passwords by Envoy Gateway.
 func validateHtpasswdFormat(data []byte) error {
 	lines := strings.Split(string(data), "\n")
 	for _, line := range lines {
@@ -1485,11 +2002,40 @@ func validateHtpasswdFormat(data []byte) error {
 		}
 
 		password := parts[1]
-		if !strings.HasPrefix(password, "{SHA}") {
-			return fmt.Errorf("unsupported htpasswd format: please use {SHA}")
+		switch htpasswdHashAlgorithm(password) {
+		case "SHA", "bcrypt", "argon2":
+		default:
+			return fmt.Errorf("unsupported htpasswd format: the password of user %s is hashed with %s, please use {SHA}, bcrypt or argon2",
+				parts[0], htpasswdHashAlgorithm(password))
 		}
 	}
-	return nil
+	return basicauth.ValidateHtpasswd(data)
+}
+
+// htpasswdHashAlgorithm returns a human-readable name of the hash algorithm
+// used for the provided htpasswd password, for use in error messages.
+func htpasswdHashAlgorithm(password string) string {
+	switch {
+	case strings.HasPrefix(password, "{SHA}"):
+		return "SHA"
+	case strings.HasPrefix(password, "$2y$"),
+		strings.HasPrefix(password, "$2a$"),
+		strings.HasPrefix(password, "$2b$"):
+		return "bcrypt"
+	case strings.HasPrefix(password, "$argon2i$"),
+		strings.HasPrefix(password, "$argon2id$"):
+		return "argon2"
+	case strings.HasPrefix(password, "$argon2d$"):
+		return "argon2d"
+	case strings.HasPrefix(password, "$apr1$"):
+		return "MD5"
+	case strings.HasPrefix(password, "$5$"):
+		return "SHA-256 crypt"
+	case strings.HasPrefix(password, "$6$"):
+		return "SHA-512 crypt"
+	default:
+		return htpasswdUnknownHashAlgorithm
+	}
 }
 
 func (t *Translator) buildExtAuth(
@@ -1674,6 +2220,24 @@ func (t *Translator) buildAuthorization(policy *egv1a1.SecurityPolicy) (*ir.Auth
 		irPrincipal.JWT = rule.Principal.JWT
 		irPrincipal.Headers = rule.Principal.Headers
 
+		if rule.Principal.ClientCertificate != nil {
+			if err := validateClientCertificatePrincipal(rule.Principal.ClientCertificate); err != nil {
+				return nil, fmt.Errorf("unable to translate authorization rule: %w", err)
+			}
+			irPrincipal.ClientCertificate = rule.Principal.ClientCertificate
+		}
+
+		if err := validateCELExpression(rule.Principal.Expression); err != nil {
+			return nil, fmt.Errorf("unable to translate authorization rule: %w", err)
+		}
+		irPrincipal.Expression = rule.Principal.Expression
+
+		if rule.Operation != nil {
+			if err := validateAuthorizationOperation(rule.Operation); err != nil {
+				return nil, fmt.Errorf("unable to translate authorization rule: %w", err)
+			}
+		}
+
 		var name string
 		if rule.Name != nil && *rule.Name != "" {
 			name = *rule.Name
@@ -1691,6 +2255,88 @@ func (t *Translator) buildAuthorization(policy *egv1a1.SecurityPolicy) (*ir.Auth
 	return irAuth, nil
 }
 
+// validateAuthorizationOperation validates the regular expressions of the path and header matches,
+// and the CEL expression of an operation.
+func validateAuthorizationOperation(operation *egv1a1.Operation) error {
+	for _, path := range operation.Paths {
+		if ptr.Deref(path.Type, egv1a1.StringMatchExact) == egv1a1.StringMatchRegularExpression {
+			if err := regex.Validate(path.Value); err != nil {
+				return err
+			}
+		}
+	}
+	for _, header := range operation.Headers {
+		if ptr.Deref(header.Type, gwapiv1.HeaderMatchExact) == gwapiv1.HeaderMatchRegularExpression {
+			if err := regex.Validate(header.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return validateCELExpression(operation.Expression)
+}
+
+// validateClientCertificatePrincipal validates the regular expressions of the matches of a client certificate principal.
+// Each SAN is matched separately in the SANs joined with commas by Envoy, so the SAN matches must not match a comma.
+func validateClientCertificatePrincipal(principal *egv1a1.ClientCertificatePrincipal) error {
+	for _, matches := range [][]egv1a1.StringMatch{principal.Subjects, principal.Issuers} {
+		for _, match := range matches {
+			if ptr.Deref(match.Type, egv1a1.StringMatchExact) == egv1a1.StringMatchRegularExpression {
+				if err := regex.Validate(match.Value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, matches := range [][]egv1a1.StringMatch{principal.URIs, principal.DNSNames, principal.Emails} {
+		for _, match := range matches {
+			if ptr.Deref(match.Type, egv1a1.StringMatchExact) == egv1a1.StringMatchRegularExpression {
+				if _, err := regex.WithoutSeparator(match.Value, ','); err != nil {
+					return err
+				}
+			} else if strings.Contains(match.Value, ",") {
+				return fmt.Errorf("SAN match %q must not contain a comma", match.Value)
+			}
+		}
+	}
+	return nil
+}
+
+func (t *Translator) buildPolicyAuth(policy *egv1a1.SecurityPolicy, resources *resource.Resources) (*ir.PolicyAuth, error) {
+	policyAuth := policy.Spec.Policy
+
+	expr := ptr.Deref(policyAuth.Inline, "")
+	if policyAuth.ValueRef != nil {
+		cm := resources.GetConfigMap(policy.Namespace, string(policyAuth.ValueRef.Name))
+		if cm == nil {
+			return nil, fmt.Errorf("policy ConfigMap %s/%s not found", policy.Namespace, policyAuth.ValueRef.Name)
+		}
+
+		value, ok := cm.Data["policy"]
+		if !ok {
+			// Fallback to the first entry in the ConfigMap data if "policy" key is not present
+			for _, v := range cm.Data {
+				value, ok = v, true
+				break
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf(
+				"policy not found in ConfigMap %s/%s, no 'policy' key and no other data found",
+				policy.Namespace, policyAuth.ValueRef.Name)
+		}
+		expr = egv1a1.CELExpression(value)
+	}
+
+	if err := validateCELExpression(&expr); err != nil {
+		return nil, err
+	}
+
+	return &ir.PolicyAuth{
+		Name: irConfigName(policy),
+		CEL:  string(expr),
+	}, nil
+}
+
 func defaultAuthorizationRuleName(policy *egv1a1.SecurityPolicy, index int) string {
 	return fmt.Sprintf(
 		"%s/authorization/rule/%s",
@@ -689,10 +689,30 @@ func Test_validateHtpasswdFormat(t *testing.T) {
 			wantError: true,
 		},
 		{
-			name:      "invalid htpasswd with bcrypt format",
+			name:      "valid htpasswd with bcrypt format",
+			htpasswd:  "user1:$2a$10$yfgRVqt86QHqo5yhsr3lPOK9d52x/xDVrZ1nKwBWNs/MV9ifts97q",
+			wantError: false,
+		},
+		{
+			name:      "valid htpasswd with argon2 format",
+			htpasswd:  "user1:$argon2id$v=19$m=65536,t=3,p=4$c2FsdHNhbHRzYWx0c2FsdA$rBWULD5jOGpQy32rLvGcmvQMVqIVNAmrCtekWvUA8bw",
+			wantError: false,
+		},
+		{
+			name:      "invalid htpasswd with malformed bcrypt format",
 			htpasswd:  "user1:$2y$hashed_user1_password",
 			wantError: true,
 		},
+		{
+			name:      "invalid htpasswd with malformed argon2 format",
+			htpasswd:  "user1:$argon2id$v=19$m=65536,t=3,p=4$hashed_user1_password",
+			wantError: true,
+		},
+		{
+			name:      "invalid htpasswd with argon2d format",
+			htpasswd:  "user1:$argon2d$v=19$m=65536,t=3,p=4$c2FsdHNhbHRzYWx0c2FsdA$rBWULD5jOGpQy32rLvGcmvQMVqIVNAmrCtekWvUA8bw",
+			wantError: true,
+		},
 		{
 			name:      "invalid htpasswd with missing colon",
 			htpasswd:  "user1{SHA}hashed_user1_password",
@@ -16,6 +16,8 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/envoyproxy/gateway/internal/ir"
 )
 
 func UpdateGatewayStatusNotAccepted(gw *gwapiv1.Gateway, reason gwapiv1.GatewayConditionReason, msg string) *gwapiv1.Gateway {
@@ -135,6 +137,38 @@ func UpdateGatewayStatusProgrammedCondition(gw *gwapiv1.Gateway, svc *corev1.Ser
 	updateGatewayProgrammedCondition(gw, envoyObj)
 }
 
+// UpdateGatewayStatusProgrammedConditionForHost updates the status addresses for the provided gateway
+// based on the addresses in its spec, and updates the Programmed condition based on the status of the
+// Envoy process and the rate limit process run on the host. rateLimitStatus is nil when the rate limit
+// service is not run.
+func UpdateGatewayStatusProgrammedConditionForHost(gw *gwapiv1.Gateway, proxyStatus, rateLimitStatus *ir.ProxyStatus) {
+	gwAddresses := make([]gwapiv1.GatewayStatusAddress, 0, len(gw.Spec.Addresses))
+	for _, addr := range gw.Spec.Addresses {
+		gwAddresses = append(gwAddresses, gwapiv1.GatewayStatusAddress{
+			Type:  addr.Type,
+			Value: addr.Value,
+		})
+	}
+	gw.Status.Addresses = gwAddresses
+
+	var cond metav1.Condition
+	switch {
+	case proxyStatus.State == ir.ProxyStateRunning && rateLimitStatus != nil && rateLimitStatus.State != ir.ProxyStateRunning:
+		cond = newCondition(string(gwapiv1.GatewayConditionProgrammed), metav1.ConditionFalse, string(gwapiv1.GatewayReasonPending),
+			fmt.Sprintf(messageFmtHostRateLimitRestarting, rateLimitStatus.Restarts, rateLimitStatus.Message), time.Now(), gw.Generation)
+	case proxyStatus.State == ir.ProxyStateRunning && proxyStatus.Restarts == 0:
+		cond = newCondition(string(gwapiv1.GatewayConditionProgrammed), metav1.ConditionTrue, string(gwapiv1.GatewayConditionProgrammed),
+			messageHostProgrammed, time.Now(), gw.Generation)
+	case proxyStatus.State == ir.ProxyStateRunning:
+		cond = newCondition(string(gwapiv1.GatewayConditionProgrammed), metav1.ConditionTrue, string(gwapiv1.GatewayConditionProgrammed),
+			fmt.Sprintf(messageFmtHostProgrammedRestarted, proxyStatus.Restarts), time.Now(), gw.Generation)
+	default:
+		cond = newCondition(string(gwapiv1.GatewayConditionProgrammed), metav1.ConditionFalse, string(gwapiv1.GatewayReasonPending),
+			fmt.Sprintf(messageFmtHostRestarting, proxyStatus.Restarts, proxyStatus.Message), time.Now(), gw.Generation)
+	}
+	gw.Status.Conditions = MergeConditions(gw.Status.Conditions, cond)
+}
+
 func SetGatewayListenerStatusCondition(gateway *gwapiv1.Gateway, listenerStatusIdx int,
 	conditionType gwapiv1.ListenerConditionType, status metav1.ConditionStatus, reason gwapiv1.ListenerConditionReason, message string,
 ) {
@@ -154,6 +188,11 @@ const (
 	messageFmtTooManyAddresses = "Too many addresses (%d) have been assigned to the Gateway, the maximum number of addresses is 16"
 	messageNoResources         = "Envoy replicas unavailable"
 	messageFmtProgrammed       = "Address assigned to the Gateway, %d/%d envoy replicas available"
+
+	messageHostProgrammed             = "Envoy process is running"
+	messageFmtHostProgrammedRestarted = "Envoy process is running, restarted %d times"
+	messageFmtHostRestarting          = "Envoy process exited unexpectedly, restarting (restarts: %d): %s"
+	messageFmtHostRateLimitRestarting = "Rate limit process exited unexpectedly, restarting (restarts: %d): %s"
 )
 
 // updateGatewayProgrammedCondition computes the Gateway Programmed status condition.
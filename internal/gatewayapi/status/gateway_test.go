@@ -19,6 +19,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/envoyproxy/gateway/internal/ir"
 )
 
 // TestUpdateGatewayStatusProgrammedCondition tests whether UpdateGatewayStatusProgrammedCondition correctly updates the addresses in the Gateway status.
@@ -425,3 +427,100 @@ func TestUpdateGatewayProgrammedCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateGatewayStatusProgrammedConditionForHost(t *testing.T) {
+	testCases := []struct {
+		name            string
+		addresses       []gwapiv1.GatewaySpecAddress
+		proxyStatus     *ir.ProxyStatus
+		rateLimitStatus *ir.ProxyStatus
+		expectAddresses []gwapiv1.GatewayStatusAddress
+		expectCondition []metav1.Condition
+	}{
+		{
+			name:            "running envoy process",
+			proxyStatus:     &ir.ProxyStatus{State: ir.ProxyStateRunning},
+			expectAddresses: []gwapiv1.GatewayStatusAddress{},
+			expectCondition: []metav1.Condition{
+				{
+					Type:    string(gwapiv1.GatewayConditionProgrammed),
+					Status:  metav1.ConditionTrue,
+					Reason:  string(gwapiv1.GatewayConditionProgrammed),
+					Message: messageHostProgrammed,
+				},
+			},
+		},
+		{
+			name: "running envoy process with addresses",
+			addresses: []gwapiv1.GatewaySpecAddress{
+				{Type: ptr.To(gwapiv1.IPAddressType), Value: "10.0.0.1"},
+			},
+			proxyStatus: &ir.ProxyStatus{State: ir.ProxyStateRunning, Restarts: 2},
+			expectAddresses: []gwapiv1.GatewayStatusAddress{
+				{Type: ptr.To(gwapiv1.IPAddressType), Value: "10.0.0.1"},
+			},
+			expectCondition: []metav1.Condition{
+				{
+					Type:    string(gwapiv1.GatewayConditionProgrammed),
+					Status:  metav1.ConditionTrue,
+					Reason:  string(gwapiv1.GatewayConditionProgrammed),
+					Message: fmt.Sprintf(messageFmtHostProgrammedRestarted, 2),
+				},
+			},
+		},
+		{
+			name:            "restarting envoy process",
+			proxyStatus:     &ir.ProxyStatus{State: ir.ProxyStateRestarting, Restarts: 1, Message: "exit status 1"},
+			expectAddresses: []gwapiv1.GatewayStatusAddress{},
+			expectCondition: []metav1.Condition{
+				{
+					Type:    string(gwapiv1.GatewayConditionProgrammed),
+					Status:  metav1.ConditionFalse,
+					Reason:  string(gwapiv1.GatewayReasonPending),
+					Message: fmt.Sprintf(messageFmtHostRestarting, 1, "exit status 1"),
+				},
+			},
+		},
+		{
+			name:            "running envoy process with running rate limit process",
+			proxyStatus:     &ir.ProxyStatus{State: ir.ProxyStateRunning},
+			rateLimitStatus: &ir.ProxyStatus{State: ir.ProxyStateRunning, Restarts: 1},
+			expectAddresses: []gwapiv1.GatewayStatusAddress{},
+			expectCondition: []metav1.Condition{
+				{
+					Type:    string(gwapiv1.GatewayConditionProgrammed),
+					Status:  metav1.ConditionTrue,
+					Reason:  string(gwapiv1.GatewayConditionProgrammed),
+					Message: messageHostProgrammed,
+				},
+			},
+		},
+		{
+			name:            "running envoy process with restarting rate limit process",
+			proxyStatus:     &ir.ProxyStatus{State: ir.ProxyStateRunning},
+			rateLimitStatus: &ir.ProxyStatus{State: ir.ProxyStateRestarting, Restarts: 3, Message: "exit status 2"},
+			expectAddresses: []gwapiv1.GatewayStatusAddress{},
+			expectCondition: []metav1.Condition{
+				{
+					Type:    string(gwapiv1.GatewayConditionProgrammed),
+					Status:  metav1.ConditionFalse,
+					Reason:  string(gwapiv1.GatewayReasonPending),
+					Message: fmt.Sprintf(messageFmtHostRateLimitRestarting, 3, "exit status 2"),
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gtw := &gwapiv1.Gateway{}
+			gtw.Spec.Addresses = tc.addresses
+			UpdateGatewayStatusProgrammedConditionForHost(gtw, tc.proxyStatus, tc.rateLimitStatus)
+
+			assert.Equal(t, tc.expectAddresses, gtw.Status.Addresses)
+			if d := cmp.Diff(tc.expectCondition, gtw.Status.Conditions, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); d != "" {
+				t.Errorf("unexpected condition diff: %s", d)
+			}
+		})
+	}
+}
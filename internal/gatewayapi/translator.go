@@ -9,6 +9,7 @@ import (
 	"errors"
 
 	"golang.org/x/exp/maps"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -103,6 +104,11 @@ type Translator struct {
 	// gateway listener port into a non privileged port
 	// and reuses the specified value.
 	ListenerPortShiftDisabled bool
+
+	// EnvoyTLSSecret is the envoy client TLS secret used when it's not found
+	// in the resources, e.g. it's loaded from the local certificates with the
+	// Host infrastructure provider.
+	EnvoyTLSSecret *corev1.Secret
 }
 
 type TranslateResult struct {
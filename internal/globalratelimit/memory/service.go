@@ -0,0 +1,295 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package memory implements the Envoy rate limit service with the rate limit
+// counters stored in memory, to provide global rate limiting without any
+// external database for a single Envoy Gateway.
+package memory
+
+import (
+	"context"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	commonratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
+	rlsv3 "github.com/envoyproxy/go-control-plane/envoy/service/ratelimit/v3"
+	rlsconfv3 "github.com/envoyproxy/go-control-plane/ratelimit/config/ratelimit/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// sweepInterval is how often the counters of the ended windows are removed.
+	sweepInterval = time.Minute
+	// shardCount is the number of shards the counters are spread over, so that the
+	// requests for different descriptors don't contend on the same lock.
+	shardCount = 64
+	// ShadowModeMetadataKey is the key of the dynamic metadata set when a request is over a limit in shadow mode.
+	// Envoy sets it in the envoy.filters.http.ratelimit namespace.
+	ShadowModeMetadataKey = "shadow_mode_over_limit"
+)
+
+// unitSeconds maps the rate limit units to their duration in seconds, in the same way as the
+// upstream rate limit service does.
+var unitSeconds = map[rlsconfv3.RateLimitUnit]int64{
+	rlsconfv3.RateLimitUnit_SECOND: 1,
+	rlsconfv3.RateLimitUnit_MINUTE: 60,
+	rlsconfv3.RateLimitUnit_HOUR:   60 * 60,
+	rlsconfv3.RateLimitUnit_DAY:    60 * 60 * 24,
+	rlsconfv3.RateLimitUnit_WEEK:   60 * 60 * 24 * 7,
+	rlsconfv3.RateLimitUnit_MONTH:  60 * 60 * 24 * 30,
+	rlsconfv3.RateLimitUnit_YEAR:   60 * 60 * 24 * 365,
+}
+
+// Service implements the Envoy rate limit service with sliding window counters stored in memory.
+// The rate limit configurations are the ones served by the rate limit xDS config server.
+//
+// The hits of a descriptor are counted in windows of the rate limit unit, and the hits of the
+// previous window are weighted by the part of it that overlaps the sliding window ending now.
+// Unlike fixed windows, this doesn't allow twice the limit around the end of a window.
+type Service struct {
+	rlsv3.UnimplementedRateLimitServiceServer
+
+	mu sync.RWMutex
+	// domains holds the rate limit descriptors of the configurations by their domain.
+	domains map[string][]*rlsconfv3.RateLimitDescriptor
+
+	shards [shardCount]shard
+	now    func() time.Time
+}
+
+// shard holds the counters of the descriptors whose key hashes to it.
+type shard struct {
+	mu sync.Mutex
+	// counters holds the hits of the descriptors by the domain and descriptor entries.
+	counters  map[string]*counter
+	lastSweep time.Time
+}
+
+type counter struct {
+	// hits and previousHits are the hits of the current and previous windows.
+	hits         uint64
+	previousHits uint64
+	// start is the start of the current window.
+	start  time.Time
+	window time.Duration
+}
+
+// expired returns whether the hits of the counter no longer contribute to the sliding window.
+func (c *counter) expired(now time.Time) bool {
+	return !now.Before(c.start.Add(2 * c.window))
+}
+
+// New returns a new Service without any rate limit configuration.
+func New() *Service {
+	s := &Service{
+		domains: map[string][]*rlsconfv3.RateLimitDescriptor{},
+		now:     time.Now,
+	}
+	for i := range s.shards {
+		s.shards[i].counters = map[string]*counter{}
+	}
+	return s
+}
+
+// SetConfigs replaces the rate limit configurations of the service.
+// The counters of the descriptors that are still configured are kept.
+func (s *Service) SetConfigs(configs []*rlsconfv3.RateLimitConfig) {
+	domains := map[string][]*rlsconfv3.RateLimitDescriptor{}
+	for _, cfg := range configs {
+		if cfg != nil {
+			domains[cfg.Domain] = append(domains[cfg.Domain], cfg.Descriptors...)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.domains = domains
+}
+
+// ShouldRateLimit implements the RateLimitServiceServer interface.
+func (s *Service) ShouldRateLimit(_ context.Context, req *rlsv3.RateLimitRequest) (*rlsv3.RateLimitResponse, error) {
+	if req.Domain == "" {
+		return nil, status.Error(codes.InvalidArgument, "rate limit domain must not be empty")
+	}
+	if len(req.Descriptors) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "rate limit descriptor list must not be empty")
+	}
+
+	s.mu.RLock()
+	descriptors := s.domains[req.Domain]
+	s.mu.RUnlock()
+
+	now := s.now()
+	resp := &rlsv3.RateLimitResponse{OverallCode: rlsv3.RateLimitResponse_OK}
+	for _, descriptor := range req.Descriptors {
+		hits := uint64(max(req.HitsAddend, 1))
+		if descriptor.HitsAddend != nil {
+			hits = descriptor.HitsAddend.Value
+		}
+		descriptorStatus, shadowOverLimit := s.hit(req.Domain, descriptors, descriptor, hits, now)
+		if descriptorStatus.Code == rlsv3.RateLimitResponse_OVER_LIMIT {
+			resp.OverallCode = rlsv3.RateLimitResponse_OVER_LIMIT
+		}
+		// The requests allowed in shadow mode are reported in the dynamic metadata, so that they can be logged.
+		if shadowOverLimit {
+			resp.DynamicMetadata = &structpb.Struct{Fields: map[string]*structpb.Value{
+				ShadowModeMetadataKey: structpb.NewBoolValue(true),
+			}}
+		}
+		resp.Statuses = append(resp.Statuses, descriptorStatus)
+	}
+	return resp, nil
+}
+
+// hit adds the hits to the counter of the descriptor, and returns the status of the descriptor,
+// and whether the descriptor is over its limit in shadow mode.
+func (s *Service) hit(domain string, descriptors []*rlsconfv3.RateLimitDescriptor,
+	descriptor *commonratelimitv3.RateLimitDescriptor, hits uint64, now time.Time,
+) (*rlsv3.RateLimitResponse_DescriptorStatus, bool) {
+	matched := findDescriptor(descriptors, descriptor.Entries)
+	if matched == nil || matched.RateLimit == nil || matched.RateLimit.Unlimited {
+		return &rlsv3.RateLimitResponse_DescriptorStatus{Code: rlsv3.RateLimitResponse_OK}, false
+	}
+
+	limit := matched.RateLimit
+	seconds, ok := unitSeconds[limit.Unit]
+	if !ok {
+		return &rlsv3.RateLimitResponse_DescriptorStatus{Code: rlsv3.RateLimitResponse_OK}, false
+	}
+	window := time.Duration(seconds) * time.Second
+
+	key := counterKey(domain, descriptor.Entries)
+	sh := &s.shards[shardIndex(key)]
+	sh.mu.Lock()
+	sh.sweep(now)
+	// The windows are aligned on the Unix epoch, in the same way as the upstream rate limit service does.
+	c := sh.counter(key, time.Unix(now.Unix()/seconds*seconds, 0), window)
+	c.hits += hits
+	// The hits of the previous window are weighted by its overlap with the sliding window.
+	elapsed := now.Sub(c.start)
+	used := c.hits + uint64(float64(c.previousHits)*float64(window-elapsed)/float64(window))
+	resetAt := c.start.Add(window)
+	sh.mu.Unlock()
+
+	descriptorStatus := &rlsv3.RateLimitResponse_DescriptorStatus{
+		Code: rlsv3.RateLimitResponse_OK,
+		CurrentLimit: &rlsv3.RateLimitResponse_RateLimit{
+			Name:            limit.Name,
+			RequestsPerUnit: limit.RequestsPerUnit,
+			Unit:            rlsv3.RateLimitResponse_RateLimit_Unit(limit.Unit),
+		},
+		DurationUntilReset: durationpb.New(resetAt.Sub(now)),
+	}
+	if used <= uint64(limit.RequestsPerUnit) {
+		descriptorStatus.LimitRemaining = limit.RequestsPerUnit - uint32(used)
+		return descriptorStatus, false
+	}
+	// Requests are allowed in shadow mode, even if they are over the limit.
+	if matched.ShadowMode {
+		return descriptorStatus, true
+	}
+	descriptorStatus.Code = rlsv3.RateLimitResponse_OVER_LIMIT
+	return descriptorStatus, false
+}
+
+// counter returns the counter of the key with its window moved forward to the given start.
+// The caller must hold the lock of the shard.
+func (sh *shard) counter(key string, start time.Time, window time.Duration) *counter {
+	c, ok := sh.counters[key]
+	switch {
+	case !ok || c.window != window || c.expired(start):
+		// The hits of the previous window, if any, no longer overlap the sliding window.
+		c = &counter{start: start, window: window}
+		sh.counters[key] = c
+	case start.After(c.start):
+		c.previousHits, c.hits, c.start = c.hits, 0, start
+	}
+	return c
+}
+
+// sweep removes the counters that no longer contribute to the sliding window.
+// The caller must hold the lock of the shard.
+func (sh *shard) sweep(now time.Time) {
+	if now.Sub(sh.lastSweep) < sweepInterval {
+		return
+	}
+	sh.lastSweep = now
+	for key, c := range sh.counters {
+		if c.expired(now) {
+			delete(sh.counters, key)
+		}
+	}
+}
+
+// findDescriptor returns the configured descriptor that matches all the entries of a request descriptor,
+// or nil if none matches. The entries are matched in the same way as the upstream rate limit service:
+// a descriptor with the same key and value takes precedence over a descriptor with a wildcard value,
+// which takes precedence over a descriptor with the same key but no value.
+func findDescriptor(descriptors []*rlsconfv3.RateLimitDescriptor,
+	entries []*commonratelimitv3.RateLimitDescriptor_Entry,
+) *rlsconfv3.RateLimitDescriptor {
+	var matched *rlsconfv3.RateLimitDescriptor
+	for _, entry := range entries {
+		matched = matchEntry(descriptors, entry)
+		if matched == nil {
+			return nil
+		}
+		descriptors = matched.Descriptors
+	}
+	return matched
+}
+
+func matchEntry(descriptors []*rlsconfv3.RateLimitDescriptor,
+	entry *commonratelimitv3.RateLimitDescriptor_Entry,
+) *rlsconfv3.RateLimitDescriptor {
+	var wildcard, keyOnly *rlsconfv3.RateLimitDescriptor
+	for _, d := range descriptors {
+		if d.Key != entry.Key {
+			continue
+		}
+		switch {
+		case d.Value == "":
+			if keyOnly == nil {
+				keyOnly = d
+			}
+		case d.Value == entry.Value:
+			return d
+		case strings.HasSuffix(d.Value, "*") && strings.HasPrefix(entry.Value, strings.TrimSuffix(d.Value, "*")):
+			if wildcard == nil {
+				wildcard = d
+			}
+		}
+	}
+	if wildcard != nil {
+		return wildcard
+	}
+	return keyOnly
+}
+
+// shardIndex returns the index of the shard holding the counter of the key.
+func shardIndex(key string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return h.Sum32() % shardCount
+}
+
+// counterKey returns the key of the counter of a request descriptor in a domain.
+func counterKey(domain string, entries []*commonratelimitv3.RateLimitDescriptor_Entry) string {
+	var b strings.Builder
+	b.WriteString(strconv.Quote(domain))
+	for _, entry := range entries {
+		b.WriteString("_")
+		b.WriteString(strconv.Quote(entry.Key))
+		b.WriteString("_")
+		b.WriteString(strconv.Quote(entry.Value))
+	}
+	return b.String()
+}
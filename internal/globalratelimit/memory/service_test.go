@@ -0,0 +1,280 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	commonratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
+	rlsv3 "github.com/envoyproxy/go-control-plane/envoy/service/ratelimit/v3"
+	rlsconfv3 "github.com/envoyproxy/go-control-plane/ratelimit/config/ratelimit/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func testConfigs() []*rlsconfv3.RateLimitConfig {
+	return []*rlsconfv3.RateLimitConfig{
+		{
+			Name:   "default/eg/http",
+			Domain: "default/eg/http",
+			Descriptors: []*rlsconfv3.RateLimitDescriptor{
+				{
+					Key:   "httproute/default/foo/rule/0/match/0/example",
+					Value: "httproute/default/foo/rule/0/match/0/example",
+					Descriptors: []*rlsconfv3.RateLimitDescriptor{
+						{
+							Key:   "rule-0-match-0",
+							Value: "admin",
+							RateLimit: &rlsconfv3.RateLimitPolicy{
+								Unit:            rlsconfv3.RateLimitUnit_MINUTE,
+								RequestsPerUnit: 3,
+							},
+						},
+						{
+							Key:   "rule-0-match-0",
+							Value: "team-*",
+							RateLimit: &rlsconfv3.RateLimitPolicy{
+								Unit:            rlsconfv3.RateLimitUnit_MINUTE,
+								RequestsPerUnit: 2,
+							},
+						},
+						{
+							Key: "rule-0-match-0",
+							RateLimit: &rlsconfv3.RateLimitPolicy{
+								Unit:            rlsconfv3.RateLimitUnit_HOUR,
+								RequestsPerUnit: 1,
+							},
+						},
+						{
+							Key:        "rule-1-match-0",
+							ShadowMode: true,
+							RateLimit: &rlsconfv3.RateLimitPolicy{
+								Unit:            rlsconfv3.RateLimitUnit_SECOND,
+								RequestsPerUnit: 1,
+							},
+						},
+						{
+							Key: "rule-2-match-0",
+							RateLimit: &rlsconfv3.RateLimitPolicy{
+								Unlimited: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func request(value string, hits uint32) *rlsv3.RateLimitRequest {
+	return &rlsv3.RateLimitRequest{
+		Domain: "default/eg/http",
+		Descriptors: []*commonratelimitv3.RateLimitDescriptor{
+			{
+				Entries: []*commonratelimitv3.RateLimitDescriptor_Entry{
+					{Key: "httproute/default/foo/rule/0/match/0/example", Value: "httproute/default/foo/rule/0/match/0/example"},
+					{Key: "rule-0-match-0", Value: value},
+				},
+			},
+		},
+		HitsAddend: hits,
+	}
+}
+
+func TestServiceShouldRateLimit(t *testing.T) {
+	now := time.Unix(1699999980, 0)
+	s := New()
+	s.now = func() time.Time { return now }
+	s.SetConfigs(testConfigs())
+
+	testCases := []struct {
+		name      string
+		req       *rlsv3.RateLimitRequest
+		code      rlsv3.RateLimitResponse_Code
+		remaining uint32
+	}{
+		{
+			name:      "exact value",
+			req:       request("admin", 0),
+			code:      rlsv3.RateLimitResponse_OK,
+			remaining: 2,
+		},
+		{
+			name:      "exact value with hits addend",
+			req:       request("admin", 2),
+			code:      rlsv3.RateLimitResponse_OK,
+			remaining: 0,
+		},
+		{
+			name: "exact value over limit",
+			req:  request("admin", 1),
+			code: rlsv3.RateLimitResponse_OVER_LIMIT,
+		},
+		{
+			name:      "wildcard value",
+			req:       request("team-a", 2),
+			code:      rlsv3.RateLimitResponse_OK,
+			remaining: 0,
+		},
+		{
+			name: "wildcard value is counted per value",
+			req:  request("team-a", 1),
+			code: rlsv3.RateLimitResponse_OVER_LIMIT,
+		},
+		{
+			name:      "another wildcard value",
+			req:       request("team-b", 1),
+			code:      rlsv3.RateLimitResponse_OK,
+			remaining: 1,
+		},
+		{
+			name:      "key only",
+			req:       request("guest", 1),
+			code:      rlsv3.RateLimitResponse_OK,
+			remaining: 0,
+		},
+		{
+			name: "key only over limit",
+			req:  request("guest", 1),
+			code: rlsv3.RateLimitResponse_OVER_LIMIT,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := s.ShouldRateLimit(context.Background(), tc.req)
+			require.NoError(t, err)
+			require.Equal(t, tc.code, resp.OverallCode)
+			require.Len(t, resp.Statuses, 1)
+			require.Equal(t, tc.remaining, resp.Statuses[0].LimitRemaining)
+		})
+	}
+
+	// The 4 hits of the previous window are weighted by the half of it that overlaps the sliding window.
+	now = now.Add(90 * time.Second)
+	resp, err := s.ShouldRateLimit(context.Background(), request("admin", 1))
+	require.NoError(t, err)
+	require.Equal(t, rlsv3.RateLimitResponse_OK, resp.OverallCode)
+	require.Equal(t, uint32(0), resp.Statuses[0].LimitRemaining)
+	require.Equal(t, 30*time.Second, resp.Statuses[0].DurationUntilReset.AsDuration())
+	resp, err = s.ShouldRateLimit(context.Background(), request("admin", 1))
+	require.NoError(t, err)
+	require.Equal(t, rlsv3.RateLimitResponse_OVER_LIMIT, resp.OverallCode)
+
+	// The counters are reset once the previous window no longer overlaps the sliding window.
+	now = now.Add(90 * time.Second)
+	resp, err = s.ShouldRateLimit(context.Background(), request("admin", 1))
+	require.NoError(t, err)
+	require.Equal(t, rlsv3.RateLimitResponse_OK, resp.OverallCode)
+	require.Equal(t, uint32(2), resp.Statuses[0].LimitRemaining)
+	require.Equal(t, time.Minute, resp.Statuses[0].DurationUntilReset.AsDuration())
+}
+
+func TestServiceShouldRateLimitConcurrent(t *testing.T) {
+	s := New()
+	s.SetConfigs(testConfigs())
+
+	// The hits of concurrent requests for the same descriptor are all counted.
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.ShouldRateLimit(context.Background(), request("guest", 1))
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	resp, err := s.ShouldRateLimit(context.Background(), request("guest", 0))
+	require.NoError(t, err)
+	require.Equal(t, rlsv3.RateLimitResponse_OVER_LIMIT, resp.OverallCode)
+	require.Equal(t, uint32(0), resp.Statuses[0].LimitRemaining)
+}
+
+func TestServiceShouldRateLimitDescriptors(t *testing.T) {
+	s := New()
+	s.SetConfigs(testConfigs())
+
+	entries := func(key string) []*commonratelimitv3.RateLimitDescriptor_Entry {
+		return []*commonratelimitv3.RateLimitDescriptor_Entry{
+			{Key: "httproute/default/foo/rule/0/match/0/example", Value: "httproute/default/foo/rule/0/match/0/example"},
+			{Key: key, Value: "value"},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		descriptor *commonratelimitv3.RateLimitDescriptor
+		code       rlsv3.RateLimitResponse_Code
+		limited    bool
+		shadow     bool
+	}{
+		{
+			name:       "shadow mode is never over limit",
+			descriptor: &commonratelimitv3.RateLimitDescriptor{Entries: entries("rule-1-match-0"), HitsAddend: wrapperspb.UInt64(5)},
+			code:       rlsv3.RateLimitResponse_OK,
+			limited:    true,
+			shadow:     true,
+		},
+		{
+			name:       "unlimited",
+			descriptor: &commonratelimitv3.RateLimitDescriptor{Entries: entries("rule-2-match-0"), HitsAddend: wrapperspb.UInt64(5)},
+			code:       rlsv3.RateLimitResponse_OK,
+		},
+		{
+			name:       "unknown descriptor",
+			descriptor: &commonratelimitv3.RateLimitDescriptor{Entries: entries("unknown")},
+			code:       rlsv3.RateLimitResponse_OK,
+		},
+		{
+			name:       "descriptor hits addend overrides the request one",
+			descriptor: &commonratelimitv3.RateLimitDescriptor{Entries: entries("rule-0-match-0"), HitsAddend: wrapperspb.UInt64(2)},
+			code:       rlsv3.RateLimitResponse_OVER_LIMIT,
+			limited:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := s.ShouldRateLimit(context.Background(), &rlsv3.RateLimitRequest{
+				Domain:      "default/eg/http",
+				Descriptors: []*commonratelimitv3.RateLimitDescriptor{tc.descriptor},
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.code, resp.OverallCode)
+			require.Equal(t, tc.limited, resp.Statuses[0].CurrentLimit != nil)
+			require.Equal(t, tc.shadow, resp.DynamicMetadata.GetFields()[ShadowModeMetadataKey].GetBoolValue())
+		})
+	}
+}
+
+func TestServiceShouldRateLimitInvalidRequest(t *testing.T) {
+	s := New()
+	_, err := s.ShouldRateLimit(context.Background(), &rlsv3.RateLimitRequest{})
+	require.Error(t, err)
+	_, err = s.ShouldRateLimit(context.Background(), &rlsv3.RateLimitRequest{Domain: "default/eg/http"})
+	require.Error(t, err)
+}
+
+func TestServiceSetConfigs(t *testing.T) {
+	s := New()
+	s.SetConfigs(testConfigs())
+	resp, err := s.ShouldRateLimit(context.Background(), request("admin", 3))
+	require.NoError(t, err)
+	require.Equal(t, rlsv3.RateLimitResponse_OK, resp.OverallCode)
+
+	// Nothing is limited once the configurations are removed.
+	s.SetConfigs(nil)
+	resp, err = s.ShouldRateLimit(context.Background(), request("admin", 3))
+	require.NoError(t, err)
+	require.Equal(t, rlsv3.RateLimitResponse_OK, resp.OverallCode)
+	require.Nil(t, resp.Statuses[0].CurrentLimit)
+}
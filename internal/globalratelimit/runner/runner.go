@@ -11,13 +11,16 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"path/filepath"
 	"strconv"
 
 	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	rlsv3 "github.com/envoyproxy/go-control-plane/envoy/service/ratelimit/v3"
 	cachetype "github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
 	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	serverv3 "github.com/envoyproxy/go-control-plane/pkg/server/v3"
+	rlsconfv3 "github.com/envoyproxy/go-control-plane/ratelimit/config/ratelimit/v3"
 	"github.com/telepresenceio/watchable"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -25,6 +28,7 @@ import (
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/crypto"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/globalratelimit/memory"
 	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes/ratelimit"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/message"
@@ -43,20 +47,19 @@ const (
 	rateLimitTLSKeyFilepath = "/certs/tls.key"
 	// rateLimitTLSCACertFilepath is the ratelimit ca cert file.
 	rateLimitTLSCACertFilepath = "/certs/ca.crt"
-
-	// TODO: Make these path configurable.
-	// Default certificates path for envoy-gateway with Host infrastructure provider.
-	localTLSCertFilepath = "/tmp/envoy-gateway/certs/envoy-gateway/tls.crt"
-	localTLSKeyFilepath  = "/tmp/envoy-gateway/certs/envoy-gateway/tls.key"
-	localTLSCaFilepath   = "/tmp/envoy-gateway/certs/envoy-gateway/ca.crt"
 )
 
 type Config struct {
 	config.Server
-	XdsIR           *message.XdsIR
+	XdsIR *message.XdsIR
+	// LocalCertsDir is the directory of the local certificates, which are loaded with the Host
+	// infrastructure provider.
+	LocalCertsDir   string
 	grpc            *grpc.Server
 	cache           cachev3.SnapshotCache
 	snapshotVersion int64
+	// memory is the rate limit service served with the Local backend, it's nil otherwise.
+	memory *memory.Service
 }
 
 type Runner struct {
@@ -95,6 +98,13 @@ func (r *Runner) Start(ctx context.Context) (err error) {
 	// Register xDS Config server.
 	discoveryv3.RegisterAggregatedDiscoveryServiceServer(r.grpc, serverv3.NewServer(ctx, r.cache, serverv3.CallbackFuncs{}))
 
+	// Register the rate limit service with the Local backend, which stores the rate limit counters
+	// in memory instead of running a separate rate limit service.
+	if r.EnvoyGateway.RateLimit != nil && r.EnvoyGateway.RateLimit.Backend.Type == egv1a1.LocalBackendType {
+		r.memory = memory.New()
+		rlsv3.RegisterRateLimitServiceServer(r.grpc, r.memory)
+	}
+
 	// Start and listen xDS gRPC config Server.
 	go r.serveXdsConfigServer(ctx)
 
@@ -195,6 +205,17 @@ func (r *Runner) updateSnapshot(ctx context.Context, resource types.XdsResources
 	if err := r.addNewSnapshot(ctx, resource); err != nil {
 		r.Logger.Error(err, "failed to update the snapshot cache")
 	}
+
+	// The rate limit service of the Local backend uses the same configurations as the snapshot.
+	if r.memory != nil {
+		var configs []*rlsconfv3.RateLimitConfig
+		for _, res := range resource[resourcev3.RateLimitConfigType] {
+			if cfg, ok := res.(*rlsconfv3.RateLimitConfig); ok {
+				configs = append(configs, cfg)
+			}
+		}
+		r.memory.SetConfigs(configs)
+	}
 }
 
 func (r *Runner) addNewSnapshot(ctx context.Context, resource types.XdsResources) error {
@@ -224,7 +245,10 @@ func (r *Runner) loadTLSConfig() (tlsConfig *tls.Config, err error) {
 		}
 
 	case r.EnvoyGateway.Provider.IsRunningOnHost():
-		tlsConfig, err = crypto.LoadTLSConfig(localTLSCertFilepath, localTLSKeyFilepath, localTLSCaFilepath)
+		tlsConfig, err = crypto.LoadTLSConfig(
+			filepath.Join(r.LocalCertsDir, "envoy-gateway", "tls.crt"),
+			filepath.Join(r.LocalCertsDir, "envoy-gateway", "tls.key"),
+			filepath.Join(r.LocalCertsDir, "envoy-gateway", "ca.crt"))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create tls config: %w", err)
 		}
@@ -12,6 +12,8 @@ import (
 	"testing"
 	"time"
 
+	commonratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
+	rlsv3 "github.com/envoyproxy/go-control-plane/envoy/service/ratelimit/v3"
 	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
 	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
@@ -23,6 +25,7 @@ import (
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/globalratelimit/memory"
 	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes/ratelimit"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/message"
@@ -243,3 +246,53 @@ func Test_subscribeAndTranslate(t *testing.T) {
 		})
 	}
 }
+
+func Test_updateSnapshotLocalBackend(t *testing.T) {
+	cfg, err := config.New(os.Stdout)
+	require.NoError(t, err)
+	r := New(&Config{
+		Server: *cfg,
+		cache:  cachev3.NewSnapshotCache(false, cachev3.IDHash{}, nil),
+		memory: memory.New(),
+	})
+
+	req := &rlsv3.RateLimitRequest{
+		Domain: "default/gw0/listener-0",
+		Descriptors: []*commonratelimitv3.RateLimitDescriptor{
+			{
+				Entries: []*commonratelimitv3.RateLimitDescriptor_Entry{
+					{Key: "route-0", Value: "route-0"},
+				},
+			},
+		},
+		HitsAddend: 2,
+	}
+
+	r.updateSnapshot(context.Background(), map[resourcev3.Type][]cachetypes.Resource{
+		resourcev3.RateLimitConfigType: {
+			&rlsconfv3.RateLimitConfig{
+				Name:   "default/gw0/listener-0",
+				Domain: "default/gw0/listener-0",
+				Descriptors: []*rlsconfv3.RateLimitDescriptor{
+					{
+						Key:   "route-0",
+						Value: "route-0",
+						RateLimit: &rlsconfv3.RateLimitPolicy{
+							Unit:            rlsconfv3.RateLimitUnit_HOUR,
+							RequestsPerUnit: 1,
+						},
+					},
+				},
+			},
+		},
+	})
+	resp, err := r.memory.ShouldRateLimit(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, rlsv3.RateLimitResponse_OVER_LIMIT, resp.OverallCode)
+
+	// The rate limit service follows the snapshot when the configuration is removed.
+	r.updateSnapshot(context.Background(), map[resourcev3.Type][]cachetypes.Resource{})
+	resp, err = r.memory.ShouldRateLimit(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, rlsv3.RateLimitResponse_OK, resp.OverallCode)
+}
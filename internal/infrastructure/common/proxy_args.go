@@ -16,6 +16,9 @@ import (
 	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
 )
 
+// defaultDrainTimeout is the default drain timeout of the Envoy proxy.
+const defaultDrainTimeout = 60 * time.Second
+
 func getIPFamily(infra *ir.ProxyInfra) *egv1a1.IPFamily {
 	if infra == nil || infra.Config == nil {
 		return nil
@@ -82,16 +85,11 @@ func BuildProxyArgs(
 		args = append(args, fmt.Sprintf("--component-log-level %s", componentsLogLevel))
 	}
 
-	// Default drain timeout.
-	drainTimeout := 60.0
-	if shutdownConfig != nil && shutdownConfig.DrainTimeout != nil {
-		d, err := time.ParseDuration(string(*shutdownConfig.DrainTimeout))
-		if err != nil {
-			return nil, err
-		}
-		drainTimeout = d.Seconds()
+	drainTimeout, err := GetProxyDrainTimeout(shutdownConfig)
+	if err != nil {
+		return nil, err
 	}
-	args = append(args, fmt.Sprintf("--drain-time-s %.0f", drainTimeout))
+	args = append(args, fmt.Sprintf("--drain-time-s %.0f", drainTimeout.Seconds()))
 
 	if infra.Config != nil {
 		args = append(args, infra.Config.Spec.ExtraArgs...)
@@ -99,3 +97,12 @@ func BuildProxyArgs(
 
 	return args, nil
 }
+
+// GetProxyDrainTimeout returns the drain timeout of the Envoy proxy from the shutdown config,
+// or the default drain timeout if it is unspecified.
+func GetProxyDrainTimeout(shutdownConfig *egv1a1.ShutdownConfig) (time.Duration, error) {
+	if shutdownConfig != nil && shutdownConfig.DrainTimeout != nil {
+		return time.ParseDuration(string(*shutdownConfig.DrainTimeout))
+	}
+	return defaultDrainTimeout, nil
+}
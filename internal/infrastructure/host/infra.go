@@ -8,20 +8,23 @@ package host
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sync"
+
+	"k8s.io/utils/ptr"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/infrastructure/common"
 	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/message"
 	"github.com/envoyproxy/gateway/internal/utils/file"
 )
 
 const (
-	// TODO: Make these path configurable.
-	defaultHomeDir          = "/tmp/envoy-gateway"
-	defaultLocalCertPathDir = "/tmp/envoy-gateway/certs/envoy"
+	defaultHomeDir = "/tmp/envoy-gateway"
 
 	// XdsTLSCertFilename is the fully qualified name of the file containing Envoy's
 	// xDS server TLS certificate.
@@ -45,37 +48,113 @@ type Infra struct {
 
 	// proxyContextMap store the context of each running proxy by its name for lifecycle management.
 	proxyContextMap map[string]*proxyContext
-
-	// TODO: remove this field once it supports the configurable homeDir
+	// proxyMu guards proxyContextMap.
+	proxyMu sync.Mutex
+
+	// envoyVersion is the version of Envoy to run via func-e.
+	envoyVersion string
+	// envoyPath is the path to a local Envoy binary, it takes precedence over envoyVersion.
+	envoyPath string
+	// adminServer and statsServer are the addresses of the Envoy admin and stats servers.
+	adminServer *egv1a1.HostServerAddress
+	statsServer *egv1a1.HostServerAddress
+	// out is where the Envoy processes write their logs to.
+	out io.Writer
+	// infraStatuses is where the status of the Envoy processes is published to, if not nil.
+	infraStatuses *message.InfraStatuses
+
+	// rateLimitPath is the path to a local rate limit binary, it's looked up in the PATH if empty.
+	rateLimitPath string
+	// rateLimitContext stores the context of the running rate limit process, if any.
+	rateLimitContext *rateLimitContext
+	rateLimitMu      sync.Mutex
+
+	// sdsConfigPath is the directory of the certificates and SDS config of the Envoy processes.
 	sdsConfigPath string
 }
 
-func NewInfra(runnerCtx context.Context, cfg *config.Server, logger logging.Logger) (*Infra, error) {
+func NewInfra(runnerCtx context.Context, cfg *config.Server, logger logging.Logger, infraStatuses *message.InfraStatuses) (*Infra, error) {
+	hostCfg := hostInfrastructureProvider(cfg.EnvoyGateway)
+	homeDir := WorkingDir(cfg.EnvoyGateway)
+	certsDir := LocalEnvoyCertsDir(homeDir)
+
 	// Ensure the home directory exist.
-	if err := os.MkdirAll(defaultHomeDir, 0o750); err != nil {
+	if err := os.MkdirAll(homeDir, 0o750); err != nil {
 		return nil, fmt.Errorf("failed to create dir: %w", err)
 	}
 
 	// Check local certificates dir exist.
-	if _, err := os.Lstat(defaultLocalCertPathDir); err != nil {
+	if _, err := os.Lstat(certsDir); err != nil {
 		return nil, fmt.Errorf("failed to stat dir: %w", err)
 	}
 
 	// Ensure the sds config exist.
-	if err := createSdsConfig(defaultLocalCertPathDir); err != nil {
+	if err := createSdsConfig(certsDir); err != nil {
 		return nil, fmt.Errorf("failed to create sds config: %w", err)
 	}
 
+	var out io.Writer = os.Stdout
+	if hostCfg.LogFile != nil {
+		f, err := os.OpenFile(*hostCfg.LogFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		out = f
+	}
+
 	infra := &Infra{
-		HomeDir:         defaultHomeDir,
+		HomeDir:         homeDir,
 		Logger:          logger,
 		EnvoyGateway:    cfg.EnvoyGateway,
 		proxyContextMap: make(map[string]*proxyContext),
-		sdsConfigPath:   defaultLocalCertPathDir,
+		envoyVersion:    ptr.Deref(hostCfg.EnvoyVersion, ""),
+		envoyPath:       ptr.Deref(hostCfg.EnvoyPath, ""),
+		adminServer:     hostCfg.Admin,
+		statsServer:     hostCfg.Stats,
+		out:             out,
+		infraStatuses:   infraStatuses,
+		rateLimitPath:   ptr.Deref(hostCfg.RateLimitPath, ""),
+		sdsConfigPath:   certsDir,
 	}
 	return infra, nil
 }
 
+// WorkingDir returns the working directory of the Host infrastructure provider, where the
+// Envoy binaries, the certificates and the runtime files of the processes are stored.
+func WorkingDir(eg *egv1a1.EnvoyGateway) string {
+	if dir := hostInfrastructureProvider(eg).WorkingDir; dir != nil {
+		return *dir
+	}
+	return defaultHomeDir
+}
+
+// LocalCertsDir returns the directory of the certificates generated by "envoy-gateway certgen --local",
+// with a subdirectory for Envoy Gateway, the Envoy processes and the rate limit process.
+func LocalCertsDir(homeDir string) string {
+	return filepath.Join(homeDir, "certs")
+}
+
+// LocalEnvoyCertsDir returns the directory of the certificates of the Envoy processes, including the
+// client certificate and the trusted CA used to connect to the control plane components.
+func LocalEnvoyCertsDir(homeDir string) string {
+	return filepath.Join(LocalCertsDir(homeDir), "envoy")
+}
+
+// LocalRateLimitCertsDir returns the directory of the certificates of the rate limit process.
+func LocalRateLimitCertsDir(homeDir string) string {
+	return filepath.Join(LocalCertsDir(homeDir), "envoy-rate-limit")
+}
+
+// hostInfrastructureProvider returns the host infrastructure provider settings,
+// or empty settings if they are unspecified.
+func hostInfrastructureProvider(eg *egv1a1.EnvoyGateway) *egv1a1.EnvoyGatewayHostInfrastructureProvider {
+	if eg != nil && eg.Provider != nil && eg.Provider.Custom != nil &&
+		eg.Provider.Custom.Infrastructure != nil && eg.Provider.Custom.Infrastructure.Host != nil {
+		return eg.Provider.Custom.Infrastructure.Host
+	}
+	return &egv1a1.EnvoyGatewayHostInfrastructureProvider{}
+}
+
 // createSdsConfig creates the needing SDS config under certain directory.
 func createSdsConfig(dir string) error {
 	if err := file.Write(common.GetSdsCAConfigMapData(
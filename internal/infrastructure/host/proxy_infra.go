@@ -8,10 +8,20 @@ package host
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"net"
+	"net/http"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	func_e "github.com/tetratelabs/func-e"
 	"github.com/tetratelabs/func-e/api"
 	"k8s.io/utils/ptr"
@@ -23,19 +33,65 @@ import (
 	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
 )
 
-// proxyContext corresponds to the context of the Envoy process.
+// proxyContext corresponds to the context of the supervised Envoy process.
 type proxyContext struct {
-	// cancel is the function to cancel the context passed to the Envoy process.
+	// cancel is the function to cancel the context passed to the supervisor of the Envoy process.
 	cancel context.CancelFunc
-	// exit will receive an item when the Envoy process completely stopped, via funcE.ExitChannel.
+	// exit will receive an item when the supervisor and the Envoy process completely stopped.
 	exit chan struct{}
+	// run is the latest run settings of the Envoy process, used to detect the changes that need
+	// the Envoy process to be replaced.
+	run envoyRun
+	// replace receives the run settings to replace the running Envoy process with.
+	replace chan envoyRun
 }
 
+// envoyRun holds the settings to run an Envoy process with.
+type envoyRun struct {
+	args []string
+	// drainTimeout is how long the Envoy process is drained for before it is replaced.
+	drainTimeout time.Duration
+}
+
+// hotRestartDisabled returns whether hot restart is disabled by the extra args of the Envoy process.
+func (r envoyRun) hotRestartDisabled() bool {
+	return slices.ContainsFunc(r.args, func(arg string) bool {
+		return strings.HasPrefix(arg, "--disable-hot-restart")
+	})
+}
+
+func (r envoyRun) equal(other envoyRun) bool {
+	return slices.Equal(r.args, other.args) && r.drainTimeout == other.drainTimeout
+}
+
+const (
+	// envoyWaitDelay is how long to wait for the local Envoy binary to exit after it is interrupted.
+	envoyWaitDelay = 30 * time.Second
+	// envoyRestartInitialInterval and envoyRestartMaxInterval bound the backoff between the restarts
+	// of an Envoy process that exits unexpectedly.
+	envoyRestartInitialInterval = 500 * time.Millisecond
+	envoyRestartMaxInterval     = 60 * time.Second
+	// envoyParentShutdownGrace is how long the Envoy process of the previous restart epoch is kept
+	// after its drain timeout, before it is shut down by the Envoy process that replaced it.
+	envoyParentShutdownGrace = 5 * time.Second
+)
+
 // Close implements the Manager interface.
 func (i *Infra) Close() error {
+	i.proxyMu.Lock()
+	names := make([]string, 0, len(i.proxyContextMap))
 	for name := range i.proxyContextMap {
+		names = append(names, name)
+	}
+	i.proxyMu.Unlock()
+
+	for _, name := range names {
 		i.stopEnvoy(name)
 	}
+	i.stopRateLimit()
+	if f, ok := i.out.(*os.File); ok && f != os.Stdout {
+		return f.Close()
+	}
 	return nil
 }
 
@@ -51,20 +107,9 @@ func (i *Infra) CreateOrUpdateProxyInfra(ctx context.Context, infra *ir.Infra) e
 
 	proxyInfra := infra.GetProxyInfra()
 	proxyName := utils.GetHashedName(proxyInfra.Name, 64)
-	// Return directly if the proxy is running.
-	if _, ok := i.proxyContextMap[proxyName]; ok {
-		return nil
-	}
-
 	proxyConfig := proxyInfra.GetProxyConfig()
-	// Disable Prometheus to make envoy running as a host process successfully.
-	// TODO: Add Prometheus support to host infra.
 	bootstrapConfigOptions := &bootstrap.RenderBootstrapConfigOptions{
-		ProxyMetrics: &egv1a1.ProxyMetrics{
-			Prometheus: &egv1a1.ProxyPrometheusProvider{
-				Disable: true,
-			},
-		},
+		ProxyMetrics: i.proxyMetrics(proxyConfig),
 		SdsConfig: bootstrap.SdsConfigPath{
 			Certificate: filepath.Join(i.sdsConfigPath, common.SdsCertFilename),
 			TrustedCA:   filepath.Join(i.sdsConfigPath, common.SdsCAFilename),
@@ -73,6 +118,14 @@ func (i *Infra) CreateOrUpdateProxyInfra(ctx context.Context, infra *ir.Infra) e
 		AdminServerPort: ptr.To(int32(0)),
 		StatsServerPort: ptr.To(int32(0)),
 	}
+	if i.adminServer != nil {
+		bootstrapConfigOptions.AdminServerHost = i.adminServer.Host
+		bootstrapConfigOptions.AdminServerPort = ptr.To(i.adminServer.Port)
+	}
+	if i.statsServer != nil {
+		bootstrapConfigOptions.StatsServerHost = i.statsServer.Host
+		bootstrapConfigOptions.StatsServerPort = ptr.To(i.statsServer.Port)
+	}
 	if i.EnvoyGateway != nil {
 		bootstrapConfigOptions.TopologyInjectorDisabled = i.EnvoyGateway.TopologyInjectorDisabled()
 	}
@@ -80,28 +133,277 @@ func (i *Infra) CreateOrUpdateProxyInfra(ctx context.Context, infra *ir.Infra) e
 	if err != nil {
 		return err
 	}
-	i.runEnvoy(ctx, os.Stdout, proxyName, args)
+	drainTimeout, err := common.GetProxyDrainTimeout(proxyConfig.Spec.Shutdown)
+	if err != nil {
+		return err
+	}
+	run := envoyRun{args: args, drainTimeout: drainTimeout}
+
+	i.proxyMu.Lock()
+	defer i.proxyMu.Unlock()
+
+	pCtx, running := i.proxyContextMap[proxyName]
+	// Every proxy binds the same admin and stats addresses when they are specified.
+	if !running && (i.adminServer != nil || i.statsServer != nil) && len(i.proxyContextMap) > 0 {
+		return fmt.Errorf("cannot run more than one proxy when admin or stats address is specified for host infrastructure")
+	}
+
+	if !running {
+		i.runEnvoy(ctx, i.out, proxyName, proxyInfra.Name, run)
+		return nil
+	}
+
+	// Return directly if the proxy is running with the same settings.
+	if pCtx.run.equal(run) {
+		return nil
+	}
+
+	i.Logger.Info("replacing envoy since its settings have changed", "name", proxyInfra.Name)
+	pCtx.run = run
+	// Only the latest settings matter if the supervisor hasn't picked up the previous ones yet.
+	select {
+	case <-pCtx.replace:
+	default:
+	}
+	pCtx.replace <- run
 	return nil
 }
 
-// runEnvoy runs the Envoy process with the given arguments and name in a separate goroutine.
-func (i *Infra) runEnvoy(ctx context.Context, out io.Writer, name string, args []string) {
+// proxyMetrics returns the metrics settings of the proxy from the EnvoyProxy telemetry settings.
+// Prometheus is disabled unless both admin and stats addresses are specified, since the stats
+// server forwards the Prometheus requests to the admin server.
+func (i *Infra) proxyMetrics(proxyConfig *egv1a1.EnvoyProxy) *egv1a1.ProxyMetrics {
+	metrics := &egv1a1.ProxyMetrics{}
+	if proxyConfig.Spec.Telemetry != nil && proxyConfig.Spec.Telemetry.Metrics != nil {
+		metrics = proxyConfig.Spec.Telemetry.Metrics.DeepCopy()
+	}
+
+	if i.adminServer == nil || i.statsServer == nil {
+		if metrics.Prometheus == nil {
+			metrics.Prometheus = &egv1a1.ProxyPrometheusProvider{}
+		}
+		metrics.Prometheus.Disable = true
+	}
+
+	return metrics
+}
+
+// runEnvoy runs the Envoy process with the given settings and name under a supervisor in a separate goroutine.
+// The status of the Envoy process is published with the given proxy infra name.
+// The caller must hold proxyMu.
+func (i *Infra) runEnvoy(ctx context.Context, out io.Writer, name, infraName string, run envoyRun) {
 	pCtx, cancel := context.WithCancel(ctx)
 	exit := make(chan struct{}, 1)
-	i.proxyContextMap[name] = &proxyContext{cancel: cancel, exit: exit}
+	replace := make(chan envoyRun, 1)
+	i.proxyContextMap[name] = &proxyContext{cancel: cancel, exit: exit, run: run, replace: replace}
 	go func() {
-		// Run blocks until pCtx is done or the process exits where the latter doesn't happen when
-		// Envoy successfully starts up. So, this will not return until pCtx is done in practice.
+		// superviseEnvoy blocks until pCtx is done.
 		defer func() {
 			exit <- struct{}{}
 		}()
-		err := func_e.Run(pCtx, args, api.HomeDir(i.HomeDir), api.Out(out))
-		if err != nil {
-			i.Logger.Error(err, "failed to run envoy")
-		}
+		i.superviseEnvoy(pCtx, out, name, infraName, run, replace)
 	}()
 }
 
+// superviseEnvoy runs the Envoy process, and restarts it with backoff when it exits unexpectedly.
+// When new settings are received from replace, the running Envoy process is hot restarted: the new
+// Envoy process is started first and takes over the listeners, then drains and shuts down the previous
+// one. If hot restart is disabled, the running Envoy process is drained and stopped before the new one
+// is started instead.
+// It blocks until ctx is done and all the Envoy processes completely stopped.
+func (i *Infra) superviseEnvoy(ctx context.Context, out io.Writer, name, infraName string, run envoyRun, replace <-chan envoyRun) {
+	defer i.deleteProxyStatus(infraName)
+
+	// parents tracks the Envoy processes being shut down by the ones that replaced them.
+	parents := new(sync.WaitGroup)
+	defer parents.Wait()
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = envoyRestartInitialInterval
+	b.MaxInterval = envoyRestartMaxInterval
+	b.MaxElapsedTime = 0 // Never stop restarting.
+
+	var restarts int32
+	args, _ := i.hotRestartArgs(name, 0, run)
+	var epoch uint32
+	for {
+		pCtx, cancel := context.WithCancel(ctx)
+		done := make(chan error, 1)
+		startedAt := time.Now()
+		go func(args []string) {
+			done <- i.runEnvoyProcess(pCtx, out, args)
+		}(args)
+		i.setProxyStatus(infraName, &ir.ProxyStatus{State: ir.ProxyStateRunning, Restarts: restarts})
+
+		var err error
+		select {
+		case <-ctx.Done():
+			cancel()
+			<-done
+			return
+		case next := <-replace:
+			if hotArgs, ok := i.hotRestartArgs(name, epoch+1, next); ok {
+				i.Logger.Info("hot restarting envoy", "name", infraName, "epoch", epoch+1)
+				parents.Add(1)
+				go func(drainTimeout time.Duration) {
+					defer parents.Done()
+					waitEnvoyParentShutdown(done, cancel, drainTimeout)
+				}(run.drainTimeout)
+				args, epoch = hotArgs, epoch+1
+			} else {
+				i.drainEnvoy(ctx, run.drainTimeout)
+				cancel()
+				<-done
+				args, _ = i.hotRestartArgs(name, 0, next)
+				epoch = 0
+			}
+			run = next
+			b.Reset()
+			continue
+		case err = <-done:
+			cancel()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Envoy exited unexpectedly, reset the backoff if it was running long enough.
+		if time.Since(startedAt) > envoyRestartMaxInterval {
+			b.Reset()
+		}
+		if err == nil {
+			err = errors.New("envoy exited")
+		}
+		restarts++
+		delay := b.NextBackOff()
+		i.Logger.Error(err, "envoy exited unexpectedly, restarting it", "name", infraName, "restarts", restarts, "delay", delay)
+		i.setProxyStatus(infraName, &ir.ProxyStatus{State: ir.ProxyStateRestarting, Restarts: restarts, Message: err.Error()})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		case run = <-replace:
+		}
+		// There is no running Envoy process to hot restart from.
+		args, _ = i.hotRestartArgs(name, 0, run)
+		epoch = 0
+	}
+}
+
+// hotRestartArgs returns the arguments to run the Envoy process with at the given hot restart epoch,
+// and whether hot restart is possible. The Envoy process of epoch 0 picks a free base ID and records it
+// under the home directory, so that the Envoy processes of the next epochs can hot restart from it.
+func (i *Infra) hotRestartArgs(name string, epoch uint32, run envoyRun) ([]string, bool) {
+	if run.hotRestartDisabled() {
+		return run.args, false
+	}
+
+	baseIDPath := filepath.Join(i.HomeDir, name+".base-id")
+	parentShutdown := fmt.Sprintf("--parent-shutdown-time-s %.0f", (run.drainTimeout + envoyParentShutdownGrace).Seconds())
+	if epoch == 0 {
+		return append(slices.Clone(run.args), "--use-dynamic-base-id", "--base-id-path "+baseIDPath, parentShutdown), true
+	}
+
+	baseID, err := os.ReadFile(baseIDPath)
+	if err != nil {
+		i.Logger.Error(err, "failed to read envoy base id, hot restart is skipped", "path", baseIDPath)
+		return nil, false
+	}
+	return append(slices.Clone(run.args),
+		"--base-id "+strings.TrimSpace(string(baseID)),
+		fmt.Sprintf("--restart-epoch %d", epoch),
+		parentShutdown), true
+}
+
+// waitEnvoyParentShutdown waits for the Envoy process of the previous hot restart epoch to be shut down
+// by the Envoy process that replaced it, and stops it if it's still running after its parent shutdown time.
+func waitEnvoyParentShutdown(done <-chan error, cancel context.CancelFunc, drainTimeout time.Duration) {
+	defer cancel()
+
+	timer := time.NewTimer(drainTimeout + 2*envoyParentShutdownGrace)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return
+	case <-timer.C:
+	}
+	cancel()
+	<-done
+}
+
+// drainEnvoy gracefully drains the listeners of the Envoy process through its admin server, and waits for
+// the drain timeout to let the in-flight requests complete. It does nothing if the admin address is unknown.
+// It's only used when hot restart is disabled.
+func (i *Infra) drainEnvoy(ctx context.Context, drainTimeout time.Duration) {
+	if i.adminServer == nil {
+		return
+	}
+
+	host := ptr.Deref(i.adminServer.Host, bootstrap.EnvoyAdminAddress)
+	url := fmt.Sprintf("http://%s/drain_listeners?graceful", net.JoinHostPort(host, strconv.Itoa(int(i.adminServer.Port))))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		i.Logger.Error(err, "failed to create drain request")
+		return
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		i.Logger.Error(err, "failed to drain envoy")
+		return
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		i.Logger.Error(fmt.Errorf("unexpected status code %d", resp.StatusCode), "failed to drain envoy")
+		return
+	}
+
+	i.Logger.Info("draining envoy", "timeout", drainTimeout)
+	select {
+	case <-ctx.Done():
+	case <-time.After(drainTimeout):
+	}
+}
+
+// setProxyStatus publishes the status of the proxy by its infra name.
+func (i *Infra) setProxyStatus(infraName string, status *ir.ProxyStatus) {
+	if i.infraStatuses != nil {
+		i.infraStatuses.ProxyStatuses.Store(infraName, status)
+	}
+}
+
+// deleteProxyStatus deletes the published status of the proxy by its infra name.
+func (i *Infra) deleteProxyStatus(infraName string) {
+	if i.infraStatuses != nil {
+		i.infraStatuses.ProxyStatuses.Delete(infraName)
+	}
+}
+
+// runEnvoyProcess runs the Envoy process and blocks until it exits or ctx is done.
+// The local Envoy binary is run if specified, otherwise func-e downloads and runs Envoy.
+func (i *Infra) runEnvoyProcess(ctx context.Context, out io.Writer, args []string) error {
+	if i.envoyPath == "" {
+		opts := []api.RunOption{api.HomeDir(i.HomeDir), api.Out(out), api.EnvoyOut(out), api.EnvoyErr(out)}
+		if i.envoyVersion != "" {
+			opts = append(opts, api.EnvoyVersion(i.envoyVersion))
+		}
+		return func_e.Run(ctx, args, opts...)
+	}
+
+	cmd := exec.CommandContext(ctx, i.envoyPath, args...) // #nosec G204 -- the binary is configured by the operator.
+	cmd.Dir = i.HomeDir
+	cmd.Stdout, cmd.Stderr = out, out
+	// Interrupt Envoy to let it exit gracefully, consistent with func-e.
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = envoyWaitDelay
+	if err := cmd.Run(); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
 // DeleteProxyInfra removes the managed host process, if it doesn't exist.
 func (i *Infra) DeleteProxyInfra(_ context.Context, infra *ir.Infra) error {
 	if infra == nil {
@@ -116,10 +418,14 @@ func (i *Infra) DeleteProxyInfra(_ context.Context, infra *ir.Infra) error {
 
 // stopEnvoy stops the Envoy process by its name. It will block until the process completely stopped.
 func (i *Infra) stopEnvoy(proxyName string) {
-	if pCtx, ok := i.proxyContextMap[proxyName]; ok {
+	i.proxyMu.Lock()
+	pCtx, ok := i.proxyContextMap[proxyName]
+	delete(i.proxyContextMap, proxyName)
+	i.proxyMu.Unlock()
+
+	if ok {
 		pCtx.cancel()    // Cancel causes the Envoy process to exit.
 		<-pCtx.exit      // Wait for the Envoy process to completely exit.
 		close(pCtx.exit) // Close the channel to avoid leaking.
-		delete(i.proxyContextMap, proxyName)
 	}
 }
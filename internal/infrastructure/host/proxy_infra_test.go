@@ -8,19 +8,26 @@ package host
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"os"
+	"os/exec"
 	"path"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	func_e "github.com/tetratelabs/func-e"
 	"github.com/tetratelabs/func-e/api"
+	"k8s.io/utils/ptr"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/crypto"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/utils"
 	"github.com/envoyproxy/gateway/internal/utils/file"
 )
 
@@ -47,6 +54,7 @@ func newMockInfra(t *testing.T, cfg *config.Server) *Infra {
 		Logger:          logging.DefaultLogger(os.Stdout, egv1a1.LogLevelInfo),
 		EnvoyGateway:    cfg.EnvoyGateway,
 		proxyContextMap: make(map[string]*proxyContext),
+		out:             os.Stdout,
 		sdsConfigPath:   proxyDir,
 	}
 	return infra
@@ -104,7 +112,7 @@ func TestInfra_runEnvoy_stopEnvoy(t *testing.T) {
 			"admin: {address: {socket_address: {address: '127.0.0.1', port_value: 9901}}}",
 		}
 		out := &bytes.Buffer{}
-		i.runEnvoy(context.Background(), out, "test", args)
+		i.runEnvoy(context.Background(), out, "test", "test", envoyRun{args: args})
 		require.Len(t, i.proxyContextMap, 1)
 		i.stopEnvoy("test")
 		require.Empty(t, i.proxyContextMap)
@@ -112,3 +120,205 @@ func TestInfra_runEnvoy_stopEnvoy(t *testing.T) {
 		// which will panic.
 	}
 }
+
+func TestInfra_runEnvoyProcess_envoyPath(t *testing.T) {
+	sleep, err := exec.LookPath("sleep")
+	if err != nil {
+		t.Skip("sleep binary is not available")
+	}
+
+	i := &Infra{HomeDir: t.TempDir(), envoyPath: sleep}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- i.runEnvoyProcess(ctx, &bytes.Buffer{}, []string{"60"})
+	}()
+
+	cancel()
+	select {
+	case err := <-done:
+		// The process is interrupted by the cancellation, which is not an error.
+		require.NoError(t, err)
+	case <-time.After(envoyWaitDelay):
+		t.Fatal("process did not exit after cancellation")
+	}
+}
+
+// writeFakeEnvoy writes a script that records its arguments in a file and runs the given command,
+// it returns the path of the script and the file.
+func writeFakeEnvoy(t *testing.T, command string) (string, string) {
+	t.Helper()
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh binary is not available")
+	}
+
+	dir := t.TempDir()
+	record := path.Join(dir, "args")
+	script := path.Join(dir, "envoy")
+	// Record the arguments of every run in a single line.
+	content := fmt.Sprintf("#!%s\necho $(echo \"$@\" | tr '\\n' ' ') >> %s\n%s\n", sh, record, command)
+	require.NoError(t, os.WriteFile(script, []byte(content), 0o700)) // #nosec G306
+	return script, record
+}
+
+func readRuns(t *testing.T, record string) []string {
+	t.Helper()
+	content, err := os.ReadFile(record)
+	if err != nil {
+		return nil
+	}
+	return strings.Split(strings.TrimSpace(string(content)), "\n")
+}
+
+func TestInfra_superviseEnvoy_restart(t *testing.T) {
+	script, record := writeFakeEnvoy(t, "exit 1")
+	statuses := new(message.InfraStatuses)
+	i := &Infra{
+		HomeDir:         t.TempDir(),
+		Logger:          logging.DefaultLogger(os.Stdout, egv1a1.LogLevelInfo),
+		proxyContextMap: make(map[string]*proxyContext),
+		envoyPath:       script,
+		infraStatuses:   statuses,
+	}
+
+	i.runEnvoy(context.Background(), &bytes.Buffer{}, "test", "envoy-gateway-system/test", envoyRun{args: []string{"crash"}})
+	require.Eventually(t, func() bool {
+		status, ok := statuses.ProxyStatuses.Load("envoy-gateway-system/test")
+		return ok && status.Restarts >= 2 && len(readRuns(t, record)) >= 2
+	}, 10*time.Second, 50*time.Millisecond)
+
+	status, _ := statuses.ProxyStatuses.Load("envoy-gateway-system/test")
+	require.Contains(t, []ir.ProxyState{ir.ProxyStateRunning, ir.ProxyStateRestarting}, status.State)
+	for _, run := range readRuns(t, record) {
+		// Every restart starts a new hot restart sequence, since the previous process exited.
+		require.True(t, strings.HasPrefix(run, "crash --use-dynamic-base-id"), run)
+		require.NotContains(t, run, "--restart-epoch")
+	}
+
+	i.stopEnvoy("test")
+	require.Empty(t, i.proxyContextMap)
+	_, ok := statuses.ProxyStatuses.Load("envoy-gateway-system/test")
+	require.False(t, ok)
+}
+
+func TestInfraCreateOrUpdateProxy_replace(t *testing.T) {
+	script, record := writeFakeEnvoy(t, "exec sleep 60")
+	cfg, err := config.New(os.Stdout)
+	require.NoError(t, err)
+	i := newMockInfra(t, cfg)
+	i.envoyPath = script
+	statuses := new(message.InfraStatuses)
+	i.infraStatuses = statuses
+	t.Cleanup(func() {
+		require.NoError(t, i.Close())
+	})
+
+	infra := ir.NewInfra()
+	infra.Proxy.Name = "envoy-gateway-system/test"
+	infra.Proxy.Config = &egv1a1.EnvoyProxy{}
+	infra.Proxy.Listeners = ir.NewProxyListeners()
+
+	require.NoError(t, i.CreateOrUpdateProxyInfra(context.Background(), infra))
+	require.Eventually(t, func() bool {
+		return len(readRuns(t, record)) == 1
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// Nothing happens when the settings haven't changed.
+	require.NoError(t, i.CreateOrUpdateProxyInfra(context.Background(), infra))
+	require.Never(t, func() bool {
+		return len(readRuns(t, record)) > 1
+	}, time.Second, 50*time.Millisecond)
+
+	// The process is hot restarted with the base id recorded by the first one when the settings have changed.
+	require.Contains(t, readRuns(t, record)[0], "--use-dynamic-base-id")
+	proxyName := utils.GetHashedName(infra.Proxy.Name, 64)
+	require.NoError(t, os.WriteFile(path.Join(i.HomeDir, proxyName+".base-id"), []byte("7\n"), 0o600))
+	infra.Proxy.Config.Spec.Concurrency = ptr.To(int32(4))
+	require.NoError(t, i.CreateOrUpdateProxyInfra(context.Background(), infra))
+	require.Eventually(t, func() bool {
+		runs := readRuns(t, record)
+		return len(runs) == 2 && strings.Contains(runs[1], "--concurrency 4")
+	}, 10*time.Second, 50*time.Millisecond)
+	runs := readRuns(t, record)
+	require.Contains(t, runs[1], "--base-id 7 --restart-epoch 1 --parent-shutdown-time-s 65")
+	require.Len(t, i.proxyContextMap, 1)
+
+	// The process is stopped before it's replaced when hot restart is disabled.
+	infra.Proxy.Config.Spec.ExtraArgs = []string{"--disable-hot-restart"}
+	require.NoError(t, i.CreateOrUpdateProxyInfra(context.Background(), infra))
+	require.Eventually(t, func() bool {
+		return len(readRuns(t, record)) == 3
+	}, 10*time.Second, 50*time.Millisecond)
+	runs = readRuns(t, record)
+	require.True(t, strings.HasSuffix(runs[2], "--disable-hot-restart"), runs[2])
+
+	status, ok := statuses.ProxyStatuses.Load("envoy-gateway-system/test")
+	require.True(t, ok)
+	require.Equal(t, &ir.ProxyStatus{State: ir.ProxyStateRunning}, status)
+}
+
+func TestInfra_proxyMetrics(t *testing.T) {
+	testCases := []struct {
+		name        string
+		adminServer *egv1a1.HostServerAddress
+		statsServer *egv1a1.HostServerAddress
+		metrics     *egv1a1.ProxyMetrics
+		expect      *egv1a1.ProxyMetrics
+	}{
+		{
+			name: "prometheus disabled without admin and stats address",
+			expect: &egv1a1.ProxyMetrics{
+				Prometheus: &egv1a1.ProxyPrometheusProvider{Disable: true},
+			},
+		},
+		{
+			name:        "prometheus disabled without stats address",
+			adminServer: &egv1a1.HostServerAddress{Port: 19000},
+			metrics: &egv1a1.ProxyMetrics{
+				EnableVirtualHostStats: ptr.To(true),
+			},
+			expect: &egv1a1.ProxyMetrics{
+				Prometheus:             &egv1a1.ProxyPrometheusProvider{Disable: true},
+				EnableVirtualHostStats: ptr.To(true),
+			},
+		},
+		{
+			name:        "prometheus enabled with admin and stats address",
+			adminServer: &egv1a1.HostServerAddress{Port: 19000},
+			statsServer: &egv1a1.HostServerAddress{Port: 19001},
+			metrics: &egv1a1.ProxyMetrics{
+				EnableVirtualHostStats: ptr.To(true),
+			},
+			expect: &egv1a1.ProxyMetrics{
+				EnableVirtualHostStats: ptr.To(true),
+			},
+		},
+		{
+			name:        "prometheus disabled by envoyproxy",
+			adminServer: &egv1a1.HostServerAddress{Port: 19000},
+			statsServer: &egv1a1.HostServerAddress{Port: 19001},
+			metrics: &egv1a1.ProxyMetrics{
+				Prometheus: &egv1a1.ProxyPrometheusProvider{Disable: true},
+			},
+			expect: &egv1a1.ProxyMetrics{
+				Prometheus: &egv1a1.ProxyPrometheusProvider{Disable: true},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			i := &Infra{adminServer: tc.adminServer, statsServer: tc.statsServer}
+			proxyConfig := &egv1a1.EnvoyProxy{}
+			if tc.metrics != nil {
+				proxyConfig.Spec.Telemetry = &egv1a1.ProxyTelemetry{Metrics: tc.metrics}
+			}
+			require.Equal(t, tc.expect, i.proxyMetrics(proxyConfig))
+			// The EnvoyProxy settings must not be mutated.
+			if tc.metrics != nil {
+				require.Equal(t, tc.metrics, proxyConfig.Spec.Telemetry.Metrics)
+			}
+		})
+	}
+}
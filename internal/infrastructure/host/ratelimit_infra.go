@@ -7,17 +7,260 @@ package host
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes/ratelimit"
+	"github.com/envoyproxy/gateway/internal/ir"
 )
 
-// TODO: add ratelimit support for host infra
+const (
+	// defaultRateLimitBinary is the name of the rate limit binary looked up in the PATH.
+	defaultRateLimitBinary = "ratelimit"
+
+	// RateLimitGRPCPort is the port that the rate limit process listens on for rate limit requests.
+	RateLimitGRPCPort = 18081
+	// rateLimitHTTPPort and rateLimitDebugPort are the ports of the HTTP and debug servers of the
+	// rate limit process, which are only bound to the loopback address.
+	rateLimitHTTPPort  = 18080
+	rateLimitDebugPort = 16070
+	rateLimitHost      = "127.0.0.1"
+
+	rateLimitHostEnvVar            = "HOST"
+	rateLimitPortEnvVar            = "PORT"
+	rateLimitGRPCHostEnvVar        = "GRPC_HOST"
+	rateLimitGRPCPortEnvVar        = "GRPC_PORT"
+	rateLimitDebugHostEnvVar       = "DEBUG_HOST"
+	rateLimitDebugPortEnvVar       = "DEBUG_PORT"
+	rateLimitXdsServerTLSSANEnvVar = "CONFIG_GRPC_XDS_SERVER_TLS_SAN"
+	rateLimitRuntimeSubdirectory   = "ratelimit"
+)
+
+// rateLimitContext corresponds to the context of the supervised rate limit process.
+type rateLimitContext struct {
+	// cancel is the function to cancel the context passed to the supervisor of the rate limit process.
+	cancel context.CancelFunc
+	// exit will receive an item when the supervisor and the rate limit process completely stopped.
+	exit chan struct{}
+}
+
+// GetRateLimitServiceURL returns the URL of the rate limit service for the host infrastructure.
+// With the Local backend, the rate limit service is served by Envoy Gateway on the same port
+// as the rate limit xDS config server, otherwise it's served by the rate limit process.
+func GetRateLimitServiceURL(rateLimit *egv1a1.RateLimit) string {
+	port := RateLimitGRPCPort
+	if rateLimit != nil && rateLimit.Backend.Type == egv1a1.LocalBackendType {
+		port = ratelimit.XdsGrpcSotwConfigServerPort
+	}
+	return fmt.Sprintf("grpc://%s", net.JoinHostPort(rateLimitHost, strconv.Itoa(port)))
+}
 
 // CreateOrUpdateRateLimitInfra creates the managed host rate limit process, if it doesn't exist.
 func (i *Infra) CreateOrUpdateRateLimitInfra(ctx context.Context) error {
-	return fmt.Errorf("create/update ratelimit infrastructure is not supported yet for host infrastructure")
+	if i.EnvoyGateway == nil || i.EnvoyGateway.RateLimit == nil {
+		return errors.New("ratelimit is not configured")
+	}
+
+	rateLimit := i.EnvoyGateway.RateLimit
+	// The rate limit service is served by Envoy Gateway itself with the Local backend.
+	if rateLimit.Backend.Type == egv1a1.LocalBackendType {
+		return nil
+	}
+
+	i.rateLimitMu.Lock()
+	defer i.rateLimitMu.Unlock()
+
+	if i.rateLimitContext != nil {
+		return nil
+	}
+
+	path := i.rateLimitPath
+	if path == "" {
+		var err error
+		if path, err = exec.LookPath(defaultRateLimitBinary); err != nil {
+			return fmt.Errorf("failed to find the ratelimit binary: %w", err)
+		}
+	}
+
+	env, err := i.rateLimitEnv(rateLimit)
+	if err != nil {
+		return err
+	}
+
+	rCtx, cancel := context.WithCancel(ctx)
+	exit := make(chan struct{}, 1)
+	i.rateLimitContext = &rateLimitContext{cancel: cancel, exit: exit}
+	go func() {
+		// superviseRateLimit blocks until rCtx is done.
+		defer func() {
+			exit <- struct{}{}
+		}()
+		i.superviseRateLimit(rCtx, i.out, path, env)
+	}()
+	return nil
+}
+
+// DeleteRateLimitInfra removes the managed host rate limit process, if it exists.
+func (i *Infra) DeleteRateLimitInfra(_ context.Context) error {
+	i.stopRateLimit()
+	return nil
 }
 
-// DeleteRateLimitInfra removes the managed host rate limit process, if it doesn't exist.
-func (i *Infra) DeleteRateLimitInfra(ctx context.Context) error {
-	return fmt.Errorf("delete ratelimit infrastructure is not supported yet for host infrastructure")
+// rateLimitEnv returns the environment variables to run the rate limit process with, it mirrors
+// the settings of the rate limit Deployment with the Kubernetes infrastructure provider.
+func (i *Infra) rateLimitEnv(rateLimit *egv1a1.RateLimit) ([]string, error) {
+	certsDir := LocalRateLimitCertsDir(i.HomeDir)
+	certFile := filepath.Join(certsDir, XdsTLSCertFilename)
+	keyFile := filepath.Join(certsDir, XdsTLSKeyFilename)
+	caFile := filepath.Join(certsDir, XdsTLSCaFilename)
+	env := map[string]string{
+		ratelimit.RuntimeRootEnvVar:                    i.HomeDir,
+		ratelimit.RuntimeSubdirectoryEnvVar:            rateLimitRuntimeSubdirectory,
+		ratelimit.RuntimeIgnoreDotfilesEnvVar:          "true",
+		ratelimit.RuntimeWatchRootEnvVar:               "false",
+		ratelimit.LogLevelEnvVar:                       "info",
+		ratelimit.UseStatsdEnvVar:                      "false",
+		ratelimit.ConfigTypeEnvVar:                     "GRPC_XDS_SOTW",
+		ratelimit.ConfigGrpcXdsServerURLEnvVar:         net.JoinHostPort(rateLimitHost, strconv.Itoa(ratelimit.XdsGrpcSotwConfigServerPort)),
+		ratelimit.ConfigGrpcXdsNodeIDEnvVar:            ratelimit.InfraName,
+		ratelimit.GRPCServerUseTLSEnvVar:               "true",
+		ratelimit.GRPCServerTLSCertEnvVar:              certFile,
+		ratelimit.GRPCServerTLSKeyEnvVarEnvVar:         keyFile,
+		ratelimit.GRPCServerTLSCACertEnvVar:            caFile,
+		ratelimit.ConfigGRPCXDSServerUseTLSEnvVar:      "true",
+		ratelimit.ConfigGRPCXDSClientTLSCertEnvVar:     certFile,
+		ratelimit.ConfigGRPCXDSClientTLSKeyEnvVar:      keyFile,
+		ratelimit.ConfigGRPCXDSServerTLSCACertEnvVar:   caFile,
+		ratelimit.ForceStartWithoutInitialConfigEnvVar: "true",
+		// The certificate of the xDS config server is issued for the Envoy Gateway Service name,
+		// rather than the loopback address the rate limit process connects to.
+		rateLimitXdsServerTLSSANEnvVar: ratelimit.XdsGrpcSotwConfigServerHost,
+		rateLimitHostEnvVar:            rateLimitHost,
+		rateLimitPortEnvVar:            strconv.Itoa(rateLimitHTTPPort),
+		rateLimitGRPCHostEnvVar:        rateLimitHost,
+		rateLimitGRPCPortEnvVar:        strconv.Itoa(RateLimitGRPCPort),
+		rateLimitDebugHostEnvVar:       rateLimitHost,
+		rateLimitDebugPortEnvVar:       strconv.Itoa(rateLimitDebugPort),
+	}
+
+	switch backend := rateLimit.Backend; {
+	case backend.Type == egv1a1.RedisBackendType && backend.Redis != nil:
+		// Secret references are rejected for the host infrastructure, so all the envs have plain values.
+		for _, e := range ratelimit.RedisEnv(backend.Redis) {
+			env[e.Name] = e.Value
+		}
+	case backend.Type == egv1a1.MemcachedBackendType && backend.Memcached != nil:
+		env[ratelimit.BackendTypeEnvVar] = "memcache"
+		env[ratelimit.MemcacheHostPortEnvVar] = strings.Join(backend.Memcached.URLs, ",")
+		if backend.Memcached.MaxIdleConns != nil {
+			env[ratelimit.MemcacheMaxIdleConnsEnvVar] = strconv.Itoa(int(*backend.Memcached.MaxIdleConns))
+		}
+		if backend.Memcached.TLS != nil {
+			env[ratelimit.MemcacheTLSEnvVar] = "true"
+		}
+	default:
+		return nil, fmt.Errorf("unsupported ratelimit backend %v for host infrastructure", backend.Type)
+	}
+
+	vars := make([]string, 0, len(env))
+	for k, v := range env {
+		vars = append(vars, k+"="+v)
+	}
+	slices.Sort(vars)
+	return vars, nil
+}
+
+// superviseRateLimit runs the rate limit process, and restarts it with backoff when it exits unexpectedly.
+// The status of the rate limit process is published the same way as the status of the Envoy processes.
+// It blocks until ctx is done and the rate limit process completely stopped.
+func (i *Infra) superviseRateLimit(ctx context.Context, out io.Writer, path string, env []string) {
+	defer i.deleteRateLimitStatus()
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = envoyRestartInitialInterval
+	b.MaxInterval = envoyRestartMaxInterval
+	b.MaxElapsedTime = 0 // Never stop restarting.
+
+	var restarts int32
+	for {
+		startedAt := time.Now()
+		i.setRateLimitStatus(&ir.ProxyStatus{State: ir.ProxyStateRunning, Restarts: restarts})
+		err := i.runRateLimitProcess(ctx, out, path, env)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// The rate limit process exited unexpectedly, reset the backoff if it was running long enough.
+		if time.Since(startedAt) > envoyRestartMaxInterval {
+			b.Reset()
+		}
+		if err == nil {
+			err = errors.New("ratelimit exited")
+		}
+		restarts++
+		delay := b.NextBackOff()
+		i.Logger.Error(err, "ratelimit exited unexpectedly, restarting it", "restarts", restarts, "delay", delay)
+		i.setRateLimitStatus(&ir.ProxyStatus{State: ir.ProxyStateRestarting, Restarts: restarts, Message: err.Error()})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// setRateLimitStatus publishes the status of the rate limit process.
+func (i *Infra) setRateLimitStatus(status *ir.ProxyStatus) {
+	if i.infraStatuses != nil {
+		i.infraStatuses.RateLimitStatuses.Store(ratelimit.InfraName, status)
+	}
+}
+
+// deleteRateLimitStatus deletes the published status of the rate limit process.
+func (i *Infra) deleteRateLimitStatus() {
+	if i.infraStatuses != nil {
+		i.infraStatuses.RateLimitStatuses.Delete(ratelimit.InfraName)
+	}
+}
+
+// runRateLimitProcess runs the rate limit process and blocks until it exits or ctx is done.
+func (i *Infra) runRateLimitProcess(ctx context.Context, out io.Writer, path string, env []string) error {
+	cmd := exec.CommandContext(ctx, path) // #nosec G204 -- the binary is configured by the operator.
+	cmd.Dir = i.HomeDir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout, cmd.Stderr = out, out
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = envoyWaitDelay
+	if err := cmd.Run(); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+// stopRateLimit stops the rate limit process. It will block until the process completely stopped.
+func (i *Infra) stopRateLimit() {
+	i.rateLimitMu.Lock()
+	defer i.rateLimitMu.Unlock()
+
+	if rCtx := i.rateLimitContext; rCtx != nil {
+		rCtx.cancel()    // Cancel causes the rate limit process to exit.
+		<-rCtx.exit      // Wait for the rate limit process to completely exit.
+		close(rCtx.exit) // Close the channel to avoid leaking.
+		i.rateLimitContext = nil
+	}
 }
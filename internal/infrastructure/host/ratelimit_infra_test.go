@@ -0,0 +1,173 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package host
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes/ratelimit"
+	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+func TestGetRateLimitServiceURL(t *testing.T) {
+	require.Equal(t, "grpc://127.0.0.1:18081", GetRateLimitServiceURL(&egv1a1.RateLimit{
+		Backend: egv1a1.RateLimitDatabaseBackend{Type: egv1a1.RedisBackendType},
+	}))
+	require.Equal(t, "grpc://127.0.0.1:18001", GetRateLimitServiceURL(&egv1a1.RateLimit{
+		Backend: egv1a1.RateLimitDatabaseBackend{Type: egv1a1.LocalBackendType},
+	}))
+}
+
+func TestInfra_rateLimitEnv(t *testing.T) {
+	i := &Infra{HomeDir: "/tmp/envoy-gateway"}
+	env, err := i.rateLimitEnv(&egv1a1.RateLimit{
+		Backend: egv1a1.RateLimitDatabaseBackend{
+			Type: egv1a1.RedisBackendType,
+			Redis: &egv1a1.RateLimitRedisSettings{
+				URL: "localhost:6379",
+				TLS: &egv1a1.RedisTLSSettings{},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Subset(t, env, []string{
+		"CONFIG_GRPC_XDS_SERVER_URL=127.0.0.1:18001",
+		"CONFIG_GRPC_XDS_SERVER_TLS_SAN=envoy-gateway",
+		"CONFIG_GRPC_XDS_NODE_ID=envoy-ratelimit",
+		"GRPC_HOST=127.0.0.1",
+		"GRPC_PORT=18081",
+		"GRPC_SERVER_TLS_CERT=/tmp/envoy-gateway/certs/envoy-rate-limit/tls.crt",
+		"REDIS_URL=localhost:6379",
+		"REDIS_TLS=true",
+		"RUNTIME_ROOT=/tmp/envoy-gateway",
+	})
+
+	env, err = i.rateLimitEnv(&egv1a1.RateLimit{
+		Backend: egv1a1.RateLimitDatabaseBackend{
+			Type: egv1a1.RedisBackendType,
+			Redis: &egv1a1.RateLimitRedisSettings{
+				Type: ptr.To(egv1a1.RedisTypeSentinel),
+				Sentinel: &egv1a1.RedisSentinelSettings{
+					MasterName: "mymaster",
+					URLs:       []string{"localhost:26379", "localhost:26380"},
+				},
+				PoolSize: ptr.To[int32](20),
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Subset(t, env, []string{
+		"REDIS_TYPE=SENTINEL",
+		"REDIS_URL=mymaster,localhost:26379,localhost:26380",
+		"REDIS_POOL_SIZE=20",
+	})
+
+	env, err = i.rateLimitEnv(&egv1a1.RateLimit{
+		Backend: egv1a1.RateLimitDatabaseBackend{
+			Type: egv1a1.MemcachedBackendType,
+			Memcached: &egv1a1.RateLimitMemcachedSettings{
+				URLs:         []string{"localhost:11211", "localhost:11212"},
+				MaxIdleConns: ptr.To[int32](4),
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Subset(t, env, []string{
+		"BACKEND_TYPE=memcache",
+		"MEMCACHE_HOST_PORT=localhost:11211,localhost:11212",
+		"MEMCACHE_MAX_IDLE_CONNS=4",
+	})
+	require.NotContains(t, env, "REDIS_SOCKET_TYPE=tcp")
+
+	_, err = i.rateLimitEnv(&egv1a1.RateLimit{
+		Backend: egv1a1.RateLimitDatabaseBackend{Type: egv1a1.LocalBackendType},
+	})
+	require.Error(t, err)
+}
+
+func TestInfraCreateOrUpdateRateLimitInfra(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh binary is not available")
+	}
+	dir := t.TempDir()
+	record := path.Join(dir, "runs")
+	script := path.Join(dir, "ratelimit")
+	// Record the redis URL of every run.
+	content := fmt.Sprintf("#!%s\necho $REDIS_URL >> %s\nexit 1\n", sh, record)
+	require.NoError(t, os.WriteFile(script, []byte(content), 0o700)) // #nosec G306
+
+	statuses := new(message.InfraStatuses)
+	i := &Infra{
+		HomeDir:       t.TempDir(),
+		Logger:        logging.DefaultLogger(os.Stdout, egv1a1.LogLevelInfo),
+		out:           os.Stdout,
+		rateLimitPath: script,
+		infraStatuses: statuses,
+		EnvoyGateway: &egv1a1.EnvoyGateway{
+			EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+				RateLimit: &egv1a1.RateLimit{
+					Backend: egv1a1.RateLimitDatabaseBackend{
+						Type:  egv1a1.RedisBackendType,
+						Redis: &egv1a1.RateLimitRedisSettings{URL: "localhost:6379"},
+					},
+				},
+			},
+		},
+	}
+
+	// The rate limit process is restarted when it exits unexpectedly.
+	require.NoError(t, i.CreateOrUpdateRateLimitInfra(context.Background()))
+	require.Eventually(t, func() bool {
+		return len(readRuns(t, record)) >= 2
+	}, 10*time.Second, 50*time.Millisecond)
+	for _, run := range readRuns(t, record) {
+		require.Equal(t, "localhost:6379", run)
+	}
+	// The restarts of the rate limit process are reported in its status.
+	require.Eventually(t, func() bool {
+		status, ok := statuses.RateLimitStatuses.Load(ratelimit.InfraName)
+		return ok && status.Restarts >= 1
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// Nothing happens when the rate limit process is running.
+	require.NoError(t, i.CreateOrUpdateRateLimitInfra(context.Background()))
+	require.NotNil(t, i.rateLimitContext)
+
+	require.NoError(t, i.DeleteRateLimitInfra(context.Background()))
+	require.Nil(t, i.rateLimitContext)
+	_, ok := statuses.RateLimitStatuses.Load(ratelimit.InfraName)
+	require.False(t, ok)
+	// Deleting is a no-op once the rate limit process is stopped.
+	require.NoError(t, i.DeleteRateLimitInfra(context.Background()))
+}
+
+func TestInfraCreateOrUpdateRateLimitInfra_local(t *testing.T) {
+	i := &Infra{
+		EnvoyGateway: &egv1a1.EnvoyGateway{
+			EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+				RateLimit: &egv1a1.RateLimit{
+					Backend: egv1a1.RateLimitDatabaseBackend{Type: egv1a1.LocalBackendType},
+				},
+			},
+		},
+	}
+
+	// No rate limit process is run with the Local backend.
+	require.NoError(t, i.CreateOrUpdateRateLimitInfra(context.Background()))
+	require.Nil(t, i.rateLimitContext)
+}
@@ -34,6 +34,8 @@ const (
 	envoyPodEnvVar = "ENVOY_POD_NAME"
 	// envoyZoneEnvVar is the Envoy pod locality zone name
 	envoyZoneEnvVar = "ENVOY_SERVICE_ZONE"
+	// envoyRegionEnvVar is the Envoy pod locality region name
+	envoyRegionEnvVar = "ENVOY_SERVICE_REGION"
 )
 
 // ExpectedResourceHashedName returns expected resource hashed name including up to the 48 characters of the original name.
@@ -445,6 +447,15 @@ func expectedContainerEnv(containerSpec *egv1a1.KubernetesContainerSpec) []corev
 				},
 			},
 		},
+		{
+			Name: envoyRegionEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					APIVersion: "v1",
+					FieldPath:  fmt.Sprintf("metadata.annotations['%s']", corev1.LabelTopologyRegion),
+				},
+			},
+		},
 	}
 
 	if containerSpec != nil {
@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes/resource"
@@ -27,6 +28,30 @@ const (
 	RedisSocketTypeEnvVar = "REDIS_SOCKET_TYPE"
 	// RedisURLEnvVar is the redis url.
 	RedisURLEnvVar = "REDIS_URL"
+	// RedisTypeEnvVar is the redis deployment type.
+	RedisTypeEnvVar = "REDIS_TYPE"
+	// RedisAuthEnvVar is the redis auth.
+	RedisAuthEnvVar = "REDIS_AUTH"
+	// RedisPasswordSecretKey is the key of the redis password in the Secret referenced by the passwordRef.
+	RedisPasswordSecretKey = "password"
+	// RedisPoolSizeEnvVar is the number of connections to each redis host.
+	RedisPoolSizeEnvVar = "REDIS_POOL_SIZE"
+	// RedisPipelineWindowEnvVar is the duration after which the buffered redis commands are flushed.
+	RedisPipelineWindowEnvVar = "REDIS_PIPELINE_WINDOW"
+	// RedisPipelineLimitEnvVar is the maximum number of buffered redis commands.
+	RedisPipelineLimitEnvVar = "REDIS_PIPELINE_LIMIT"
+	// RedisPerSecondEnvVar enables the separate redis for per second limits.
+	RedisPerSecondEnvVar = "REDIS_PERSECOND"
+	// RedisPerSecondSocketTypeEnvVar is the per second redis socket type.
+	RedisPerSecondSocketTypeEnvVar = "REDIS_PERSECOND_SOCKET_TYPE"
+	// RedisPerSecondURLEnvVar is the per second redis url.
+	RedisPerSecondURLEnvVar = "REDIS_PERSECOND_URL"
+	// RedisPerSecondTypeEnvVar is the per second redis deployment type.
+	RedisPerSecondTypeEnvVar = "REDIS_PERSECOND_TYPE"
+	// RedisPerSecondAuthEnvVar is the per second redis auth.
+	RedisPerSecondAuthEnvVar = "REDIS_PERSECOND_AUTH"
+	// RedisPerSecondPoolSizeEnvVar is the number of connections to each per second redis host.
+	RedisPerSecondPoolSizeEnvVar = "REDIS_PERSECOND_POOL_SIZE"
 	// RedisTLSEnvVar is the redis tls.
 	RedisTLSEnvVar = "REDIS_TLS"
 	// RedisTLSClientCertEnvVar is the redis tls client cert.
@@ -37,6 +62,22 @@ const (
 	RedisTLSClientKeyEnvVar = "REDIS_TLS_CLIENT_KEY"
 	// RedisTLSClientKeyFilename is the redis client key file.
 	RedisTLSClientKeyFilename = "/redis-certs/tls.key"
+	// BackendTypeEnvVar is the type of the rate limit backend.
+	BackendTypeEnvVar = "BACKEND_TYPE"
+	// MemcacheHostPortEnvVar is the comma separated list of memcached host:port.
+	MemcacheHostPortEnvVar = "MEMCACHE_HOST_PORT"
+	// MemcacheMaxIdleConnsEnvVar is the maximum number of idle connections to each memcached server.
+	MemcacheMaxIdleConnsEnvVar = "MEMCACHE_MAX_IDLE_CONNS"
+	// MemcacheTLSEnvVar is the memcached tls.
+	MemcacheTLSEnvVar = "MEMCACHE_TLS"
+	// MemcacheTLSClientCertEnvVar is the memcached tls client cert.
+	MemcacheTLSClientCertEnvVar = "MEMCACHE_TLS_CLIENT_CERT"
+	// MemcacheTLSClientCertFilename is the memcached tls client cert file.
+	MemcacheTLSClientCertFilename = "/memcached-certs/tls.crt"
+	// MemcacheTLSClientKeyEnvVar is the memcached tls client key.
+	MemcacheTLSClientKeyEnvVar = "MEMCACHE_TLS_CLIENT_KEY"
+	// MemcacheTLSClientKeyFilename is the memcached client key file.
+	MemcacheTLSClientKeyFilename = "/memcached-certs/tls.key"
 	// RuntimeRootEnvVar is the runtime root.
 	RuntimeRootEnvVar = "RUNTIME_ROOT"
 	// RuntimeSubdirectoryEnvVar is the runtime subdirectory.
@@ -115,6 +156,12 @@ func GetServiceURL(namespace, dnsDomain string) string {
 	return fmt.Sprintf("grpc://%s.%s.svc.%s:%d", InfraName, namespace, dnsDomain, InfraGRPCPort)
 }
 
+// GetLocalServiceURL returns the URL for the rate limit service with the Local backend,
+// which is served by Envoy Gateway on the same port as the ratelimit xDS config server.
+func GetLocalServiceURL(namespace, dnsDomain string) string {
+	return fmt.Sprintf("grpc://%s.%s.svc.%s:%d", XdsGrpcSotwConfigServerHost, namespace, dnsDomain, XdsGrpcSotwConfigServerPort)
+}
+
 // LabelSelector returns the string slice form labels used for all envoy rate limit resources.
 func LabelSelector() []string {
 	rlLabelMap := rateLimitLabels()
@@ -228,7 +275,7 @@ func expectedContainerVolumeMounts(rateLimit *egv1a1.RateLimit, rateLimitDeploym
 		})
 	}
 
-	if rateLimit.Backend.Redis.TLS != nil {
+	if rateLimit.Backend.Redis != nil && rateLimit.Backend.Redis.TLS != nil {
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{
 			Name:      "redis-certs",
 			MountPath: "/redis-certs",
@@ -236,6 +283,16 @@ func expectedContainerVolumeMounts(rateLimit *egv1a1.RateLimit, rateLimitDeploym
 		})
 	}
 
+	if rateLimit.Backend.Memcached != nil &&
+		rateLimit.Backend.Memcached.TLS != nil &&
+		rateLimit.Backend.Memcached.TLS.CertificateRef != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "memcached-certs",
+			MountPath: "/memcached-certs",
+			ReadOnly:  true,
+		})
+	}
+
 	return resource.ExpectedContainerVolumeMounts(rateLimitDeployment.Container, volumeMounts)
 }
 
@@ -257,6 +314,20 @@ func expectedDeploymentVolumes(rateLimit *egv1a1.RateLimit, rateLimitDeployment
 		})
 	}
 
+	if rateLimit.Backend.Memcached != nil &&
+		rateLimit.Backend.Memcached.TLS != nil &&
+		rateLimit.Backend.Memcached.TLS.CertificateRef != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: "memcached-certs",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  string(rateLimit.Backend.Memcached.TLS.CertificateRef.Name),
+					DefaultMode: ptr.To[int32](420),
+				},
+			},
+		})
+	}
+
 	volumes = append(volumes, corev1.Volume{
 		Name: "certs",
 		VolumeSource: corev1.VolumeSource{
@@ -365,35 +436,46 @@ func expectedRateLimitContainerEnv(rateLimit *egv1a1.RateLimit, rateLimitDeploym
 	}
 
 	if rateLimit.Backend.Redis != nil {
+		env = append(env, RedisEnv(rateLimit.Backend.Redis)...)
+	}
+
+	if rateLimit.Backend.Memcached != nil {
 		env = append(env, []corev1.EnvVar{
 			{
-				Name:  RedisSocketTypeEnvVar,
-				Value: "tcp",
+				Name:  BackendTypeEnvVar,
+				Value: "memcache",
 			},
 			{
-				Name:  RedisURLEnvVar,
-				Value: rateLimit.Backend.Redis.URL,
+				Name:  MemcacheHostPortEnvVar,
+				Value: strings.Join(rateLimit.Backend.Memcached.URLs, ","),
 			},
 		}...)
-	}
 
-	if rateLimit.Backend.Redis != nil && rateLimit.Backend.Redis.TLS != nil {
-		env = append(env, corev1.EnvVar{
-			Name:  RedisTLSEnvVar,
-			Value: "true",
-		})
+		if rateLimit.Backend.Memcached.MaxIdleConns != nil {
+			env = append(env, corev1.EnvVar{
+				Name:  MemcacheMaxIdleConnsEnvVar,
+				Value: strconv.Itoa(int(*rateLimit.Backend.Memcached.MaxIdleConns)),
+			})
+		}
 
-		if rateLimit.Backend.Redis.TLS.CertificateRef != nil {
-			env = append(env, []corev1.EnvVar{
-				{
-					Name:  RedisTLSClientCertEnvVar,
-					Value: RedisTLSClientCertFilename,
-				},
-				{
-					Name:  RedisTLSClientKeyEnvVar,
-					Value: RedisTLSClientKeyFilename,
-				},
-			}...)
+		if rateLimit.Backend.Memcached.TLS != nil {
+			env = append(env, corev1.EnvVar{
+				Name:  MemcacheTLSEnvVar,
+				Value: "true",
+			})
+
+			if rateLimit.Backend.Memcached.TLS.CertificateRef != nil {
+				env = append(env, []corev1.EnvVar{
+					{
+						Name:  MemcacheTLSClientCertEnvVar,
+						Value: MemcacheTLSClientCertFilename,
+					},
+					{
+						Name:  MemcacheTLSClientKeyEnvVar,
+						Value: MemcacheTLSClientKeyFilename,
+					},
+				}...)
+			}
 		}
 	}
 
@@ -461,14 +543,189 @@ func expectedRateLimitContainerEnv(rateLimit *egv1a1.RateLimit, rateLimitDeploym
 	return resource.ExpectedContainerEnv(rateLimitDeployment.Container, env)
 }
 
-// Validate the ratelimit tls secret validating.
+// RedisEnv returns the rateLimit container envs to connect to Redis.
+func RedisEnv(redis *egv1a1.RateLimitRedisSettings) []corev1.EnvVar {
+	redisType, redisURL := redisTypeAndURL(redis.Type, redis.URL, redis.Sentinel, redis.Cluster)
+	env := []corev1.EnvVar{
+		{
+			Name:  RedisSocketTypeEnvVar,
+			Value: "tcp",
+		},
+		{
+			Name:  RedisURLEnvVar,
+			Value: redisURL,
+		},
+	}
+
+	if redisType != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  RedisTypeEnvVar,
+			Value: redisType,
+		})
+	}
+
+	if redis.PasswordRef != nil {
+		env = append(env, redisPasswordEnv(RedisAuthEnvVar, redis.PasswordRef))
+	}
+
+	if redis.TLS != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  RedisTLSEnvVar,
+			Value: "true",
+		})
+
+		if redis.TLS.CertificateRef != nil {
+			env = append(env, []corev1.EnvVar{
+				{
+					Name:  RedisTLSClientCertEnvVar,
+					Value: RedisTLSClientCertFilename,
+				},
+				{
+					Name:  RedisTLSClientKeyEnvVar,
+					Value: RedisTLSClientKeyFilename,
+				},
+			}...)
+		}
+	}
+
+	if redis.PoolSize != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  RedisPoolSizeEnvVar,
+			Value: strconv.Itoa(int(*redis.PoolSize)),
+		})
+	}
+
+	if redis.PipelineWindow != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  RedisPipelineWindowEnvVar,
+			Value: string(*redis.PipelineWindow),
+		})
+	}
+
+	if redis.PipelineLimit != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  RedisPipelineLimitEnvVar,
+			Value: strconv.Itoa(int(*redis.PipelineLimit)),
+		})
+	}
+
+	if perSecond := redis.PerSecond; perSecond != nil {
+		perSecondType, perSecondURL := redisTypeAndURL(perSecond.Type, perSecond.URL, perSecond.Sentinel, perSecond.Cluster)
+		env = append(env, []corev1.EnvVar{
+			{
+				Name:  RedisPerSecondEnvVar,
+				Value: "true",
+			},
+			{
+				Name:  RedisPerSecondSocketTypeEnvVar,
+				Value: "tcp",
+			},
+			{
+				Name:  RedisPerSecondURLEnvVar,
+				Value: perSecondURL,
+			},
+		}...)
+
+		if perSecondType != "" {
+			env = append(env, corev1.EnvVar{
+				Name:  RedisPerSecondTypeEnvVar,
+				Value: perSecondType,
+			})
+		}
+
+		if perSecond.PasswordRef != nil {
+			env = append(env, redisPasswordEnv(RedisPerSecondAuthEnvVar, perSecond.PasswordRef))
+		}
+
+		if perSecond.PoolSize != nil {
+			env = append(env, corev1.EnvVar{
+				Name:  RedisPerSecondPoolSizeEnvVar,
+				Value: strconv.Itoa(int(*perSecond.PoolSize)),
+			})
+		}
+	}
+
+	return env
+}
+
+// redisTypeAndURL returns the type and url of a Redis deployment, in the format of the rate limit service.
+// The type is empty if it's unspecified, and the url of a Sentinel deployment starts with the master name.
+func redisTypeAndURL(redisType *egv1a1.RedisType, url string, sentinel *egv1a1.RedisSentinelSettings,
+	cluster *egv1a1.RedisClusterSettings,
+) (string, string) {
+	if redisType == nil {
+		return "", url
+	}
+
+	switch *redisType {
+	case egv1a1.RedisTypeSentinel:
+		if sentinel != nil {
+			return "SENTINEL", strings.Join(append([]string{sentinel.MasterName}, sentinel.URLs...), ",")
+		}
+		return "SENTINEL", url
+	case egv1a1.RedisTypeCluster:
+		if cluster != nil {
+			return "CLUSTER", strings.Join(cluster.URLs, ",")
+		}
+		return "CLUSTER", url
+	default:
+		return "SINGLE", url
+	}
+}
+
+// redisPasswordEnv returns the env with the Redis password of the Secret referenced by the passwordRef.
+func redisPasswordEnv(name string, passwordRef *gwapiv1.SecretObjectReference) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: string(passwordRef.Name),
+				},
+				Key: RedisPasswordSecretKey,
+			},
+		},
+	}
+}
+
+// Validate the ratelimit tls and password secret validating.
 func Validate(ctx context.Context, client client.Client, gateway *egv1a1.EnvoyGateway, namespace string) error {
-	if gateway.RateLimit.Backend.Redis != nil &&
-		gateway.RateLimit.Backend.Redis.TLS != nil &&
-		gateway.RateLimit.Backend.Redis.TLS.CertificateRef != nil {
-		certificateRef := gateway.RateLimit.Backend.Redis.TLS.CertificateRef
-		_, _, err := kubernetes.ValidateSecretObjectReference(ctx, client, certificateRef, namespace)
-		return err
+	var certificateRef *gwapiv1.SecretObjectReference
+	var passwordRefs []*gwapiv1.SecretObjectReference
+	switch backend := gateway.RateLimit.Backend; {
+	case backend.Redis != nil:
+		if backend.Redis.TLS != nil {
+			certificateRef = backend.Redis.TLS.CertificateRef
+		}
+		if backend.Redis.PasswordRef != nil {
+			passwordRefs = append(passwordRefs, backend.Redis.PasswordRef)
+		}
+		if backend.Redis.PerSecond != nil && backend.Redis.PerSecond.PasswordRef != nil {
+			passwordRefs = append(passwordRefs, backend.Redis.PerSecond.PasswordRef)
+		}
+	case backend.Memcached != nil && backend.Memcached.TLS != nil:
+		certificateRef = backend.Memcached.TLS.CertificateRef
+	}
+
+	if certificateRef != nil {
+		if _, _, err := kubernetes.ValidateSecretObjectReference(ctx, client, certificateRef, namespace); err != nil {
+			return err
+		}
+	}
+
+	for _, passwordRef := range passwordRefs {
+		// The password is read from the Secret by the rate limit container, which can only
+		// reference Secrets in its own namespace.
+		if passwordRef.Namespace != nil && string(*passwordRef.Namespace) != namespace {
+			return fmt.Errorf("ratelimit redis password Secret %s must be in namespace %s", passwordRef.Name, namespace)
+		}
+		secret, _, err := kubernetes.ValidateSecretObjectReference(ctx, client, passwordRef, namespace)
+		if err != nil {
+			return err
+		}
+		if _, ok := secret.Data[RedisPasswordSecretKey]; !ok {
+			return fmt.Errorf("ratelimit redis password Secret %s has no %q key", passwordRef.Name, RedisPasswordSecretKey)
+		}
 	}
 
 	return nil
@@ -29,11 +29,6 @@ import (
 	"github.com/envoyproxy/gateway/internal/utils/test"
 )
 
-const (
-	// RedisAuthEnvVar is the redis auth.
-	RedisAuthEnvVar = "REDIS_AUTH"
-)
-
 var ownerReferenceUID = map[string]types.UID{
 	ResourceKindService:        "test-owner-reference-uid-for-service",
 	ResourceKindDeployment:     "test-owner-reference-uid-for-deployment",
@@ -505,6 +500,56 @@ func TestDeployment(t *testing.T) {
 				},
 			},
 		},
+		{
+			caseName: "redis-settings",
+			rateLimit: &egv1a1.RateLimit{
+				Backend: egv1a1.RateLimitDatabaseBackend{
+					Type: egv1a1.RedisBackendType,
+					Redis: &egv1a1.RateLimitRedisSettings{
+						Type: ptr.To(egv1a1.RedisTypeCluster),
+						Cluster: &egv1a1.RedisClusterSettings{
+							URLs: []string{"redis-0.redis.svc:6379", "redis-1.redis.svc:6379"},
+						},
+						PasswordRef: &gwapiv1.SecretObjectReference{
+							Name: "redis-password",
+						},
+						PoolSize:       ptr.To[int32](20),
+						PipelineWindow: ptr.To(gwapiv1.Duration("1ms")),
+						PipelineLimit:  ptr.To[int32](8),
+						PerSecond: &egv1a1.RedisPerSecondSettings{
+							Type: ptr.To(egv1a1.RedisTypeSentinel),
+							Sentinel: &egv1a1.RedisSentinelSettings{
+								MasterName: "mymaster",
+								URLs:       []string{"sentinel-0.redis.svc:26379", "sentinel-1.redis.svc:26379"},
+							},
+							PasswordRef: &gwapiv1.SecretObjectReference{
+								Name: "redis-persecond-password",
+							},
+							PoolSize: ptr.To[int32](5),
+						},
+					},
+				},
+			},
+			deploy: cfg.EnvoyGateway.GetEnvoyGatewayProvider().GetEnvoyGatewayKubeProvider().RateLimitDeployment,
+		},
+		{
+			caseName: "memcached-settings",
+			rateLimit: &egv1a1.RateLimit{
+				Backend: egv1a1.RateLimitDatabaseBackend{
+					Type: egv1a1.MemcachedBackendType,
+					Memcached: &egv1a1.RateLimitMemcachedSettings{
+						URLs: []string{"memcached-0.memcached.svc:11211", "memcached-1.memcached.svc:11211"},
+						TLS: &egv1a1.MemcachedTLSSettings{
+							CertificateRef: &gwapiv1.SecretObjectReference{
+								Name: "memcached-cert",
+							},
+						},
+						MaxIdleConns: ptr.To[int32](10),
+					},
+				},
+			},
+			deploy: cfg.EnvoyGateway.GetEnvoyGatewayProvider().GetEnvoyGatewayKubeProvider().RateLimitDeployment,
+		},
 		{
 			caseName: "tolerations",
 			rateLimit: &egv1a1.RateLimit{
@@ -930,3 +975,8 @@ func TestGetServiceURL(t *testing.T) {
 	got := GetServiceURL("envoy-gateway-system", "example-cluster.local")
 	assert.Equal(t, "grpc://envoy-ratelimit.envoy-gateway-system.svc.example-cluster.local:8081", got)
 }
+
+func TestGetLocalServiceURL(t *testing.T) {
+	got := GetLocalServiceURL("envoy-gateway-system", "example-cluster.local")
+	assert.Equal(t, "grpc://envoy-gateway.envoy-gateway-system.svc.example-cluster.local:18001", got)
+}
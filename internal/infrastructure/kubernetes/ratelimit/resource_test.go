@@ -6,9 +6,17 @@
 package ratelimit
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 )
 
 func TestCheckTraceEndpointScheme(t *testing.T) {
@@ -36,3 +44,81 @@ func TestCheckTraceEndpointScheme(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+	cli := fake.NewClientBuilder().WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "envoy-gateway-system", Name: "redis-password"},
+			Data:       map[string][]byte{"password": []byte("secret")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "envoy-gateway-system", Name: "no-password"},
+			Data:       map[string][]byte{"pass": []byte("secret")},
+		},
+	).Build()
+
+	cases := []struct {
+		caseName  string
+		redis     *egv1a1.RateLimitRedisSettings
+		expectErr string
+	}{
+		{
+			caseName: "password ref",
+			redis: &egv1a1.RateLimitRedisSettings{
+				URL:         "redis.redis.svc:6379",
+				PasswordRef: &gwapiv1.SecretObjectReference{Name: "redis-password"},
+			},
+		},
+		{
+			caseName: "per second password ref without password key",
+			redis: &egv1a1.RateLimitRedisSettings{
+				URL: "redis.redis.svc:6379",
+				PerSecond: &egv1a1.RedisPerSecondSettings{
+					URL:         "redis-persecond.redis.svc:6379",
+					PasswordRef: &gwapiv1.SecretObjectReference{Name: "no-password"},
+				},
+			},
+			expectErr: `ratelimit redis password Secret no-password has no "password" key`,
+		},
+		{
+			caseName: "password ref in another namespace",
+			redis: &egv1a1.RateLimitRedisSettings{
+				URL: "redis.redis.svc:6379",
+				PasswordRef: &gwapiv1.SecretObjectReference{
+					Name:      "redis-password",
+					Namespace: ptr.To[gwapiv1.Namespace]("redis"),
+				},
+			},
+			expectErr: "ratelimit redis password Secret redis-password must be in namespace envoy-gateway-system",
+		},
+		{
+			caseName: "missing password secret",
+			redis: &egv1a1.RateLimitRedisSettings{
+				URL:         "redis.redis.svc:6379",
+				PasswordRef: &gwapiv1.SecretObjectReference{Name: "missing"},
+			},
+			expectErr: "cannot find Secret missing in namespace envoy-gateway-system",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.caseName, func(t *testing.T) {
+			eg := &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					RateLimit: &egv1a1.RateLimit{
+						Backend: egv1a1.RateLimitDatabaseBackend{
+							Type:  egv1a1.RedisBackendType,
+							Redis: tc.redis,
+						},
+					},
+				},
+			}
+			err := Validate(context.Background(), cli, eg, "envoy-gateway-system")
+			if tc.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.expectErr)
+			}
+		})
+	}
+}
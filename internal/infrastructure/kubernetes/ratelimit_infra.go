@@ -7,16 +7,29 @@ package kubernetes
 
 import (
 	"context"
+	"fmt"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes/ratelimit"
 )
 
 // CreateOrUpdateRateLimitInfra creates the managed kube rate limit infra, if it doesn't exist.
 func (i *Infra) CreateOrUpdateRateLimitInfra(ctx context.Context) error {
+	// The rate limit service is served by Envoy Gateway itself with the Local backend,
+	// remove the rate limit infra left by a previous backend.
+	if i.EnvoyGateway.RateLimit != nil && i.EnvoyGateway.RateLimit.Backend.Type == egv1a1.LocalBackendType {
+		if err := i.DeleteRateLimitInfra(ctx); err != nil {
+			return err
+		}
+		return i.validateLocalRateLimit(ctx)
+	}
+
 	if err := ratelimit.Validate(ctx, i.Client.Client, i.EnvoyGateway, i.ControllerNamespace); err != nil {
 		return err
 	}
@@ -50,6 +63,35 @@ func (i *Infra) CreateOrUpdateRateLimitInfra(ctx context.Context) error {
 	return i.createOrUpdate(ctx, r)
 }
 
+// validateLocalRateLimit checks that a single Envoy Gateway replica is run with the Local rate limit backend,
+// since every replica keeps its own counters and the proxies are load balanced across the replicas.
+func (i *Infra) validateLocalRateLimit(ctx context.Context) error {
+	key := types.NamespacedName{
+		Namespace: i.ControllerNamespace,
+		Name:      "envoy-gateway",
+	}
+
+	deployment := new(appsv1.Deployment)
+	if err := i.Client.Get(ctx, key, deployment); err != nil {
+		return err
+	}
+	if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas > 1 {
+		return fmt.Errorf("ratelimit local backend requires a single envoy-gateway replica, found %d replicas", *deployment.Spec.Replicas)
+	}
+
+	hpa := new(autoscalingv2.HorizontalPodAutoscaler)
+	err := i.Client.Get(ctx, key, hpa)
+	switch {
+	case kerrors.IsNotFound(err):
+	case err != nil:
+		return err
+	case hpa.Spec.MaxReplicas > 1:
+		return fmt.Errorf("ratelimit local backend requires a single envoy-gateway replica, the envoy-gateway HorizontalPodAutoscaler allows up to %d replicas", hpa.Spec.MaxReplicas)
+	}
+
+	return nil
+}
+
 // DeleteRateLimitInfra removes the managed kube infra, if it doesn't exist.
 func (i *Infra) DeleteRateLimitInfra(ctx context.Context) error {
 	// Delete ratelimit infra do not require the uid of owner reference.
@@ -11,10 +11,14 @@ import (
 
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes/ratelimit"
 	"github.com/envoyproxy/gateway/internal/provider/kubernetes"
 )
@@ -180,6 +184,45 @@ func TestCreateRateLimitInfra(t *testing.T) {
 	}
 }
 
+func TestCreateRateLimitInfraLocalBackend(t *testing.T) {
+	kube := newTestInfra(t)
+	createEnvoyGatewayService(t, kube.Client.Client, kube.ControllerNamespace)
+	createEnvoyGatewayDeployment(t, kube.Client.Client, kube.ControllerNamespace)
+	createEnvoyGatewayServiceAccount(t, kube.Client.Client, kube.ControllerNamespace)
+	createRateLimitTLSSecret(t, kube.Client.Client)
+	require.NoError(t, kube.CreateOrUpdateRateLimitInfra(context.Background()))
+
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: kube.ControllerNamespace,
+			Name:      ratelimit.InfraName,
+		},
+	}
+	require.NoError(t, kube.Client.Get(context.Background(), client.ObjectKeyFromObject(deploy), deploy))
+
+	// The rate limit infra is removed when switching to the Local backend.
+	kube.EnvoyGateway.RateLimit.Backend = egv1a1.RateLimitDatabaseBackend{Type: egv1a1.LocalBackendType}
+	require.NoError(t, kube.CreateOrUpdateRateLimitInfra(context.Background()))
+	err := kube.Client.Get(context.Background(), client.ObjectKeyFromObject(deploy), deploy)
+	require.True(t, kerrors.IsNotFound(err))
+
+	// The Local backend is rejected when more than one envoy-gateway replica can be run.
+	egDeploy := &appsv1.Deployment{}
+	egKey := client.ObjectKey{Namespace: kube.ControllerNamespace, Name: "envoy-gateway"}
+	require.NoError(t, kube.Client.Get(context.Background(), egKey, egDeploy))
+	egDeploy.Spec.Replicas = ptr.To[int32](2)
+	require.NoError(t, kube.Client.Update(context.Background(), egDeploy))
+	require.ErrorContains(t, kube.CreateOrUpdateRateLimitInfra(context.Background()), "found 2 replicas")
+
+	egDeploy.Spec.Replicas = ptr.To[int32](1)
+	require.NoError(t, kube.Client.Update(context.Background(), egDeploy))
+	require.NoError(t, kube.Client.Create(context.Background(), &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Namespace: kube.ControllerNamespace, Name: "envoy-gateway"},
+		Spec:       autoscalingv2.HorizontalPodAutoscalerSpec{MaxReplicas: 3},
+	}))
+	require.ErrorContains(t, kube.CreateOrUpdateRateLimitInfra(context.Background()), "allows up to 3 replicas")
+}
+
 func TestDeleteRateLimitInfra(t *testing.T) {
 	testCases := []struct {
 		name   string
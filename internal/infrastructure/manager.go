@@ -19,6 +19,7 @@ import (
 	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/message"
 )
 
 var (
@@ -41,12 +42,13 @@ type Manager interface {
 }
 
 // NewManager returns a new infrastructure Manager.
-func NewManager(ctx context.Context, cfg *config.Server, logger logging.Logger) (mgr Manager, err error) {
+// The statuses of the managed infrastructure are published to infraStatuses, if supported by the provider.
+func NewManager(ctx context.Context, cfg *config.Server, logger logging.Logger, infraStatuses *message.InfraStatuses) (mgr Manager, err error) {
 	switch cfg.EnvoyGateway.Provider.Type {
 	case egv1a1.ProviderTypeKubernetes:
 		mgr, err = newManagerForKubernetes(cfg)
 	case egv1a1.ProviderTypeCustom:
-		mgr, err = newManagerForCustom(ctx, cfg, logger)
+		mgr, err = newManagerForCustom(ctx, cfg, logger, infraStatuses)
 	}
 
 	if err != nil {
@@ -65,11 +67,11 @@ func newManagerForKubernetes(cfg *config.Server) (Manager, error) {
 	return kubernetes.NewInfra(cli, cfg), nil
 }
 
-func newManagerForCustom(ctx context.Context, cfg *config.Server, logger logging.Logger) (Manager, error) {
+func newManagerForCustom(ctx context.Context, cfg *config.Server, logger logging.Logger, infraStatuses *message.InfraStatuses) (Manager, error) {
 	infra := cfg.EnvoyGateway.Provider.Custom.Infrastructure
 	switch infra.Type {
 	case egv1a1.InfrastructureProviderTypeHost:
-		return host.NewInfra(ctx, cfg, logger)
+		return host.NewInfra(ctx, cfg, logger, infraStatuses)
 	default:
 		return nil, fmt.Errorf("unsupported provider type: %s", infra.Type)
 	}
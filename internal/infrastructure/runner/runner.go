@@ -20,7 +20,8 @@ import (
 
 type Config struct {
 	config.Server
-	InfraIR *message.InfraIR
+	InfraIR           *message.InfraIR
+	ProviderResources *message.ProviderResources
 }
 
 type Runner struct {
@@ -51,7 +52,11 @@ func (r *Runner) Start(ctx context.Context) (err error) {
 		return nil
 	}
 
-	r.mgr, err = infrastructure.NewManager(ctx, &r.Server, r.Logger)
+	var infraStatuses *message.InfraStatuses
+	if r.ProviderResources != nil {
+		infraStatuses = &r.ProviderResources.InfraStatuses
+	}
+	r.mgr, err = infrastructure.NewManager(ctx, &r.Server, r.Logger, infraStatuses)
 	if err != nil {
 		r.Logger.Error(err, "failed to create new manager")
 		return err
@@ -123,6 +123,29 @@ const (
 	UDPProtocolType ProtocolType = "UDP"
 )
 
+// ProxyState defines the state of the managed proxy infrastructure.
+type ProxyState string
+
+const (
+	// ProxyStateRunning indicates that the proxy is running.
+	ProxyStateRunning ProxyState = "Running"
+
+	// ProxyStateRestarting indicates that the proxy exited unexpectedly,
+	// and is waiting to be restarted.
+	ProxyStateRestarting ProxyState = "Restarting"
+)
+
+// ProxyStatus defines the observed status of the managed proxy infrastructure.
+// +k8s:deepcopy-gen=true
+type ProxyStatus struct {
+	// State is the current state of the proxy.
+	State ProxyState `json:"state" yaml:"state"`
+	// Restarts is the number of times the proxy has been restarted after exiting unexpectedly.
+	Restarts int32 `json:"restarts,omitempty" yaml:"restarts,omitempty"`
+	// Message is a human-readable message indicating details about the state.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
 // NewInfra returns a new Infra with default parameters.
 func NewInfra() *Infra {
 	return &Infra{
@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/netip"
+	"strings"
 	"time"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -80,6 +81,8 @@ var (
 	ErrBothNumTrustedHopsAndTrustedCIDRsInvalid = errors.New("only one of ClientIPDetection.XForwardedFor.NumTrustedHops and ClientIPDetection.XForwardedFor.TrustedCIDRs must be set")
 	ErrPanicThresholdInvalid                    = errors.New("PanicThreshold value is outside of 0-100 range")
 	ErrCredentialInjectionCredentialEmpty       = errors.New("field CredentialInjection.Credential must be specified")
+	ErrCredentialInjectionTokenEndpointEmpty    = errors.New("field CredentialInjection.OAuth2.TokenEndpoint must be specified")
+	ErrCredentialInjectionClientSecretEmpty     = errors.New("field CredentialInjection.OAuth2.ClientSecret must be specified")
 
 	redacted = []byte("[redacted]")
 )
@@ -596,6 +599,8 @@ type ResponseOverrideRule struct {
 type CustomResponseMatch struct {
 	// Status code to match on. The match evaluates to true if any of the matches are successful.
 	StatusCodes []StatusCodeMatch `json:"statusCodes"`
+	// Expression is the CEL expression to match on.
+	Expression *egv1a1.CELExpression `json:"expression,omitempty"`
 }
 
 // StatusCodeMatch defines the configuration for matching a status code.
@@ -655,16 +660,52 @@ type CredentialInjection struct {
 	Overwrite *bool `json:"overwrite,omitempty"`
 
 	// Credential is the credential to be injected.
-	Credential PrivateBytes `json:"credential"`
+	// It's empty when the credential is retrieved with OAuth2.
+	Credential PrivateBytes `json:"credential,omitempty"`
+
+	// OAuth2 defines the OAuth2 client credentials flow to retrieve the access token to be injected.
+	OAuth2 *OAuth2ClientCredentials `json:"oauth2,omitempty" yaml:"oauth2,omitempty"`
 }
 
 func (c *CredentialInjection) Validate() error {
+	if c.OAuth2 != nil {
+		if c.OAuth2.TokenEndpoint == "" {
+			return ErrCredentialInjectionTokenEndpointEmpty
+		}
+		if len(c.OAuth2.ClientSecret) == 0 {
+			return ErrCredentialInjectionClientSecretEmpty
+		}
+		return nil
+	}
 	if len(c.Credential) == 0 {
 		return ErrCredentialInjectionCredentialEmpty
 	}
 	return nil
 }
 
+// OAuth2ClientCredentials defines the configuration to retrieve an access token with the
+// OAuth2 client credentials flow.
+// +k8s:deepcopy-gen=true
+type OAuth2ClientCredentials struct {
+	// TokenEndpoint is the URL of the OAuth2 token endpoint.
+	TokenEndpoint string `json:"tokenEndpoint" yaml:"tokenEndpoint"`
+
+	// ClientID is the client ID used to authenticate to the token endpoint.
+	ClientID string `json:"clientID" yaml:"clientID"`
+
+	// ClientSecret is the client secret used to authenticate to the token endpoint.
+	ClientSecret PrivateBytes `json:"clientSecret,omitempty" yaml:"clientSecret,omitempty"`
+
+	// Scopes are the scopes requested for the access token.
+	Scopes []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+
+	// ClientSecretPost sends the client credentials in the request body instead of the Authorization header.
+	ClientSecretPost bool `json:"clientSecretPost,omitempty" yaml:"clientSecretPost,omitempty"`
+
+	// TokenFetchRetryInterval is the interval between retries when the access token can't be retrieved.
+	TokenFetchRetryInterval *metav1.Duration `json:"tokenFetchRetryInterval,omitempty" yaml:"tokenFetchRetryInterval,omitempty"`
+}
+
 // HealthCheckSettings provides HealthCheck configuration on the HTTP/HTTPS listener.
 // +k8s:deepcopy-gen=true
 type HealthCheckSettings egv1a1.HealthCheckSettings
@@ -811,7 +852,7 @@ func (h *HTTPRoute) GetRetry() *Retry {
 func (h *HTTPRoute) NeedsClusterPerSetting() bool {
 	if h.Traffic != nil &&
 		h.Traffic.LoadBalancer != nil &&
-		h.Traffic.LoadBalancer.PreferLocal != nil {
+		(h.Traffic.LoadBalancer.PreferLocal != nil || h.Traffic.LoadBalancer.LocalityWeighted != nil) {
 		return true
 	}
 	return h.Destination.NeedsClusterPerSetting()
@@ -901,6 +942,14 @@ type TrafficFeatures struct {
 	Telemetry *egv1a1.BackendTelemetry `json:"telemetry,omitempty" yaml:"telemetry,omitempty"`
 	// RequestBuffer defines the schema for enabling buffered requests
 	RequestBuffer *RequestBuffer `json:"requestBuffer,omitempty" yaml:"requestBuffer,omitempty"`
+	// CredentialInjection defines the credential injected into the requests forwarded to the backends.
+	// The CredentialInjection of the route, if any, takes precedence.
+	CredentialInjection *CredentialInjection `json:"credentialInjection,omitempty" yaml:"credentialInjection,omitempty"`
+	// AdaptiveConcurrency defines the adaptive concurrency limit of the backend.
+	AdaptiveConcurrency *AdaptiveConcurrency `json:"adaptiveConcurrency,omitempty" yaml:"adaptiveConcurrency,omitempty"`
+	// Failover defines the settings of the priority based failover between the backends.
+	// The priority levels themselves are set on the DestinationSettings.
+	Failover *Failover `json:"failover,omitempty" yaml:"failover,omitempty"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -929,6 +978,11 @@ func (b *TrafficFeatures) Validate() error {
 			errs = errors.Join(errs, err)
 		}
 	}
+	if b.CredentialInjection != nil {
+		if err := b.CredentialInjection.Validate(); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
 
 	return errs
 }
@@ -938,6 +992,8 @@ func (b *TrafficFeatures) Validate() error {
 type SecurityFeatures struct {
 	// CORS policy for the route.
 	CORS *CORS `json:"cors,omitempty" yaml:"cors,omitempty"`
+	// CSRF protection for the route.
+	CSRF *CSRF `json:"csrf,omitempty" yaml:"csrf,omitempty"`
 	// JWT defines the schema for authenticating HTTP requests using JSON Web Tokens (JWT).
 	JWT *JWT `json:"jwt,omitempty" yaml:"jwt,omitempty"`
 	// OIDC defines the schema for authenticating HTTP requests using OpenID Connect (OIDC).
@@ -950,6 +1006,8 @@ type SecurityFeatures struct {
 	ExtAuth *ExtAuth `json:"extAuth,omitempty" yaml:"extAuth,omitempty"`
 	// Authorization defines the schema for the authorization.
 	Authorization *Authorization `json:"authorization,omitempty" yaml:"authorization,omitempty"`
+	// PolicyAuth defines the schema for the authorization with a policy evaluated by Envoy.
+	PolicyAuth *PolicyAuth `json:"policyAuth,omitempty" yaml:"policyAuth,omitempty"`
 }
 
 // EnvoyExtensionFeatures holds the information associated with the Envoy Extension Policy.
@@ -1002,6 +1060,19 @@ type CORS struct {
 	AllowCredentials bool `json:"allowCredentials,omitempty" yaml:"allowCredentials,omitempty"`
 }
 
+// CSRF holds the Cross-Site Request Forgery (CSRF) protection for the route.
+//
+// +k8s:deepcopy-gen=true
+type CSRF struct {
+	// AdditionalOrigins defines the origins that are allowed in addition to the destination origin.
+	// The matches are applied to the host and port of the origin.
+	AdditionalOrigins []*StringMatch `json:"additionalOrigins,omitempty" yaml:"additionalOrigins,omitempty"`
+	// FilterEnabled defines the fraction of requests for which the CSRF protection is enforced.
+	FilterEnabled *gwapiv1.Fraction `json:"filterEnabled,omitempty" yaml:"filterEnabled,omitempty"`
+	// ShadowEnabled defines the fraction of requests for which the CSRF protection is evaluated but not enforced.
+	ShadowEnabled *gwapiv1.Fraction `json:"shadowEnabled,omitempty" yaml:"shadowEnabled,omitempty"`
+}
+
 // JWT defines the schema for authenticating HTTP requests using
 // JSON Web Tokens (JWT).
 //
@@ -1067,6 +1138,18 @@ type RemoteJWKS struct {
 	// URI is the HTTPS URI to fetch the JWKS. Envoy's system trust bundle is used to validate the server certificate.
 	// If a custom trust bundle is needed, it can be specified in a BackendTLSConfig resource and target the BackendRefs.
 	URI string `json:"uri"`
+
+	// CacheDuration is the duration for which the fetched JWKS is cached.
+	CacheDuration *metav1.Duration `json:"cacheDuration,omitempty"`
+
+	// Timeout is the timeout for the requests to fetch the JWKS.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// FastListener allows the listener to be activated before the initial JWKS fetch completes.
+	FastListener bool `json:"fastListener,omitempty"`
+
+	// FailedRefetchDuration is the interval at which the JWKS is fetched again after a failed fetch.
+	FailedRefetchDuration *metav1.Duration `json:"failedRefetchDuration,omitempty"`
 }
 
 // OIDC defines the schema for authenticating HTTP requests using
@@ -1152,6 +1235,69 @@ type OIDC struct {
 	// Automatic access token refresh will be performed for these requests, if enabled.
 	// This behavior can be useful for AJAX requests.
 	DenyRedirect *egv1a1.OIDCDenyRedirect `json:"denyRedirect,omitempty"`
+
+	// IDTokenClaims defines how the ID token set by the oauth filter is validated
+	// and which of its claims are extracted into request headers.
+	IDTokenClaims *OIDCIDTokenClaims `json:"idTokenClaims,omitempty"`
+
+	// Session defines the checks of the sessions performed by Envoy Gateway.
+	Session *OIDCSession `json:"session,omitempty"`
+}
+
+// AccessTokenCookieName returns the name of the cookie the oauth filter stores the access token in.
+func (o *OIDC) AccessTokenCookieName() string {
+	if o.CookieNameOverrides != nil && o.CookieNameOverrides.AccessToken != nil {
+		return *o.CookieNameOverrides.AccessToken
+	}
+	return fmt.Sprintf("AccessToken-%s", o.CookieSuffix)
+}
+
+// IDTokenCookieName returns the name of the cookie the oauth filter stores the ID token in.
+func (o *OIDC) IDTokenCookieName() string {
+	if o.CookieNameOverrides != nil && o.CookieNameOverrides.IDToken != nil {
+		return *o.CookieNameOverrides.IDToken
+	}
+	return fmt.Sprintf("IdToken-%s", o.CookieSuffix)
+}
+
+// OIDCSession defines the checks of the OIDC sessions that Envoy Gateway performs for each
+// request, which Envoy sends to it with the ext_authz filter.
+//
+// +k8s:deepcopy-gen=true
+type OIDCSession struct {
+	// Issuer is the OIDC Provider's issuer identifier, used to validate the iss claim of the logout tokens.
+	Issuer string `json:"issuer"`
+
+	// BackchannelLogoutPath is the path the OIDC Provider sends the back-channel logout requests to.
+	BackchannelLogoutPath string `json:"backchannelLogoutPath,omitempty"`
+
+	// JWKSURI is the URI of the OIDC Provider's JSON Web Key Set, used to validate the logout tokens.
+	JWKSURI string `json:"jwksURI,omitempty"`
+
+	// IntrospectionEndpoint is the OIDC Provider's token introspection endpoint.
+	// If set, the access tokens of the sessions are introspected.
+	IntrospectionEndpoint string `json:"introspectionEndpoint,omitempty"`
+
+	// UserInfoEndpoint is the OIDC Provider's UserInfo endpoint.
+	UserInfoEndpoint string `json:"userInfoEndpoint,omitempty"`
+
+	// UserInfoToHeaders is a list of claims of the UserInfo response that must be extracted into HTTP request headers.
+	UserInfoToHeaders []egv1a1.ClaimToHeader `json:"userInfoToHeaders,omitempty"`
+}
+
+// OIDCIDTokenClaims defines the schema for extracting the claims of the ID token
+// issued by the OIDC Provider into HTTP request headers.
+//
+// +k8s:deepcopy-gen=true
+type OIDCIDTokenClaims struct {
+	// Issuer is the OIDC Provider's issuer identifier, used to validate the iss claim of the ID token.
+	Issuer string `json:"issuer"`
+
+	// RemoteJWKS defines how to fetch the JWKS used to validate the ID token.
+	RemoteJWKS RemoteJWKS `json:"remoteJWKS"`
+
+	// ClaimToHeaders is a list of ID token claims that must be extracted into HTTP request headers.
+	ClaimToHeaders []egv1a1.ClaimToHeader `json:"claimToHeaders"`
 }
 
 // OIDCProvider defines the schema for the OIDC Provider.
@@ -1185,6 +1331,9 @@ type BasicAuth struct {
 	// The username-password pairs in htpasswd format.
 	Users PrivateBytes `json:"users,omitempty" yaml:"users,omitempty"`
 
+	// LDAP is the LDAP directory used to verify the user credentials, instead of the htpasswd users.
+	LDAP *LDAPUserStore `json:"ldap,omitempty" yaml:"ldap,omitempty"`
+
 	// This field specifies the header name to forward a successfully authenticated user to
 	// the backend. The header will be added to the request with the username as the value.
 	//
@@ -1192,14 +1341,63 @@ type BasicAuth struct {
 	ForwardUsernameHeader *string `json:"forwardUsernameHeader,omitempty" yaml:"forwardUsernameHeader,omitempty"`
 }
 
+// VerifiedByEnvoyGateway returns true if the credentials are verified by Envoy Gateway instead of
+// the basic_auth filter of Envoy, which only supports the SHA hash algorithm.
+func (b *BasicAuth) VerifiedByEnvoyGateway() bool {
+	if b.LDAP != nil {
+		return true
+	}
+	for _, line := range strings.Split(string(b.Users), "\n") {
+		_, password, found := strings.Cut(strings.TrimSpace(line), ":")
+		if found && !strings.HasPrefix(password, "{SHA}") {
+			return true
+		}
+	}
+	return false
+}
+
+// LDAPUserStore defines an LDAP directory used to verify the user credentials.
+//
+// +k8s:deepcopy-gen=true
+type LDAPUserStore struct {
+	// URL is the URL of the LDAP server.
+	URL string `json:"url" yaml:"url"`
+	// StartTLS upgrades the connection to an ldap:// server to TLS.
+	StartTLS bool `json:"startTLS,omitempty" yaml:"startTLS,omitempty"`
+	// CACertificate is the PEM encoded CA certificates used to verify the certificate of the LDAP
+	// server, instead of the trusted CA certificates of Envoy Gateway.
+	CACertificate []byte `json:"caCertificate,omitempty" yaml:"caCertificate,omitempty"`
+	// BindDN is the distinguished name used to search for the users.
+	BindDN string `json:"bindDN" yaml:"bindDN"`
+	// BindPassword is the password of the bind DN.
+	BindPassword PrivateBytes `json:"bindPassword,omitempty" yaml:"bindPassword,omitempty"`
+	// BaseDN is the distinguished name the users are searched under.
+	BaseDN string `json:"baseDN" yaml:"baseDN"`
+	// SearchFilter is the LDAP filter used to search for the user, where "%s" is replaced by the
+	// escaped username.
+	SearchFilter string `json:"searchFilter" yaml:"searchFilter"`
+}
+
 // APIKeyAuth defines the schema for the API Key Authentication.
 //
 // +k8s:deepcopy-gen=true
 type APIKeyAuth struct {
+	// Name is a unique name for an APIKeyAuth configuration.
+	// The xds translator only generates one filter verified by Envoy Gateway for each unique name.
+	Name string `json:"name" yaml:"name"`
+
 	// The API key to be used for authentication.
 	// Key is the client id and the value is the API key to be used for authentication.
 	Credentials map[string]PrivateBytes `json:"credentials,omitempty" yaml:"credentials,omitempty"`
 
+	// HashedCredentials holds the hashed API keys by client id, which are verified by Envoy Gateway.
+	// The SHA, bcrypt and argon2 hash algorithms are supported.
+	HashedCredentials map[string]PrivateBytes `json:"hashedCredentials,omitempty" yaml:"hashedCredentials,omitempty"`
+
+	// Metadata holds the metadata of the API keys by client id. The API keys with metadata are
+	// verified by Envoy Gateway, which returns the metadata as dynamic metadata.
+	Metadata map[string]*APIKeyMetadata `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
 	// ExtractFrom is where to fetch the key from the coming request.
 	// The value from the first source that has a key will be used.
 	ExtractFrom []*ExtractFrom `json:"extractFrom"`
@@ -1216,6 +1414,24 @@ type APIKeyAuth struct {
 	Sanitize *bool `json:"sanitize,omitempty"`
 }
 
+// VerifiedByEnvoyGateway returns true if the API keys are verified by Envoy Gateway instead of
+// the api_key_auth filter of Envoy, which only supports plain text keys without metadata.
+func (a *APIKeyAuth) VerifiedByEnvoyGateway() bool {
+	return len(a.HashedCredentials) > 0 || len(a.Metadata) > 0
+}
+
+// APIKeyMetadata defines the metadata of an API key.
+//
+// +k8s:deepcopy-gen=true
+type APIKeyMetadata struct {
+	// Tenant is the tenant of the client of the API key.
+	Tenant string `json:"tenant,omitempty" yaml:"tenant,omitempty"`
+	// Plan is the plan of the client of the API key.
+	Plan string `json:"plan,omitempty" yaml:"plan,omitempty"`
+	// ExpiresAt is the time the API key is no longer accepted at.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty" yaml:"expiresAt,omitempty"`
+}
+
 // ExtractFrom defines the source of the key.
 //
 // +k8s:deepcopy-gen=true
@@ -1371,6 +1587,17 @@ type AuthorizationRule struct {
 	Principal Principal `json:"principal"`
 }
 
+// PolicyAuth defines the schema for the authorization with a policy evaluated by Envoy.
+//
+// +k8s:deepcopy-gen=true
+type PolicyAuth struct {
+	// Name is a unique name for the policy.
+	Name string `json:"name"`
+	// CEL is the CEL expression of the policy.
+	// The request is allowed if the expression evaluates to true.
+	CEL string `json:"cel"`
+}
+
 // Principal defines the schema for the principal.
 //
 // +k8s:deepcopy-gen=true
@@ -1381,6 +1608,10 @@ type Principal struct {
 	JWT *egv1a1.JWTPrincipal `json:"jwt,omitempty"`
 	// Headers defines the headers to be matched.
 	Headers []egv1a1.AuthorizationHeaderMatch `json:"headers,omitempty"`
+	// ClientCertificate defines the client certificate principal to be matched.
+	ClientCertificate *egv1a1.ClientCertificatePrincipal `json:"clientCertificate,omitempty"`
+	// Expression defines the CEL expression to be matched.
+	Expression *egv1a1.CELExpression `json:"expression,omitempty"`
 }
 
 // FaultInjection defines the schema for injecting faults into requests.
@@ -1708,6 +1939,10 @@ type DestinationEndpoint struct {
 	Draining bool `json:"draining,omitempty" yaml:"draining,omitempty"`
 	// Zone refers to the topology zone the Endpoint resides in
 	Zone *string `json:"zone,omitempty" yaml:"zone,omitempty"`
+	// Region refers to the topology region the Endpoint resides in
+	Region *string `json:"region,omitempty" yaml:"region,omitempty"`
+	// SubZone refers to the topology sub-zone the Endpoint resides in
+	SubZone *string `json:"subZone,omitempty" yaml:"subZone,omitempty"`
 }
 
 // Validate the fields within the DestinationEndpoint structure
@@ -2208,6 +2443,9 @@ type LocalRateLimit struct {
 
 	// Rules for rate limiting.
 	Rules []*RateLimitRule `json:"rules,omitempty" yaml:"rules,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+
+	// ShadowMode evaluates the rate limits without rejecting the requests exceeding them.
+	ShadowMode bool `json:"shadowMode,omitempty" yaml:"shadowMode,omitempty"`
 }
 
 // RateLimitRule holds the match and limit configuration for ratelimiting.
@@ -2215,6 +2453,12 @@ type LocalRateLimit struct {
 type RateLimitRule struct {
 	// HeaderMatches define the match conditions on the request headers for this route.
 	HeaderMatches []*StringMatch `json:"headerMatches" yaml:"headerMatches"`
+	// QueryParamMatches define the match conditions on the request query parameters for this route.
+	QueryParamMatches []*StringMatch `json:"queryParamMatches,omitempty" yaml:"queryParamMatches,omitempty"`
+	// MetadataMatches define the match conditions on the dynamic metadata of the request for this route.
+	MetadataMatches []*MetadataMatch `json:"metadataMatches,omitempty" yaml:"metadataMatches,omitempty"`
+	// ExpressionMatches define the CEL expressions which must evaluate to true for this route.
+	ExpressionMatches []egv1a1.CELExpression `json:"expressionMatches,omitempty" yaml:"expressionMatches,omitempty"`
 	// CIDRMatch define the match conditions on the source IP's CIDR for this route.
 	CIDRMatch *CIDRMatch `json:"cidrMatch,omitempty" yaml:"cidrMatch,omitempty"`
 	// Limit holds the rate limit values.
@@ -2232,6 +2476,9 @@ type RateLimitRule struct {
 	Shared *bool `json:"shared,omitempty" yaml:"shared,omitempty"`
 	// Name is a unique identifier for this rule, set as <policy-ns>/<policy-name>/rule/<rule-index>.
 	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// ShadowMode evaluates the limit without rejecting the requests exceeding it.
+	// Only used by global rate limits.
+	ShadowMode bool `json:"shadowMode,omitempty" yaml:"shadowMode,omitempty"`
 }
 
 // RateLimitCost specifies the cost of the request or response.
@@ -2239,6 +2486,17 @@ type RateLimitRule struct {
 type RateLimitCost struct {
 	Number *uint64 `json:"number,omitempty" yaml:"number,omitempty"`
 	Format *string `json:"format,omitempty" yaml:"format,omitempty"`
+	// Header is the response header to read the cost from.
+	Header *RateLimitCostHeader `json:"header,omitempty" yaml:"header,omitempty"`
+}
+
+// RateLimitCostHeader specifies the response header to read the rate limit cost from.
+// +k8s:deepcopy-gen=true
+type RateLimitCostHeader struct {
+	// Name is the name of the response header.
+	Name string `json:"name" yaml:"name"`
+	// Max is the maximum cost, the greater values of the header are capped to it.
+	Max uint64 `json:"max" yaml:"max"`
 }
 
 type CIDRMatch struct {
@@ -2251,9 +2509,22 @@ type CIDRMatch struct {
 	Distinct bool `json:"distinct" yaml:"distinct"`
 }
 
+// MetadataMatch defines the match condition on a string value of the dynamic metadata of the request.
+// +k8s:deepcopy-gen=true
+type MetadataMatch struct {
+	// Namespace is the filter namespace of the dynamic metadata.
+	Namespace string `json:"namespace" yaml:"namespace"`
+	// Path is the path of the keys to the value within the namespace.
+	Path []string `json:"path" yaml:"path"`
+	// Exact match condition.
+	// If not set, each distinct value of the metadata is matched separately.
+	Exact *string `json:"exact,omitempty" yaml:"exact,omitempty"`
+}
+
 // TODO zhaohuabing: remove this function
 func (r *RateLimitRule) IsMatchSet() bool {
-	return len(r.HeaderMatches) != 0 || r.CIDRMatch != nil
+	return len(r.HeaderMatches) != 0 || len(r.QueryParamMatches) != 0 || len(r.MetadataMatches) != 0 ||
+		len(r.ExpressionMatches) != 0 || r.CIDRMatch != nil
 }
 
 type RateLimitUnit egv1a1.RateLimitUnit
@@ -2516,6 +2787,8 @@ type LoadBalancer struct {
 	ConsistentHash *ConsistentHash `json:"consistentHash,omitempty" yaml:"consistentHash,omitempty"`
 	// PreferLocal defines the configuration related to the distribution of requests between locality zones.
 	PreferLocal *PreferLocalZone `json:"preferLocal,omitempty" yaml:"preferLocal,omitempty"`
+	// LocalityWeighted defines the distribution of requests between the localities of the endpoints.
+	LocalityWeighted *LocalityWeighted `json:"localityWeighted,omitempty" yaml:"localityWeighted,omitempty"`
 	// EndpointOverride defines the configuration for endpoint override.
 	// When specified, the load balancer will attempt to route requests to endpoints
 	// based on the override information extracted from request headers or metadata.
@@ -2622,10 +2895,23 @@ type CircuitBreaker struct {
 	// The maximum number of parallel retries that Envoy will make.
 	MaxParallelRetries *uint32 `json:"maxParallelRetries,omitempty" yaml:"maxParallelRetries,omitempty"`
 
+	// RetryBudget limits the parallel retries to a percentage of the active requests.
+	// It takes precedence over MaxParallelRetries.
+	RetryBudget *RetryBudget `json:"retryBudget,omitempty" yaml:"retryBudget,omitempty"`
+
 	// PerEndpoint defines per-endpoint Circuit Breakers
 	PerEndpoint *PerEndpointCircuitBreakers `json:"perEndpoint,omitempty"`
 }
 
+// RetryBudget defines the maximum number of parallel retries as a percentage of the active requests.
+// +k8s:deepcopy-gen=true
+type RetryBudget struct {
+	// Percent is the percentage of the active requests that can be retries at the same time.
+	Percent *uint32 `json:"percent,omitempty" yaml:"percent,omitempty"`
+	// MinRetryConcurrency is the minimum number of parallel retries that are always allowed.
+	MinRetryConcurrency *uint32 `json:"minRetryConcurrency,omitempty" yaml:"minRetryConcurrency,omitempty"`
+}
+
 // PerEndpointCircuitBreakers defines the per-endpoint Circuit Breaker configuration.
 // +k8s:deepcopy-gen=true
 type PerEndpointCircuitBreakers struct {
@@ -2660,6 +2946,40 @@ type OutlierDetection struct {
 	BaseEjectionTime *metav1.Duration `json:"baseEjectionTime,omitempty" yaml:"baseEjectionTime,omitempty"`
 	// MaxEjectionPercent sets the maximum percentage of hosts in a cluster that can be ejected.
 	MaxEjectionPercent *int32 `json:"maxEjectionPercent,omitempty" yaml:"maxEjectionPercent,omitempty"`
+	// MaxEjectionTime defines the maximum duration for which a host can be ejected.
+	MaxEjectionTime *metav1.Duration `json:"maxEjectionTime,omitempty" yaml:"maxEjectionTime,omitempty"`
+	// MaxEjectionTimeJitter defines a random duration added to the ejection time of a host.
+	MaxEjectionTimeJitter *metav1.Duration `json:"maxEjectionTimeJitter,omitempty" yaml:"maxEjectionTimeJitter,omitempty"`
+	// SuccessRate defines the success rate outlier detection settings.
+	SuccessRate *OutlierDetectionSuccessRate `json:"successRate,omitempty" yaml:"successRate,omitempty"`
+	// FailurePercentage defines the failure percentage outlier detection settings.
+	FailurePercentage *OutlierDetectionFailurePercentage `json:"failurePercentage,omitempty" yaml:"failurePercentage,omitempty"`
+}
+
+// OutlierDetectionSuccessRate defines the success rate outlier detection settings
+// +k8s:deepcopy-gen=true
+type OutlierDetectionSuccessRate struct {
+	// MinimumHosts is the minimum number of hosts required to perform the detection.
+	MinimumHosts *uint32 `json:"minimumHosts,omitempty" yaml:"minimumHosts,omitempty"`
+	// RequestVolume is the minimum number of requests of a host to be included in the detection.
+	RequestVolume *uint32 `json:"requestVolume,omitempty" yaml:"requestVolume,omitempty"`
+	// StdevFactor is the factor, multiplied by 1000, applied to the standard deviation of the success rates.
+	StdevFactor *uint32 `json:"stdevFactor,omitempty" yaml:"stdevFactor,omitempty"`
+	// EnforcingPercent is the probability that a detected outlier is ejected.
+	EnforcingPercent *uint32 `json:"enforcingPercent,omitempty" yaml:"enforcingPercent,omitempty"`
+}
+
+// OutlierDetectionFailurePercentage defines the failure percentage outlier detection settings
+// +k8s:deepcopy-gen=true
+type OutlierDetectionFailurePercentage struct {
+	// Threshold is the failure percentage of a host above which it is ejected.
+	Threshold *uint32 `json:"threshold,omitempty" yaml:"threshold,omitempty"`
+	// MinimumHosts is the minimum number of hosts required to perform the detection.
+	MinimumHosts *uint32 `json:"minimumHosts,omitempty" yaml:"minimumHosts,omitempty"`
+	// RequestVolume is the minimum number of requests of a host to be included in the detection.
+	RequestVolume *uint32 `json:"requestVolume,omitempty" yaml:"requestVolume,omitempty"`
+	// EnforcingPercent is the probability that a detected outlier is ejected.
+	EnforcingPercent *uint32 `json:"enforcingPercent,omitempty" yaml:"enforcingPercent,omitempty"`
 }
 
 // ActiveHealthCheck defines active health check settings
@@ -2958,6 +3278,8 @@ type PerRetryPolicy struct {
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
 	// Backoff is the backoff policy to be applied per retry attempt.
 	BackOff *BackOffPolicy `json:"backOff,omitempty"`
+	// HedgeOnTimeout enables request hedging when the timeout of an attempt is reached.
+	HedgeOnTimeout bool `json:"hedgeOnTimeout,omitempty"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -2966,6 +3288,27 @@ type BackOffPolicy struct {
 	BaseInterval *metav1.Duration `json:"baseInterval,omitempty"`
 	// MaxInterval is the maximum interval between retries.
 	MaxInterval *metav1.Duration `json:"maxInterval,omitempty"`
+	// RateLimited is the backoff policy driven by the rate limit headers returned by the backend.
+	RateLimited *RateLimitedBackOff `json:"rateLimited,omitempty"`
+}
+
+// RateLimitedBackOff defines a retry backoff policy driven by the reset headers returned
+// by a rate limited backend.
+// +k8s:deepcopy-gen=true
+type RateLimitedBackOff struct {
+	// ResetHeaders are the headers that specify when the rate limit of the backend is reset.
+	ResetHeaders []RetryResetHeader `json:"resetHeaders,omitempty"`
+	// MaxInterval is the maximum interval between retries when the interval is taken from a reset header.
+	MaxInterval *metav1.Duration `json:"maxInterval,omitempty"`
+}
+
+// RetryResetHeader defines a response header that specifies when the rate limit of the backend is reset.
+// +k8s:deepcopy-gen=true
+type RetryResetHeader struct {
+	// Name is the name of the header.
+	Name string `json:"name"`
+	// Format is the format of the header value.
+	Format egv1a1.RetryResetHeaderFormat `json:"format"`
 }
 
 // TLSUpstreamConfig contains sni and ca file in []byte format.
@@ -3201,6 +3544,40 @@ type RequestBuffer struct {
 	Limit resource.Quantity `json:"limit" yaml:"limit"`
 }
 
+// Failover holds the settings of the priority based failover between the backends.
+// +k8s:deepcopy-gen=true
+type Failover struct {
+	// OverprovisioningFactor is the percentage applied to the ratio of healthy hosts of a priority level.
+	OverprovisioningFactor *uint32 `json:"overprovisioningFactor,omitempty" yaml:"overprovisioningFactor,omitempty"`
+	// WeightedPriorityHealth computes the health of a priority level from the load balancing weight of its healthy hosts.
+	WeightedPriorityHealth bool `json:"weightedPriorityHealth,omitempty" yaml:"weightedPriorityHealth,omitempty"`
+}
+
+// AdaptiveConcurrency holds the information for the adaptive concurrency filter.
+// +k8s:deepcopy-gen=true
+type AdaptiveConcurrency struct {
+	// SampleAggregatePercentile is the percentile of the sampled latencies compared with the minRTT.
+	SampleAggregatePercentile *uint32 `json:"sampleAggregatePercentile,omitempty" yaml:"sampleAggregatePercentile,omitempty"`
+	// MaxConcurrencyLimit is the upper bound of the concurrency limit.
+	MaxConcurrencyLimit *uint32 `json:"maxConcurrencyLimit,omitempty" yaml:"maxConcurrencyLimit,omitempty"`
+	// ConcurrencyUpdateInterval is the interval between two updates of the concurrency limit.
+	ConcurrencyUpdateInterval *metav1.Duration `json:"concurrencyUpdateInterval,omitempty" yaml:"concurrencyUpdateInterval,omitempty"`
+	// MinRTTInterval is the time between two minRTT measurements.
+	MinRTTInterval *metav1.Duration `json:"minRTTInterval,omitempty" yaml:"minRTTInterval,omitempty"`
+	// MinRTTFixedValue is a fixed minRTT used instead of measuring it.
+	MinRTTFixedValue *metav1.Duration `json:"minRTTFixedValue,omitempty" yaml:"minRTTFixedValue,omitempty"`
+	// MinRTTRequestCount is the number of requests sampled to measure the minRTT.
+	MinRTTRequestCount *uint32 `json:"minRTTRequestCount,omitempty" yaml:"minRTTRequestCount,omitempty"`
+	// MinRTTJitter is the percentage of the interval added as a random delay between two minRTT measurements.
+	MinRTTJitter *uint32 `json:"minRTTJitter,omitempty" yaml:"minRTTJitter,omitempty"`
+	// MinConcurrency is the concurrency limit applied while the minRTT is measured.
+	MinConcurrency *uint32 `json:"minConcurrency,omitempty" yaml:"minConcurrency,omitempty"`
+	// MinRTTBuffer is the percentage of the minRTT added as a tolerance to the sampled latencies.
+	MinRTTBuffer *uint32 `json:"minRTTBuffer,omitempty" yaml:"minRTTBuffer,omitempty"`
+	// ConcurrencyLimitExceededStatus is the status code returned when the concurrency limit is reached.
+	ConcurrencyLimitExceededStatus *uint32 `json:"concurrencyLimitExceededStatus,omitempty" yaml:"concurrencyLimitExceededStatus,omitempty"`
+}
+
 // PreferLocalZone configures zone-aware routing to prefer sending traffic to the local locality zone.
 // +k8s:deepcopy-gen=true
 type PreferLocalZone struct {
@@ -3220,6 +3597,30 @@ type ForceLocalZone struct {
 	MinEndpointsInZoneThreshold *uint32 `json:"minEndpointsInZoneThreshold,omitempty" yaml:"minEndpointsInZoneThreshold,omitempty"`
 }
 
+// LocalityWeighted defines the distribution of requests between the localities of the endpoints.
+// +k8s:deepcopy-gen=true
+type LocalityWeighted struct {
+	// Weights defines the weights of the localities, the first matching entry applies.
+	Weights []LocalityWeight `json:"weights,omitempty" yaml:"weights,omitempty"`
+	// Origin is the locality of the gateway, used to order the localities into priority levels.
+	Origin *Locality `json:"origin,omitempty" yaml:"origin,omitempty"`
+}
+
+// Locality identifies a locality of the endpoints, an empty field matches any value.
+// +k8s:deepcopy-gen=true
+type Locality struct {
+	Region  string `json:"region,omitempty" yaml:"region,omitempty"`
+	Zone    string `json:"zone,omitempty" yaml:"zone,omitempty"`
+	SubZone string `json:"subZone,omitempty" yaml:"subZone,omitempty"`
+}
+
+// LocalityWeight defines the weight of the localities matching a Locality.
+// +k8s:deepcopy-gen=true
+type LocalityWeight struct {
+	Locality `json:",inline" yaml:",inline"`
+	Weight   uint32 `json:"weight" yaml:"weight"`
+}
+
 // EndpointOverride defines the configuration for endpoint override.
 // +k8s:deepcopy-gen=true
 type EndpointOverride struct {
@@ -114,6 +114,38 @@ func (in *APIKeyAuth) DeepCopyInto(out *APIKeyAuth) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.HashedCredentials != nil {
+		in, out := &in.HashedCredentials, &out.HashedCredentials
+		*out = make(map[string]PrivateBytes, len(*in))
+		for key, val := range *in {
+			var outVal []byte
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(PrivateBytes, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]*APIKeyMetadata, len(*in))
+		for key, val := range *in {
+			var outVal *APIKeyMetadata
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(APIKeyMetadata)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
 	if in.ExtractFrom != nil {
 		in, out := &in.ExtractFrom, &out.ExtractFrom
 		*out = make([]*ExtractFrom, len(*in))
@@ -147,6 +179,25 @@ func (in *APIKeyAuth) DeepCopy() *APIKeyAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIKeyMetadata) DeepCopyInto(out *APIKeyMetadata) {
+	*out = *in
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyMetadata.
+func (in *APIKeyMetadata) DeepCopy() *APIKeyMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(APIKeyMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessLog) DeepCopyInto(out *AccessLog) {
 	*out = *in
@@ -261,6 +312,71 @@ func (in *ActiveHealthCheck) DeepCopy() *ActiveHealthCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdaptiveConcurrency) DeepCopyInto(out *AdaptiveConcurrency) {
+	*out = *in
+	if in.SampleAggregatePercentile != nil {
+		in, out := &in.SampleAggregatePercentile, &out.SampleAggregatePercentile
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MaxConcurrencyLimit != nil {
+		in, out := &in.MaxConcurrencyLimit, &out.MaxConcurrencyLimit
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.ConcurrencyUpdateInterval != nil {
+		in, out := &in.ConcurrencyUpdateInterval, &out.ConcurrencyUpdateInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MinRTTInterval != nil {
+		in, out := &in.MinRTTInterval, &out.MinRTTInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MinRTTFixedValue != nil {
+		in, out := &in.MinRTTFixedValue, &out.MinRTTFixedValue
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MinRTTRequestCount != nil {
+		in, out := &in.MinRTTRequestCount, &out.MinRTTRequestCount
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MinRTTJitter != nil {
+		in, out := &in.MinRTTJitter, &out.MinRTTJitter
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MinConcurrency != nil {
+		in, out := &in.MinConcurrency, &out.MinConcurrency
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MinRTTBuffer != nil {
+		in, out := &in.MinRTTBuffer, &out.MinRTTBuffer
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.ConcurrencyLimitExceededStatus != nil {
+		in, out := &in.ConcurrencyLimitExceededStatus, &out.ConcurrencyLimitExceededStatus
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdaptiveConcurrency.
+func (in *AdaptiveConcurrency) DeepCopy() *AdaptiveConcurrency {
+	if in == nil {
+		return nil
+	}
+	out := new(AdaptiveConcurrency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddHeader) DeepCopyInto(out *AddHeader) {
 	*out = *in
@@ -341,6 +457,11 @@ func (in *BackOffPolicy) DeepCopyInto(out *BackOffPolicy) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.RateLimited != nil {
+		in, out := &in.RateLimited, &out.RateLimited
+		*out = new(RateLimitedBackOff)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackOffPolicy.
@@ -381,6 +502,11 @@ func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
 		*out = make(PrivateBytes, len(*in))
 		copy(*out, *in)
 	}
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(LDAPUserStore)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ForwardUsernameHeader != nil {
 		in, out := &in.ForwardUsernameHeader, &out.ForwardUsernameHeader
 		*out = new(string)
@@ -459,6 +585,42 @@ func (in *CORS) DeepCopy() *CORS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSRF) DeepCopyInto(out *CSRF) {
+	*out = *in
+	if in.AdditionalOrigins != nil {
+		in, out := &in.AdditionalOrigins, &out.AdditionalOrigins
+		*out = make([]*StringMatch, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(StringMatch)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.FilterEnabled != nil {
+		in, out := &in.FilterEnabled, &out.FilterEnabled
+		*out = new(apisv1.Fraction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ShadowEnabled != nil {
+		in, out := &in.ShadowEnabled, &out.ShadowEnabled
+		*out = new(apisv1.Fraction)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSRF.
+func (in *CSRF) DeepCopy() *CSRF {
+	if in == nil {
+		return nil
+	}
+	out := new(CSRF)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CircuitBreaker) DeepCopyInto(out *CircuitBreaker) {
 	*out = *in
@@ -487,6 +649,11 @@ func (in *CircuitBreaker) DeepCopyInto(out *CircuitBreaker) {
 		*out = new(uint32)
 		**out = **in
 	}
+	if in.RetryBudget != nil {
+		in, out := &in.RetryBudget, &out.RetryBudget
+		*out = new(RetryBudget)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PerEndpoint != nil {
 		in, out := &in.PerEndpoint, &out.PerEndpoint
 		*out = new(PerEndpointCircuitBreakers)
@@ -748,6 +915,11 @@ func (in *CredentialInjection) DeepCopyInto(out *CredentialInjection) {
 		*out = make(PrivateBytes, len(*in))
 		copy(*out, *in)
 	}
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(OAuth2ClientCredentials)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialInjection.
@@ -800,6 +972,11 @@ func (in *CustomResponseMatch) DeepCopyInto(out *CustomResponseMatch) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Expression != nil {
+		in, out := &in.Expression, &out.Expression
+		*out = new(v1alpha1.CELExpression)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomResponseMatch.
@@ -860,6 +1037,16 @@ func (in *DestinationEndpoint) DeepCopyInto(out *DestinationEndpoint) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(string)
+		**out = **in
+	}
+	if in.SubZone != nil {
+		in, out := &in.SubZone, &out.SubZone
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DestinationEndpoint.
@@ -1274,6 +1461,26 @@ func (in *ExtractFrom) DeepCopy() *ExtractFrom {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Failover) DeepCopyInto(out *Failover) {
+	*out = *in
+	if in.OverprovisioningFactor != nil {
+		in, out := &in.OverprovisioningFactor, &out.OverprovisioningFactor
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Failover.
+func (in *Failover) DeepCopy() *Failover {
+	if in == nil {
+		return nil
+	}
+	out := new(Failover)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FaultInjection) DeepCopyInto(out *FaultInjection) {
 	*out = *in
@@ -2309,6 +2516,31 @@ func (in *JWTProvider) DeepCopy() *JWTProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAPUserStore) DeepCopyInto(out *LDAPUserStore) {
+	*out = *in
+	if in.CACertificate != nil {
+		in, out := &in.CACertificate, &out.CACertificate
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.BindPassword != nil {
+		in, out := &in.BindPassword, &out.BindPassword
+		*out = make(PrivateBytes, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPUserStore.
+func (in *LDAPUserStore) DeepCopy() *LDAPUserStore {
+	if in == nil {
+		return nil
+	}
+	out := new(LDAPUserStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LeastRequest) DeepCopyInto(out *LeastRequest) {
 	*out = *in
@@ -2372,6 +2604,11 @@ func (in *LoadBalancer) DeepCopyInto(out *LoadBalancer) {
 		*out = new(PreferLocalZone)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LocalityWeighted != nil {
+		in, out := &in.LocalityWeighted, &out.LocalityWeighted
+		*out = new(LocalityWeighted)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.EndpointOverride != nil {
 		in, out := &in.EndpointOverride, &out.EndpointOverride
 		*out = new(EndpointOverride)
@@ -2416,6 +2653,62 @@ func (in *LocalRateLimit) DeepCopy() *LocalRateLimit {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Locality) DeepCopyInto(out *Locality) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Locality.
+func (in *Locality) DeepCopy() *Locality {
+	if in == nil {
+		return nil
+	}
+	out := new(Locality)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalityWeight) DeepCopyInto(out *LocalityWeight) {
+	*out = *in
+	out.Locality = in.Locality
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalityWeight.
+func (in *LocalityWeight) DeepCopy() *LocalityWeight {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalityWeight)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalityWeighted) DeepCopyInto(out *LocalityWeighted) {
+	*out = *in
+	if in.Weights != nil {
+		in, out := &in.Weights, &out.Weights
+		*out = make([]LocalityWeight, len(*in))
+		copy(*out, *in)
+	}
+	if in.Origin != nil {
+		in, out := &in.Origin, &out.Origin
+		*out = new(Locality)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalityWeighted.
+func (in *LocalityWeighted) DeepCopy() *LocalityWeighted {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalityWeighted)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Lua) DeepCopyInto(out *Lua) {
 	*out = *in
@@ -2436,6 +2729,31 @@ func (in *Lua) DeepCopy() *Lua {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataMatch) DeepCopyInto(out *MetadataMatch) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exact != nil {
+		in, out := &in.Exact, &out.Exact
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataMatch.
+func (in *MetadataMatch) DeepCopy() *MetadataMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Metrics) DeepCopyInto(out *Metrics) {
 	*out = *in
@@ -2476,6 +2794,36 @@ func (in *MirrorPolicy) DeepCopy() *MirrorPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2ClientCredentials) DeepCopyInto(out *OAuth2ClientCredentials) {
+	*out = *in
+	if in.ClientSecret != nil {
+		in, out := &in.ClientSecret, &out.ClientSecret
+		*out = make(PrivateBytes, len(*in))
+		copy(*out, *in)
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TokenFetchRetryInterval != nil {
+		in, out := &in.TokenFetchRetryInterval, &out.TokenFetchRetryInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2ClientCredentials.
+func (in *OAuth2ClientCredentials) DeepCopy() *OAuth2ClientCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2ClientCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OIDC) DeepCopyInto(out *OIDC) {
 	*out = *in
@@ -2530,6 +2878,16 @@ func (in *OIDC) DeepCopyInto(out *OIDC) {
 		*out = new(v1alpha1.OIDCDenyRedirect)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.IDTokenClaims != nil {
+		in, out := &in.IDTokenClaims, &out.IDTokenClaims
+		*out = new(OIDCIDTokenClaims)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Session != nil {
+		in, out := &in.Session, &out.Session
+		*out = new(OIDCSession)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDC.
@@ -2542,6 +2900,27 @@ func (in *OIDC) DeepCopy() *OIDC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCIDTokenClaims) DeepCopyInto(out *OIDCIDTokenClaims) {
+	*out = *in
+	in.RemoteJWKS.DeepCopyInto(&out.RemoteJWKS)
+	if in.ClaimToHeaders != nil {
+		in, out := &in.ClaimToHeaders, &out.ClaimToHeaders
+		*out = make([]v1alpha1.ClaimToHeader, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCIDTokenClaims.
+func (in *OIDCIDTokenClaims) DeepCopy() *OIDCIDTokenClaims {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCIDTokenClaims)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OIDCProvider) DeepCopyInto(out *OIDCProvider) {
 	*out = *in
@@ -2572,6 +2951,26 @@ func (in *OIDCProvider) DeepCopy() *OIDCProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCSession) DeepCopyInto(out *OIDCSession) {
+	*out = *in
+	if in.UserInfoToHeaders != nil {
+		in, out := &in.UserInfoToHeaders, &out.UserInfoToHeaders
+		*out = make([]v1alpha1.ClaimToHeader, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCSession.
+func (in *OIDCSession) DeepCopy() *OIDCSession {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCSession)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenTelemetryAccessLog) DeepCopyInto(out *OpenTelemetryAccessLog) {
 	*out = *in
@@ -2660,6 +3059,26 @@ func (in *OutlierDetection) DeepCopyInto(out *OutlierDetection) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MaxEjectionTime != nil {
+		in, out := &in.MaxEjectionTime, &out.MaxEjectionTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxEjectionTimeJitter != nil {
+		in, out := &in.MaxEjectionTimeJitter, &out.MaxEjectionTimeJitter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SuccessRate != nil {
+		in, out := &in.SuccessRate, &out.SuccessRate
+		*out = new(OutlierDetectionSuccessRate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailurePercentage != nil {
+		in, out := &in.FailurePercentage, &out.FailurePercentage
+		*out = new(OutlierDetectionFailurePercentage)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutlierDetection.
@@ -2672,6 +3091,76 @@ func (in *OutlierDetection) DeepCopy() *OutlierDetection {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutlierDetectionFailurePercentage) DeepCopyInto(out *OutlierDetectionFailurePercentage) {
+	*out = *in
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MinimumHosts != nil {
+		in, out := &in.MinimumHosts, &out.MinimumHosts
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.RequestVolume != nil {
+		in, out := &in.RequestVolume, &out.RequestVolume
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.EnforcingPercent != nil {
+		in, out := &in.EnforcingPercent, &out.EnforcingPercent
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutlierDetectionFailurePercentage.
+func (in *OutlierDetectionFailurePercentage) DeepCopy() *OutlierDetectionFailurePercentage {
+	if in == nil {
+		return nil
+	}
+	out := new(OutlierDetectionFailurePercentage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutlierDetectionSuccessRate) DeepCopyInto(out *OutlierDetectionSuccessRate) {
+	*out = *in
+	if in.MinimumHosts != nil {
+		in, out := &in.MinimumHosts, &out.MinimumHosts
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.RequestVolume != nil {
+		in, out := &in.RequestVolume, &out.RequestVolume
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.StdevFactor != nil {
+		in, out := &in.StdevFactor, &out.StdevFactor
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.EnforcingPercent != nil {
+		in, out := &in.EnforcingPercent, &out.EnforcingPercent
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutlierDetectionSuccessRate.
+func (in *OutlierDetectionSuccessRate) DeepCopy() *OutlierDetectionSuccessRate {
+	if in == nil {
+		return nil
+	}
+	out := new(OutlierDetectionSuccessRate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PathSettings) DeepCopyInto(out *PathSettings) {
 	*out = *in
@@ -2732,6 +3221,21 @@ func (in *PerRetryPolicy) DeepCopy() *PerRetryPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyAuth) DeepCopyInto(out *PolicyAuth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyAuth.
+func (in *PolicyAuth) DeepCopy() *PolicyAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PreferLocalZone) DeepCopyInto(out *PreferLocalZone) {
 	*out = *in
@@ -2783,6 +3287,16 @@ func (in *Principal) DeepCopyInto(out *Principal) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(v1alpha1.ClientCertificatePrincipal)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expression != nil {
+		in, out := &in.Expression, &out.Expression
+		*out = new(v1alpha1.CELExpression)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Principal.
@@ -2896,6 +3410,21 @@ func (in *ProxyProtocolSettings) DeepCopy() *ProxyProtocolSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyStatus) DeepCopyInto(out *ProxyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyStatus.
+func (in *ProxyStatus) DeepCopy() *ProxyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Random) DeepCopyInto(out *Random) {
 	*out = *in
@@ -2949,6 +3478,11 @@ func (in *RateLimitCost) DeepCopyInto(out *RateLimitCost) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(RateLimitCostHeader)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitCost.
@@ -2961,6 +3495,21 @@ func (in *RateLimitCost) DeepCopy() *RateLimitCost {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitCostHeader) DeepCopyInto(out *RateLimitCostHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitCostHeader.
+func (in *RateLimitCostHeader) DeepCopy() *RateLimitCostHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitCostHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RateLimitRule) DeepCopyInto(out *RateLimitRule) {
 	*out = *in
@@ -2975,6 +3524,33 @@ func (in *RateLimitRule) DeepCopyInto(out *RateLimitRule) {
 			}
 		}
 	}
+	if in.QueryParamMatches != nil {
+		in, out := &in.QueryParamMatches, &out.QueryParamMatches
+		*out = make([]*StringMatch, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(StringMatch)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.MetadataMatches != nil {
+		in, out := &in.MetadataMatches, &out.MetadataMatches
+		*out = make([]*MetadataMatch, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(MetadataMatch)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ExpressionMatches != nil {
+		in, out := &in.ExpressionMatches, &out.ExpressionMatches
+		*out = make([]v1alpha1.CELExpression, len(*in))
+		copy(*out, *in)
+	}
 	if in.CIDRMatch != nil {
 		in, out := &in.CIDRMatch, &out.CIDRMatch
 		*out = new(CIDRMatch)
@@ -3023,6 +3599,31 @@ func (in *RateLimitValue) DeepCopy() *RateLimitValue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitedBackOff) DeepCopyInto(out *RateLimitedBackOff) {
+	*out = *in
+	if in.ResetHeaders != nil {
+		in, out := &in.ResetHeaders, &out.ResetHeaders
+		*out = make([]RetryResetHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxInterval != nil {
+		in, out := &in.MaxInterval, &out.MaxInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitedBackOff.
+func (in *RateLimitedBackOff) DeepCopy() *RateLimitedBackOff {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitedBackOff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReadyListener) DeepCopyInto(out *ReadyListener) {
 	*out = *in
@@ -3106,6 +3707,21 @@ func (in *RemoteJWKS) DeepCopyInto(out *RemoteJWKS) {
 		*out = new(TrafficFeatures)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CacheDuration != nil {
+		in, out := &in.CacheDuration, &out.CacheDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FailedRefetchDuration != nil {
+		in, out := &in.FailedRefetchDuration, &out.FailedRefetchDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteJWKS.
@@ -3239,6 +3855,31 @@ func (in *Retry) DeepCopy() *Retry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryBudget) DeepCopyInto(out *RetryBudget) {
+	*out = *in
+	if in.Percent != nil {
+		in, out := &in.Percent, &out.Percent
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MinRetryConcurrency != nil {
+		in, out := &in.MinRetryConcurrency, &out.MinRetryConcurrency
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryBudget.
+func (in *RetryBudget) DeepCopy() *RetryBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RetryOn) DeepCopyInto(out *RetryOn) {
 	*out = *in
@@ -3264,6 +3905,21 @@ func (in *RetryOn) DeepCopy() *RetryOn {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryResetHeader) DeepCopyInto(out *RetryResetHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryResetHeader.
+func (in *RetryResetHeader) DeepCopy() *RetryResetHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryResetHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoundRobin) DeepCopyInto(out *RoundRobin) {
 	*out = *in
@@ -3328,6 +3984,11 @@ func (in *SecurityFeatures) DeepCopyInto(out *SecurityFeatures) {
 		*out = new(CORS)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CSRF != nil {
+		in, out := &in.CSRF, &out.CSRF
+		*out = new(CSRF)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.JWT != nil {
 		in, out := &in.JWT, &out.JWT
 		*out = new(JWT)
@@ -3358,6 +4019,11 @@ func (in *SecurityFeatures) DeepCopyInto(out *SecurityFeatures) {
 		*out = new(Authorization)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PolicyAuth != nil {
+		in, out := &in.PolicyAuth, &out.PolicyAuth
+		*out = new(PolicyAuth)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityFeatures.
@@ -4105,6 +4771,21 @@ func (in *TrafficFeatures) DeepCopyInto(out *TrafficFeatures) {
 		*out = new(RequestBuffer)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CredentialInjection != nil {
+		in, out := &in.CredentialInjection, &out.CredentialInjection
+		*out = new(CredentialInjection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdaptiveConcurrency != nil {
+		in, out := &in.AdaptiveConcurrency, &out.AdaptiveConcurrency
+		*out = new(AdaptiveConcurrency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(Failover)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficFeatures.
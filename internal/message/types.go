@@ -32,6 +32,9 @@ type ProviderResources struct {
 
 	// ExtensionStatuses is a group of gw-api extension resource statuses map.
 	ExtensionStatuses
+
+	// InfraStatuses is a group of managed infrastructure statuses maps.
+	InfraStatuses
 }
 
 func (p *ProviderResources) GetResources() []*resource.Resources {
@@ -70,6 +73,7 @@ func (p *ProviderResources) Close() {
 	p.GatewayAPIResources.Close()
 	p.GatewayAPIStatuses.Close()
 	p.PolicyStatuses.Close()
+	p.InfraStatuses.Close()
 }
 
 // GatewayAPIStatuses contains gateway API resources statuses
@@ -122,6 +126,19 @@ func (p *PolicyStatuses) Close() {
 	p.ExtensionPolicyStatuses.Close()
 }
 
+// InfraStatuses contains the statuses of the managed infrastructure
+type InfraStatuses struct {
+	// ProxyStatuses is a map from a proxy infra name to the status of the proxy.
+	ProxyStatuses watchable.Map[string, *ir.ProxyStatus]
+	// RateLimitStatuses is a map from the rate limit infra name to the status of the rate limit service.
+	RateLimitStatuses watchable.Map[string, *ir.ProxyStatus]
+}
+
+func (i *InfraStatuses) Close() {
+	i.ProxyStatuses.Close()
+	i.RateLimitStatuses.Close()
+}
+
 // XdsIR message
 type XdsIR struct {
 	watchable.Map[string, *ir.Xds]
@@ -171,4 +188,8 @@ const (
 	GatewayStatusMessageName MessageName = "gateway-status"
 	// GatewayClassStatusMessageName is a message containing updates to GatewayClass status
 	GatewayClassStatusMessageName MessageName = "gatewayclass-status"
+	// ProxyStatusMessageName is a message containing updates to the status of managed proxies
+	ProxyStatusMessageName MessageName = "proxy-status"
+	// RateLimitStatusMessageName is a message containing updates to the status of the managed rate limit service
+	RateLimitStatusMessageName MessageName = "ratelimit-status"
 )
@@ -7,7 +7,6 @@ package file
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -15,11 +14,11 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
-	"time"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
@@ -27,17 +26,20 @@ import (
 	"github.com/envoyproxy/gateway/internal/filewatcher"
 	"github.com/envoyproxy/gateway/internal/message"
 	"github.com/envoyproxy/gateway/internal/provider/kubernetes"
+	"github.com/envoyproxy/gateway/internal/provider/offline"
 	"github.com/envoyproxy/gateway/internal/utils/path"
 )
 
 type Provider struct {
 	paths      []string
+	recursive  bool
 	logger     logr.Logger
 	watcher    filewatcher.FileWatcher
 	resources  *message.ProviderResources
 	reconciler *kubernetes.OfflineGatewayAPIReconciler
-	store      *resourcesStore
+	store      *offline.ResourcesStore
 	status     *StatusHandler
+	loader     *resourcesLoader
 
 	// ready indicates whether the provider can start watching filesystem events.
 	ready atomic.Bool
@@ -46,8 +48,12 @@ type Provider struct {
 func New(ctx context.Context, svr *config.Server, resources *message.ProviderResources) (*Provider, error) {
 	logger := svr.Logger.Logger
 	paths := sets.New[string]()
-	if svr.EnvoyGateway.Provider.Custom.Resource.File != nil {
-		paths.Insert(svr.EnvoyGateway.Provider.Custom.Resource.File.Paths...)
+	recursive := false
+	var include, exclude []string
+	if cfg := svr.EnvoyGateway.Provider.Custom.Resource.File; cfg != nil {
+		paths.Insert(cfg.Paths...)
+		recursive = ptr.Deref(cfg.Recursive, false)
+		include, exclude = cfg.Include, cfg.Exclude
 	}
 
 	// Create gateway-api offline reconciler.
@@ -59,12 +65,14 @@ func New(ctx context.Context, svr *config.Server, resources *message.ProviderRes
 
 	return &Provider{
 		paths:      paths.UnsortedList(),
+		recursive:  recursive,
 		logger:     logger,
 		watcher:    filewatcher.NewWatcher(),
 		resources:  resources,
 		reconciler: reconciler,
-		store:      newResourcesStore(svr.EnvoyGateway.Gateway.ControllerName, reconciler.Client, resources, logger),
+		store:      offline.NewResourcesStore(svr.EnvoyGateway.Gateway.ControllerName, reconciler.Client, resources, logger),
 		status:     statusHandler,
+		loader:     newResourcesLoader(include, exclude),
 	}, nil
 }
 
@@ -84,37 +92,29 @@ func (p *Provider) Start(ctx context.Context) error {
 		}
 		return nil
 	}
-	go p.startHealthProbeServer(ctx, readyzChecker)
+	go offline.StartHealthProbeServer(ctx, p.logger, readyzChecker)
 
 	// Offline controller should be started before initial resources load.
 	// Nor we may lose some messages from controller.
 	wg := new(sync.WaitGroup)
 	wg.Add(2)
-	go p.startReconciling(ctx, wg)
+	go offline.StartReconciling(ctx, wg, p.logger, p.store, p.reconciler)
 	go p.status.Start(ctx, wg)
 	wg.Wait()
 
 	initDirs, initFiles := path.ListDirsAndFiles(p.paths)
+	if p.recursive {
+		initDirs = listDirsRecursively(initDirs.UnsortedList())
+	}
 	// Initially load resources.
-	if err := p.store.ReloadAll(ctx, initFiles.UnsortedList(), initDirs.UnsortedList()); err != nil {
+	if err := p.reloadAll(ctx, initFiles.UnsortedList(), initDirs.UnsortedList()); err != nil {
 		p.logger.Error(err, "failed to reload resources initially")
 	}
 
 	// Add paths to the watcher, and aggregate all path channels into one.
 	aggCh := make(chan fsnotify.Event)
-	for _, path := range p.paths {
-		if err := p.watcher.Add(path); err != nil {
-			p.logger.Error(err, "failed to add watch", "path", path)
-		} else {
-			p.logger.Info("Watching path added", "path", path)
-		}
-
-		ch := p.watcher.Events(path)
-		go func(c chan fsnotify.Event) {
-			for msg := range c {
-				aggCh <- msg
-			}
-		}(ch)
+	for _, path := range sets.New(p.paths...).Union(initDirs).UnsortedList() {
+		p.watch(path, aggCh)
 	}
 
 	p.ready.Store(true)
@@ -167,78 +167,70 @@ func (p *Provider) Start(ctx context.Context) error {
 				continue
 			}
 			p.logger.Info("file changed", "op", event.Op, "name", event.Name, "dir", filepath.Dir(event.Name))
+			if p.recursive {
+				p.syncDirs(event, curDirs, aggCh)
+			}
 
 		handle:
-			if err := p.store.ReloadAll(ctx, curFiles.UnsortedList(), curDirs.UnsortedList()); err != nil {
+			if err := p.reloadAll(ctx, curFiles.UnsortedList(), curDirs.UnsortedList()); err != nil {
 				p.logger.Error(err, "error when reload resources", "op", event.Op, "name", event.Name)
 			}
 		}
 	}
 }
 
-// startReconciling starts reconcile on offline controller when receiving signal from resources store.
-func (p *Provider) startReconciling(ctx context.Context, ready *sync.WaitGroup) {
-	p.logger.Info("start reconciling")
-	defer p.logger.Info("stop reconciling")
-	ready.Done()
-
-	for {
-		select {
-		case rid := <-p.store.reconcile:
-			p.logger.Info("start reconcile", "id", rid, "time", time.Now())
-			if err := p.reconciler.Reconcile(ctx); err != nil {
-				p.logger.Error(err, "failed to reconcile", "id", rid)
-			}
-			p.logger.Info("reconcile finished", "id", rid, "time", time.Now())
+// watch adds the path to the watcher, and forwards its events to the aggregated channel.
+func (p *Provider) watch(path string, aggCh chan<- fsnotify.Event) {
+	if err := p.watcher.Add(path); err != nil {
+		p.logger.Error(err, "failed to add watch", "path", path)
+		return
+	}
+	p.logger.Info("Watching path added", "path", path)
 
-		case <-ctx.Done():
-			return
+	ch := p.watcher.Events(path)
+	go func(c chan fsnotify.Event) {
+		for msg := range c {
+			aggCh <- msg
 		}
-	}
+	}(ch)
 }
 
-func (p *Provider) startHealthProbeServer(ctx context.Context, readyzChecker healthz.Checker) {
-	const (
-		readyzEndpoint  = "/readyz"
-		healthzEndpoint = "/healthz"
-	)
-
-	mux := http.NewServeMux()
-	srv := &http.Server{
-		Addr:              ":8081",
-		Handler:           mux,
-		MaxHeaderBytes:    1 << 20,
-		IdleTimeout:       90 * time.Second, // matches http.DefaultTransport keep-alive timeout
-		ReadHeaderTimeout: 32 * time.Second,
+// syncDirs keeps the watched directories in sync with the subdirectories
+// being created or removed under the recursively watched directories.
+func (p *Provider) syncDirs(event fsnotify.Event, curDirs sets.Set[string], aggCh chan<- fsnotify.Event) {
+	if info, err := os.Lstat(event.Name); err == nil {
+		if !info.IsDir() || curDirs.Has(event.Name) {
+			return
+		}
+		for dir := range listDirsRecursively([]string{event.Name}) {
+			curDirs.Insert(dir)
+			p.watch(dir, aggCh)
+		}
+		return
 	}
 
-	readyzHandler := &healthz.Handler{
-		Checks: map[string]healthz.Checker{
-			readyzEndpoint: readyzChecker,
-		},
-	}
-	mux.Handle(readyzEndpoint, http.StripPrefix(readyzEndpoint, readyzHandler))
-	// Append '/' suffix to handle subpaths.
-	mux.Handle(readyzEndpoint+"/", http.StripPrefix(readyzEndpoint, readyzHandler))
-
-	healthzHandler := &healthz.Handler{
-		Checks: map[string]healthz.Checker{
-			healthzEndpoint: healthz.Ping,
-		},
+	if !curDirs.Has(event.Name) {
+		return
 	}
-	mux.Handle(healthzEndpoint, http.StripPrefix(healthzEndpoint, healthzHandler))
-	// Append '/' suffix to handle subpaths.
-	mux.Handle(healthzEndpoint+"/", http.StripPrefix(healthzEndpoint, readyzHandler))
-
-	go func() {
-		<-ctx.Done()
-		if err := srv.Close(); err != nil {
-			p.logger.Error(err, "failed to close health probe server")
+	for dir := range curDirs {
+		if dir != event.Name && !strings.HasPrefix(dir, event.Name+string(filepath.Separator)) {
+			continue
+		}
+		curDirs.Delete(dir)
+		if err := p.watcher.Remove(dir); err != nil {
+			p.logger.Error(err, "failed to remove watch", "path", dir)
+		} else {
+			p.logger.Info("Watching path removed", "path", dir)
 		}
-	}()
-
-	p.logger.Info("starting health probe server", "address", srv.Addr)
-	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-		p.logger.Error(err, "failed to start health probe server")
 	}
 }
+
+// reloadAll loads all resources from the given files and directories, and stores them.
+// The files that fail to load are skipped, and the last good version of them is kept.
+func (p *Provider) reloadAll(ctx context.Context, files, dirs []string) error {
+	// TODO(sh2): add arbitrary number of resources support for load function.
+	resources, errs := p.loader.load(files, dirs)
+	p.status.UpdateFileStatus(errs)
+
+	return p.store.StoreAll(ctx, resources)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/yaml"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
@@ -117,7 +118,7 @@ func TestFileProvider(t *testing.T) {
 	// Wait for file provider to be ready.
 	waitFileProviderReady(t)
 
-	require.Equal(t, "gateway.envoyproxy.io/gatewayclass-controller", fp.store.name)
+	require.Equal(t, "gateway.envoyproxy.io/gatewayclass-controller", fp.store.Name())
 
 	t.Run("initial resource load", func(t *testing.T) {
 		// Wait for the first reconcile to kick in.
@@ -248,6 +249,72 @@ func TestFileProvider(t *testing.T) {
 	})
 }
 
+func TestFileProviderRecursive(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	watchDirPath, _ := os.MkdirTemp(os.TempDir(), "test-recursive-dir-*")
+	teamADir := filepath.Join(watchDirPath, "team-a")
+	require.NoError(t, os.MkdirAll(teamADir, 0o750))
+	teamAFile := filepath.Join(teamADir, "test.yaml")
+	writeResourcesFile(t, teamAFile, newResourcesParam1())
+
+	cfg, err := newFileProviderConfig([]string{watchDirPath})
+	require.NoError(t, err)
+	cfg.EnvoyGateway.Provider.Custom.Resource.File.Recursive = ptr.To(true)
+	pResources := new(message.ProviderResources)
+	fp, err := New(ctx, cfg, pResources)
+	require.NoError(t, err)
+	go func() {
+		if err := fp.Start(ctx); err != nil {
+			t.Errorf("failed to start file provider: %v", err)
+		}
+	}()
+
+	require.Eventually(t, fp.ready.Load, resourcesUpdateTimeout, resourcesUpdateTick)
+
+	t.Run("initial resource load from subdirectory", func(t *testing.T) {
+		require.Eventually(t, func() bool {
+			return pResources.GetResourcesByGatewayClass("eg-1") != nil
+		}, resourcesUpdateTimeout, resourcesUpdateTick)
+	})
+
+	teamBDir := filepath.Join(watchDirPath, "team-b")
+	t.Run("add a new subdirectory", func(t *testing.T) {
+		require.NoError(t, os.MkdirAll(teamBDir, 0o750))
+		writeResourcesFile(t, filepath.Join(teamBDir, "test.yaml"), newResourcesParam2())
+
+		require.Eventually(t, func() bool {
+			return pResources.GetResourcesByGatewayClass("eg-1") != nil &&
+				pResources.GetResourcesByGatewayClass("eg-2") != nil
+		}, resourcesUpdateTimeout, resourcesUpdateTick)
+	})
+
+	t.Run("invalid file keeps its last good resources", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(teamAFile, []byte("invalid"), 0o600))
+
+		require.Eventually(t, func() bool {
+			return len(fp.status.InvalidFiles()) == 1
+		}, resourcesUpdateTimeout, resourcesUpdateTick)
+		require.Equal(t, []string{teamAFile}, fp.status.InvalidFiles())
+		require.NotNil(t, pResources.GetResourcesByGatewayClass("eg-1"))
+		require.NotNil(t, pResources.GetResourcesByGatewayClass("eg-2"))
+	})
+
+	t.Run("remove a subdirectory", func(t *testing.T) {
+		require.NoError(t, os.RemoveAll(teamBDir))
+
+		require.Eventually(t, func() bool {
+			return pResources.GetResourcesByGatewayClass("eg-1") != nil &&
+				pResources.GetResourcesByGatewayClass("eg-2") == nil
+		}, resourcesUpdateTimeout, resourcesUpdateTick)
+	})
+
+	t.Cleanup(func() {
+		cancel()
+		_ = os.RemoveAll(watchDirPath)
+	})
+}
+
 func writeResourcesFile(t *testing.T, dst string, params *resourcesParam) {
 	var buf bytes.Buffer
 
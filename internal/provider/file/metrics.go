@@ -0,0 +1,22 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package file
+
+import "github.com/envoyproxy/gateway/internal/metrics"
+
+var (
+	fileLoadErrorsTotal = metrics.NewCounter(
+		"file_provider_load_errors_total",
+		"Total number of times a file started failing to load.",
+	)
+
+	fileInvalidFiles = metrics.NewGauge(
+		"file_provider_invalid_files",
+		"Current number of files that fail to load.",
+	)
+
+	fileLabel = metrics.NewLabel("file")
+)
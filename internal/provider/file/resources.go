@@ -7,74 +7,166 @@ package file
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/util/sets"
+
 	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
 )
 
-// loadFromFilesAndDirs loads resources from specific files and directories.
-func loadFromFilesAndDirs(files, dirs []string) ([]*resource.Resources, error) {
-	var rs []*resource.Resources
+// resourcesLoader loads resources from files while isolating the errors of each file,
+// a file that fails to load does not prevent the other files from being loaded.
+type resourcesLoader struct {
+	include []string
+	exclude []string
 
-	for _, file := range files {
-		r, err := loadFromFile(file)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load resources from file %s: %w", file, err)
-		}
-		rs = append(rs, r)
+	// lastGood holds the last successfully loaded resources, keyed by file path.
+	lastGood map[string]*resource.Resources
+	// seen holds the paths of the files that have existed, so that the removal
+	// of an explicitly listed file isn't reported as an error.
+	seen sets.Set[string]
+}
+
+func newResourcesLoader(include, exclude []string) *resourcesLoader {
+	return &resourcesLoader{
+		include:  include,
+		exclude:  exclude,
+		lastGood: map[string]*resource.Resources{},
+		seen:     sets.New[string](),
 	}
+}
 
+// load loads resources from the specific files and all the files under the specific
+// directories excluding subdirectories.
+//
+// If an existing file fails to load, the last good version of its resources is used
+// instead. A file that has been removed is skipped without an error, only a file that
+// has never existed is reported. The errors are returned keyed by the path of the file
+// or directory.
+func (l *resourcesLoader) load(files, dirs []string) ([]*resource.Resources, map[string]error) {
+	errs := map[string]error{}
+	allFiles := sets.New(files...)
 	for _, dir := range dirs {
-		r, err := loadFromDir(dir)
+		dirFiles, err := l.listDir(dir)
 		if err != nil {
-			return nil, err
+			errs[dir] = err
+			continue
 		}
-		rs = append(rs, r...)
+		allFiles.Insert(dirFiles...)
 	}
 
-	return rs, nil
-}
+	var rs []*resource.Resources
+	for _, file := range sets.List(allFiles) {
+		r, err := loadFromFile(file)
+		if err != nil {
+			if _, statErr := os.Stat(file); statErr != nil {
+				delete(l.lastGood, file)
+				if !l.seen.Has(file) {
+					errs[file] = fmt.Errorf("failed to load resources from file %s: %w", file, err)
+				}
+				continue
+			}
+			errs[file] = fmt.Errorf("failed to load resources from file %s: %w", file, err)
+			r = l.lastGood[file]
+		} else {
+			l.lastGood[file] = r
+		}
+		l.seen.Insert(file)
 
-// loadFromFile loads resources from a specific file.
-func loadFromFile(path string) (*resource.Resources, error) {
-	if _, err := os.Stat(path); err != nil {
-		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("file %s is not exist", path)
+		if r != nil {
+			rs = append(rs, r)
 		}
-		return nil, err
 	}
 
-	bytes, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
+	// Forget the files that are no longer listed.
+	for file := range l.lastGood {
+		if !allFiles.Has(file) {
+			delete(l.lastGood, file)
+		}
 	}
+	l.seen = l.seen.Intersection(allFiles)
 
-	return resource.LoadResourcesFromYAMLBytes(bytes, false)
+	return rs, errs
 }
 
-// loadFromDir loads resources from all the files under a specific directory excluding subdirectories.
-func loadFromDir(path string) ([]*resource.Resources, error) {
-	entries, err := os.ReadDir(path)
+// listDir lists all the files under a specific directory excluding subdirectories,
+// hidden files and the files filtered out by the include and exclude patterns.
+func (l *resourcesLoader) listDir(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, err
 	}
 
-	var rs []*resource.Resources
+	var files []string
 	for _, entry := range entries {
 		// Ignoring subdirectories and all hidden files and directories.
 		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
 			continue
 		}
-		full := filepath.Join(path, entry.Name())
-		r, err := loadFromFile(full)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load resources from file %s: %w", full, err)
+		if !l.matches(entry.Name()) {
+			continue
 		}
+		files = append(files, filepath.Join(dir, entry.Name()))
+	}
+
+	return files, nil
+}
+
+// matches returns true if the file name matches any of the include patterns,
+// and none of the exclude patterns.
+func (l *resourcesLoader) matches(name string) bool {
+	for _, pattern := range l.exclude {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return false
+		}
+	}
+
+	if len(l.include) == 0 {
+		return true
+	}
+	for _, pattern := range l.include {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// loadFromFile loads resources from a specific file.
+func loadFromFile(path string) (*resource.Resources, error) {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("file %s is not exist", path)
+		}
+		return nil, err
+	}
 
-		rs = append(rs, r)
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
 	}
 
-	return rs, nil
+	return resource.LoadResourcesFromYAMLBytes(bytes, false)
+}
+
+// listDirsRecursively returns the given directories and all their subdirectories,
+// hidden subdirectories are ignored.
+func listDirsRecursively(dirs []string) sets.Set[string] {
+	out := sets.New[string]()
+	for _, dir := range dirs {
+		_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || !d.IsDir() {
+				return nil
+			}
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			out.Insert(path)
+			return nil
+		})
+	}
+	return out
 }
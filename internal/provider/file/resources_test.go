@@ -12,76 +12,98 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
-func Test_loadFromFilesAndDirs(t *testing.T) {
+const validGatewayClass = `
+apiVersion: gateway.networking.k8s.io/v1
+kind: GatewayClass
+metadata:
+  name: aigw-run
+spec:
+  controllerName: gateway.envoyproxy.io/gatewayclass-controller
+`
+
+func Test_resourcesLoader(t *testing.T) {
 	t.Run("non-existent file", func(t *testing.T) {
-		_, err := loadFromFilesAndDirs([]string{"non-existent-file"}, nil)
-		require.ErrorContains(t, err, "file non-existent-file is not exist")
+		rs, errs := newResourcesLoader(nil, nil).load([]string{"non-existent-file"}, nil)
+		require.Empty(t, rs)
+		require.ErrorContains(t, errs["non-existent-file"], "file non-existent-file is not exist")
 	})
 	t.Run("invalid content in a file", func(t *testing.T) {
 		tmpfile := t.TempDir() + "/invalid.yaml"
 		err := os.WriteFile(tmpfile, []byte("invalid"), 0o600)
 		require.NoError(t, err)
-		_, err = loadFromFilesAndDirs([]string{tmpfile}, nil)
-		require.ErrorContains(t, err,
+		rs, errs := newResourcesLoader(nil, nil).load([]string{tmpfile}, nil)
+		require.Empty(t, rs)
+		require.ErrorContains(t, errs[tmpfile],
 			fmt.Sprintf("failed to load resources from file %s", tmpfile))
 	})
 	t.Run("non-existent directory", func(t *testing.T) {
-		_, err := loadFromFilesAndDirs(nil, []string{"non-existent-directory"})
-		require.ErrorContains(t, err, "no such file or directory")
+		_, errs := newResourcesLoader(nil, nil).load(nil, []string{"non-existent-directory"})
+		require.ErrorContains(t, errs["non-existent-directory"], "no such file or directory")
 	})
-	t.Run("invalid content in a file in a directory", func(t *testing.T) {
+	t.Run("invalid content in a file in a directory does not block other files", func(t *testing.T) {
 		tmpdir := t.TempDir()
-		err := os.WriteFile(filepath.Join(tmpdir, "invalid.yaml"), []byte("invalid"), 0o600)
-		require.NoError(t, err)
-		_, err = loadFromFilesAndDirs(nil, []string{tmpdir})
-		require.ErrorContains(t, err,
-			fmt.Sprintf("failed to load resources from file %s", filepath.Join(tmpdir, "invalid.yaml")))
+		invalid := filepath.Join(tmpdir, "invalid.yaml")
+		require.NoError(t, os.WriteFile(invalid, []byte("invalid"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(tmpdir, "valid.yaml"), []byte(validGatewayClass), 0o600))
+		rs, errs := newResourcesLoader(nil, nil).load(nil, []string{tmpdir})
+		require.Len(t, rs, 1)
+		require.Len(t, errs, 1)
+		require.ErrorContains(t, errs[invalid],
+			fmt.Sprintf("failed to load resources from file %s", invalid))
 	})
-	t.Run("ok", func(t *testing.T) {
+	t.Run("last good version of an invalid file is kept", func(t *testing.T) {
 		tmpfile := t.TempDir() + "/valid.yaml"
-		err := os.WriteFile(tmpfile, []byte(`
-apiVersion: gateway.networking.k8s.io/v1
-kind: GatewayClass
-metadata:
-  name: aigw-run
-spec:
-  controllerName: gateway.envoyproxy.io/gatewayclass-controller
-`), 0o600)
-		require.NoError(t, err)
-		rs, err := loadFromFilesAndDirs([]string{tmpfile}, nil)
-		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(tmpfile, []byte(validGatewayClass), 0o600))
+		loader := newResourcesLoader(nil, nil)
+		rs, errs := loader.load([]string{tmpfile}, nil)
 		require.Len(t, rs, 1)
-	})
-}
+		require.Empty(t, errs)
 
-func Test_loadFromDir(t *testing.T) {
-	t.Run("non-existent directory", func(t *testing.T) {
-		_, err := loadFromDir("non-existent-directory")
-		require.ErrorContains(t, err, "no such file or directory")
+		require.NoError(t, os.WriteFile(tmpfile, []byte("invalid"), 0o600))
+		rs, errs = loader.load([]string{tmpfile}, nil)
+		require.Len(t, rs, 1)
+		require.Equal(t, "aigw-run", rs[0].GatewayClass.Name)
+		require.Len(t, errs, 1)
+
+		require.NoError(t, os.Remove(tmpfile))
+		rs, errs = loader.load([]string{tmpfile}, nil)
+		require.Empty(t, rs)
+		require.Empty(t, errs)
 	})
-	t.Run("invalid content in a file", func(t *testing.T) {
+	t.Run("include and exclude patterns", func(t *testing.T) {
 		tmpdir := t.TempDir()
-		err := os.WriteFile(filepath.Join(tmpdir, "invalid.yaml"), []byte("invalid"), 0o600)
-		require.NoError(t, err)
-		_, err = loadFromDir(tmpdir)
-		require.ErrorContains(t, err,
-			fmt.Sprintf("failed to load resources from file %s", filepath.Join(tmpdir, "invalid.yaml")))
+		for _, name := range []string{"a.yaml", "b.yaml", "c.yml", "d.txt"} {
+			require.NoError(t, os.WriteFile(filepath.Join(tmpdir, name), []byte(validGatewayClass), 0o600))
+		}
+		rs, errs := newResourcesLoader([]string{"*.yaml", "*.yml"}, []string{"b.*"}).load(nil, []string{tmpdir})
+		require.Empty(t, errs)
+		require.Len(t, rs, 2)
 	})
 	t.Run("ok", func(t *testing.T) {
-		tmpdir := t.TempDir()
-		err := os.WriteFile(filepath.Join(tmpdir, "valid.yaml"), []byte(`
-apiVersion: gateway.networking.k8s.io/v1
-kind: GatewayClass
-metadata:
-  name: aigw-run
-spec:
-  controllerName: gateway.envoyproxy.io/gatewayclass-controller
-`), 0o600)
-		require.NoError(t, err)
-		rs, err := loadFromDir(tmpdir)
+		tmpfile := t.TempDir() + "/valid.yaml"
+		err := os.WriteFile(tmpfile, []byte(validGatewayClass), 0o600)
 		require.NoError(t, err)
+		rs, errs := newResourcesLoader(nil, nil).load([]string{tmpfile}, nil)
+		require.Empty(t, errs)
 		require.Len(t, rs, 1)
 	})
 }
+
+func Test_listDirsRecursively(t *testing.T) {
+	tmpdir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpdir, "team-a", "nested"), 0o750))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpdir, "team-b"), 0o750))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpdir, ".hidden", "nested"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpdir, "file.yaml"), []byte(validGatewayClass), 0o600))
+
+	want := sets.New(
+		tmpdir,
+		filepath.Join(tmpdir, "team-a"),
+		filepath.Join(tmpdir, "team-a", "nested"),
+		filepath.Join(tmpdir, "team-b"),
+	)
+	require.Equal(t, want, listDirsRecursively([]string{tmpdir}))
+}
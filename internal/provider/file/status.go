@@ -12,6 +12,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/yaml"
 
 	"github.com/envoyproxy/gateway/internal/provider/kubernetes"
@@ -21,6 +22,10 @@ type StatusHandler struct {
 	logger        logr.Logger
 	updateChannel chan kubernetes.Update
 	wg            *sync.WaitGroup
+
+	mu sync.Mutex
+	// invalidFiles holds the paths of the files that currently fail to load.
+	invalidFiles sets.Set[string]
 }
 
 func NewStatusHandler(log logr.Logger) *StatusHandler {
@@ -28,6 +33,7 @@ func NewStatusHandler(log logr.Logger) *StatusHandler {
 		logger:        log,
 		updateChannel: make(chan kubernetes.Update, 1000),
 		wg:            new(sync.WaitGroup),
+		invalidFiles:  sets.New[string](),
 	}
 
 	u.wg.Add(1)
@@ -87,6 +93,38 @@ func (u *StatusHandler) logStatus(update kubernetes.Update) {
 	log.Info(fmt.Sprintf("Got new status for %s\n%s", kubernetes.KindOf(obj), string(byteStatus)))
 }
 
+// UpdateFileStatus surfaces the errors of the files that failed to load.
+// A file is reported when it starts failing to load, and when it recovers,
+// so that a file that keeps failing across reloads is only counted once.
+func (u *StatusHandler) UpdateFileStatus(errs map[string]error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for _, path := range sets.List(sets.KeySet(errs)) {
+		if !u.invalidFiles.Has(path) {
+			fileLoadErrorsTotal.With(fileLabel.Value(path)).Increment()
+			u.logger.Error(errs[path], "file failed to load, skipping it", "file", path)
+		}
+	}
+
+	for path := range u.invalidFiles {
+		if _, ok := errs[path]; !ok {
+			u.logger.Info("file loaded successfully again", "file", path)
+		}
+	}
+
+	u.invalidFiles = sets.KeySet(errs)
+	fileInvalidFiles.Record(float64(u.invalidFiles.Len()))
+}
+
+// InvalidFiles returns the paths of the files that currently fail to load.
+func (u *StatusHandler) InvalidFiles() []string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return sets.List(u.invalidFiles)
+}
+
 // Writer retrieves the interface that should be used to write to the StatusHandler.
 func (u *StatusHandler) Writer() kubernetes.Updater {
 	return &StatusWriter{
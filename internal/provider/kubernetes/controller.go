@@ -46,6 +46,7 @@ import (
 	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
 	"github.com/envoyproxy/gateway/internal/gatewayapi/status"
 	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes/proxy"
+	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/logging"
 	"github.com/envoyproxy/gateway/internal/message"
 	workqueuemetrics "github.com/envoyproxy/gateway/internal/metrics/workqueue"
@@ -109,6 +110,8 @@ type subscriptions struct {
 	securityPolicyStatuses       <-chan watchable.Snapshot[types.NamespacedName, *gwapiv1a2.PolicyStatus]
 	backendStatuses              <-chan watchable.Snapshot[types.NamespacedName, *egv1a1.BackendStatus]
 	extensionPolicyStatuses      <-chan watchable.Snapshot[message.NamespacedNameAndGVK, *gwapiv1a2.PolicyStatus]
+	proxyStatuses                <-chan watchable.Snapshot[string, *ir.ProxyStatus]
+	rateLimitStatuses            <-chan watchable.Snapshot[string, *ir.ProxyStatus]
 }
 
 // newGatewayAPIController
@@ -216,6 +219,8 @@ func (r *gatewayAPIReconciler) subscribeToResources(ctx context.Context) {
 	r.subscriptions.securityPolicyStatuses = r.resources.SecurityPolicyStatuses.Subscribe(ctx)
 	r.subscriptions.backendStatuses = r.resources.BackendStatuses.Subscribe(ctx)
 	r.subscriptions.extensionPolicyStatuses = r.resources.ExtensionPolicyStatuses.Subscribe(ctx)
+	r.subscriptions.proxyStatuses = r.resources.ProxyStatuses.Subscribe(ctx)
+	r.subscriptions.rateLimitStatuses = r.resources.RateLimitStatuses.Subscribe(ctx)
 }
 
 func (r *gatewayAPIReconciler) backendAPIDisabled() bool {
@@ -489,6 +494,9 @@ func (r *gatewayAPIReconciler) Reconcile(ctx context.Context, _ reconcile.Reques
 			gwcResource.Namespaces = append(gwcResource.Namespaces, namespace)
 		}
 
+		// Add the Nodes of the endpoints to the resourceTree, to set the region of the endpoints.
+		gwcResource.Nodes = r.store.listEndpointNodes(gwcResource.EndpointSlices)
+
 		if gwcResource.EnvoyProxyForGatewayClass != nil && gwcResource.EnvoyProxyForGatewayClass.Spec.MergeGateways != nil {
 			if *gwcResource.EnvoyProxyForGatewayClass.Spec.MergeGateways {
 				r.mergeGateways.Insert(managedGC.Name)
@@ -859,6 +867,10 @@ func (r *gatewayAPIReconciler) processSecurityPolicyObjectRefs(
 		// Add the referenced Secrets in BasicAuth to the resourceTree
 		basicAuth := policy.Spec.BasicAuth
 		if basicAuth != nil {
+			secretRef := basicAuth.Users
+			if basicAuth.LDAP != nil {
+				secretRef = basicAuth.LDAP.BindPassword
+			}
 			if err := r.processSecretRef(
 				ctx,
 				resourceMap,
@@ -866,14 +878,14 @@ func (r *gatewayAPIReconciler) processSecurityPolicyObjectRefs(
 				resource.KindSecurityPolicy,
 				policy.Namespace,
 				policy.Name,
-				basicAuth.Users); err != nil {
+				secretRef); err != nil {
 				// If the error is transient, we return it to allow Reconcile to retry.
 				if isTransientError(err) {
 					return err
 				}
 				r.log.Error(err,
 					"failed to process BasicAuth SecretRef for SecurityPolicy",
-					"policy", policy, "secretRef", basicAuth.Users)
+					"policy", policy, "secretRef", secretRef)
 			}
 		}
 
@@ -949,6 +961,28 @@ func (r *gatewayAPIReconciler) processSecurityPolicyObjectRefs(
 				}
 			}
 		}
+
+		if policy.Spec.Policy != nil && policy.Spec.Policy.ValueRef != nil {
+			valueRef := policy.Spec.Policy.ValueRef
+			if err := r.processConfigMapRef(
+				ctx,
+				resourceMap,
+				resourceTree,
+				resource.KindSecurityPolicy,
+				policy.Namespace,
+				policy.Name,
+				gwapiv1.SecretObjectReference{
+					Group: &valueRef.Group,
+					Kind:  &valueRef.Kind,
+					Name:  valueRef.Name,
+				}); err != nil {
+				// If the error is transient, we return it to allow Reconcile to retry.
+				if isTransientError(err) {
+					return err
+				}
+				r.log.Error(err, "failed to process Policy ConfigMap", "policy", policy, "valueRef", valueRef)
+			}
+		}
 	}
 	return nil
 }
@@ -1299,6 +1333,49 @@ func (r *gatewayAPIReconciler) processBtpConfigMapRefs(
 	return nil
 }
 
+// processBtpSecretRefs adds the referenced Secrets in BackendTrafficPolicies
+// to the resourceTree
+func (r *gatewayAPIReconciler) processBtpSecretRefs(
+	ctx context.Context, resourceTree *resource.Resources, resourceMap *resourceMappings,
+) error {
+	for _, policy := range resourceTree.BackendTrafficPolicies {
+		if policy.Spec.CredentialInjection == nil {
+			continue
+		}
+		for _, ref := range credentialInjectionSecretRefs(policy.Spec.CredentialInjection) {
+			secret := new(corev1.Secret)
+			err := r.client.Get(ctx,
+				types.NamespacedName{Namespace: policy.Namespace, Name: string(ref.Name)},
+				secret,
+			)
+			// we don't return an error here, because we want to continue
+			// reconciling the rest of the BackendTrafficPolicies despite that this
+			// reference is invalid.
+			// This BackendTrafficPolicies will be marked as invalid in its status
+			// when translating to IR because the referenced secret can't be
+			// found.
+			if err != nil {
+				// If the error is transient, we return it to allow Reconcile to retry.
+				if isTransientError(err) {
+					return err
+				}
+				r.log.Error(err,
+					"failed to process CredentialInjection secret for BackendTrafficPolicy",
+					"policy", policy, "secret", ref.Name)
+				continue
+			}
+
+			resourceMap.allAssociatedNamespaces.Insert(policy.Namespace)
+			if !resourceMap.allAssociatedSecrets.Has(utils.NamespacedName(secret).String()) {
+				resourceMap.allAssociatedSecrets.Insert(utils.NamespacedName(secret).String())
+				resourceTree.Secrets = append(resourceTree.Secrets, secret)
+				r.log.Info("processing Secret", "namespace", policy.Namespace, "name", string(ref.Name))
+			}
+		}
+	}
+	return nil
+}
+
 func (r *gatewayAPIReconciler) getNamespace(ctx context.Context, name string) (*corev1.Namespace, error) {
 	nsKey := types.NamespacedName{Name: name}
 	ns := new(corev1.Namespace)
@@ -1607,7 +1684,10 @@ func (r *gatewayAPIReconciler) processBackendTrafficPolicies(ctx context.Context
 			resourceTree.BackendTrafficPolicies = append(resourceTree.BackendTrafficPolicies, &backendTrafficPolicy)
 		}
 	}
-	return r.processBtpConfigMapRefs(ctx, resourceTree, resourceMap)
+	if err := r.processBtpConfigMapRefs(ctx, resourceTree, resourceMap); err != nil {
+		return err
+	}
+	return r.processBtpSecretRefs(ctx, resourceTree, resourceMap)
 }
 
 // processSecurityPolicies adds SecurityPolicies and their referenced resources to the resourceTree
@@ -9,11 +9,16 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/message"
 )
 
@@ -47,3 +52,88 @@ func TestNewOfflineGatewayAPIController(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+type chanUpdater chan Update
+
+func (u chanUpdater) Send(update Update) {
+	u <- update
+}
+
+func TestOfflineGatewayAPIControllerProxyStatus(t *testing.T) {
+	cfg, err := config.New(os.Stdout)
+	require.NoError(t, err)
+	cfg.EnvoyGateway.Provider = &egv1a1.EnvoyGatewayProvider{
+		Type: egv1a1.ProviderTypeCustom,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := make(chanUpdater, 10)
+	pResources := new(message.ProviderResources)
+	r, err := NewOfflineGatewayAPIController(ctx, cfg, updates, pResources)
+	require.NoError(t, err)
+
+	gtw := &gwapiv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway"},
+		Spec:       gwapiv1.GatewaySpec{GatewayClassName: "eg"},
+	}
+	require.NoError(t, r.Client.Create(ctx, gtw))
+	key := types.NamespacedName{Namespace: "default", Name: "gateway"}
+	pResources.GatewayStatuses.Store(key, &gwapiv1.GatewayStatus{})
+
+	programmed := func(update Update) *metav1.Condition {
+		obj := update.Mutator.Mutate(gtw.DeepCopy()).(*gwapiv1.Gateway)
+		for _, cond := range obj.Status.Conditions {
+			if cond.Type == string(gwapiv1.GatewayConditionProgrammed) {
+				return &cond
+			}
+		}
+		return nil
+	}
+
+	testCases := []struct {
+		name   string
+		status *ir.ProxyStatus
+		expect metav1.ConditionStatus
+	}{
+		{
+			name:   "running",
+			status: &ir.ProxyStatus{State: ir.ProxyStateRunning},
+			expect: metav1.ConditionTrue,
+		},
+		{
+			name:   "restarting",
+			status: &ir.ProxyStatus{State: ir.ProxyStateRestarting, Restarts: 1, Message: "exit status 1"},
+			expect: metav1.ConditionFalse,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pResources.ProxyStatuses.Store("default/gateway", tc.status)
+			require.Eventually(t, func() bool {
+				select {
+				case update := <-updates:
+					cond := programmed(update)
+					return update.NamespacedName == key && cond != nil && cond.Status == tc.expect
+				default:
+					return false
+				}
+			}, 5*time.Second, 10*time.Millisecond)
+		})
+	}
+	t.Run("deleted", func(t *testing.T) {
+		pResources.ProxyStatuses.Delete("default/gateway")
+		require.Eventually(t, func() bool {
+			select {
+			case update := <-updates:
+				cond := programmed(update)
+				// The programmed condition falls back to the one of a gateway without a running proxy.
+				return update.NamespacedName == key && cond != nil && cond.Status == metav1.ConditionFalse &&
+					cond.Reason == string(gwapiv1.GatewayReasonAddressNotAssigned)
+			default:
+				return false
+			}
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
@@ -12,6 +12,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
@@ -127,14 +128,17 @@ func (r *gatewayAPIReconciler) processRouteFilterConfigMapRef(
 	}
 }
 
-// processRouteFilterSecretRef adds the referenced Secret in a HTTPRouteFilter
+// processRouteFilterSecretRef adds the referenced Secrets in a HTTPRouteFilter
 // to the resourceTree
 func (r *gatewayAPIReconciler) processRouteFilterSecretRef(
 	ctx context.Context, filter *egv1a1.HTTPRouteFilter,
 	resourceMap *resourceMappings, resourceTree *resource.Resources,
 ) {
-	if filter.Spec.CredentialInjection != nil {
-		name := string(filter.Spec.CredentialInjection.Credential.ValueRef.Name)
+	if filter.Spec.CredentialInjection == nil {
+		return
+	}
+	for _, ref := range credentialInjectionSecretRefs(filter.Spec.CredentialInjection) {
+		name := string(ref.Name)
 		secret := new(corev1.Secret)
 		err := r.client.Get(ctx, types.NamespacedName{Namespace: filter.Namespace, Name: name}, secret)
 		// we don't return an error here, because we want to continue
@@ -145,9 +149,9 @@ func (r *gatewayAPIReconciler) processRouteFilterSecretRef(
 		// found.
 		if err != nil {
 			r.log.Error(err,
-				"failed to process CredentialInjection ValueRef for HTTPRouteFilter",
-				"filter", filter, "ValueRef", filter.Spec.CredentialInjection.Credential.ValueRef.Name)
-			return
+				"failed to process CredentialInjection secret for HTTPRouteFilter",
+				"filter", filter, "secret", name)
+			continue
 		}
 		resourceMap.allAssociatedNamespaces.Insert(filter.Namespace)
 		if !resourceMap.allAssociatedSecrets.Has(utils.NamespacedName(secret).String()) {
@@ -157,3 +161,16 @@ func (r *gatewayAPIReconciler) processRouteFilterSecretRef(
 		}
 	}
 }
+
+// credentialInjectionSecretRefs returns the Secrets referenced by a credential injection,
+// which must be in the same namespace as the referencing resource.
+func credentialInjectionSecretRefs(injection *egv1a1.HTTPCredentialInjectionFilter) []gwapiv1.SecretObjectReference {
+	var refs []gwapiv1.SecretObjectReference
+	if injection.Credential.ValueRef.Name != "" {
+		refs = append(refs, injection.Credential.ValueRef)
+	}
+	if injection.Credential.OAuth2 != nil {
+		refs = append(refs, injection.Credential.OAuth2.ClientSecret)
+	}
+	return refs
+}
@@ -52,6 +52,7 @@ const (
 	secretEnvoyExtensionPolicyIndex  = "secretEnvoyExtensionPolicyIndex"
 	httpRouteFilterHTTPRouteIndex    = "httpRouteFilterHTTPRouteIndex"
 	configMapBtpIndex                = "configMapBtpIndex"
+	secretBtpIndex                   = "secretBtpIndex"
 	configMapEepIndex                = "configMapEepIndex"
 	configMapHTTPRouteFilterIndex    = "configMapHTTPRouteFilterIndex"
 	secretHTTPRouteFilterIndex       = "secretHTTPRouteFilterIndex"
@@ -601,7 +602,11 @@ func secretSecurityPolicyIndexFunc(rawObj client.Object) []string {
 		secretReferences = append(secretReferences, securityPolicy.Spec.APIKeyAuth.CredentialRefs...)
 	}
 	if securityPolicy.Spec.BasicAuth != nil {
-		secretReferences = append(secretReferences, securityPolicy.Spec.BasicAuth.Users)
+		if securityPolicy.Spec.BasicAuth.LDAP != nil {
+			secretReferences = append(secretReferences, securityPolicy.Spec.BasicAuth.LDAP.BindPassword)
+		} else {
+			secretReferences = append(secretReferences, securityPolicy.Spec.BasicAuth.Users)
+		}
 	}
 
 	for _, reference := range secretReferences {
@@ -666,22 +671,33 @@ func backendSecurityPolicyIndexFunc(rawObj client.Object) []string {
 func configMapSecurityPolicyIndexFunc(rawObj client.Object) []string {
 	securityPolicy := rawObj.(*egv1a1.SecurityPolicy)
 
+	values := []string{}
 	if securityPolicy.Spec.JWT != nil {
 		for _, provider := range securityPolicy.Spec.JWT.Providers {
 			if provider.LocalJWKS != nil &&
 				provider.LocalJWKS.Type != nil &&
 				*provider.LocalJWKS.Type == egv1a1.LocalJWKSTypeValueRef {
-				return []string{
+				values = append(values,
 					types.NamespacedName{
 						Namespace: securityPolicy.Namespace,
 						Name:      string(provider.LocalJWKS.ValueRef.Name),
 					}.String(),
-				}
+				)
+				break
 			}
 		}
 	}
 
-	return []string{}
+	if securityPolicy.Spec.Policy != nil && securityPolicy.Spec.Policy.ValueRef != nil {
+		values = append(values,
+			types.NamespacedName{
+				Namespace: securityPolicy.Namespace,
+				Name:      string(securityPolicy.Spec.Policy.ValueRef.Name),
+			}.String(),
+		)
+	}
+
+	return values
 }
 
 // addCtpIndexers adds indexing on ClientTrafficPolicy, for ConfigMap or Secret objects that are
@@ -821,17 +837,37 @@ func clusterTrustBundleBackendIndexFunc(rawObj client.Object) []string {
 	return ctbReferences
 }
 
-// addBtpIndexers adds indexing on BackendTrafficPolicy, for ConfigMap objects that are
+// addBtpIndexers adds indexing on BackendTrafficPolicy, for ConfigMap and Secret objects that are
 // referenced in BackendTrafficPolicy objects. This helps in querying for BackendTrafficPolies that are
-// affected by a particular ConfigMap CRUD.
+// affected by a particular ConfigMap or Secret CRUD.
 func addBtpIndexers(ctx context.Context, mgr manager.Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(ctx, &egv1a1.BackendTrafficPolicy{}, configMapBtpIndex, configMapBtpIndexFunc); err != nil {
 		return err
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &egv1a1.BackendTrafficPolicy{}, secretBtpIndex, secretBtpIndexFunc); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+func secretBtpIndexFunc(rawObj client.Object) []string {
+	btp := rawObj.(*egv1a1.BackendTrafficPolicy)
+	var secretReferences []string
+	if btp.Spec.CredentialInjection != nil {
+		for _, ref := range credentialInjectionSecretRefs(btp.Spec.CredentialInjection) {
+			secretReferences = append(secretReferences,
+				types.NamespacedName{
+					Namespace: btp.Namespace,
+					Name:      string(ref.Name),
+				}.String(),
+			)
+		}
+	}
+	return secretReferences
+}
+
 func configMapBtpIndexFunc(rawObj client.Object) []string {
 	btp := rawObj.(*egv1a1.BackendTrafficPolicy)
 	var configMapReferences []string
@@ -912,12 +948,14 @@ func secretRouteFilterIndexFunc(rawObj client.Object) []string {
 	filter := rawObj.(*egv1a1.HTTPRouteFilter)
 	var secretReferences []string
 	if filter.Spec.CredentialInjection != nil {
-		secretReferences = append(secretReferences,
-			types.NamespacedName{
-				Namespace: filter.Namespace,
-				Name:      string(filter.Spec.CredentialInjection.Credential.ValueRef.Name),
-			}.String(),
-		)
+		for _, ref := range credentialInjectionSecretRefs(filter.Spec.CredentialInjection) {
+			secretReferences = append(secretReferences,
+				types.NamespacedName{
+					Namespace: filter.Namespace,
+					Name:      string(ref.Name),
+				}.String(),
+			)
+		}
 	}
 
 	return secretReferences
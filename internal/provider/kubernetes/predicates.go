@@ -193,6 +193,12 @@ func (r *gatewayAPIReconciler) validateSecretForReconcile(obj client.Object) boo
 		}
 	}
 
+	if r.btpCRDExists {
+		if r.isBackendTrafficPolicyReferencingSecret(&nsName) {
+			return true
+		}
+	}
+
 	return false
 }
 
@@ -274,6 +280,18 @@ func (r *gatewayAPIReconciler) isHTTPRouteFilterReferencingSecret(nsName *types.
 	return true
 }
 
+func (r *gatewayAPIReconciler) isBackendTrafficPolicyReferencingSecret(nsName *types.NamespacedName) bool {
+	btpList := &egv1a1.BackendTrafficPolicyList{}
+	if err := r.client.List(context.Background(), btpList, &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(secretBtpIndex, nsName.String()),
+	}); err != nil {
+		r.log.Error(err, "unable to find associated BackendTrafficPolicy")
+		return false
+	}
+
+	return len(btpList.Items) > 0
+}
+
 func (r *gatewayAPIReconciler) isBackendTLSPolicyReferencingSecret(nsName *types.NamespacedName) bool {
 	btlsList := &gwapiv1a3.BackendTLSPolicyList{}
 	if err := r.client.List(context.Background(), btlsList, &client.ListOptions{
@@ -692,6 +710,15 @@ func (r *gatewayAPIReconciler) envoyObjectForGateway(ctx context.Context, gatewa
 	return nil, nil
 }
 
+// proxyInfraName returns the name of the proxy infra serving the gateway, which is the name of the
+// GatewayClass when the gateways are merged, see gatewayapi.Translator.IRKey.
+func (r *gatewayAPIReconciler) proxyInfraName(gateway *gwapiv1.Gateway) string {
+	if r.mergeGateways.Has(string(gateway.Spec.GatewayClassName)) {
+		return string(gateway.Spec.GatewayClassName)
+	}
+	return utils.NamespacedName(gateway).String()
+}
+
 // envoyServiceForGateway returns the Envoy service, returning nil if the service doesn't exist.
 func (r *gatewayAPIReconciler) envoyServiceForGateway(ctx context.Context, gateway *gwapiv1.Gateway) (*corev1.Service, error) {
 	var services corev1.ServiceList
@@ -20,6 +20,8 @@ import (
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
 	"github.com/envoyproxy/gateway/internal/gatewayapi/status"
+	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes/ratelimit"
+	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/message"
 	"github.com/envoyproxy/gateway/internal/utils"
 )
@@ -81,6 +83,54 @@ func (r *gatewayAPIReconciler) subscribeAndUpdateStatus(ctx context.Context, ext
 		r.log.Info("gateway status subscriber shutting down")
 	}()
 
+	// Gateway object status updater for the status changes of the Envoy processes run on the host
+	go func() {
+		message.HandleSubscription(
+			message.Metadata{Runner: string(egv1a1.LogComponentProviderRunner), Message: message.ProxyStatusMessageName},
+			r.subscriptions.proxyStatuses,
+			func(update message.Update[string, *ir.ProxyStatus], errChan chan error) {
+				// Refresh the status of the gateways served by the proxy, including when the proxy
+				// is deleted so that their Programmed condition no longer reflects the stopped process.
+				for key, val := range r.resources.GatewayStatuses.LoadAll() {
+					gtw := new(gwapiv1.Gateway)
+					if err := r.client.Get(ctx, key, gtw); err != nil {
+						continue
+					}
+					if r.proxyInfraName(gtw) != update.Key {
+						continue
+					}
+					gtw.Status = *val
+					r.updateStatusForGateway(ctx, gtw)
+				}
+			},
+		)
+		r.log.Info("proxy status subscriber shutting down")
+	}()
+
+	// Gateway object status updater for the status changes of the rate limit process run on the host
+	go func() {
+		message.HandleSubscription(
+			message.Metadata{Runner: string(egv1a1.LogComponentProviderRunner), Message: message.RateLimitStatusMessageName},
+			r.subscriptions.rateLimitStatuses,
+			func(update message.Update[string, *ir.ProxyStatus], errChan chan error) {
+				// The rate limit service is shared by all the gateways, so refresh the status
+				// of every gateway whose proxy is run on the host.
+				for key, val := range r.resources.GatewayStatuses.LoadAll() {
+					gtw := new(gwapiv1.Gateway)
+					if err := r.client.Get(ctx, key, gtw); err != nil {
+						continue
+					}
+					if _, ok := r.resources.ProxyStatuses.Load(r.proxyInfraName(gtw)); !ok {
+						continue
+					}
+					gtw.Status = *val
+					r.updateStatusForGateway(ctx, gtw)
+				}
+			},
+		)
+		r.log.Info("rate limit status subscriber shutting down")
+	}()
+
 	// HTTPRoute object status updater
 	go func() {
 		message.HandleSubscription(
@@ -576,7 +626,13 @@ func (r *gatewayAPIReconciler) updateStatusForGateway(ctx context.Context, gtw *
 		// to true in the Gateway API translator
 		status.UpdateGatewayStatusAccepted(gtw)
 		// update address field and programmed condition
-		status.UpdateGatewayStatusProgrammedCondition(gtw, svc, envoyObj, r.store.listNodeAddresses())
+		if proxyStatus, ok := r.resources.ProxyStatuses.Load(r.proxyInfraName(gtw)); ok {
+			// The Envoy proxy is run on the host rather than in Kubernetes.
+			rateLimitStatus, _ := r.resources.RateLimitStatuses.Load(ratelimit.InfraName)
+			status.UpdateGatewayStatusProgrammedConditionForHost(gtw, proxyStatus, rateLimitStatus)
+		} else {
+			status.UpdateGatewayStatusProgrammedCondition(gtw, svc, envoyObj, r.store.listNodeAddresses())
+		}
 	}
 
 	key := utils.NamespacedName(gtw)
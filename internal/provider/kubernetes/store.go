@@ -10,6 +10,9 @@ import (
 	"sync"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/envoyproxy/gateway/internal/gatewayapi/status"
 )
@@ -17,13 +20,15 @@ import (
 type nodeDetails struct {
 	name      string
 	addresses status.NodeAddresses
+	region    string
 }
 
 // kubernetesProviderStore holds cached information for the kubernetes provider.
 type kubernetesProviderStore struct {
 	// nodes holds information required for updating Gateway status with the Node
 	// addresses, in case the Gateway is exposed on every Node of the cluster, using
-	// Service of type NodePort.
+	// Service of type NodePort, and the region of the Nodes to set the region of the
+	// endpoints of the EndpointSlices.
 	nodes map[string]nodeDetails
 	mu    sync.Mutex
 }
@@ -35,7 +40,7 @@ func newProviderStore() *kubernetesProviderStore {
 }
 
 func (p *kubernetesProviderStore) addNode(n *corev1.Node) {
-	details := nodeDetails{name: n.Name}
+	details := nodeDetails{name: n.Name, region: n.Labels[corev1.LabelTopologyRegion]}
 
 	var internalIPs, externalIPs status.NodeAddresses
 	for _, addr := range n.Status.Addresses {
@@ -84,3 +89,29 @@ func (p *kubernetesProviderStore) listNodeAddresses() status.NodeAddresses {
 	}
 	return addrs
 }
+
+// listEndpointNodes returns the Nodes of the endpoints of the EndpointSlices which have a
+// topology region, with only their name and region label.
+func (p *kubernetesProviderStore) listEndpointNodes(endpointSlices []*discoveryv1.EndpointSlice) []*corev1.Node {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var nodes []*corev1.Node
+	added := make(map[string]bool)
+	for _, endpointSlice := range endpointSlices {
+		for _, endpoint := range endpointSlice.Endpoints {
+			name := ptr.Deref(endpoint.NodeName, "")
+			n, ok := p.nodes[name]
+			if !ok || n.region == "" || added[name] {
+				continue
+			}
+			added[name] = true
+			nodes = append(nodes, &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   n.name,
+					Labels: map[string]string{corev1.LabelTopologyRegion: n.region},
+				},
+			})
+		}
+	}
+	return nodes
+}
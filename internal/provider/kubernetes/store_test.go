@@ -10,7 +10,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/envoyproxy/gateway/internal/gatewayapi/status"
 )
@@ -120,6 +122,55 @@ func TestNodeDetailsAddressStore(t *testing.T) {
 	}
 }
 
+func TestListEndpointNodes(t *testing.T) {
+	store := newProviderStore()
+	store.addNode(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node1",
+			Labels: map[string]string{
+				corev1.LabelTopologyRegion: "us-east-1",
+				corev1.LabelTopologyZone:   "us-east-1a",
+			},
+		},
+		Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{{
+			Address: "1.1.1.1",
+			Type:    corev1.NodeInternalIP,
+		}}},
+	})
+	store.addNode(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node2"},
+	})
+	store.addNode(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node3",
+			Labels: map[string]string{corev1.LabelTopologyRegion: "us-west-2"},
+		},
+	})
+
+	endpointSlices := []*discoveryv1.EndpointSlice{
+		{
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.0.0.1"}, NodeName: ptr.To("node1")},
+				{Addresses: []string{"10.0.0.2"}, NodeName: ptr.To("node2")},
+				{Addresses: []string{"10.0.0.3"}},
+			},
+		},
+		{
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.0.0.4"}, NodeName: ptr.To("node1")},
+				{Addresses: []string{"10.0.0.5"}, NodeName: ptr.To("node4")},
+			},
+		},
+	}
+
+	assert.Equal(t, []*corev1.Node{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node1",
+			Labels: map[string]string{corev1.LabelTopologyRegion: "us-east-1"},
+		},
+	}}, store.listEndpointNodes(endpointSlices))
+}
+
 func TestRace(t *testing.T) {
 	s := newProviderStore()
 
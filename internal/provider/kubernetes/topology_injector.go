@@ -76,12 +76,17 @@ func (m *ProxyTopologyInjector) Handle(ctx context.Context, req admission.Reques
 	}
 	logger = logger.WithValues("node", node)
 
-	if zone, ok := node.Labels[corev1.LabelTopologyZone]; ok {
-		if binding.Annotations == nil {
-			binding.Annotations = map[string]string{}
+	injected := false
+	for _, label := range []string{corev1.LabelTopologyRegion, corev1.LabelTopologyZone} {
+		if value, ok := node.Labels[label]; ok {
+			if binding.Annotations == nil {
+				binding.Annotations = map[string]string{}
+			}
+			binding.Annotations[label] = fmt.Sprintf("%q", value)
+			injected = true
 		}
-		binding.Annotations[corev1.LabelTopologyZone] = fmt.Sprintf("%q", zone)
-	} else {
+	}
+	if !injected {
 		logger.V(1).Info("Skipping injection due to missing topology label on node")
 		return admission.Allowed("Skipping injection due to missing topology label on node")
 	}
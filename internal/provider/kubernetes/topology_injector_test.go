@@ -71,6 +71,47 @@ func TestProxyTopologyInjector_Handle(t *testing.T) {
 				},
 			}},
 		},
+		{
+			caseName: "valid binding with region",
+			obj: &corev1.Binding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      defaultPod.Name,
+					Namespace: defaultPod.Namespace,
+				},
+				Target: corev1.ObjectReference{Name: "node-B"},
+			},
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node-B",
+					Labels: map[string]string{
+						corev1.LabelTopologyRegion: "us-east-1",
+						corev1.LabelTopologyZone:   "us-east-1a",
+					},
+				},
+			},
+			pod: defaultPod,
+			expectedPatchResp: []jsonpatch.JsonPatchOperation{{
+				Operation: "add",
+				Path:      "/metadata/annotations",
+				Value: map[string]interface{}{
+					"topology.kubernetes.io/region": "\"us-east-1\"",
+					"topology.kubernetes.io/zone":   "\"us-east-1a\"",
+				},
+			}},
+		},
+		{
+			caseName: "skip binding - no topology label on node",
+			obj: &corev1.Binding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      defaultPod.Name,
+					Namespace: defaultPod.Namespace,
+				},
+				Target: corev1.ObjectReference{Name: "node-C"},
+			},
+			node:              &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-C"}},
+			pod:               defaultPod,
+			expectedPatchResp: nil,
+		},
 		{
 			caseName: "empty target",
 			obj: &corev1.Binding{
@@ -0,0 +1,155 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kv
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+)
+
+const defaultDialTimeout = 5 * time.Second
+
+// Client is the subset of the key/value store API used by the KV provider.
+type Client interface {
+	// List returns all the key/value pairs under the given prefix, and the
+	// revision of the store at which they were read.
+	List(ctx context.Context, prefix string) (map[string][]byte, int64, error)
+
+	// Watch watches the given prefix for changes after the given revision.
+	// The returned channel receives the latest revision on every change, and
+	// is closed when the watch fails or the context is canceled.
+	Watch(ctx context.Context, prefix string, rev int64) <-chan int64
+
+	// Put writes the value under the given key.
+	Put(ctx context.Context, key string, value []byte) error
+
+	// Close closes the client.
+	Close() error
+}
+
+type etcdClient struct {
+	cli *clientv3.Client
+}
+
+var _ Client = (*etcdClient)(nil)
+
+// newEtcdClient creates a Client that talks to a store implementing the etcd v3 API.
+func newEtcdClient(cfg *egv1a1.EnvoyGatewayKVResourceProvider) (Client, error) {
+	dialTimeout := defaultDialTimeout
+	if cfg.DialTimeout != nil {
+		d, err := time.ParseDuration(string(*cfg.DialTimeout))
+		if err != nil {
+			return nil, fmt.Errorf("invalid dial timeout: %w", err)
+		}
+		dialTimeout = d
+	}
+
+	tlsCfg, err := buildTLSConfig(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints:   cfg.Endpoints,
+		DialTimeout: dialTimeout,
+		TLS:         tlsCfg,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &etcdClient{cli: cli}, nil
+}
+
+func (c *etcdClient) List(ctx context.Context, prefix string) (map[string][]byte, int64, error) {
+	resp, err := c.cli.Get(ctx, prefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	kvs := make(map[string][]byte, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		kvs[string(kv.Key)] = kv.Value
+	}
+	return kvs, resp.Header.Revision, nil
+}
+
+func (c *etcdClient) Watch(ctx context.Context, prefix string, rev int64) <-chan int64 {
+	out := make(chan int64)
+	wch := c.cli.Watch(ctx, prefix, clientv3.WithPrefix(), clientv3.WithRev(rev))
+
+	go func() {
+		defer close(out)
+		for resp := range wch {
+			// The watch is canceled if the revision has been compacted,
+			// the caller is expected to list and watch again.
+			if resp.Err() != nil {
+				return
+			}
+			if len(resp.Events) == 0 {
+				continue
+			}
+
+			select {
+			case out <- resp.Header.Revision:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+func (c *etcdClient) Put(ctx context.Context, key string, value []byte) error {
+	_, err := c.cli.Put(ctx, key, string(value))
+	return err
+}
+
+func (c *etcdClient) Close() error {
+	return c.cli.Close()
+}
+
+func buildTLSConfig(cfg *egv1a1.KVTLSSettings) (*tls.Config, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if cfg.CAFile != nil {
+		ca, err := os.ReadFile(*cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("failed to parse ca file %s", *cfg.CAFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if cfg.CertFile != nil && cfg.KeyFile != nil {
+		cert, err := tls.LoadX509KeyPair(*cfg.CertFile, *cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
+}
@@ -0,0 +1,193 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kv
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/provider/kubernetes"
+	"github.com/envoyproxy/gateway/internal/provider/offline"
+)
+
+// retryInterval is the interval to wait before listing and watching
+// the key/value store again after a failure.
+const retryInterval = 5 * time.Second
+
+type Provider struct {
+	prefix     string
+	logger     logr.Logger
+	client     Client
+	resources  *message.ProviderResources
+	reconciler *kubernetes.OfflineGatewayAPIReconciler
+	store      *offline.ResourcesStore
+	status     *StatusHandler
+
+	// ready indicates whether the provider has loaded the resources at least once.
+	ready atomic.Bool
+}
+
+func New(ctx context.Context, svr *config.Server, resources *message.ProviderResources) (*Provider, error) {
+	cfg := svr.EnvoyGateway.Provider.Custom.Resource.KV
+	if cfg == nil {
+		return nil, fmt.Errorf("missing kv resource provider settings")
+	}
+
+	cli, err := newEtcdClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kv client: %w", err)
+	}
+
+	return newProvider(ctx, svr, resources, cli)
+}
+
+func newProvider(ctx context.Context, svr *config.Server, resources *message.ProviderResources, cli Client) (*Provider, error) {
+	logger := svr.Logger.Logger
+	cfg := svr.EnvoyGateway.Provider.Custom.Resource.KV
+
+	prefix, statusPrefix := cfg.GetPrefix(), cfg.GetStatusPrefix()
+
+	// Create gateway-api offline reconciler.
+	statusHandler := NewStatusHandler(logger, cli, statusPrefix)
+	reconciler, err := kubernetes.NewOfflineGatewayAPIController(ctx, svr, statusHandler.Writer(), resources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create offline gateway-api controller: %w", err)
+	}
+
+	return &Provider{
+		prefix:     prefix,
+		logger:     logger,
+		client:     cli,
+		resources:  resources,
+		reconciler: reconciler,
+		store:      offline.NewResourcesStore(svr.EnvoyGateway.Gateway.ControllerName, reconciler.Client, resources, logger),
+		status:     statusHandler,
+	}, nil
+}
+
+func (p *Provider) Type() egv1a1.ProviderType {
+	return egv1a1.ProviderTypeCustom
+}
+
+func (p *Provider) Start(ctx context.Context) error {
+	defer func() {
+		_ = p.client.Close()
+	}()
+
+	// Start runnable servers.
+	var readyzChecker healthz.Checker = func(req *http.Request) error {
+		if !p.ready.Load() {
+			return fmt.Errorf("kv provider not ready yet")
+		}
+		return nil
+	}
+	go offline.StartHealthProbeServer(ctx, p.logger, readyzChecker)
+
+	// Offline controller should be started before initial resources load.
+	// Nor we may lose some messages from controller.
+	wg := new(sync.WaitGroup)
+	wg.Add(2)
+	go offline.StartReconciling(ctx, wg, p.logger, p.store, p.reconciler)
+	go p.status.Start(ctx, wg)
+	wg.Wait()
+
+	for {
+		if err := p.listAndWatch(ctx); err != nil {
+			p.logger.Error(err, "failed to list and watch resources", "prefix", p.prefix)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// listAndWatch loads all resources under the prefix, then reloads them every
+// time the prefix changes until the watch fails or the context is canceled.
+func (p *Provider) listAndWatch(ctx context.Context) error {
+	rev, err := p.reloadAll(ctx)
+	if err != nil {
+		return err
+	}
+	p.ready.Store(true)
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	p.logger.Info("watching prefix", "prefix", p.prefix, "revision", rev)
+	for range p.client.Watch(watchCtx, p.prefix, rev+1) {
+		// Keep the revision of the last successful reload on failure.
+		newRev, err := p.reloadAll(ctx)
+		if err != nil {
+			p.logger.Error(err, "error when reload resources", "prefix", p.prefix, "revision", rev)
+			continue
+		}
+		rev = newRev
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	return fmt.Errorf("watch on prefix %s closed", p.prefix)
+}
+
+// reloadAll loads and stores all resources under the prefix, and returns the
+// revision of the store they were read at.
+func (p *Provider) reloadAll(ctx context.Context) (int64, error) {
+	kvs, rev, err := p.client.List(ctx, p.prefix)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list resources: %w", err)
+	}
+
+	resources, errs := loadFromKeyValues(kvs)
+	for k, err := range errs {
+		p.logger.Error(err, "skipping key with invalid resources", "key", k)
+	}
+
+	return rev, p.store.StoreAll(ctx, resources)
+}
+
+// loadFromKeyValues loads resources from the values of all keys, in key order.
+// The keys whose value can't be decoded are skipped, and returned with their error,
+// so that a single invalid key doesn't prevent the other keys from being loaded.
+func loadFromKeyValues(kvs map[string][]byte) ([]*resource.Resources, map[string]error) {
+	keys := make([]string, 0, len(kvs))
+	for k := range kvs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	rs := make([]*resource.Resources, 0, len(keys))
+	errs := make(map[string]error)
+	for _, k := range keys {
+		if len(kvs[k]) == 0 {
+			continue
+		}
+
+		r, err := resource.LoadResourcesFromYAMLBytes(kvs[k], false)
+		if err != nil {
+			errs[k] = err
+			continue
+		}
+		rs = append(rs, r)
+	}
+
+	return rs, errs
+}
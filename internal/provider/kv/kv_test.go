@@ -0,0 +1,321 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kv
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"net"
+	"net/url"
+	"os"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/embed"
+	"go.uber.org/zap"
+	"k8s.io/utils/ptr"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+const (
+	resourcesUpdateTimeout = 1 * time.Minute
+	resourcesUpdateTick    = 1 * time.Second
+)
+
+// startEtcd starts an embedded etcd server, and returns its client endpoint.
+// The server is served over TLS if tlsInfo is set.
+func startEtcd(t *testing.T, tlsInfo *transport.TLSInfo) string {
+	t.Helper()
+
+	scheme := "http"
+	if tlsInfo != nil {
+		scheme = "https"
+	}
+	clientURL := url.URL{Scheme: scheme, Host: freeAddr(t)}
+	peerURL := url.URL{Scheme: "http", Host: freeAddr(t)}
+
+	cfg := embed.NewConfig()
+	cfg.Dir = t.TempDir()
+	cfg.LogLevel = "error"
+	cfg.ListenClientUrls = []url.URL{clientURL}
+	cfg.AdvertiseClientUrls = []url.URL{clientURL}
+	cfg.ListenPeerUrls = []url.URL{peerURL}
+	cfg.AdvertisePeerUrls = []url.URL{peerURL}
+	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
+	if tlsInfo != nil {
+		cfg.ClientTLSInfo = *tlsInfo
+	}
+
+	e, err := embed.StartEtcd(cfg)
+	require.NoError(t, err)
+	t.Cleanup(e.Close)
+
+	select {
+	case <-e.Server.ReadyNotify():
+	case <-time.After(resourcesUpdateTimeout):
+		e.Server.Stop()
+		t.Fatal("embedded etcd took too long to start")
+	}
+
+	return clientURL.String()
+}
+
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().String()
+}
+
+func newTestEtcdClient(t *testing.T, endpoint string) *clientv3.Client {
+	t.Helper()
+
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{endpoint},
+		DialTimeout: defaultDialTimeout,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cli.Close() })
+	return cli
+}
+
+type resourcesParam struct {
+	GatewayClassName    string
+	GatewayName         string
+	GatewayListenerPort string
+	HTTPRouteName       string
+	HTTPRouteHostname   string
+	BackendName         string
+	EndpointPort        string
+}
+
+func newResourcesParam(name, port string) *resourcesParam {
+	return &resourcesParam{
+		GatewayClassName:    name,
+		GatewayName:         name,
+		GatewayListenerPort: port,
+		HTTPRouteName:       name,
+		HTTPRouteHostname:   "www." + name + ".com",
+		BackendName:         name,
+		EndpointPort:        "3001",
+	}
+}
+
+func newKVProviderConfig(endpoint string) (*config.Server, error) {
+	cfg, err := config.New(os.Stdout)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.EnvoyGateway.Provider = &egv1a1.EnvoyGatewayProvider{
+		Type: egv1a1.ProviderTypeCustom,
+		Custom: &egv1a1.EnvoyGatewayCustomProvider{
+			Resource: egv1a1.EnvoyGatewayResourceProvider{
+				Type: egv1a1.ResourceProviderTypeKV,
+				KV: &egv1a1.EnvoyGatewayKVResourceProvider{
+					Endpoints: []string{endpoint},
+				},
+			},
+		},
+	}
+	cfg.EnvoyGateway.ExtensionAPIs = &egv1a1.ExtensionAPISettings{
+		EnableBackend: true,
+	}
+	return cfg, nil
+}
+
+func TestKVProvider(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	endpoint := startEtcd(t, nil)
+	etcd := newTestEtcdClient(t, endpoint)
+	_, err := etcd.Put(ctx, egv1a1.DefaultKVResourcePrefix+"eg-1", string(renderResources(t, newResourcesParam("eg-1", "8801"))))
+	require.NoError(t, err)
+
+	cfg, err := newKVProviderConfig(endpoint)
+	require.NoError(t, err)
+	cli, err := newEtcdClient(cfg.EnvoyGateway.Provider.Custom.Resource.KV)
+	require.NoError(t, err)
+	pResources := new(message.ProviderResources)
+	kp, err := newProvider(ctx, cfg, pResources, cli)
+	require.NoError(t, err)
+	go func() {
+		if err := kp.Start(ctx); err != nil {
+			t.Errorf("failed to start kv provider: %v", err)
+		}
+	}()
+
+	require.Eventually(t, kp.ready.Load, resourcesUpdateTimeout, resourcesUpdateTick)
+
+	t.Run("initial resource load", func(t *testing.T) {
+		require.Eventually(t, func() bool {
+			return pResources.GetResourcesByGatewayClass("eg-1") != nil
+		}, resourcesUpdateTimeout, resourcesUpdateTick)
+	})
+
+	t.Run("status is written back", func(t *testing.T) {
+		key := statusKey(egv1a1.DefaultKVStatusPrefix, "GatewayClass", "", "eg-1")
+		require.Eventually(t, func() bool {
+			resp, err := etcd.Get(ctx, key)
+			return err == nil && len(resp.Kvs) > 0 && len(resp.Kvs[0].Value) > 0
+		}, resourcesUpdateTimeout, resourcesUpdateTick)
+	})
+
+	t.Run("add a new key", func(t *testing.T) {
+		_, err := etcd.Put(ctx, egv1a1.DefaultKVResourcePrefix+"eg-2", string(renderResources(t, newResourcesParam("eg-2", "8802"))))
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			return pResources.GetResourcesByGatewayClass("eg-1") != nil &&
+				pResources.GetResourcesByGatewayClass("eg-2") != nil
+		}, resourcesUpdateTimeout, resourcesUpdateTick)
+	})
+
+	t.Run("invalid value is skipped", func(t *testing.T) {
+		_, err := etcd.Put(ctx, egv1a1.DefaultKVResourcePrefix+"invalid", "kind: [")
+		require.NoError(t, err)
+		// The key written after the invalid one is only loaded once the invalid one has been skipped.
+		_, err = etcd.Put(ctx, egv1a1.DefaultKVResourcePrefix+"eg-3", string(renderResources(t, newResourcesParam("eg-3", "8803"))))
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			return pResources.GetResourcesByGatewayClass("eg-1") != nil &&
+				pResources.GetResourcesByGatewayClass("eg-2") != nil &&
+				pResources.GetResourcesByGatewayClass("eg-3") != nil
+		}, resourcesUpdateTimeout, resourcesUpdateTick)
+	})
+
+	t.Run("remove all keys", func(t *testing.T) {
+		_, err := etcd.Delete(ctx, egv1a1.DefaultKVResourcePrefix, clientv3.WithPrefix())
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			return len(pResources.GetResources()) == 0
+		}, resourcesUpdateTimeout, resourcesUpdateTick)
+	})
+}
+
+func TestEtcdClientWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	endpoint := startEtcd(t, nil)
+	etcd := newTestEtcdClient(t, endpoint)
+	cli, err := newEtcdClient(&egv1a1.EnvoyGatewayKVResourceProvider{Endpoints: []string{endpoint}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cli.Close() })
+
+	_, rev, err := cli.List(ctx, "/foo/")
+	require.NoError(t, err)
+
+	t.Run("changes under the prefix are watched", func(t *testing.T) {
+		ch := cli.Watch(ctx, "/foo/", rev+1)
+		_, err := etcd.Put(ctx, "/bar/a", "a")
+		require.NoError(t, err)
+		resp, err := etcd.Put(ctx, "/foo/a", "a")
+		require.NoError(t, err)
+
+		select {
+		case got := <-ch:
+			// The change outside of the prefix isn't notified.
+			require.Equal(t, resp.Header.Revision, got)
+		case <-time.After(resourcesUpdateTimeout):
+			t.Fatal("timed out waiting for the watch")
+		}
+	})
+
+	t.Run("watch is closed when the revision is compacted", func(t *testing.T) {
+		resp, err := etcd.Put(ctx, "/foo/a", "b")
+		require.NoError(t, err)
+		_, err = etcd.Compact(ctx, resp.Header.Revision)
+		require.NoError(t, err)
+
+		ch := cli.Watch(ctx, "/foo/", rev+1)
+		select {
+		case _, ok := <-ch:
+			require.False(t, ok)
+		case <-time.After(resourcesUpdateTimeout):
+			t.Fatal("timed out waiting for the watch to be closed")
+		}
+	})
+}
+
+func TestEtcdClientTLS(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	tlsInfo, err := transport.SelfCert(zap.NewNop(), t.TempDir(), []string{"127.0.0.1:0"}, 1, x509.ExtKeyUsageClientAuth)
+	require.NoError(t, err)
+	tlsInfo.TrustedCAFile = tlsInfo.CertFile
+	tlsInfo.ClientCertAuth = true
+	endpoint := startEtcd(t, &tlsInfo)
+
+	t.Run("client certificate", func(t *testing.T) {
+		cli, err := newEtcdClient(&egv1a1.EnvoyGatewayKVResourceProvider{
+			Endpoints: []string{endpoint},
+			TLS: &egv1a1.KVTLSSettings{
+				CAFile:   ptr.To(tlsInfo.CertFile),
+				CertFile: ptr.To(tlsInfo.CertFile),
+				KeyFile:  ptr.To(tlsInfo.KeyFile),
+			},
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = cli.Close() })
+
+		require.NoError(t, cli.Put(ctx, "/foo/a", []byte("a")))
+		kvs, _, err := cli.List(ctx, "/foo/")
+		require.NoError(t, err)
+		require.Equal(t, map[string][]byte{"/foo/a": []byte("a")}, kvs)
+	})
+
+	t.Run("missing client certificate", func(t *testing.T) {
+		cli, err := newEtcdClient(&egv1a1.EnvoyGatewayKVResourceProvider{
+			Endpoints: []string{endpoint},
+			TLS: &egv1a1.KVTLSSettings{
+				CAFile: ptr.To(tlsInfo.CertFile),
+			},
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = cli.Close() })
+
+		listCtx, listCancel := context.WithTimeout(ctx, 2*time.Second)
+		defer listCancel()
+		_, _, err = cli.List(listCtx, "/foo/")
+		require.Error(t, err)
+	})
+}
+
+func TestLoadFromKeyValues(t *testing.T) {
+	rs, errs := loadFromKeyValues(map[string][]byte{
+		"/eg/a":       renderResources(t, newResourcesParam("a", "8801")),
+		"/eg/empty":   {},
+		"/eg/invalid": []byte("kind: ["),
+		"/eg/b":       renderResources(t, newResourcesParam("b", "8802")),
+	})
+
+	require.Len(t, rs, 2)
+	require.Equal(t, "a", rs[0].GatewayClass.Name)
+	require.Equal(t, "b", rs[1].GatewayClass.Name)
+	require.Len(t, errs, 1)
+	require.Contains(t, errs, "/eg/invalid")
+}
+
+func renderResources(t *testing.T, params *resourcesParam) []byte {
+	var buf bytes.Buffer
+
+	tmpl, err := template.ParseFiles("testdata/resources.tmpl")
+	require.NoError(t, err)
+	require.NoError(t, tmpl.Execute(&buf, params))
+	return buf.Bytes()
+}
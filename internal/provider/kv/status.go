@@ -0,0 +1,118 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kv
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/envoyproxy/gateway/internal/provider/kubernetes"
+)
+
+// StatusHandler writes the status of the resources back into the key/value store.
+type StatusHandler struct {
+	logger        logr.Logger
+	client        Client
+	prefix        string
+	updateChannel chan kubernetes.Update
+	wg            *sync.WaitGroup
+}
+
+func NewStatusHandler(log logr.Logger, client Client, prefix string) *StatusHandler {
+	u := &StatusHandler{
+		logger:        log,
+		client:        client,
+		prefix:        prefix,
+		updateChannel: make(chan kubernetes.Update, 1000),
+		wg:            new(sync.WaitGroup),
+	}
+
+	u.wg.Add(1)
+
+	return u
+}
+
+// Start runs the goroutine to perform status writes.
+func (u *StatusHandler) Start(ctx context.Context, ready *sync.WaitGroup) {
+	u.logger.Info("started status update handler")
+	defer u.logger.Info("stopped status update handler")
+
+	// Enable Updaters to start sending updates to this handler.
+	u.wg.Done()
+	ready.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case update := <-u.updateChannel:
+			u.logger.Info("received a status update",
+				"kind", kubernetes.KindOf(update.Resource),
+				"namespace", update.NamespacedName.Namespace,
+				"name", update.NamespacedName.Name,
+			)
+
+			if err := u.writeStatus(ctx, update); err != nil {
+				u.logger.Error(err, "failed to write status", "key", update.NamespacedName.String())
+			}
+		}
+	}
+}
+
+func (u *StatusHandler) writeStatus(ctx context.Context, update kubernetes.Update) error {
+	obj := update.Resource
+	newObj := update.Mutator.Mutate(obj)
+
+	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(newObj)
+	if err != nil {
+		return fmt.Errorf("failed to convert object: %w", err)
+	}
+
+	rawStatus, ok := raw["status"]
+	if !ok {
+		return fmt.Errorf("no status field")
+	}
+
+	byteStatus, err := yaml.Marshal(rawStatus)
+	if err != nil {
+		return fmt.Errorf("failed to marshal status: %w", err)
+	}
+
+	return u.client.Put(ctx, statusKey(u.prefix, kubernetes.KindOf(obj),
+		update.NamespacedName.Namespace, update.NamespacedName.Name), byteStatus)
+}
+
+// statusKey returns the key that holds the status of the given resource.
+func statusKey(prefix, kind, namespace, name string) string {
+	return prefix + path.Join(kind, namespace, name)
+}
+
+// Writer retrieves the interface that should be used to write to the StatusHandler.
+func (u *StatusHandler) Writer() kubernetes.Updater {
+	return &StatusWriter{
+		updateChannel: u.updateChannel,
+		wg:            u.wg,
+	}
+}
+
+// StatusWriter takes status updates and sends these to the StatusHandler via a channel.
+type StatusWriter struct {
+	updateChannel chan<- kubernetes.Update
+	wg            *sync.WaitGroup
+}
+
+// Send sends the given Update off to the update channel for writing by the StatusHandler.
+func (u *StatusWriter) Send(update kubernetes.Update) {
+	// Wait until updater is ready
+	u.wg.Wait()
+	u.updateChannel <- update
+}
@@ -0,0 +1,63 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package offline
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// StartHealthProbeServer starts the readyz and healthz endpoints for offline providers.
+func StartHealthProbeServer(ctx context.Context, logger logr.Logger, readyzChecker healthz.Checker) {
+	const (
+		readyzEndpoint  = "/readyz"
+		healthzEndpoint = "/healthz"
+	)
+
+	mux := http.NewServeMux()
+	srv := &http.Server{
+		Addr:              ":8081",
+		Handler:           mux,
+		MaxHeaderBytes:    1 << 20,
+		IdleTimeout:       90 * time.Second, // matches http.DefaultTransport keep-alive timeout
+		ReadHeaderTimeout: 32 * time.Second,
+	}
+
+	readyzHandler := &healthz.Handler{
+		Checks: map[string]healthz.Checker{
+			readyzEndpoint: readyzChecker,
+		},
+	}
+	mux.Handle(readyzEndpoint, http.StripPrefix(readyzEndpoint, readyzHandler))
+	// Append '/' suffix to handle subpaths.
+	mux.Handle(readyzEndpoint+"/", http.StripPrefix(readyzEndpoint, readyzHandler))
+
+	healthzHandler := &healthz.Handler{
+		Checks: map[string]healthz.Checker{
+			healthzEndpoint: healthz.Ping,
+		},
+	}
+	mux.Handle(healthzEndpoint, http.StripPrefix(healthzEndpoint, healthzHandler))
+	// Append '/' suffix to handle subpaths.
+	mux.Handle(healthzEndpoint+"/", http.StripPrefix(healthzEndpoint, readyzHandler))
+
+	go func() {
+		<-ctx.Done()
+		if err := srv.Close(); err != nil {
+			logger.Error(err, "failed to close health probe server")
+		}
+	}()
+
+	logger.Info("starting health probe server", "address", srv.Addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Error(err, "failed to start health probe server")
+	}
+}
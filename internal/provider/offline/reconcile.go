@@ -0,0 +1,39 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package offline
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/envoyproxy/gateway/internal/provider/kubernetes"
+)
+
+// StartReconciling starts reconcile on offline controller when receiving signal from resources store.
+func StartReconciling(ctx context.Context, ready *sync.WaitGroup, logger logr.Logger,
+	store *ResourcesStore, reconciler *kubernetes.OfflineGatewayAPIReconciler,
+) {
+	logger.Info("start reconciling")
+	defer logger.Info("stop reconciling")
+	ready.Done()
+
+	for {
+		select {
+		case rid := <-store.Reconcile():
+			logger.Info("start reconcile", "id", rid, "time", time.Now())
+			if err := reconciler.Reconcile(ctx); err != nil {
+				logger.Error(err, "failed to reconcile", "id", rid)
+			}
+			logger.Info("reconcile finished", "id", rid, "time", time.Now())
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
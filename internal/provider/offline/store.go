@@ -3,7 +3,9 @@
 // The full text of the Apache license is available in the LICENSE file at
 // the root of the repo.
 
-package file
+// Package offline contains the building blocks shared by the resource providers
+// that run the gateway-api reconcile logic without a Kubernetes apiserver.
+package offline
 
 import (
 	"context"
@@ -32,7 +34,9 @@ const (
 	GatewayDeletionOrder = 3
 )
 
-type resourcesStore struct {
+// ResourcesStore stores resources into the offline gateway-api client and
+// signals the offline reconciler whenever the stored resources change.
+type ResourcesStore struct {
 	name      string
 	keys      sets.Set[storeKey]
 	client    client.Client
@@ -56,8 +60,9 @@ func (s storeKey) String() string {
 		s.GroupVersionKind.String(), s.NamespacedName.String(), s.deletionOrder)
 }
 
-func newResourcesStore(name string, client client.Client, resources *message.ProviderResources, logger logr.Logger) *resourcesStore {
-	return &resourcesStore{
+// NewResourcesStore returns a ResourcesStore backed by the given offline client.
+func NewResourcesStore(name string, client client.Client, resources *message.ProviderResources, logger logr.Logger) *ResourcesStore {
+	return &ResourcesStore{
 		name:      name,
 		keys:      sets.New[storeKey](),
 		client:    client,
@@ -74,14 +79,20 @@ func newStoreKey(obj client.Object) storeKey {
 	}
 }
 
-// ReloadAll loads and stores all resources from all given files and directories.
-func (r *resourcesStore) ReloadAll(ctx context.Context, files, dirs []string) error {
-	// TODO(sh2): add arbitrary number of resources support for load function.
-	resources, err := loadFromFilesAndDirs(files, dirs)
-	if err != nil {
-		return err
-	}
+// Name returns the name of the store.
+func (r *ResourcesStore) Name() string {
+	return r.name
+}
+
+// Reconcile returns the channel that receives a reconcile ID every time
+// the stored resources need to be reconciled.
+func (r *ResourcesStore) Reconcile() <-chan int64 {
+	return r.reconcile
+}
 
+// StoreAll stores all the given resources, and removes the previously stored
+// resources that no longer exist in them.
+func (r *ResourcesStore) StoreAll(ctx context.Context, resources []*resource.Resources) error {
 	var errList error
 	currentKeys := sets.New[storeKey]()
 	for _, res := range resources {
@@ -124,12 +135,12 @@ func (r *resourcesStore) ReloadAll(ctx context.Context, files, dirs []string) er
 }
 
 // storeResources stores resources via offline gateway-api client.
-// For file provider, all gateway-api resources will be stored except:
+// For offline providers, all gateway-api resources will be stored except:
 // - Service
 // - ServiceImport
 // - EndpointSlices
 // Becasues these resources has no effects on the host infra layer.
-func (r *resourcesStore) storeResources(ctx context.Context, re *resource.Resources) (sets.Set[storeKey], error) {
+func (r *ResourcesStore) storeResources(ctx context.Context, re *resource.Resources) (sets.Set[storeKey], error) {
 	if re == nil {
 		return nil, nil
 	}
@@ -265,7 +276,7 @@ func (r *resourcesStore) storeResources(ctx context.Context, re *resource.Resour
 }
 
 // stroeObjectWithKeys stores object while collecting its key.
-func (r *resourcesStore) stroeObjectWithKeys(ctx context.Context, obj client.Object, keys sets.Set[storeKey]) error {
+func (r *ResourcesStore) stroeObjectWithKeys(ctx context.Context, obj client.Object, keys sets.Set[storeKey]) error {
 	key, err := r.storeObject(ctx, obj)
 	if err != nil && key != nil {
 		return fmt.Errorf("failed to store %s %s: %w", key.Kind, key.NamespacedName.String(), err)
@@ -281,7 +292,7 @@ func (r *resourcesStore) stroeObjectWithKeys(ctx context.Context, obj client.Obj
 }
 
 // storeObject will do create for non-exist object and update for existing object.
-func (r *resourcesStore) storeObject(ctx context.Context, obj client.Object) (*storeKey, error) {
+func (r *ResourcesStore) storeObject(ctx context.Context, obj client.Object) (*storeKey, error) {
 	if obj == nil || reflect.ValueOf(obj).IsNil() {
 		return nil, nil
 	}
@@ -17,6 +17,7 @@ import (
 	"github.com/envoyproxy/gateway/internal/provider"
 	"github.com/envoyproxy/gateway/internal/provider/file"
 	"github.com/envoyproxy/gateway/internal/provider/kubernetes"
+	"github.com/envoyproxy/gateway/internal/provider/kv"
 )
 
 type Config struct {
@@ -95,6 +96,12 @@ func (r *Runner) createCustomResourceProvider(ctx context.Context) (p provider.P
 			return nil, fmt.Errorf("failed to create provider %s: %w", egv1a1.ProviderTypeCustom, err)
 		}
 
+	case egv1a1.ResourceProviderTypeKV:
+		p, err = kv.New(ctx, &r.Server, r.ProviderResources)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create provider %s: %w", egv1a1.ProviderTypeCustom, err)
+		}
+
 	default:
 		return nil, fmt.Errorf("unsupported resource provider type")
 	}
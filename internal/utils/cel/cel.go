@@ -0,0 +1,56 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package cel compiles the CEL expressions evaluated by Envoy against the attributes of the requests.
+// See https://www.envoyproxy.io/docs/envoy/latest/intro/arch_overview/advanced/attributes for the attributes.
+package cel
+
+import (
+	"fmt"
+
+	celgo "github.com/google/cel-go/cel"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// attributes are the top-level attributes provided by Envoy to the CEL expressions.
+var attributes = []string{
+	"request",
+	"response",
+	"connection",
+	"upstream",
+	"source",
+	"destination",
+	"metadata",
+	"filter_state",
+	"xds",
+}
+
+var env = func() *celgo.Env {
+	opts := make([]celgo.EnvOption, 0, len(attributes))
+	for _, attribute := range attributes {
+		opts = append(opts, celgo.Variable(attribute, celgo.DynType))
+	}
+	e, err := celgo.NewEnv(opts...)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}()
+
+// Compile compiles a CEL expression which must evaluate to a bool, and returns the parsed expression.
+func Compile(expr string) (*exprpb.ParsedExpr, error) {
+	ast, issues := env.Compile(expr)
+	if issues.Err() != nil {
+		return nil, fmt.Errorf("CEL expression %q is invalid: %w", expr, issues.Err())
+	}
+	if t := ast.OutputType(); !t.IsExactType(celgo.BoolType) && !t.IsExactType(celgo.DynType) {
+		return nil, fmt.Errorf("CEL expression %q is invalid: it evaluates to %s instead of bool", expr, t)
+	}
+	parsed, err := celgo.AstToParsedExpr(ast)
+	if err != nil {
+		return nil, fmt.Errorf("CEL expression %q is invalid: %w", expr, err)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,55 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr string
+	}{
+		{
+			name: "request attributes",
+			expr: "request.method in ['GET', 'HEAD'] && request.headers['x-tenant'] == 'foo'",
+		},
+		{
+			name: "client certificate and JWT claims",
+			expr: "connection.mtls && metadata.filter_metadata['envoy.filters.http.jwt_authn']['example'].sub == 'foo'",
+		},
+		{
+			name:    "syntax error",
+			expr:    "request.headers[",
+			wantErr: "Syntax error",
+		},
+		{
+			name:    "undeclared attribute",
+			expr:    "foo == 'bar'",
+			wantErr: "undeclared reference to 'foo'",
+		},
+		{
+			name:    "not a bool",
+			expr:    "request.size + 1",
+			wantErr: "evaluates to int instead of bool",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := Compile(tt.expr)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, parsed.GetExpr())
+		})
+	}
+}
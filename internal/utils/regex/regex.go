@@ -8,6 +8,13 @@ package regex
 import (
 	"fmt"
 	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+	"unicode"
+
+	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 // Validate validates a regex string.
@@ -17,3 +24,86 @@ func Validate(regex string) error {
 	}
 	return nil
 }
+
+// queryString matches the optional query string at the end of the :path pseudo-header.
+const queryString = `(\?.*)?`
+
+// PathMatch returns the regular expression matching the :path pseudo-header of the requests
+// matched by the path match of an HTTPRoute rule. The regular expression must match the whole
+// pseudo-header value.
+// Unlike the path of the route match, the :path pseudo-header includes the query string of the request,
+// which is ignored by the returned regular expression.
+func PathMatch(path *gwapiv1.HTTPPathMatch) (string, error) {
+	value := ptr.Deref(path.Value, "/")
+	switch ptr.Deref(path.Type, gwapiv1.PathMatchPathPrefix) {
+	case gwapiv1.PathMatchExact:
+		return regexp.QuoteMeta(value) + queryString, nil
+	case gwapiv1.PathMatchPathPrefix:
+		// The prefix is matched on a path element basis, in the same way as the path match of a route.
+		return regexp.QuoteMeta(strings.TrimSuffix(value, "/")) + `([/?].*)?`, nil
+	case gwapiv1.PathMatchRegularExpression:
+		if err := Validate(value); err != nil {
+			return "", err
+		}
+		return "(?:" + value + ")" + queryString, nil
+	default:
+		return "", fmt.Errorf("unsupported path match type %s", *path.Type)
+	}
+}
+
+// WithoutSeparator returns a regular expression equivalent to regex, except that it never matches the separator,
+// so it only matches within a single element of a list joined with the separator.
+// An error is returned if regex is invalid or has a literal separator.
+func WithoutSeparator(regex string, sep rune) (string, error) {
+	re, err := syntax.Parse(regex, syntax.Perl)
+	if err != nil {
+		return "", fmt.Errorf("regex %q is invalid: %w", regex, err)
+	}
+	if err := excludeRune(re, sep); err != nil {
+		return "", fmt.Errorf("regex %q is invalid: %w", regex, err)
+	}
+	return re.String(), nil
+}
+
+// excludeRune modifies the parsed regular expression so that none of its characters matches r.
+func excludeRune(re *syntax.Regexp, r rune) error {
+	switch re.Op {
+	case syntax.OpLiteral:
+		if slices.Contains(re.Rune, r) {
+			return fmt.Errorf("it must not contain the separator %q", r)
+		}
+	case syntax.OpAnyChar:
+		re.Op = syntax.OpCharClass
+		re.Rune = removeRune([]rune{0, unicode.MaxRune}, r)
+	case syntax.OpAnyCharNotNL:
+		re.Op = syntax.OpCharClass
+		re.Rune = removeRune([]rune{0, '\n' - 1, '\n' + 1, unicode.MaxRune}, r)
+	case syntax.OpCharClass:
+		re.Rune = removeRune(re.Rune, r)
+	}
+	for _, sub := range re.Sub {
+		if err := excludeRune(sub, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeRune removes r from the rune ranges of a character class.
+func removeRune(ranges []rune, r rune) []rune {
+	var result []rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+		if r < lo || r > hi {
+			result = append(result, lo, hi)
+			continue
+		}
+		if lo < r {
+			result = append(result, lo, r-1)
+		}
+		if r < hi {
+			result = append(result, r+1, hi)
+		}
+	}
+	return result
+}
@@ -5,7 +5,13 @@
 
 package regex
 
-import "testing"
+import (
+	"regexp"
+	"testing"
+
+	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
 
 func TestValidate(t *testing.T) {
 	tests := []struct {
@@ -32,3 +38,126 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestPathMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     gwapiv1.HTTPPathMatch
+		match    []string
+		notMatch []string
+		wantErr  bool
+	}{
+		{
+			name:     "Exact",
+			path:     gwapiv1.HTTPPathMatch{Type: ptr.To(gwapiv1.PathMatchExact), Value: ptr.To("/api/login")},
+			match:    []string{"/api/login", "/api/login?next=/"},
+			notMatch: []string{"/api/login/", "/api/logins", "/api"},
+		},
+		{
+			name:     "PathPrefix",
+			path:     gwapiv1.HTTPPathMatch{Type: ptr.To(gwapiv1.PathMatchPathPrefix), Value: ptr.To("/admin/")},
+			match:    []string{"/admin", "/admin/", "/admin/users", "/admin?debug=true"},
+			notMatch: []string{"/administrator", "/api/admin"},
+		},
+		{
+			name:  "Default",
+			path:  gwapiv1.HTTPPathMatch{},
+			match: []string{"/", "/api", "/api?foo=bar"},
+		},
+		{
+			name:     "RegularExpression",
+			path:     gwapiv1.HTTPPathMatch{Type: ptr.To(gwapiv1.PathMatchRegularExpression), Value: ptr.To("/users/[0-9]+")},
+			match:    []string{"/users/1", "/users/1?foo=bar"},
+			notMatch: []string{"/users/a"},
+		},
+		{
+			name:    "Invalid regular expression",
+			path:    gwapiv1.HTTPPathMatch{Type: ptr.To(gwapiv1.PathMatchRegularExpression), Value: ptr.To("/users/[0-9+")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PathMatch(&tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PathMatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			// The regular expressions are matched against the whole value, in the same way as Envoy does.
+			re := regexp.MustCompile("^(?:" + got + ")$")
+			for _, p := range tt.match {
+				if !re.MatchString(p) {
+					t.Errorf("PathMatch() = %q, expected to match %q", got, p)
+				}
+			}
+			for _, p := range tt.notMatch {
+				if re.MatchString(p) {
+					t.Errorf("PathMatch() = %q, expected not to match %q", got, p)
+				}
+			}
+		})
+	}
+}
+
+func TestWithoutSeparator(t *testing.T) {
+	tests := []struct {
+		name     string
+		regex    string
+		match    []string
+		notMatch []string
+		wantErr  bool
+	}{
+		{
+			name:     "any character",
+			regex:    "spiffe://example.com/.*",
+			match:    []string{"spiffe://example.com/ns/default"},
+			notMatch: []string{"spiffe://example.com/a,spiffe://other.com/b"},
+		},
+		{
+			name:     "negated character class",
+			regex:    "[^/]+\\.example\\.com",
+			match:    []string{"api.example.com"},
+			notMatch: []string{"a,b.example.com"},
+		},
+		{
+			name:     "character class with the separator",
+			regex:    "[a-z,]+",
+			match:    []string{"abc"},
+			notMatch: []string{"a,b"},
+		},
+		{
+			name:    "literal separator",
+			regex:   "a,b",
+			wantErr: true,
+		},
+		{
+			name:    "invalid regex",
+			regex:   "[a-z",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WithoutSeparator(tt.regex, ',')
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WithoutSeparator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			re := regexp.MustCompile("^(?:" + got + ")$")
+			for _, v := range tt.match {
+				if !re.MatchString(v) {
+					t.Errorf("WithoutSeparator() = %q, expected to match %q", got, v)
+				}
+			}
+			for _, v := range tt.notMatch {
+				if re.MatchString(v) {
+					t.Errorf("WithoutSeparator() = %q, expected not to match %q", got, v)
+				}
+			}
+		})
+	}
+}
@@ -144,7 +144,9 @@ type RenderBootstrapConfigOptions struct {
 	ServiceClusterName       *string
 	XdsServerHost            *string
 	XdsServerPort            *int32
+	AdminServerHost          *string
 	AdminServerPort          *int32
+	StatsServerHost          *string
 	StatsServerPort          *int32
 	MaxHeapSizeBytes         uint64
 	GatewayNamespaceMode     bool
@@ -305,6 +307,14 @@ func GetRenderedBootstrapConfig(opts *RenderBootstrapConfigOptions) (string, err
 				cfg.parameters.StatsServer.Address = netutils.IPv6ListenerAddress
 			}
 		}
+
+		// Override the various server host after the IP family is applied.
+		if opts.AdminServerHost != nil {
+			cfg.parameters.AdminServer.Address = *opts.AdminServerHost
+		}
+		if opts.StatsServerHost != nil {
+			cfg.parameters.StatsServer.Address = *opts.StatsServerHost
+		}
 		cfg.parameters.GatewayNamespaceMode = opts.GatewayNamespaceMode
 		cfg.parameters.OverloadManager.MaxHeapSizeBytes = opts.MaxHeapSizeBytes
 		if opts.ServiceClusterName != nil {
@@ -12,6 +12,7 @@ cluster_manager:
   local_cluster_name: {{ .ServiceClusterName }}
 node:
   locality:
+    region: $(ENVOY_SERVICE_REGION)
     zone: $(ENVOY_SERVICE_ZONE)
 {{- if .StatsMatcher  }}
 stats_config:
@@ -167,6 +167,16 @@ func TestGetRenderedBootstrapConfig(t *testing.T) {
 				SdsConfig:       sds,
 			},
 		},
+		{
+			name: "custom-server-host",
+			opts: &RenderBootstrapConfigOptions{
+				AdminServerHost: ptr.To("0.0.0.0"),
+				AdminServerPort: ptr.To(int32(2222)),
+				StatsServerHost: ptr.To("127.0.0.1"),
+				StatsServerPort: ptr.To(int32(3333)),
+				SdsConfig:       sds,
+			},
+		},
 		{
 			name: "with-max-heap-size-bytes",
 			opts: &RenderBootstrapConfigOptions{
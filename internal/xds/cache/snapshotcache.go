@@ -22,7 +22,9 @@ import (
 	"time"
 
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
 	serverv3 "github.com/envoyproxy/go-control-plane/pkg/server/v3"
 	"go.uber.org/zap"
@@ -92,14 +94,14 @@ func (s *snapshotCache) GenerateNewSnapshot(irKey string, resources types.XdsRes
 
 	s.lastSnapshot[irKey] = snapshot
 
-	for _, node := range s.getNodeIDs(irKey) {
-		s.log.Debugf("Generating a snapshot with Node %s", node)
+	for _, node := range s.getNodes(irKey) {
+		s.log.Debugf("Generating a snapshot with Node %s", node.Id)
 
-		if err = s.SetSnapshot(context.TODO(), node, snapshot); err != nil {
-			xdsSnapshotUpdateTotal.WithFailure(metrics.ReasonError, nodeIDLabel.Value(node)).Increment()
+		if err = s.SetSnapshot(context.TODO(), node.Id, localizeSnapshot(snapshot, node)); err != nil {
+			xdsSnapshotUpdateTotal.WithFailure(metrics.ReasonError, nodeIDLabel.Value(node.Id)).Increment()
 			return err
 		} else {
-			xdsSnapshotUpdateTotal.WithSuccess(nodeIDLabel.Value(node)).Increment()
+			xdsSnapshotUpdateTotal.WithSuccess(nodeIDLabel.Value(node.Id)).Increment()
 		}
 	}
 
@@ -136,17 +138,43 @@ func NewSnapshotCache(ads bool, logger logging.Logger) SnapshotCacheWithCallback
 	}
 }
 
-// getNodeIDs retrieves the node ids from the node info map whose
+// getNodes retrieves the nodes from the node info map whose
 // cluster field matches the ir key
-func (s *snapshotCache) getNodeIDs(irKey string) []string {
-	var nodeIDs []string
+func (s *snapshotCache) getNodes(irKey string) []*corev3.Node {
+	var nodes []*corev3.Node
 	for _, node := range s.streamIDNodeInfo {
 		if node != nil && node.Cluster == irKey {
-			nodeIDs = append(nodeIDs, node.Id)
+			nodes = append(nodes, node)
 		}
 	}
 
-	return nodeIDs
+	return nodes
+}
+
+// localizeSnapshot returns the snapshot for the node, where the localities of the endpoints
+// which are ordered by their distance to the proxy are prioritized from the node locality.
+// The snapshot is shared by the nodes if it has no such locality.
+func localizeSnapshot(snapshot *cachev3.Snapshot, node *corev3.Node) *cachev3.Snapshot {
+	endpoints := snapshot.Resources[cachetypes.Endpoint]
+	items := make([]cachetypes.Resource, 0, len(endpoints.Items))
+	localized := false
+	for _, item := range endpoints.Items {
+		res := item.Resource
+		if cla, ok := res.(*endpointv3.ClusterLoadAssignment); ok {
+			if l := types.LocalizeLoadAssignment(cla, node.GetLocality()); l != cla {
+				res = l
+				localized = true
+			}
+		}
+		items = append(items, res)
+	}
+	if !localized {
+		return snapshot
+	}
+
+	nodeSnapshot := &cachev3.Snapshot{Resources: snapshot.Resources}
+	nodeSnapshot.Resources[cachetypes.Endpoint] = cachev3.NewResources(endpoints.Version, items)
+	return nodeSnapshot
 }
 
 // OnStreamOpen and the other OnStream* functions implement the callbacks for the
@@ -221,7 +249,7 @@ func (s *snapshotCache) OnStreamRequest(streamID int64, req *discoveryv3.Discove
 
 	_, err := s.GetSnapshot(nodeID)
 	if err != nil {
-		err = s.SetSnapshot(context.TODO(), nodeID, s.lastSnapshot[cluster])
+		err = s.SetSnapshot(context.TODO(), nodeID, localizeSnapshot(s.lastSnapshot[cluster], s.streamIDNodeInfo[streamID]))
 		if err != nil {
 			return err
 		}
@@ -339,7 +367,7 @@ func (s *snapshotCache) OnStreamDeltaRequest(streamID int64, req *discoveryv3.De
 
 	_, err := s.GetSnapshot(nodeID)
 	if err != nil {
-		err = s.SetSnapshot(context.TODO(), nodeID, s.lastSnapshot[cluster])
+		err = s.SetSnapshot(context.TODO(), nodeID, localizeSnapshot(s.lastSnapshot[cluster], s.streamIDNodeInfo[streamID]))
 		if err != nil {
 			return err
 		}
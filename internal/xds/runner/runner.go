@@ -10,10 +10,12 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"time"
 
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
 	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/service/cluster/v3"
 	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/service/endpoint/v3"
@@ -29,6 +31,7 @@ import (
 	ktypes "k8s.io/apimachinery/pkg/types"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/basicauth"
 	"github.com/envoyproxy/gateway/internal/crypto"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	extension "github.com/envoyproxy/gateway/internal/extension/types"
@@ -55,12 +58,6 @@ const (
 	// xdsTLSCaFilepath is the fully qualified path of the file containing the
 	// xDS server trusted CA certificate.
 	xdsTLSCaFilepath = "/certs/ca.crt"
-
-	// TODO: Make these path configurable.
-	// Default certificates path for envoy-gateway with Host infrastructure provider.
-	localTLSCertFilepath = "/tmp/envoy-gateway/certs/envoy-gateway/tls.crt"
-	localTLSKeyFilepath  = "/tmp/envoy-gateway/certs/envoy-gateway/tls.key"
-	localTLSCaFilepath   = "/tmp/envoy-gateway/certs/envoy-gateway/ca.crt"
 	// defaultKubernetesIssuer is the default issuer URL for Kubernetes.
 	// This is used for validating Service Account JWT tokens.
 	defaultKubernetesIssuer = "https://kubernetes.default.svc.cluster.local"
@@ -73,6 +70,13 @@ type Config struct {
 	XdsIR             *message.XdsIR
 	ExtensionManager  extension.Manager
 	ProviderResources *message.ProviderResources
+	// RateLimitServiceURL and RateLimitCACertFilepath override the URL of the rate limit service
+	// and the CA certificate the proxies verify it with, e.g. with the Host infrastructure provider.
+	RateLimitServiceURL     string
+	RateLimitCACertFilepath string
+	// LocalCertsDir is the directory of the local certificates, which are loaded with the Host
+	// infrastructure provider.
+	LocalCertsDir string
 	// Test-configurable TLS paths
 	TLSCertPath string
 	TLSKeyPath  string
@@ -81,10 +85,12 @@ type Config struct {
 
 type Runner struct {
 	Config
+	// basicAuth verifies the basic auth credentials and API keys that Envoy can't verify.
+	basicAuth *basicauth.Service
 }
 
 func New(cfg *Config) *Runner {
-	return &Runner{Config: *cfg}
+	return &Runner{Config: *cfg, basicAuth: basicauth.New()}
 }
 
 func (r *Runner) Name() string {
@@ -148,6 +154,7 @@ func (r *Runner) Start(ctx context.Context) (err error) {
 
 	r.grpc = grpc.NewServer(grpcOpts...)
 	registerServer(serverv3.NewServer(ctx, r.cache, r.cache), r.grpc)
+	authv3.RegisterAuthorizationServer(r.grpc, r.basicAuth)
 
 	// Start and listen xDS gRPC Server.
 	go r.serveXdsServer(ctx)
@@ -205,11 +212,19 @@ func (r *Runner) subscribeAndTranslate(sub <-chan watchable.Snapshot[string, *ir
 			val := update.Value
 
 			if update.Delete {
+				r.basicAuth.Delete(key)
 				if err := r.cache.GenerateNewSnapshot(key, nil); err != nil {
 					r.Logger.Error(err, "failed to delete the snapshot")
 					errChan <- err
 				}
 			} else {
+				// Update the basic auth credentials and API keys verified by Envoy Gateway before the proxies
+				// are configured to send them.
+				if err := r.basicAuth.Update(key, val); err != nil {
+					r.Logger.Error(err, "failed to update the basic auth service")
+					errChan <- err
+				}
+
 				// Translate to xds resources
 				t := &translator.Translator{
 					ControllerNamespace: r.ControllerNamespace,
@@ -229,6 +244,15 @@ func (r *Runner) subscribeAndTranslate(sub <-chan watchable.Snapshot[string, *ir
 						ServiceURL: ratelimit.GetServiceURL(r.ControllerNamespace, r.DNSDomain),
 						FailClosed: r.EnvoyGateway.RateLimit.FailClosed,
 					}
+					// The rate limit service is served by Envoy Gateway itself with the Local backend.
+					if r.EnvoyGateway.RateLimit.Backend.Type == egv1a1.LocalBackendType {
+						t.GlobalRateLimit.ServiceURL = ratelimit.GetLocalServiceURL(r.ControllerNamespace, r.DNSDomain)
+					}
+					// The rate limit service runs on the same host as the proxies with the Host infrastructure provider.
+					if r.RateLimitServiceURL != "" {
+						t.GlobalRateLimit.ServiceURL = r.RateLimitServiceURL
+						t.GlobalRateLimit.CACertFilepath = r.RateLimitCACertFilepath
+					}
 					if r.EnvoyGateway.RateLimit.Timeout != nil {
 						d, err := time.ParseDuration(string(*r.EnvoyGateway.RateLimit.Timeout))
 						if err != nil {
@@ -322,9 +346,9 @@ func (r *Runner) loadTLSConfig() (tlsConfig *tls.Config, err error) {
 			keyPath = xdsTLSKeyFilepath
 			caPath = xdsTLSCaFilepath
 		case r.EnvoyGateway.Provider.IsRunningOnHost():
-			certPath = localTLSCertFilepath
-			keyPath = localTLSKeyFilepath
-			caPath = localTLSCaFilepath
+			certPath = filepath.Join(r.LocalCertsDir, "envoy-gateway", "tls.crt")
+			keyPath = filepath.Join(r.LocalCertsDir, "envoy-gateway", "tls.key")
+			caPath = filepath.Join(r.LocalCertsDir, "envoy-gateway", "ca.crt")
 		default:
 			return nil, fmt.Errorf("no valid tls certificates")
 		}
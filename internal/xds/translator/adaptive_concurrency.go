@@ -0,0 +1,175 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"errors"
+	"time"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	adaptiveconcurrencyv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/adaptive_concurrency/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xdstype "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/utils/proto"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+const (
+	defaultAdaptiveConcurrencyUpdateInterval = 100 * time.Millisecond
+	defaultAdaptiveConcurrencyMinRTTInterval = 60 * time.Second
+)
+
+func init() {
+	registerHTTPFilter(&adaptiveConcurrency{})
+}
+
+type adaptiveConcurrency struct{}
+
+var _ httpFilter = &adaptiveConcurrency{}
+
+// patchHCM builds and appends the adaptive concurrency Filters to the HTTP Connection Manager
+// if applicable, and it does not already exist.
+// Note: this method creates an adaptive concurrency filter for each route that contains an
+// adaptive concurrency config, as the filter has no per-route config: the concurrency limit is
+// computed for each route separately, and shared by the backends of the route.
+// The filter is disabled by default. It is enabled on the route level.
+func (*adaptiveConcurrency) patchHCM(mgr *hcmv3.HttpConnectionManager, irListener *ir.HTTPListener) error {
+	var errs error
+
+	if mgr == nil {
+		return errors.New("hcm is nil")
+	}
+	if irListener == nil {
+		return errors.New("ir listener is nil")
+	}
+
+	for _, route := range irListener.Routes {
+		if !routeContainsAdaptiveConcurrency(route) {
+			continue
+		}
+
+		filterName := adaptiveConcurrencyFilterName(route)
+		if hcmContainsFilter(mgr, filterName) {
+			continue
+		}
+
+		filter, err := buildHCMAdaptiveConcurrencyFilter(filterName, route.Traffic.AdaptiveConcurrency)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		mgr.HttpFilters = append(mgr.HttpFilters, filter)
+	}
+
+	return errs
+}
+
+// buildHCMAdaptiveConcurrencyFilter returns an adaptive concurrency HTTP filter from the provided IR.
+func buildHCMAdaptiveConcurrencyFilter(name string, ac *ir.AdaptiveConcurrency) (*hcmv3.HttpFilter, error) {
+	acAny, err := proto.ToAnyWithValidation(buildAdaptiveConcurrencyConfig(ac))
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcmv3.HttpFilter{
+		Name:     name,
+		Disabled: true,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: acAny,
+		},
+	}, nil
+}
+
+func buildAdaptiveConcurrencyConfig(ac *ir.AdaptiveConcurrency) *adaptiveconcurrencyv3.AdaptiveConcurrency {
+	limitParams := &adaptiveconcurrencyv3.GradientControllerConfig_ConcurrencyLimitCalculationParams{
+		ConcurrencyUpdateInterval: durationpb.New(defaultAdaptiveConcurrencyUpdateInterval),
+	}
+	if ac.MaxConcurrencyLimit != nil {
+		limitParams.MaxConcurrencyLimit = wrapperspb.UInt32(*ac.MaxConcurrencyLimit)
+	}
+	if ac.ConcurrencyUpdateInterval != nil {
+		limitParams.ConcurrencyUpdateInterval = durationpb.New(ac.ConcurrencyUpdateInterval.Duration)
+	}
+
+	minRTTParams := &adaptiveconcurrencyv3.GradientControllerConfig_MinimumRTTCalculationParams{}
+	switch {
+	case ac.MinRTTFixedValue != nil:
+		// Leaving the interval unset disables the dynamic sampling of the minRTT.
+		minRTTParams.FixedValue = durationpb.New(ac.MinRTTFixedValue.Duration)
+	case ac.MinRTTInterval != nil:
+		minRTTParams.Interval = durationpb.New(ac.MinRTTInterval.Duration)
+	default:
+		minRTTParams.Interval = durationpb.New(defaultAdaptiveConcurrencyMinRTTInterval)
+	}
+	if ac.MinRTTRequestCount != nil {
+		minRTTParams.RequestCount = wrapperspb.UInt32(*ac.MinRTTRequestCount)
+	}
+	if ac.MinRTTJitter != nil {
+		minRTTParams.Jitter = &xdstype.Percent{Value: float64(*ac.MinRTTJitter)}
+	}
+	if ac.MinConcurrency != nil {
+		minRTTParams.MinConcurrency = wrapperspb.UInt32(*ac.MinConcurrency)
+	}
+	if ac.MinRTTBuffer != nil {
+		minRTTParams.Buffer = &xdstype.Percent{Value: float64(*ac.MinRTTBuffer)}
+	}
+
+	gradient := &adaptiveconcurrencyv3.GradientControllerConfig{
+		ConcurrencyLimitParams: limitParams,
+		MinRttCalcParams:       minRTTParams,
+	}
+	if ac.SampleAggregatePercentile != nil {
+		gradient.SampleAggregatePercentile = &xdstype.Percent{Value: float64(*ac.SampleAggregatePercentile)}
+	}
+
+	acProto := &adaptiveconcurrencyv3.AdaptiveConcurrency{
+		ConcurrencyControllerConfig: &adaptiveconcurrencyv3.AdaptiveConcurrency_GradientControllerConfig{
+			GradientControllerConfig: gradient,
+		},
+	}
+	if ac.ConcurrencyLimitExceededStatus != nil {
+		acProto.ConcurrencyLimitExceededStatus = &xdstype.HttpStatus{
+			Code: xdstype.StatusCode(*ac.ConcurrencyLimitExceededStatus),
+		}
+	}
+
+	return acProto
+}
+
+func adaptiveConcurrencyFilterName(route *ir.HTTPRoute) string {
+	return perRouteFilterName(egv1a1.EnvoyFilterAdaptiveConcurrency, route.Name)
+}
+
+// routeContainsAdaptiveConcurrency returns true if the provided route has an adaptive concurrency config.
+func routeContainsAdaptiveConcurrency(irRoute *ir.HTTPRoute) bool {
+	return irRoute != nil &&
+		irRoute.Traffic != nil &&
+		irRoute.Traffic.AdaptiveConcurrency != nil
+}
+
+// patchRoute enables the adaptive concurrency filter of the route, if applicable.
+func (*adaptiveConcurrency) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute, _ *ir.HTTPListener) error {
+	if route == nil {
+		return errors.New("xds route is nil")
+	}
+	if irRoute == nil {
+		return errors.New("ir route is nil")
+	}
+	if !routeContainsAdaptiveConcurrency(irRoute) {
+		return nil
+	}
+
+	return enableFilterOnRoute(route, adaptiveConcurrencyFilterName(irRoute))
+}
+
+func (*adaptiveConcurrency) patchResources(*types.ResourceVersionTable, []*ir.HTTPRoute) error {
+	return nil
+}
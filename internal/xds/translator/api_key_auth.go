@@ -8,6 +8,7 @@ package translator
 import (
 	"errors"
 	"fmt"
+	"slices"
 
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	apikeyauthv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/api_key_auth/v3"
@@ -16,6 +17,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/basicauth"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/utils/proto"
 	"github.com/envoyproxy/gateway/internal/xds/types"
@@ -31,6 +33,8 @@ var _ httpFilter = &apiKeyAuth{}
 
 // patchHCM builds and appends the api_key_auth Filter to the HTTP Connection Manager
 // if applicable, and it does not already exist.
+// The API keys verified by Envoy Gateway are verified by an ext_authz filter for each unique
+// name instead.
 func (*apiKeyAuth) patchHCM(mgr *hcmv3.HttpConnectionManager, irListener *ir.HTTPListener) error {
 	if mgr == nil {
 		return errors.New("hcm is nil")
@@ -38,33 +42,77 @@ func (*apiKeyAuth) patchHCM(mgr *hcmv3.HttpConnectionManager, irListener *ir.HTT
 	if irListener == nil {
 		return errors.New("ir listener is nil")
 	}
-	if hcmContainsFilter(mgr, egv1a1.EnvoyFilterAPIKeyAuth.String()) {
-		return nil
-	}
 
 	var (
 		irAPIKeyAuth *ir.APIKeyAuth
 		filter       *hcmv3.HttpFilter
+		errs         error
 		err          error
 	)
 
 	for _, route := range irListener.Routes {
-		if route.Security != nil && route.Security.APIKeyAuth != nil {
-			irAPIKeyAuth = route.Security.APIKeyAuth
-			break
+		if route.Security == nil || route.Security.APIKeyAuth == nil {
+			continue
+		}
+		if !route.Security.APIKeyAuth.VerifiedByEnvoyGateway() {
+			if irAPIKeyAuth == nil {
+				irAPIKeyAuth = route.Security.APIKeyAuth
+			}
+			continue
 		}
+		if hcmContainsFilter(mgr, apiKeyAuthVerifierFilterName(route.Security.APIKeyAuth)) {
+			continue
+		}
+		if filter, err = buildHCMAPIKeyAuthVerifierFilter(route.Security.APIKeyAuth); err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		mgr.HttpFilters = append(mgr.HttpFilters, filter)
 	}
-	if irAPIKeyAuth == nil {
-		return nil
+	if irAPIKeyAuth == nil || hcmContainsFilter(mgr, egv1a1.EnvoyFilterAPIKeyAuth.String()) {
+		return errs
 	}
 
 	// We use the first route that contains the api key auth config to build the filter.
 	// The HCM-level filter config doesn't matter since it is overridden at the route level.
 	if filter, err = buildHCMAPIKeyAuthFilter(irAPIKeyAuth); err != nil {
-		return err
+		return errors.Join(errs, err)
 	}
 	mgr.HttpFilters = append(mgr.HttpFilters, filter)
-	return err
+	return errs
+}
+
+// buildHCMAPIKeyAuthVerifierFilter returns an ext_authz HTTP filter calling the basic auth service
+// of Envoy Gateway, which verifies the hashed API keys and the API keys with metadata, and returns
+// the client id and the metadata of the API key as dynamic metadata.
+func buildHCMAPIKeyAuthVerifierFilter(apiKeyAuth *ir.APIKeyAuth) (*hcmv3.HttpFilter, error) {
+	var headers []string
+	for _, e := range apiKeyAuth.ExtractFrom {
+		headers = append(headers, e.Headers...)
+		if len(e.Cookies) > 0 && !slices.Contains(headers, "cookie") {
+			headers = append(headers, "cookie")
+		}
+	}
+	// The path holding the query parameters is always sent, at least one header must be allowed.
+	if len(headers) == 0 {
+		headers = append(headers, ":path")
+	}
+	verifierAny, err := proto.ToAnyWithValidation(verifierConfig(headers...))
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcmv3.HttpFilter{
+		Name: apiKeyAuthVerifierFilterName(apiKeyAuth),
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: verifierAny,
+		},
+		Disabled: true,
+	}, nil
+}
+
+func apiKeyAuthVerifierFilterName(apiKeyAuth *ir.APIKeyAuth) string {
+	return perRouteFilterName(egv1a1.EnvoyFilterAPIKeyAuth, apiKeyAuth.Name)
 }
 
 // buildHCMAPIKeyAuthFilter returns a api_key_auth HTTP filter from the provided IR HTTPRoute.
@@ -101,17 +149,30 @@ func (*apiKeyAuth) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute, _ *ir
 		return nil
 	}
 
+	filterName := egv1a1.EnvoyFilterAPIKeyAuth.String()
+	if irRoute.Security.APIKeyAuth.VerifiedByEnvoyGateway() {
+		filterName = apiKeyAuthVerifierFilterName(irRoute.Security.APIKeyAuth)
+	}
 	perFilterCfg := route.GetTypedPerFilterConfig()
-	if _, ok := perFilterCfg[egv1a1.EnvoyFilterAPIKeyAuth.String()]; ok {
+	if _, ok := perFilterCfg[filterName]; ok {
 		// This should not happen since this is the only place where the filter
 		// config is added in a route.
 		return fmt.Errorf("route already contains filter config: %s, %+v",
-			egv1a1.EnvoyFilterAPIKeyAuth.String(), route)
+			filterName, route)
 	}
 
 	// Overwrite the HCM level filter config with the per route filter config.
-	apiKeyAuthProto := buildAPIKeyAuthFilterPerRouteConfig(irRoute.Security.APIKeyAuth)
-	apiKeyAuthAny, err := proto.ToAnyWithValidation(apiKeyAuthProto)
+	// The basic auth service of Envoy Gateway looks up the config by its name, which is sent in
+	// the context extensions.
+	var (
+		apiKeyAuthAny *anypb.Any
+		err           error
+	)
+	if irRoute.Security.APIKeyAuth.VerifiedByEnvoyGateway() {
+		apiKeyAuthAny, err = verifierPerRouteConfig(basicauth.APIKeyContextExtensionKey, irRoute.Security.APIKeyAuth.Name)
+	} else {
+		apiKeyAuthAny, err = proto.ToAnyWithValidation(buildAPIKeyAuthFilterPerRouteConfig(irRoute.Security.APIKeyAuth))
+	}
 	if err != nil {
 		return err
 	}
@@ -119,7 +180,7 @@ func (*apiKeyAuth) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute, _ *ir
 	if perFilterCfg == nil {
 		route.TypedPerFilterConfig = make(map[string]*anypb.Any)
 	}
-	route.TypedPerFilterConfig[egv1a1.EnvoyFilterAPIKeyAuth.String()] = apiKeyAuthAny
+	route.TypedPerFilterConfig[filterName] = apiKeyAuthAny
 
 	return nil
 }
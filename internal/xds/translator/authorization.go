@@ -8,26 +8,36 @@ package translator
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
 	cncfv3 "github.com/cncf/xds/go/xds/core/v3"
 	matcherv3 "github.com/cncf/xds/go/xds/type/matcher/v3"
+	typev3 "github.com/cncf/xds/go/xds/type/v3"
+	mutation_rulesv3 "github.com/envoyproxy/go-control-plane/envoy/config/common/mutation_rules/v3"
 	configv3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	rbacconfigv3 "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	rbacv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
 	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	early_header_mutationv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/early_header_mutation/header_mutation/v3"
 	networkinput "github.com/envoyproxy/go-control-plane/envoy/extensions/matching/common_inputs/network/v3"
+	sslinputv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/matching/common_inputs/ssl/v3"
 	ipmatcherv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/matching/input_matchers/ip/v3"
 	metadatav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/matching/input_matchers/metadata/v3"
 	envoymatcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	protobuf "google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
+	"k8s.io/utils/ptr"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/utils/cel"
 	"github.com/envoyproxy/gateway/internal/utils/proto"
+	"github.com/envoyproxy/gateway/internal/utils/regex"
 	"github.com/envoyproxy/gateway/internal/xds/types"
 )
 
@@ -37,6 +47,17 @@ func init() {
 
 type rbac struct{}
 
+const (
+	// clientCertificateIssuerHeader is the internal request header set to the issuer of the client certificate,
+	// which is not provided by the inputs of the RBAC matcher.
+	clientCertificateIssuerHeader = "x-envoy-gateway-client-cert-issuer"
+	// clientCertificateEmailsHeader is the internal request header set to the email SANs of the client certificate,
+	// joined with commas, which are not provided by the inputs of the RBAC matcher.
+	clientCertificateEmailsHeader = "x-envoy-gateway-client-cert-emails"
+	// clientCertificateHeaderMutation is the name of the early header mutation setting the client certificate headers.
+	clientCertificateHeaderMutation = "envoy.http.early_header_mutation.header_mutation/client-certificate"
+)
+
 var _ httpFilter = &rbac{}
 
 // patchHCM builds and appends the RBAC Filter to the HTTP Connection Manager if
@@ -57,6 +78,12 @@ func (*rbac) patchHCM(
 		return nil
 	}
 
+	if listenerContainsClientCertificateHeaders(irListener) {
+		if err := patchHCMWithClientCertificateHeaders(mgr); err != nil {
+			return err
+		}
+	}
+
 	// Return early if filter already exists.
 	for _, f := range mgr.HttpFilters {
 		if f.Name == egv1a1.EnvoyFilterRBAC.String() {
@@ -98,7 +125,7 @@ func listenerContainsRBAC(irListener *ir.HTTPListener) bool {
 	}
 
 	for _, route := range irListener.Routes {
-		if route.Security != nil && route.Security.Authorization != nil {
+		if route.Security != nil && (route.Security.Authorization != nil || route.Security.PolicyAuth != nil) {
 			return true
 		}
 	}
@@ -106,15 +133,92 @@ func listenerContainsRBAC(irListener *ir.HTTPListener) bool {
 	return false
 }
 
+// listenerContainsClientCertificateHeaders returns true if the client certificate principals of the provided
+// listener match the issuer or the email SANs, which are matched on internal request headers.
+func listenerContainsClientCertificateHeaders(irListener *ir.HTTPListener) bool {
+	if irListener == nil {
+		return false
+	}
+
+	for _, route := range irListener.Routes {
+		if route.Security == nil || route.Security.Authorization == nil {
+			continue
+		}
+		for _, rule := range route.Security.Authorization.Rules {
+			if principal := rule.Principal.ClientCertificate; principal != nil &&
+				(len(principal.Issuers) > 0 || len(principal.Emails) > 0) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// patchHCMWithClientCertificateHeaders adds the early header mutation setting the client certificate headers
+// to the HTTP Connection Manager. The headers sent by the client are removed first, so they can't be spoofed.
+func patchHCMWithClientCertificateHeaders(mgr *hcmv3.HttpConnectionManager) error {
+	for _, ext := range mgr.EarlyHeaderMutationExtensions {
+		if ext.Name == clientCertificateHeaderMutation {
+			return nil
+		}
+	}
+
+	var mutations []*mutation_rulesv3.HeaderMutation
+	for _, header := range []struct {
+		name  string
+		value string
+	}{
+		{name: clientCertificateIssuerHeader, value: "%DOWNSTREAM_PEER_ISSUER%"},
+		{name: clientCertificateEmailsHeader, value: "%DOWNSTREAM_PEER_EMAIL_SAN%"},
+	} {
+		mutations = append(mutations,
+			&mutation_rulesv3.HeaderMutation{
+				Action: &mutation_rulesv3.HeaderMutation_Remove{
+					Remove: header.name,
+				},
+			},
+			&mutation_rulesv3.HeaderMutation{
+				Action: &mutation_rulesv3.HeaderMutation_Append{
+					Append: &configv3.HeaderValueOption{
+						Header: &configv3.HeaderValue{
+							Key:   header.name,
+							Value: header.value,
+						},
+						AppendAction: configv3.HeaderValueOption_ADD_IF_ABSENT,
+					},
+				},
+			})
+	}
+
+	mutationAny, err := proto.ToAnyWithValidation(&early_header_mutationv3.HeaderMutation{
+		Mutations: mutations,
+	})
+	if err != nil {
+		return err
+	}
+
+	mgr.EarlyHeaderMutationExtensions = append(mgr.EarlyHeaderMutationExtensions, &configv3.TypedExtensionConfig{
+		Name:        clientCertificateHeaderMutation,
+		TypedConfig: mutationAny,
+	})
+	return nil
+}
+
 // patchRoute patches the provided route with the RBAC config if applicable.
-func (*rbac) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute, _ *ir.HTTPListener) error {
+func (*rbac) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute, irListener *ir.HTTPListener) error {
 	if route == nil {
 		return errors.New("xds route is nil")
 	}
 	if irRoute == nil {
 		return errors.New("ir route is nil")
 	}
-	if irRoute.Security == nil || irRoute.Security.Authorization == nil {
+	// The client certificate headers are set on all the requests of the listener, and only used by the RBAC filter.
+	if listenerContainsClientCertificateHeaders(irListener) {
+		route.RequestHeadersToRemove = append(route.RequestHeadersToRemove,
+			clientCertificateIssuerHeader, clientCertificateEmailsHeader)
+	}
+	if irRoute.Security == nil || (irRoute.Security.Authorization == nil && irRoute.Security.PolicyAuth == nil) {
 		return nil
 	}
 
@@ -131,7 +235,7 @@ func (*rbac) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute, _ *ir.HTTPL
 		err          error
 	)
 
-	if rbacPerRoute, err = buildRBACPerRoute(irRoute.Security.Authorization); err != nil {
+	if rbacPerRoute, err = buildRBACPerRoute(irRoute.Security.Authorization, irRoute.Security.PolicyAuth); err != nil {
 		return err
 	}
 
@@ -148,7 +252,7 @@ func (*rbac) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute, _ *ir.HTTPL
 	return nil
 }
 
-func buildRBACPerRoute(authorization *ir.Authorization) (*rbacv3.RBACPerRoute, error) {
+func buildRBACPerRoute(authorization *ir.Authorization, policyAuth *ir.PolicyAuth) (*rbacv3.RBACPerRoute, error) {
 	var (
 		rbac        *rbacv3.RBACPerRoute
 		allowAction *anypb.Any
@@ -173,6 +277,31 @@ func buildRBACPerRoute(authorization *ir.Authorization) (*rbacv3.RBACPerRoute, e
 		return nil, err
 	}
 
+	// The requests denied by the policy are denied before evaluating the rules,
+	// so that the requests must be allowed by both the policy and the rules.
+	if policyAuth != nil {
+		var policyPredicate *matcherv3.Matcher_MatcherList_Predicate
+		if policyPredicate, err = buildPolicyAuthDenyPredicate(policyAuth); err != nil {
+			return nil, err
+		}
+		matcherList = append(matcherList, &matcherv3.Matcher_MatcherList_FieldMatcher{
+			Predicate: policyPredicate,
+			OnMatch: &matcherv3.Matcher_OnMatch{
+				OnMatch: &matcherv3.Matcher_OnMatch_Action{
+					Action: &cncfv3.TypedExtensionConfig{
+						Name:        policyAuth.Name,
+						TypedConfig: denyAction,
+					},
+				},
+			},
+		})
+	}
+
+	// Without any authorization rules, the requests allowed by the policy are allowed.
+	if authorization == nil {
+		authorization = &ir.Authorization{DefaultAction: egv1a1.AuthorizationActionAllow}
+	}
+
 	// Build a list of matchers based on the rules.
 	// The matchers will be evaluated in order, and the first one that matches
 	// will be used to determine the action, the rest of the matchers will be
@@ -183,6 +312,15 @@ func buildRBACPerRoute(authorization *ir.Authorization) (*rbacv3.RBACPerRoute, e
 			// Predicates for HTTP methods.
 			methodPredicate *matcherv3.Matcher_MatcherList_Predicate
 
+			// Predicates for paths.
+			pathPredicate *matcherv3.Matcher_MatcherList_Predicate
+
+			// Predicates for hosts.
+			hostPredicate *matcherv3.Matcher_MatcherList_Predicate
+
+			// Predicates for the HTTP headers of the operation.
+			operationHeaderPredicate []*matcherv3.Matcher_MatcherList_Predicate
+
 			// Predicates for HTTP headers.
 			headerPredicate []*matcherv3.Matcher_MatcherList_Predicate
 
@@ -192,6 +330,12 @@ func buildRBACPerRoute(authorization *ir.Authorization) (*rbacv3.RBACPerRoute, e
 			// Predicates for JWT claims and scopes.
 			jwtPredicate []*matcherv3.Matcher_MatcherList_Predicate
 
+			// Predicates for the client certificate.
+			clientCertificatePredicate []*matcherv3.Matcher_MatcherList_Predicate
+
+			// Predicates for the CEL expressions of the operation and the principal.
+			expressionPredicate []*matcherv3.Matcher_MatcherList_Predicate
+
 			// The final predicate that will be used for the current rule.
 			finalPredicate *matcherv3.Matcher_MatcherList_Predicate
 		)
@@ -214,6 +358,12 @@ func buildRBACPerRoute(authorization *ir.Authorization) (*rbacv3.RBACPerRoute, e
 			}
 		}
 
+		if rule.Principal.ClientCertificate != nil {
+			if clientCertificatePredicate, err = buildClientCertificatePredicate(rule.Principal.ClientCertificate); err != nil {
+				return nil, err
+			}
+		}
+
 		var methodPredicates []*matcherv3.Matcher_MatcherList_Predicate
 		if rule.Operation != nil && len(rule.Operation.Methods) > 0 {
 			if methodPredicates, err = buildMethodsPredicate(rule.Operation.Methods); err != nil {
@@ -238,6 +388,34 @@ func buildRBACPerRoute(authorization *ir.Authorization) (*rbacv3.RBACPerRoute, e
 			}
 		}
 
+		if rule.Operation != nil {
+			if pathPredicate, err = buildPathsPredicate(rule.Operation.Paths); err != nil {
+				return nil, err
+			}
+			if hostPredicate, err = buildHostsPredicate(rule.Operation.Hosts); err != nil {
+				return nil, err
+			}
+			if operationHeaderPredicate, err = buildHeaderMatchesPredicate(rule.Operation.Headers); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, expr := range []*egv1a1.CELExpression{operationExpression(rule.Operation), rule.Principal.Expression} {
+			if expr == nil {
+				continue
+			}
+			var predicate *matcherv3.Matcher_MatcherList_Predicate
+			if rule.Action == egv1a1.AuthorizationActionDeny {
+				predicate, err = buildFailClosedCELPredicate(string(*expr))
+			} else {
+				predicate, err = buildCELPredicate(string(*expr))
+			}
+			if err != nil {
+				return nil, err
+			}
+			expressionPredicate = append(expressionPredicate, predicate)
+		}
+
 		if len(rule.Principal.Headers) > 0 {
 			if headerPredicate, err = buildHeadersPredicate(rule.Principal.Headers); err != nil {
 				return nil, err
@@ -249,11 +427,20 @@ func buildRBACPerRoute(authorization *ir.Authorization) (*rbacv3.RBACPerRoute, e
 		if methodPredicate != nil {
 			allPredicates = append(allPredicates, methodPredicate)
 		}
+		if pathPredicate != nil {
+			allPredicates = append(allPredicates, pathPredicate)
+		}
+		if hostPredicate != nil {
+			allPredicates = append(allPredicates, hostPredicate)
+		}
+		allPredicates = append(allPredicates, operationHeaderPredicate...)
 		if ipPredicate != nil {
 			allPredicates = append(allPredicates, ipPredicate)
 		}
 		allPredicates = append(allPredicates, jwtPredicate...)
+		allPredicates = append(allPredicates, clientCertificatePredicate...)
 		allPredicates = append(allPredicates, headerPredicate...)
+		allPredicates = append(allPredicates, expressionPredicate...)
 
 		switch {
 		case len(allPredicates) > 1:
@@ -567,6 +754,187 @@ func buildJWTPredicate(jwt egv1a1.JWTPrincipal) ([]*matcherv3.Matcher_MatcherLis
 	return jwtPredicate, nil
 }
 
+// buildPolicyAuthDenyPredicate returns the predicate matching the requests that are not allowed by the policy.
+// The CEL matcher doesn't match if the evaluation of the expression fails, for example, when a header used
+// by the expression is missing, so the expression itself is not negated to deny these requests as well.
+func buildPolicyAuthDenyPredicate(policyAuth *ir.PolicyAuth) (*matcherv3.Matcher_MatcherList_Predicate, error) {
+	predicate, err := buildCELPredicate(policyAuth.CEL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &matcherv3.Matcher_MatcherList_Predicate{
+		MatchType: &matcherv3.Matcher_MatcherList_Predicate_NotMatcher{
+			NotMatcher: predicate,
+		},
+	}, nil
+}
+
+// buildFailClosedCELPredicate returns the predicate matching the requests for which the CEL expression evaluates
+// to true, or whose evaluation fails. It is used by the deny rules, so that a failing evaluation doesn't allow
+// the requests the rule is meant to deny.
+// The CEL matcher doesn't match if the evaluation fails, so the negated expression is evaluated and the result
+// of the match is negated. The expression ends with a new line in case it ends with a comment.
+func buildFailClosedCELPredicate(expr string) (*matcherv3.Matcher_MatcherList_Predicate, error) {
+	predicate, err := buildCELPredicate("!(" + expr + "\n)")
+	if err != nil {
+		return nil, err
+	}
+
+	return &matcherv3.Matcher_MatcherList_Predicate{
+		MatchType: &matcherv3.Matcher_MatcherList_Predicate_NotMatcher{
+			NotMatcher: predicate,
+		},
+	}, nil
+}
+
+// buildCELPredicate returns the predicate matching the requests for which the CEL expression evaluates to true.
+func buildCELPredicate(expr string) (*matcherv3.Matcher_MatcherList_Predicate, error) {
+	parsedExpr, err := cel.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	inputPb, err := proto.ToAnyWithValidation(&matcherv3.HttpAttributesCelMatchInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	matcherPb, err := proto.ToAnyWithValidation(&matcherv3.CelMatcher{
+		ExprMatch: &typev3.CelExpression{
+			ExprSpecifier: &typev3.CelExpression_ParsedExpr{
+				ParsedExpr: parsedExpr,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &matcherv3.Matcher_MatcherList_Predicate{
+		MatchType: &matcherv3.Matcher_MatcherList_Predicate_SinglePredicate_{
+			SinglePredicate: &matcherv3.Matcher_MatcherList_Predicate_SinglePredicate{
+				Input: &cncfv3.TypedExtensionConfig{
+					Name:        "http_attributes_cel_match_input",
+					TypedConfig: inputPb,
+				},
+				Matcher: &matcherv3.Matcher_MatcherList_Predicate_SinglePredicate_CustomMatch{
+					CustomMatch: &cncfv3.TypedExtensionConfig{
+						Name:        "cel_matcher",
+						TypedConfig: matcherPb,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// operationExpression returns the CEL expression of the operation, if any.
+func operationExpression(operation *egv1a1.Operation) *egv1a1.CELExpression {
+	if operation == nil {
+		return nil
+	}
+	return operation.Expression
+}
+
+// buildClientCertificatePredicate returns the predicates matching the subject, issuer, URI SANs, DNS SANs
+// and email SANs of the client certificate.
+// Multiple types are ANDed together, and multiple matches of a type are ORed together.
+func buildClientCertificatePredicate(principal *egv1a1.ClientCertificatePrincipal) ([]*matcherv3.Matcher_MatcherList_Predicate, error) {
+	var predicates []*matcherv3.Matcher_MatcherList_Predicate
+	for _, certificateInput := range []struct {
+		name    string
+		input   protobuf.Message
+		matches []egv1a1.StringMatch
+		san     bool
+	}{
+		{name: "subject", input: &sslinputv3.SubjectInput{}, matches: principal.Subjects},
+		{
+			name:    "http_header",
+			input:   &envoymatcherv3.HttpRequestHeaderMatchInput{HeaderName: clientCertificateIssuerHeader},
+			matches: principal.Issuers,
+		},
+		{name: "uri_san", input: &sslinputv3.UriSanInput{}, matches: principal.URIs, san: true},
+		{name: "dns_san", input: &sslinputv3.DnsSanInput{}, matches: principal.DNSNames, san: true},
+		{
+			name:    "http_header",
+			input:   &envoymatcherv3.HttpRequestHeaderMatchInput{HeaderName: clientCertificateEmailsHeader},
+			matches: principal.Emails,
+			san:     true,
+		},
+	} {
+		if len(certificateInput.matches) == 0 {
+			continue
+		}
+
+		inputPb, err := proto.ToAnyWithValidation(certificateInput.input)
+		if err != nil {
+			return nil, err
+		}
+
+		var valuePredicates []*matcherv3.Matcher_MatcherList_Predicate
+		for i := range certificateInput.matches {
+			pattern, err := certificateValuePattern(&certificateInput.matches[i], certificateInput.san)
+			if err != nil {
+				return nil, err
+			}
+			valuePredicates = append(valuePredicates, &matcherv3.Matcher_MatcherList_Predicate{
+				MatchType: &matcherv3.Matcher_MatcherList_Predicate_SinglePredicate_{
+					SinglePredicate: &matcherv3.Matcher_MatcherList_Predicate_SinglePredicate{
+						Input: &cncfv3.TypedExtensionConfig{
+							Name:        certificateInput.name,
+							TypedConfig: inputPb,
+						},
+						Matcher: &matcherv3.Matcher_MatcherList_Predicate_SinglePredicate_ValueMatch{
+							ValueMatch: buildSafeRegexStringMatcher(pattern),
+						},
+					},
+				},
+			})
+		}
+		predicates = append(predicates, orPredicates(valuePredicates))
+	}
+	return predicates, nil
+}
+
+// certificateValuePattern returns the regular expression of a match on a value of the client certificate.
+// Envoy joins the SANs of a type with commas, so a SAN pattern can't match a comma, and matches one of the
+// joined SANs as a whole.
+func certificateValuePattern(match *egv1a1.StringMatch, san bool) (string, error) {
+	matchType := ptr.Deref(match.Type, egv1a1.StringMatchExact)
+	anyValue := ".*"
+	if san {
+		if matchType != egv1a1.StringMatchRegularExpression && strings.Contains(match.Value, ",") {
+			return "", fmt.Errorf("SAN match %q must not contain a comma", match.Value)
+		}
+		anyValue = "[^,]*"
+	}
+
+	var pattern string
+	switch matchType {
+	case egv1a1.StringMatchPrefix:
+		pattern = regexp.QuoteMeta(match.Value) + anyValue
+	case egv1a1.StringMatchSuffix:
+		pattern = anyValue + regexp.QuoteMeta(match.Value)
+	case egv1a1.StringMatchRegularExpression:
+		value := match.Value
+		if san {
+			var err error
+			if value, err = regex.WithoutSeparator(value, ','); err != nil {
+				return "", err
+			}
+		}
+		pattern = "(?:" + value + ")"
+	default:
+		pattern = regexp.QuoteMeta(match.Value)
+	}
+
+	if san {
+		pattern = "(.*,)?" + pattern + "(,.*)?"
+	}
+	return pattern, nil
+}
+
 func (c *rbac) patchResources(*types.ResourceVersionTable, []*ir.HTTPRoute) error {
 	return nil
 }
@@ -581,6 +949,130 @@ func buildMethodsPredicate(methods []gwapiv1.HTTPMethod) ([]*matcherv3.Matcher_M
 	return buildHeaderPredicate(":method", methodStrings, true)
 }
 
+// buildPathsPredicate returns the predicate matching one of the paths on the url_path attribute,
+// which is the path of the request without the query string, as matched by the url_path permission of RBAC.
+func buildPathsPredicate(paths []egv1a1.StringMatch) (*matcherv3.Matcher_MatcherList_Predicate, error) {
+	var predicates []*matcherv3.Matcher_MatcherList_Predicate
+	for i := range paths {
+		predicate, err := buildCELPredicate(urlPathExpression(&paths[i]))
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, predicate)
+	}
+	return orPredicates(predicates), nil
+}
+
+// urlPathExpression returns the CEL expression matching the url_path attribute of the request with a StringMatch.
+func urlPathExpression(match *egv1a1.StringMatch) string {
+	value := strconv.Quote(match.Value)
+	switch ptr.Deref(match.Type, egv1a1.StringMatchExact) {
+	case egv1a1.StringMatchPrefix:
+		return "request.url_path.startsWith(" + value + ")"
+	case egv1a1.StringMatchSuffix:
+		return "request.url_path.endsWith(" + value + ")"
+	case egv1a1.StringMatchRegularExpression:
+		// The CEL matches function looks for a match anywhere in the string, so the regex is anchored.
+		return "request.url_path.matches(" + strconv.Quote("^(?:"+match.Value+")$") + ")"
+	default:
+		return "request.url_path == " + value
+	}
+}
+
+// buildHostsPredicate returns the predicate matching one of the hosts on the :authority pseudo-header.
+func buildHostsPredicate(hosts []gwapiv1.Hostname) (*matcherv3.Matcher_MatcherList_Predicate, error) {
+	var predicates []*matcherv3.Matcher_MatcherList_Predicate
+	for _, host := range hosts {
+		// A wildcard label matches one or more labels, and the port of the authority is ignored.
+		pattern := regexp.QuoteMeta(string(host))
+		if suffix, ok := strings.CutPrefix(string(host), "*."); ok {
+			pattern = `[^:]+\.` + regexp.QuoteMeta(suffix)
+		}
+		predicate, err := buildStringMatcherPredicate(":authority", buildSafeRegexStringMatcher("(?i)"+pattern+"(:[0-9]+)?"))
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, predicate)
+	}
+	return orPredicates(predicates), nil
+}
+
+// buildHeaderMatchesPredicate returns the predicates matching all the header matches.
+func buildHeaderMatchesPredicate(headers []gwapiv1.HTTPHeaderMatch) ([]*matcherv3.Matcher_MatcherList_Predicate, error) {
+	var predicates []*matcherv3.Matcher_MatcherList_Predicate
+	for _, header := range headers {
+		stringMatcher := &matcherv3.StringMatcher{
+			MatchPattern: &matcherv3.StringMatcher_Exact{
+				Exact: header.Value,
+			},
+		}
+		if header.Type != nil && *header.Type == gwapiv1.HeaderMatchRegularExpression {
+			stringMatcher = buildSafeRegexStringMatcher(header.Value)
+		}
+		predicate, err := buildStringMatcherPredicate(string(header.Name), stringMatcher)
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, predicate)
+	}
+	return predicates, nil
+}
+
+func buildSafeRegexStringMatcher(pattern string) *matcherv3.StringMatcher {
+	return &matcherv3.StringMatcher{
+		MatchPattern: &matcherv3.StringMatcher_SafeRegex{
+			SafeRegex: &matcherv3.RegexMatcher{
+				EngineType: &matcherv3.RegexMatcher_GoogleRe2{
+					GoogleRe2: &matcherv3.RegexMatcher_GoogleRE2{},
+				},
+				Regex: pattern,
+			},
+		},
+	}
+}
+
+// buildStringMatcherPredicate returns the predicate matching a request header with a string matcher.
+func buildStringMatcherPredicate(name string, stringMatcher *matcherv3.StringMatcher) (*matcherv3.Matcher_MatcherList_Predicate, error) {
+	headerMatchInput, err := proto.ToAnyWithValidation(&envoymatcherv3.HttpRequestHeaderMatchInput{
+		HeaderName: name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &matcherv3.Matcher_MatcherList_Predicate{
+		MatchType: &matcherv3.Matcher_MatcherList_Predicate_SinglePredicate_{
+			SinglePredicate: &matcherv3.Matcher_MatcherList_Predicate_SinglePredicate{
+				Input: &cncfv3.TypedExtensionConfig{
+					Name:        "http_header",
+					TypedConfig: headerMatchInput,
+				},
+				Matcher: &matcherv3.Matcher_MatcherList_Predicate_SinglePredicate_ValueMatch{
+					ValueMatch: stringMatcher,
+				},
+			},
+		},
+	}, nil
+}
+
+// orPredicates returns a predicate matching any of the predicates, or nil if there are no predicates.
+func orPredicates(predicates []*matcherv3.Matcher_MatcherList_Predicate) *matcherv3.Matcher_MatcherList_Predicate {
+	switch len(predicates) {
+	case 0:
+		return nil
+	case 1:
+		return predicates[0]
+	default:
+		return &matcherv3.Matcher_MatcherList_Predicate{
+			MatchType: &matcherv3.Matcher_MatcherList_Predicate_OrMatcher{
+				OrMatcher: &matcherv3.Matcher_MatcherList_Predicate_PredicateList{
+					Predicate: predicates,
+				},
+			},
+		}
+	}
+}
+
 func buildHeadersPredicate(headers []egv1a1.AuthorizationHeaderMatch) ([]*matcherv3.Matcher_MatcherList_Predicate, error) {
 	var (
 		headersPredicates []*matcherv3.Matcher_MatcherList_Predicate // Predicates for all headers.
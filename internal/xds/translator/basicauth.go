@@ -8,19 +8,33 @@ package translator
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	basicauthv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/basic_auth/v3"
+	extauthv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
 	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/basicauth"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/utils/proto"
 	"github.com/envoyproxy/gateway/internal/xds/types"
 )
 
+const (
+	// bootstrapXdsClusterName is the name of the bootstrap cluster of the xDS server, which also serves
+	// the basic auth service of Envoy Gateway.
+	bootstrapXdsClusterName = "xds_cluster"
+	// verifierTimeout is the timeout of the basic auth service, which may query an LDAP directory.
+	verifierTimeout = 10 * time.Second
+)
+
 func init() {
 	registerHTTPFilter(&basicAuth{})
 }
@@ -73,6 +87,8 @@ func (*basicAuth) patchHCM(mgr *hcmv3.HttpConnectionManager, irListener *ir.HTTP
 // if applicable, and it does not already exist.
 
 // buildHCMBasicAuthFilter returns a basic_auth HTTP filter from the provided IR HTTPRoute.
+// If the credentials can't be verified by Envoy, an ext_authz filter calling the basic auth
+// service of Envoy Gateway is returned instead.
 func buildHCMBasicAuthFilter(basicAuth *ir.BasicAuth) (*hcmv3.HttpFilter, error) {
 	var (
 		basicAuthProto *basicauthv3.BasicAuth
@@ -80,6 +96,19 @@ func buildHCMBasicAuthFilter(basicAuth *ir.BasicAuth) (*hcmv3.HttpFilter, error)
 		err            error
 	)
 
+	if basicAuth.VerifiedByEnvoyGateway() {
+		if basicAuthAny, err = proto.ToAnyWithValidation(verifierConfig("authorization")); err != nil {
+			return nil, err
+		}
+		return &hcmv3.HttpFilter{
+			Name: basicAuthFilterName(basicAuth),
+			ConfigType: &hcmv3.HttpFilter_TypedConfig{
+				TypedConfig: basicAuthAny,
+			},
+			Disabled: true,
+		}, nil
+	}
+
 	basicAuthProto = &basicauthv3.BasicAuth{
 		Users: &corev3.DataSource{
 			Specifier: &corev3.DataSource_InlineBytes{
@@ -105,6 +134,33 @@ func buildHCMBasicAuthFilter(basicAuth *ir.BasicAuth) (*hcmv3.HttpFilter, error)
 	}, nil
 }
 
+// verifierConfig returns the ext_authz filter config calling the basic auth service of Envoy
+// Gateway, which is served by the xDS server. Only the headers holding the credentials are sent.
+func verifierConfig(headers ...string) *extauthv3.ExtAuthz {
+	allowedHeaders := &matcherv3.ListStringMatcher{}
+	for _, header := range headers {
+		allowedHeaders.Patterns = append(allowedHeaders.Patterns, &matcherv3.StringMatcher{
+			MatchPattern: &matcherv3.StringMatcher_Exact{Exact: header},
+			IgnoreCase:   true,
+		})
+	}
+	return &extauthv3.ExtAuthz{
+		TransportApiVersion: corev3.ApiVersion_V3,
+		Services: &extauthv3.ExtAuthz_GrpcService{
+			GrpcService: &corev3.GrpcService{
+				TargetSpecifier: &corev3.GrpcService_EnvoyGrpc_{
+					EnvoyGrpc: &corev3.GrpcService_EnvoyGrpc{
+						ClusterName: bootstrapXdsClusterName,
+					},
+				},
+				Timeout: durationpb.New(verifierTimeout),
+			},
+		},
+		AllowedHeaders: allowedHeaders,
+		StatusOnError:  &typev3.HttpStatus{Code: typev3.StatusCode_ServiceUnavailable},
+	}
+}
+
 func basicAuthFilterName(basicAuth *ir.BasicAuth) string {
 	return perRouteFilterName(egv1a1.EnvoyFilterBasicAuth, basicAuth.Name)
 }
@@ -141,8 +197,14 @@ func (*basicAuth) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute, _ *ir.
 	}
 
 	// Overwrite the HCM level filter config with the per route filter config.
-	basicAuthProto := basicAuthPerRouteConfig(irRoute.Security.BasicAuth)
-	if basicAuthAny, err = proto.ToAnyWithValidation(basicAuthProto); err != nil {
+	// The basic auth service of Envoy Gateway looks up the config by its name, which is sent in
+	// the context extensions.
+	if irRoute.Security.BasicAuth.VerifiedByEnvoyGateway() {
+		basicAuthAny, err = verifierPerRouteConfig(basicauth.ContextExtensionKey, irRoute.Security.BasicAuth.Name)
+	} else {
+		basicAuthAny, err = proto.ToAnyWithValidation(basicAuthPerRouteConfig(irRoute.Security.BasicAuth))
+	}
+	if err != nil {
 		return err
 	}
 
@@ -154,6 +216,25 @@ func (*basicAuth) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute, _ *ir.
 	return nil
 }
 
+// verifierPerRouteConfig returns the per route config of the ext_authz filter calling the basic
+// auth service of Envoy Gateway, which looks up the config by the name in the context extension.
+func verifierPerRouteConfig(contextExtensionKey, name string) (*anypb.Any, error) {
+	extAuthPerRouteAny, err := proto.ToAnyWithValidation(&extauthv3.ExtAuthzPerRoute{
+		Override: &extauthv3.ExtAuthzPerRoute_CheckSettings{
+			CheckSettings: &extauthv3.CheckSettings{
+				ContextExtensions: map[string]string{
+					contextExtensionKey: name,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The filter config enables the filter, which is disabled by default.
+	return proto.ToAnyWithValidation(&routev3.FilterConfig{Config: extAuthPerRouteAny})
+}
+
 func basicAuthPerRouteConfig(basicAuth *ir.BasicAuth) *basicauthv3.BasicAuthPerRoute {
 	return &basicauthv3.BasicAuthPerRoute{
 		Users: &corev3.DataSource{
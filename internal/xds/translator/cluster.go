@@ -46,6 +46,7 @@ import (
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/logging"
 	"github.com/envoyproxy/gateway/internal/utils/proto"
+	"github.com/envoyproxy/gateway/internal/xds/types"
 )
 
 const (
@@ -64,6 +65,7 @@ type xdsClusterArgs struct {
 	proxyProtocol     *ir.ProxyProtocol
 	circuitBreaker    *ir.CircuitBreaker
 	healthCheck       *ir.HealthCheck
+	failover          *ir.Failover
 	http1Settings     *ir.HTTP1Settings
 	http2Settings     *ir.HTTP2Settings
 	timeout           *ir.Timeout
@@ -555,6 +557,47 @@ func buildXdsOutlierDetection(outlierDetection *ir.OutlierDetection) *clusterv3.
 		od.ConsecutiveGatewayFailure = wrapperspb.UInt32(*outlierDetection.ConsecutiveGatewayErrors)
 	}
 
+	if outlierDetection.MaxEjectionTime != nil {
+		od.MaxEjectionTime = durationpb.New(outlierDetection.MaxEjectionTime.Duration)
+	}
+
+	if outlierDetection.MaxEjectionTimeJitter != nil {
+		od.MaxEjectionTimeJitter = durationpb.New(outlierDetection.MaxEjectionTimeJitter.Duration)
+	}
+
+	if sr := outlierDetection.SuccessRate; sr != nil {
+		if sr.MinimumHosts != nil {
+			od.SuccessRateMinimumHosts = wrapperspb.UInt32(*sr.MinimumHosts)
+		}
+		if sr.RequestVolume != nil {
+			od.SuccessRateRequestVolume = wrapperspb.UInt32(*sr.RequestVolume)
+		}
+		if sr.StdevFactor != nil {
+			od.SuccessRateStdevFactor = wrapperspb.UInt32(*sr.StdevFactor)
+		}
+		if sr.EnforcingPercent != nil {
+			od.EnforcingSuccessRate = wrapperspb.UInt32(*sr.EnforcingPercent)
+		}
+	}
+
+	if fp := outlierDetection.FailurePercentage; fp != nil {
+		// Envoy doesn't enforce the failure percentage ejection by default,
+		// so it's fully enforced unless specified otherwise.
+		od.EnforcingFailurePercentage = wrapperspb.UInt32(100)
+		if fp.EnforcingPercent != nil {
+			od.EnforcingFailurePercentage = wrapperspb.UInt32(*fp.EnforcingPercent)
+		}
+		if fp.Threshold != nil {
+			od.FailurePercentageThreshold = wrapperspb.UInt32(*fp.Threshold)
+		}
+		if fp.MinimumHosts != nil {
+			od.FailurePercentageMinimumHosts = wrapperspb.UInt32(*fp.MinimumHosts)
+		}
+		if fp.RequestVolume != nil {
+			od.FailurePercentageRequestVolume = wrapperspb.UInt32(*fp.RequestVolume)
+		}
+	}
+
 	return od
 }
 
@@ -644,6 +687,21 @@ func buildXdsClusterCircuitBreaker(circuitBreaker *ir.CircuitBreaker) *clusterv3
 			}
 		}
 
+		if circuitBreaker.RetryBudget != nil {
+			rb := &clusterv3.CircuitBreakers_Thresholds_RetryBudget{}
+			if circuitBreaker.RetryBudget.Percent != nil {
+				rb.BudgetPercent = &xdstype.Percent{
+					Value: float64(*circuitBreaker.RetryBudget.Percent),
+				}
+			}
+			if circuitBreaker.RetryBudget.MinRetryConcurrency != nil {
+				rb.MinRetryConcurrency = &wrapperspb.UInt32Value{
+					Value: *circuitBreaker.RetryBudget.MinRetryConcurrency,
+				}
+			}
+			cbt.RetryBudget = rb
+		}
+
 		if circuitBreaker.PerEndpoint != nil {
 			if circuitBreaker.PerEndpoint.MaxConnections != nil {
 				cbtPerEndpoint = []*clusterv3.CircuitBreakers_Thresholds{
@@ -665,7 +723,8 @@ func buildXdsClusterCircuitBreaker(circuitBreaker *ir.CircuitBreaker) *clusterv3
 	return ecb
 }
 
-func buildXdsClusterLoadAssignment(clusterName string, destSettings []*ir.DestinationSetting, preferLocal *ir.PreferLocalZone) *endpointv3.ClusterLoadAssignment {
+func buildXdsClusterLoadAssignment(clusterName string, destSettings []*ir.DestinationSetting, loadBalancer *ir.LoadBalancer, failover *ir.Failover) *endpointv3.ClusterLoadAssignment {
+	lb := ptr.Deref(loadBalancer, ir.LoadBalancer{})
 	localities := make([]*endpointv3.LocalityLbEndpoints, 0, len(destSettings))
 	for i, ds := range destSettings {
 
@@ -689,34 +748,65 @@ func buildXdsClusterLoadAssignment(clusterName string, destSettings []*ir.Destin
 		// if multiple backendRefs exist. This pushes part of the routing logic higher up the stack which can
 		// limit host selection controls during retries and session affinity.
 		// For more details see https://github.com/envoyproxy/gateway/issues/5307#issuecomment-2688767482
-		if ds.PreferLocal != nil || preferLocal != nil {
+		switch {
+		case lb.LocalityWeighted != nil:
+			localities = append(localities, buildLocalityWeightedLocalities(metadata, ds, lb.LocalityWeighted)...)
+		case ds.PreferLocal != nil || lb.PreferLocal != nil:
 			localities = append(localities, buildZonalLocalities(metadata, ds)...)
-		} else {
+		default:
 			localities = append(localities, buildWeightedLocalities(metadata, ds))
 		}
 	}
-	return &endpointv3.ClusterLoadAssignment{ClusterName: clusterName, Endpoints: localities}
+	if lb.LocalityWeighted != nil {
+		types.CompactLocalityPriorities(localities)
+	}
+	return &endpointv3.ClusterLoadAssignment{
+		ClusterName: clusterName,
+		Endpoints:   localities,
+		Policy:      buildXdsClusterLoadAssignmentPolicy(failover),
+	}
+}
+
+// buildXdsClusterLoadAssignmentPolicy returns the policy which controls how the traffic
+// spills over between the priority levels, or nil to use the Envoy defaults.
+func buildXdsClusterLoadAssignmentPolicy(failover *ir.Failover) *endpointv3.ClusterLoadAssignment_Policy {
+	if failover == nil || (failover.OverprovisioningFactor == nil && !failover.WeightedPriorityHealth) {
+		return nil
+	}
+
+	policy := &endpointv3.ClusterLoadAssignment_Policy{
+		WeightedPriorityHealth: failover.WeightedPriorityHealth,
+	}
+	if failover.OverprovisioningFactor != nil {
+		policy.OverprovisioningFactor = wrapperspb.UInt32(*failover.OverprovisioningFactor)
+	}
+	return policy
+}
+
+// buildLbEndpoint returns the endpoint of the locality with the default weight of 1.
+func buildLbEndpoint(metadata *corev3.Metadata, irEp *ir.DestinationEndpoint) *endpointv3.LbEndpoint {
+	healthStatus := corev3.HealthStatus_UNKNOWN
+	if irEp.Draining {
+		healthStatus = corev3.HealthStatus_DRAINING
+	}
+	return &endpointv3.LbEndpoint{
+		Metadata: metadata,
+		HostIdentifier: &endpointv3.LbEndpoint_Endpoint{
+			Endpoint: &endpointv3.Endpoint{
+				Hostname: ptr.Deref(irEp.Hostname, ""),
+				Address:  buildAddress(irEp),
+			},
+		},
+		LoadBalancingWeight: wrapperspb.UInt32(1),
+		HealthStatus:        healthStatus,
+	}
 }
 
 func buildZonalLocalities(metadata *corev3.Metadata, ds *ir.DestinationSetting) []*endpointv3.LocalityLbEndpoints {
 	var localities []*endpointv3.LocalityLbEndpoints
 	zonalEndpoints := make(map[string][]*endpointv3.LbEndpoint)
 	for _, irEp := range ds.Endpoints {
-		healthStatus := corev3.HealthStatus_UNKNOWN
-		if irEp.Draining {
-			healthStatus = corev3.HealthStatus_DRAINING
-		}
-		lbEndpoint := &endpointv3.LbEndpoint{
-			Metadata: metadata,
-			HostIdentifier: &endpointv3.LbEndpoint_Endpoint{
-				Endpoint: &endpointv3.Endpoint{
-					Hostname: ptr.Deref(irEp.Hostname, ""),
-					Address:  buildAddress(irEp),
-				},
-			},
-			LoadBalancingWeight: wrapperspb.UInt32(1),
-			HealthStatus:        healthStatus,
-		}
+		lbEndpoint := buildLbEndpoint(metadata, irEp)
 
 		zone := ptr.Deref(irEp.Zone, "")
 		zonalEndpoints[zone] = append(zonalEndpoints[zone], lbEndpoint)
@@ -741,27 +831,107 @@ func buildZonalLocalities(metadata *corev3.Metadata, ds *ir.DestinationSetting)
 	return localities
 }
 
-func buildWeightedLocalities(metadata *corev3.Metadata, ds *ir.DestinationSetting) *endpointv3.LocalityLbEndpoints {
-	endpoints := make([]*endpointv3.LbEndpoint, 0, len(ds.Endpoints))
+// buildLocalityWeightedLocalities groups the endpoints of the destination setting by their
+// region, zone and sub-zone, and sets the weight and priority of each locality.
+// The priority is the distance of the locality to the origin. Without an origin, the localities
+// are marked so that the priority is set from the locality of each proxy by the xDS server.
+func buildLocalityWeightedLocalities(metadata *corev3.Metadata, ds *ir.DestinationSetting, lw *ir.LocalityWeighted) []*endpointv3.LocalityLbEndpoints {
+	var origin *corev3.Locality
+	if lw.Origin != nil {
+		origin = &corev3.Locality{
+			Region:  lw.Origin.Region,
+			Zone:    lw.Origin.Zone,
+			SubZone: lw.Origin.SubZone,
+		}
+	}
 
+	localities := make(map[ir.Locality]*endpointv3.LocalityLbEndpoints)
 	for _, irEp := range ds.Endpoints {
-		healthStatus := corev3.HealthStatus_UNKNOWN
-		if irEp.Draining {
-			healthStatus = corev3.HealthStatus_DRAINING
-		}
-		lbEndpoint := &endpointv3.LbEndpoint{
-			Metadata: metadata,
-			HostIdentifier: &endpointv3.LbEndpoint_Endpoint{
-				Endpoint: &endpointv3.Endpoint{
-					Hostname: ptr.Deref(irEp.Hostname, ""),
-					Address:  buildAddress(irEp),
+		lbEndpoint := buildLbEndpoint(metadata, irEp)
+
+		l := ir.Locality{
+			Region:  ptr.Deref(irEp.Region, ""),
+			Zone:    ptr.Deref(irEp.Zone, ""),
+			SubZone: ptr.Deref(irEp.SubZone, ""),
+		}
+		locality, ok := localities[l]
+		if !ok {
+			locality = &endpointv3.LocalityLbEndpoints{
+				Locality: &corev3.Locality{
+					Region:  l.Region,
+					Zone:    l.Zone,
+					SubZone: l.SubZone,
 				},
-			},
-			HealthStatus: healthStatus,
+				Metadata: buildXdsMetadata(ds.Metadata),
+			}
+			if origin != nil {
+				locality.Priority = types.LocalityDistance(origin, locality.Locality)
+			} else {
+				locality.Metadata = markProxyLocalityOrigin(locality.Metadata)
+			}
+			localities[l] = locality
+		}
+		locality.LbEndpoints = append(locality.LbEndpoints, lbEndpoint)
+	}
+
+	result := make([]*endpointv3.LocalityLbEndpoints, 0, len(localities))
+	for l, locality := range localities {
+		// Localities without a configured weight are weighted by their number of endpoints,
+		// so that each endpoint receives the same share of the requests.
+		weight := uint32(len(locality.LbEndpoints))
+		for _, w := range lw.Weights {
+			if localityMatches(w.Locality, l) {
+				weight = w.Weight
+				break
+			}
+		}
+		locality.LoadBalancingWeight = wrapperspb.UInt32(weight)
+		result = append(result, locality)
+	}
+	// Sort localities by priority and locality, so that the order is deterministic.
+	sort.Slice(result, func(i, j int) bool {
+		li, lj := result[i].Locality, result[j].Locality
+		switch {
+		case result[i].Priority != result[j].Priority:
+			return result[i].Priority < result[j].Priority
+		case li.Region != lj.Region:
+			return li.Region < lj.Region
+		case li.Zone != lj.Zone:
+			return li.Zone < lj.Zone
+		default:
+			return li.SubZone < lj.SubZone
 		}
-		// Set default weight of 1 for all endpoints.
-		lbEndpoint.LoadBalancingWeight = &wrapperspb.UInt32Value{Value: 1}
-		endpoints = append(endpoints, lbEndpoint)
+	})
+
+	return result
+}
+
+// localityMatches returns true if the locality matches the selector, an empty field of
+// the selector matches any value.
+func localityMatches(selector, l ir.Locality) bool {
+	return (selector.Region == "" || selector.Region == l.Region) &&
+		(selector.Zone == "" || selector.Zone == l.Zone) &&
+		(selector.SubZone == "" || selector.SubZone == l.SubZone)
+}
+
+// markProxyLocalityOrigin adds the filter metadata which marks the locality to be prioritized
+// by its distance to the locality of the proxy.
+func markProxyLocalityOrigin(metadata *corev3.Metadata) *corev3.Metadata {
+	if metadata == nil {
+		metadata = &corev3.Metadata{}
+	}
+	if metadata.FilterMetadata == nil {
+		metadata.FilterMetadata = make(map[string]*structpb.Struct)
+	}
+	metadata.FilterMetadata[types.ProxyLocalityOriginMetadataNamespace] = &structpb.Struct{}
+	return metadata
+}
+
+func buildWeightedLocalities(metadata *corev3.Metadata, ds *ir.DestinationSetting) *endpointv3.LocalityLbEndpoints {
+	endpoints := make([]*endpointv3.LbEndpoint, 0, len(ds.Endpoints))
+
+	for _, irEp := range ds.Endpoints {
+		endpoints = append(endpoints, buildLbEndpoint(metadata, irEp))
 	}
 	locality := &endpointv3.LocalityLbEndpoints{
 		Locality: &corev3.Locality{
@@ -928,10 +1098,10 @@ func buildClusterHTTPFilters(args *xdsClusterArgs) ([]*hcmv3.HttpFilter, []*tlsv
 		// if there are backend filters.
 		if args.settings[0].Filters != nil && args.settings[0].Filters.CredentialInjection != nil {
 			filter, err := buildHCMCredentialInjectorFilter(args.settings[0].Filters.CredentialInjection)
-			filter.Disabled = false
 			if err != nil {
 				return nil, nil, err
 			}
+			filter.Disabled = false
 			secret := buildCredentialSecret(args.settings[0].Filters.CredentialInjection)
 			filters = append(filters, filter)
 			secrets = append(secrets, secret)
@@ -1172,6 +1342,7 @@ func (httpRoute *HTTPRouteTranslator) asClusterArgs(name string,
 		clusterArgs.proxyProtocol = bt.ProxyProtocol
 		clusterArgs.circuitBreaker = bt.CircuitBreaker
 		clusterArgs.healthCheck = bt.HealthCheck
+		clusterArgs.failover = bt.Failover
 		clusterArgs.timeout = bt.Timeout
 		clusterArgs.tcpkeepalive = bt.TCPKeepalive
 		clusterArgs.backendConnection = bt.BackendConnection
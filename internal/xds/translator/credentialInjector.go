@@ -14,7 +14,9 @@ import (
 	injectorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/credential_injector/v3"
 	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	genericv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/injected_credentials/generic/v3"
+	oauth2credv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/injected_credentials/oauth2/v3"
 	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/ir"
@@ -42,15 +44,16 @@ func (*credentialInjector) patchHCM(mgr *hcmv3.HttpConnectionManager, irListener
 	var errs error
 
 	for _, route := range irListener.Routes {
-		if route.CredentialInjection == nil {
+		credentialInjection := routeCredentialInjection(route)
+		if credentialInjection == nil {
 			continue
 		}
 
-		if hcmContainsFilter(mgr, credentialInjectorFilterName(route.CredentialInjection)) {
+		if hcmContainsFilter(mgr, credentialInjectorFilterName(credentialInjection)) {
 			continue
 		}
 
-		filter, err := buildHCMCredentialInjectorFilter(route.CredentialInjection)
+		filter, err := buildHCMCredentialInjectorFilter(credentialInjection)
 		if err != nil {
 			errs = errors.Join(errs, err)
 			continue
@@ -61,31 +64,32 @@ func (*credentialInjector) patchHCM(mgr *hcmv3.HttpConnectionManager, irListener
 	return errs
 }
 
+// routeCredentialInjection returns the credential injection of the route, which is either configured
+// with an HTTPRouteFilter, or with a BackendTrafficPolicy.
+// The former takes precedence as it's more specific.
+func routeCredentialInjection(route *ir.HTTPRoute) *ir.CredentialInjection {
+	if route.CredentialInjection != nil {
+		return route.CredentialInjection
+	}
+	if route.Traffic != nil {
+		return route.Traffic.CredentialInjection
+	}
+	return nil
+}
+
 func credentialInjectorFilterName(credentialInjection *ir.CredentialInjection) string {
 	return perRouteFilterName(egv1a1.EnvoyFilterCredentialInjector, credentialInjection.Name)
 }
 
 // buildHCMCredentialInjectorFilter returns a credentialInjector HTTP filter from the provided IR HTTPRoute.
 func buildHCMCredentialInjectorFilter(credentialInjection *ir.CredentialInjection) (*hcmv3.HttpFilter, error) {
-	genericCredential := &genericv3.Generic{
-		Credential: &tlsv3.SdsSecretConfig{
-			Name:      credentialSecretName(credentialInjection),
-			SdsConfig: makeConfigSource(),
-		},
-	}
-	if credentialInjection.Header != nil && *credentialInjection.Header != "" {
-		genericCredential.Header = *credentialInjection.Header
-	}
-	genericCredentialAny, err := proto.ToAnyWithValidation(genericCredential)
+	credential, err := buildInjectedCredential(credentialInjection)
 	if err != nil {
 		return nil, err
 	}
 
 	credentialInjector := &injectorv3.CredentialInjector{
-		Credential: &corev3.TypedExtensionConfig{
-			Name:        "envoy.http.injected_credentials.generic",
-			TypedConfig: genericCredentialAny,
-		},
+		Credential: credential,
 	}
 	if credentialInjection.Overwrite != nil {
 		credentialInjector.Overwrite = *credentialInjection.Overwrite
@@ -105,6 +109,92 @@ func buildHCMCredentialInjectorFilter(credentialInjection *ir.CredentialInjectio
 	}, nil
 }
 
+// buildInjectedCredential returns the credential extension of the credentialInjector HTTP filter.
+func buildInjectedCredential(credentialInjection *ir.CredentialInjection) (*corev3.TypedExtensionConfig, error) {
+	if credentialInjection.OAuth2 != nil {
+		oauth2Credential, err := buildOAuth2InjectedCredential(credentialInjection)
+		if err != nil {
+			return nil, err
+		}
+		oauth2CredentialAny, err := proto.ToAnyWithValidation(oauth2Credential)
+		if err != nil {
+			return nil, err
+		}
+		return &corev3.TypedExtensionConfig{
+			Name:        "envoy.http.injected_credentials.oauth2",
+			TypedConfig: oauth2CredentialAny,
+		}, nil
+	}
+
+	genericCredential := &genericv3.Generic{
+		Credential: &tlsv3.SdsSecretConfig{
+			Name:      credentialSecretName(credentialInjection),
+			SdsConfig: makeConfigSource(),
+		},
+	}
+	if credentialInjection.Header != nil && *credentialInjection.Header != "" {
+		genericCredential.Header = *credentialInjection.Header
+	}
+	genericCredentialAny, err := proto.ToAnyWithValidation(genericCredential)
+	if err != nil {
+		return nil, err
+	}
+	return &corev3.TypedExtensionConfig{
+		Name:        "envoy.http.injected_credentials.generic",
+		TypedConfig: genericCredentialAny,
+	}, nil
+}
+
+// buildOAuth2InjectedCredential returns the OAuth2 credential which retrieves the access token
+// from the token endpoint with the client credentials flow.
+// The client secret is stored in the credential secret of the credential injection.
+func buildOAuth2InjectedCredential(credentialInjection *ir.CredentialInjection) (*oauth2credv3.OAuth2, error) {
+	oauth2 := credentialInjection.OAuth2
+
+	cluster, err := url2Cluster(oauth2.TokenEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	// EG does not support static IP clusters for token endpoint clusters.
+	if cluster.endpointType == EndpointTypeStatic {
+		return nil, fmt.Errorf(
+			"static IP cluster is not allowed: %s",
+			oauth2.TokenEndpoint)
+	}
+
+	authType := oauth2credv3.OAuth2_BASIC_AUTH
+	if oauth2.ClientSecretPost {
+		authType = oauth2credv3.OAuth2_URL_ENCODED_BODY
+	}
+
+	oauth2Credential := &oauth2credv3.OAuth2{
+		TokenEndpoint: &corev3.HttpUri{
+			Uri: oauth2.TokenEndpoint,
+			HttpUpstreamType: &corev3.HttpUri_Cluster{
+				Cluster: cluster.name,
+			},
+			Timeout: &durationpb.Duration{
+				Seconds: defaultExtServiceRequestTimeout,
+			},
+		},
+		Scopes: oauth2.Scopes,
+		FlowType: &oauth2credv3.OAuth2_ClientCredentials_{
+			ClientCredentials: &oauth2credv3.OAuth2_ClientCredentials{
+				ClientId: oauth2.ClientID,
+				ClientSecret: &tlsv3.SdsSecretConfig{
+					Name:      credentialSecretName(credentialInjection),
+					SdsConfig: makeConfigSource(),
+				},
+				AuthType: authType,
+			},
+		},
+	}
+	if oauth2.TokenFetchRetryInterval != nil {
+		oauth2Credential.TokenFetchRetryInterval = durationpb.New(oauth2.TokenFetchRetryInterval.Duration)
+	}
+	return oauth2Credential, nil
+}
+
 func credentialSecretName(credentialInjection *ir.CredentialInjection) string {
 	return fmt.Sprintf("credential_injector/credential/%s", credentialInjection.Name)
 }
@@ -113,25 +203,53 @@ func (*credentialInjector) patchResources(resource *types.ResourceVersionTable,
 	var errs error
 
 	for _, route := range routes {
-		if route.CredentialInjection != nil {
-			secret := buildCredentialSecret(route.CredentialInjection)
+		if credentialInjection := routeCredentialInjection(route); credentialInjection != nil {
+			secret := buildCredentialSecret(credentialInjection)
 			if err := addXdsSecret(resource, secret); err != nil {
 				errs = errors.Join(errs, err)
 			}
+			if err := createCredentialTokenEndpointCluster(resource, credentialInjection); err != nil {
+				errs = errors.Join(errs, err)
+			}
+		}
+
+		// The secrets of the credential injections of the backends are added with their clusters,
+		// but the token endpoint clusters still need to be created.
+		if route.Destination != nil {
+			for _, setting := range route.Destination.Settings {
+				if setting.Filters == nil || setting.Filters.CredentialInjection == nil {
+					continue
+				}
+				if err := createCredentialTokenEndpointCluster(resource, setting.Filters.CredentialInjection); err != nil {
+					errs = errors.Join(errs, err)
+				}
+			}
 		}
 	}
 
 	return errs
 }
 
+// createCredentialTokenEndpointCluster creates the token endpoint cluster of an OAuth2 credential, if needed.
+func createCredentialTokenEndpointCluster(tCtx *types.ResourceVersionTable, credentialInjection *ir.CredentialInjection) error {
+	if credentialInjection.OAuth2 == nil {
+		return nil
+	}
+	return createOAuth2TokenEndpointCluster(tCtx, credentialInjection.OAuth2.TokenEndpoint)
+}
+
 func buildCredentialSecret(credentialInjection *ir.CredentialInjection) *tlsv3.Secret {
+	credential := credentialInjection.Credential
+	if credentialInjection.OAuth2 != nil {
+		credential = credentialInjection.OAuth2.ClientSecret
+	}
 	return &tlsv3.Secret{
 		Name: credentialSecretName(credentialInjection),
 		Type: &tlsv3.Secret_GenericSecret{
 			GenericSecret: &tlsv3.GenericSecret{
 				Secret: &corev3.DataSource{
 					Specifier: &corev3.DataSource_InlineBytes{
-						InlineBytes: credentialInjection.Credential,
+						InlineBytes: credential,
 					},
 				},
 			},
@@ -148,10 +266,11 @@ func (*credentialInjector) patchRoute(route *routev3.Route, irRoute *ir.HTTPRout
 	if irRoute == nil {
 		return errors.New("ir route is nil")
 	}
-	if irRoute.CredentialInjection == nil {
+	credentialInjection := routeCredentialInjection(irRoute)
+	if credentialInjection == nil {
 		return nil
 	}
-	filterName := credentialInjectorFilterName(irRoute.CredentialInjection)
+	filterName := credentialInjectorFilterName(credentialInjection)
 	if err := enableFilterOnRoute(route, filterName); err != nil {
 		return err
 	}
@@ -0,0 +1,146 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"errors"
+	"fmt"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	csrfv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/csrf/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xdstype "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/utils/proto"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+	"github.com/envoyproxy/gateway/internal/xds/utils/fractionalpercent"
+)
+
+func init() {
+	registerHTTPFilter(&csrf{})
+}
+
+type csrf struct{}
+
+var _ httpFilter = &csrf{}
+
+// patchHCM builds and appends the CSRF Filter to the HTTP Connection Manager if
+// applicable, and it does not already exist.
+// The filter is disabled at the HCM level and enabled on the routes with a CSRF policy.
+func (*csrf) patchHCM(mgr *hcmv3.HttpConnectionManager, irListener *ir.HTTPListener) error {
+	if mgr == nil {
+		return errors.New("hcm is nil")
+	}
+	if irListener == nil {
+		return errors.New("ir listener is nil")
+	}
+	if hcmContainsFilter(mgr, egv1a1.EnvoyFilterCSRF.String()) {
+		return nil
+	}
+
+	var irCSRF *ir.CSRF
+	for _, route := range irListener.Routes {
+		if routeContainsCSRF(route) {
+			irCSRF = route.Security.CSRF
+			break
+		}
+	}
+	if irCSRF == nil {
+		return nil
+	}
+
+	// We use the first route that contains the CSRF policy to build the filter.
+	// The HCM-level filter config doesn't matter since it is overridden at the route level.
+	csrfAny, err := proto.ToAnyWithValidation(buildCSRFPolicy(irCSRF))
+	if err != nil {
+		return err
+	}
+
+	mgr.HttpFilters = append(mgr.HttpFilters, &hcmv3.HttpFilter{
+		Name: egv1a1.EnvoyFilterCSRF.String(),
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: csrfAny,
+		},
+		Disabled: true,
+	})
+	return nil
+}
+
+// buildCSRFPolicy returns a CSRF policy from the provided IR CSRF.
+func buildCSRFPolicy(irCSRF *ir.CSRF) *csrfv3.CsrfPolicy {
+	filterEnabled := &xdstype.FractionalPercent{
+		Numerator:   100,
+		Denominator: xdstype.FractionalPercent_HUNDRED,
+	}
+	if irCSRF.FilterEnabled != nil {
+		filterEnabled = fractionalpercent.FromFraction(irCSRF.FilterEnabled)
+	}
+
+	csrfPolicy := &csrfv3.CsrfPolicy{
+		FilterEnabled: &corev3.RuntimeFractionalPercent{
+			DefaultValue: filterEnabled,
+		},
+	}
+
+	if irCSRF.ShadowEnabled != nil {
+		csrfPolicy.ShadowEnabled = &corev3.RuntimeFractionalPercent{
+			DefaultValue: fractionalpercent.FromFraction(irCSRF.ShadowEnabled),
+		}
+	}
+
+	for _, origin := range irCSRF.AdditionalOrigins {
+		csrfPolicy.AdditionalOrigins = append(csrfPolicy.AdditionalOrigins, buildXdsStringMatcher(origin))
+	}
+
+	return csrfPolicy
+}
+
+// routeContainsCSRF returns true if the provided route has a CSRF policy.
+func routeContainsCSRF(irRoute *ir.HTTPRoute) bool {
+	return irRoute != nil &&
+		irRoute.Security != nil &&
+		irRoute.Security.CSRF != nil
+}
+
+// patchRoute patches the provided route with the CSRF policy if applicable.
+func (*csrf) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute, _ *ir.HTTPListener) error {
+	if route == nil {
+		return errors.New("xds route is nil")
+	}
+	if irRoute == nil {
+		return errors.New("ir route is nil")
+	}
+	if !routeContainsCSRF(irRoute) {
+		return nil
+	}
+
+	filterCfg := route.GetTypedPerFilterConfig()
+	if _, ok := filterCfg[egv1a1.EnvoyFilterCSRF.String()]; ok {
+		// This should not happen since this is the only place where the CSRF
+		// filter config is added in a route.
+		return fmt.Errorf("route already contains csrf config: %+v", route)
+	}
+
+	csrfAny, err := proto.ToAnyWithValidation(buildCSRFPolicy(irRoute.Security.CSRF))
+	if err != nil {
+		return err
+	}
+
+	if filterCfg == nil {
+		route.TypedPerFilterConfig = make(map[string]*anypb.Any)
+	}
+	route.TypedPerFilterConfig[egv1a1.EnvoyFilterCSRF.String()] = csrfAny
+
+	return nil
+}
+
+func (*csrf) patchResources(*types.ResourceVersionTable, []*ir.HTTPRoute) error {
+	return nil
+}
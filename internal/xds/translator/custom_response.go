@@ -26,6 +26,7 @@ import (
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/utils/cel"
 	"github.com/envoyproxy/gateway/internal/utils/proto"
 	"github.com/envoyproxy/gateway/internal/xds/types"
 )
@@ -115,67 +116,128 @@ func (c *customResponse) customResponseConfig(ro *ir.ResponseOverride) (*respv3.
 			return nil, err
 		}
 
-		switch {
-		case len(r.Match.StatusCodes) == 0:
-			// This is just a sanity check, as the CRD validation should have caught this.
-			return nil, fmt.Errorf("missing status code in response override rule")
-		case len(r.Match.StatusCodes) == 1:
-			if predicate, err = c.buildSinglePredicate(r.Match.StatusCodes[0]); err != nil {
-				return nil, err
-			}
+		if predicate, err = c.buildMatchPredicate(r.Match); err != nil {
+			return nil, err
+		}
+
+		matchers = append(matchers, &matcherv3.Matcher_MatcherList_FieldMatcher{
+			Predicate: predicate,
+			OnMatch: &matcherv3.Matcher_OnMatch{
+				OnMatch: action,
+			},
+		})
+	}
 
-			matcher := &matcherv3.Matcher_MatcherList_FieldMatcher{
-				Predicate: predicate,
-				OnMatch: &matcherv3.Matcher_OnMatch{
-					OnMatch: action,
+	// Create a MatcherList.
+	// The rules will be evaluated in order, and the first match wins.
+	cr := &respv3.CustomResponse{
+		CustomResponseMatcher: &matcherv3.Matcher{
+			MatcherType: &matcherv3.Matcher_MatcherList_{
+				MatcherList: &matcherv3.Matcher_MatcherList{
+					Matchers: matchers,
 				},
-			}
+			},
+		},
+	}
 
-			matchers = append(matchers, matcher)
-		case len(r.Match.StatusCodes) > 1:
-			var predicates []*matcherv3.Matcher_MatcherList_Predicate
+	return cr, nil
+}
 
-			for _, codeMatch := range r.Match.StatusCodes {
-				if predicate, err = c.buildSinglePredicate(codeMatch); err != nil {
-					return nil, err
-				}
+// buildMatchPredicate returns the predicate of a response override rule, which ANDs the status code
+// matches and the CEL expression together.
+func (c *customResponse) buildMatchPredicate(match ir.CustomResponseMatch) (*matcherv3.Matcher_MatcherList_Predicate, error) {
+	var predicates []*matcherv3.Matcher_MatcherList_Predicate
 
-				predicates = append(predicates, predicate)
+	if len(match.StatusCodes) > 0 {
+		var codePredicates []*matcherv3.Matcher_MatcherList_Predicate
+		for _, codeMatch := range match.StatusCodes {
+			predicate, err := c.buildSinglePredicate(codeMatch)
+			if err != nil {
+				return nil, err
 			}
+			codePredicates = append(codePredicates, predicate)
+		}
 
-			// Create a single matcher that ORs all the predicates together.
+		if len(codePredicates) == 1 {
+			predicates = append(predicates, codePredicates[0])
+		} else {
+			// OR all the status code predicates together.
 			// The rule will match if any of the codes match.
-			matcher := &matcherv3.Matcher_MatcherList_FieldMatcher{
-				Predicate: &matcherv3.Matcher_MatcherList_Predicate{
-					MatchType: &matcherv3.Matcher_MatcherList_Predicate_OrMatcher{
-						OrMatcher: &matcherv3.Matcher_MatcherList_Predicate_PredicateList{
-							Predicate: predicates,
-						},
+			predicates = append(predicates, &matcherv3.Matcher_MatcherList_Predicate{
+				MatchType: &matcherv3.Matcher_MatcherList_Predicate_OrMatcher{
+					OrMatcher: &matcherv3.Matcher_MatcherList_Predicate_PredicateList{
+						Predicate: codePredicates,
 					},
 				},
-				OnMatch: &matcherv3.Matcher_OnMatch{
-					OnMatch: action,
-				},
-			}
-
-			matchers = append(matchers, matcher)
+			})
 		}
+	}
 
+	if match.Expression != nil {
+		predicate, err := c.buildExpressionPredicate(*match.Expression)
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, predicate)
 	}
 
-	// Create a MatcherList.
-	// The rules will be evaluated in order, and the first match wins.
-	cr := &respv3.CustomResponse{
-		CustomResponseMatcher: &matcherv3.Matcher{
-			MatcherType: &matcherv3.Matcher_MatcherList_{
-				MatcherList: &matcherv3.Matcher_MatcherList{
-					Matchers: matchers,
+	switch len(predicates) {
+	case 0:
+		// This is just a sanity check, as the CRD validation should have caught this.
+		return nil, fmt.Errorf("missing status code or expression in response override rule")
+	case 1:
+		return predicates[0], nil
+	default:
+		return &matcherv3.Matcher_MatcherList_Predicate{
+			MatchType: &matcherv3.Matcher_MatcherList_Predicate_AndMatcher{
+				AndMatcher: &matcherv3.Matcher_MatcherList_Predicate_PredicateList{
+					Predicate: predicates,
 				},
 			},
+		}, nil
+	}
+}
+
+// buildExpressionPredicate returns the predicate matching the responses for which the CEL expression evaluates to true.
+func (c *customResponse) buildExpressionPredicate(expression egv1a1.CELExpression) (*matcherv3.Matcher_MatcherList_Predicate, error) {
+	var (
+		httpAttributeCELInput *cncfv3.TypedExtensionConfig
+		parsedExpr            *expr.ParsedExpr
+		pb                    *anypb.Any
+		err                   error
+	)
+
+	if httpAttributeCELInput, err = c.buildHTTPAttributeCELInput(); err != nil {
+		return nil, err
+	}
+
+	if parsedExpr, err = cel.Compile(string(expression)); err != nil {
+		return nil, err
+	}
+
+	if pb, err = proto.ToAnyWithValidation(&matcherv3.CelMatcher{
+		ExprMatch: &typev3.CelExpression{
+			ExprSpecifier: &typev3.CelExpression_ParsedExpr{
+				ParsedExpr: parsedExpr,
+			},
 		},
+	}); err != nil {
+		return nil, err
 	}
 
-	return cr, nil
+	return &matcherv3.Matcher_MatcherList_Predicate{
+		MatchType: &matcherv3.Matcher_MatcherList_Predicate_SinglePredicate_{
+			SinglePredicate: &matcherv3.Matcher_MatcherList_Predicate_SinglePredicate{
+				Input: httpAttributeCELInput,
+				Matcher: &matcherv3.Matcher_MatcherList_Predicate_SinglePredicate_CustomMatch{
+					CustomMatch: &cncfv3.TypedExtensionConfig{
+						Name:        "cel-matcher",
+						TypedConfig: pb,
+					},
+				},
+			},
+		},
+	}, nil
 }
 
 func (c *customResponse) buildSinglePredicate(codeMatch ir.StatusCodeMatch) (*matcherv3.Matcher_MatcherList_Predicate, error) {
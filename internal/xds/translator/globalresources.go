@@ -87,7 +87,11 @@ func (t *Translator) createRateLimitServiceCluster(tCtx *types.ResourceVersionTa
 		Name:      destinationSettingName(clusterName),
 	}
 
-	tSocket, err := buildEnvoyClientTLSSocket(envoyClientCertificate)
+	caCertFilename := rateLimitClientTLSCACertFilename
+	if t.GlobalRateLimit.CACertFilepath != "" {
+		caCertFilename = t.GlobalRateLimit.CACertFilepath
+	}
+	tSocket, err := buildEnvoyClientTLSSocket(envoyClientCertificate, caCertFilename)
 	if err != nil {
 		return err
 	}
@@ -102,7 +106,7 @@ func (t *Translator) createRateLimitServiceCluster(tCtx *types.ResourceVersionTa
 }
 
 // buildEnvoyClientTLSSocket builds the TLS socket for Envoy to connect to the control plane components.
-func buildEnvoyClientTLSSocket(envoyClientCertificate *ir.TLSCertificate) (*corev3.TransportSocket, error) {
+func buildEnvoyClientTLSSocket(envoyClientCertificate *ir.TLSCertificate, caCertFilename string) (*corev3.TransportSocket, error) {
 	tlsCtx := &tlsv3.UpstreamTlsContext{
 		CommonTlsContext: &tlsv3.CommonTlsContext{
 			TlsParams: &tlsv3.TlsParameters{
@@ -111,7 +115,7 @@ func buildEnvoyClientTLSSocket(envoyClientCertificate *ir.TLSCertificate) (*core
 			ValidationContextType: &tlsv3.CommonTlsContext_ValidationContext{
 				ValidationContext: &tlsv3.CertificateValidationContext{
 					TrustedCa: &corev3.DataSource{
-						Specifier: &corev3.DataSource_Filename{Filename: rateLimitClientTLSCACertFilename},
+						Specifier: &corev3.DataSource_Filename{Filename: caCertFilename},
 					},
 				},
 			},
@@ -157,7 +161,7 @@ func (t *Translator) createWasmHTTPServiceCluster(tCtx *types.ResourceVersionTab
 		Name:      destinationSettingName(wasmHTTPServiceClusterName),
 	}
 
-	tSocket, err := buildEnvoyClientTLSSocket(envoyClientCertificate)
+	tSocket, err := buildEnvoyClientTLSSocket(envoyClientCertificate, rateLimitClientTLSCACertFilename)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,68 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"testing"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestCreateRateLimitServiceCluster(t *testing.T) {
+	testCases := []struct {
+		name     string
+		settings *GlobalRateLimitSettings
+		host     string
+		port     uint32
+		caCert   string
+	}{
+		{
+			name: "kubernetes",
+			settings: &GlobalRateLimitSettings{
+				ServiceURL: "grpc://envoy-ratelimit.envoy-gateway-system.svc.cluster.local:8081",
+			},
+			host:   "envoy-ratelimit.envoy-gateway-system.svc.cluster.local",
+			port:   8081,
+			caCert: "/certs/ca.crt",
+		},
+		{
+			name: "custom ca cert",
+			settings: &GlobalRateLimitSettings{
+				ServiceURL:     "grpc://127.0.0.1:18081",
+				CACertFilepath: "/tmp/envoy-gateway/certs/envoy/ca.crt",
+			},
+			host:   "127.0.0.1",
+			port:   18081,
+			caCert: "/tmp/envoy-gateway/certs/envoy/ca.crt",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := &Translator{GlobalRateLimit: tc.settings}
+			tCtx := new(types.ResourceVersionTable)
+			err := tr.createRateLimitServiceCluster(tCtx, &ir.TLSCertificate{Name: "envoy-gateway-system/envoy"}, nil)
+			require.NoError(t, err)
+
+			clusters := tCtx.GetXdsResources()[resourcev3.ClusterType]
+			require.Len(t, clusters, 1)
+			cluster := clusters[0].(*clusterv3.Cluster)
+			address := cluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().Address.GetSocketAddress()
+			require.Equal(t, tc.host, address.Address)
+			require.Equal(t, tc.port, address.GetPortValue())
+
+			tlsCtx := &tlsv3.UpstreamTlsContext{}
+			require.NoError(t, cluster.TransportSocket.GetTypedConfig().UnmarshalTo(tlsCtx))
+			require.Equal(t, tc.caCert, tlsCtx.CommonTlsContext.GetValidationContext().TrustedCa.GetFilename())
+		})
+	}
+}
@@ -101,7 +101,7 @@ func buildJWTAuthn(irListener *ir.HTTPListener) (*jwtauthnv3.JwtAuthentication,
 		}
 
 		var reqs []*jwtauthnv3.JwtRequirement
-		for i := range route.Security.JWT.Providers {
+		for i := range routeJWTProviders(route) {
 			var (
 				irProvider = route.Security.JWT.Providers[i]
 				err        error
@@ -136,40 +136,9 @@ func buildJWTAuthn(irListener *ir.HTTPListener) (*jwtauthnv3.JwtAuthentication,
 				}
 				jwtProvider.JwksSourceSpecifier = local
 			} else {
-				var jwksCluster string
-
-				jwks := irProvider.RemoteJWKS
-				if jwks.Destination != nil && len(jwks.Destination.Settings) > 0 {
-					jwksCluster = jwks.Destination.Name
-				} else {
-					var cluster *urlCluster
-					if cluster, err = url2Cluster(jwks.URI); err != nil {
-						return nil, err
-					}
-					jwksCluster = cluster.name
-				}
-
-				remote := &jwtauthnv3.JwtProvider_RemoteJwks{
-					RemoteJwks: &jwtauthnv3.RemoteJwks{
-						HttpUri: &corev3.HttpUri{
-							Uri: jwks.URI,
-							HttpUpstreamType: &corev3.HttpUri_Cluster{
-								Cluster: jwksCluster,
-							},
-							Timeout: &durationpb.Duration{Seconds: defaultExtServiceRequestTimeout},
-						},
-						CacheDuration: &durationpb.Duration{Seconds: 5 * 60},
-						AsyncFetch:    &jwtauthnv3.JwksAsyncFetch{},
-					},
-				}
-
-				// Set the retry policy if it exists.
-				if jwks.Traffic != nil && jwks.Traffic.Retry != nil {
-					var rp *corev3.RetryPolicy
-					if rp, err = buildNonRouteRetryPolicy(jwks.Traffic.Retry); err != nil {
-						return nil, err
-					}
-					remote.RemoteJwks.RetryPolicy = rp
+				var remote *jwtauthnv3.JwtProvider_RemoteJwks
+				if remote, err = buildRemoteJWKS(irProvider.RemoteJWKS); err != nil {
+					return nil, err
 				}
 				jwtProvider.JwksSourceSpecifier = remote
 			}
@@ -193,26 +162,66 @@ func buildJWTAuthn(irListener *ir.HTTPListener) (*jwtauthnv3.JwtAuthentication,
 			})
 		}
 
-		if route.Security.JWT.AllowMissing {
-			reqs = append(reqs, &jwtauthnv3.JwtRequirement{
-				RequiresType: &jwtauthnv3.JwtRequirement_AllowMissing{
-					AllowMissing: &emptypb.Empty{},
-				},
-			})
+		if route.Security.JWT != nil && route.Security.JWT.AllowMissing {
+			reqs = append(reqs, allowMissingJWTRequirement())
 		}
 
-		if len(reqs) == 1 {
-			reqMap[route.Name] = reqs[0]
-		} else {
-			orListReqs := &jwtauthnv3.JwtRequirement{
+		var req *jwtauthnv3.JwtRequirement
+		switch len(reqs) {
+		case 0:
+		case 1:
+			req = reqs[0]
+		default:
+			req = &jwtauthnv3.JwtRequirement{
 				RequiresType: &jwtauthnv3.JwtRequirement_RequiresAny{
 					RequiresAny: &jwtauthnv3.JwtRequirementOrList{
 						Requirements: reqs,
 					},
 				},
 			}
-			reqMap[route.Name] = orListReqs
 		}
+
+		// Validate the ID token set by the oauth filter and extract its claims into headers.
+		// The ID token cookie may be missing for requests the oauth filter passes through,
+		// but an invalid ID token is always rejected.
+		if routeContainsOIDCIDTokenClaims(route) {
+			oidc := route.Security.OIDC
+			oidcProvider, err := buildOIDCIDTokenJWTProvider(oidc)
+			if err != nil {
+				return nil, err
+			}
+
+			providerKey := fmt.Sprintf("%s/%s", route.Name, oidc.Name)
+			jwtProviders[providerKey] = oidcProvider
+			oidcReq := &jwtauthnv3.JwtRequirement{
+				RequiresType: &jwtauthnv3.JwtRequirement_RequiresAny{
+					RequiresAny: &jwtauthnv3.JwtRequirementOrList{
+						Requirements: []*jwtauthnv3.JwtRequirement{
+							{
+								RequiresType: &jwtauthnv3.JwtRequirement_ProviderName{
+									ProviderName: providerKey,
+								},
+							},
+							allowMissingJWTRequirement(),
+						},
+					},
+				},
+			}
+
+			if req == nil {
+				req = oidcReq
+			} else {
+				req = &jwtauthnv3.JwtRequirement{
+					RequiresType: &jwtauthnv3.JwtRequirement_RequiresAll{
+						RequiresAll: &jwtauthnv3.JwtRequirementAndList{
+							Requirements: []*jwtauthnv3.JwtRequirement{req, oidcReq},
+						},
+					},
+				}
+			}
+		}
+
+		reqMap[route.Name] = req
 	}
 
 	return &jwtauthnv3.JwtAuthentication{
@@ -221,6 +230,92 @@ func buildJWTAuthn(irListener *ir.HTTPListener) (*jwtauthnv3.JwtAuthentication,
 	}, nil
 }
 
+// buildRemoteJWKS returns a RemoteJwks source based on the provided IR RemoteJWKS.
+func buildRemoteJWKS(jwks *ir.RemoteJWKS) (*jwtauthnv3.JwtProvider_RemoteJwks, error) {
+	var jwksCluster string
+	if jwks.Destination != nil && len(jwks.Destination.Settings) > 0 {
+		jwksCluster = jwks.Destination.Name
+	} else {
+		cluster, err := url2Cluster(jwks.URI)
+		if err != nil {
+			return nil, err
+		}
+		jwksCluster = cluster.name
+	}
+
+	remote := &jwtauthnv3.JwtProvider_RemoteJwks{
+		RemoteJwks: &jwtauthnv3.RemoteJwks{
+			HttpUri: &corev3.HttpUri{
+				Uri: jwks.URI,
+				HttpUpstreamType: &corev3.HttpUri_Cluster{
+					Cluster: jwksCluster,
+				},
+				Timeout: &durationpb.Duration{Seconds: defaultExtServiceRequestTimeout},
+			},
+			CacheDuration: &durationpb.Duration{Seconds: 5 * 60},
+			AsyncFetch: &jwtauthnv3.JwksAsyncFetch{
+				FastListener: jwks.FastListener,
+			},
+		},
+	}
+
+	if jwks.Timeout != nil {
+		remote.RemoteJwks.HttpUri.Timeout = durationpb.New(jwks.Timeout.Duration)
+	}
+	if jwks.CacheDuration != nil {
+		remote.RemoteJwks.CacheDuration = durationpb.New(jwks.CacheDuration.Duration)
+	}
+	if jwks.FailedRefetchDuration != nil {
+		remote.RemoteJwks.AsyncFetch.FailedRefetchDuration = durationpb.New(jwks.FailedRefetchDuration.Duration)
+	}
+
+	// Set the retry policy if it exists.
+	if jwks.Traffic != nil && jwks.Traffic.Retry != nil {
+		rp, err := buildNonRouteRetryPolicy(jwks.Traffic.Retry)
+		if err != nil {
+			return nil, err
+		}
+		remote.RemoteJwks.RetryPolicy = rp
+	}
+	return remote, nil
+}
+
+// buildOIDCIDTokenJWTProvider returns a JwtProvider that validates the ID token
+// cookie set by the oauth filter and extracts its claims into request headers.
+func buildOIDCIDTokenJWTProvider(oidc *ir.OIDC) (*jwtauthnv3.JwtProvider, error) {
+	claims := oidc.IDTokenClaims
+	remote, err := buildRemoteJWKS(&claims.RemoteJWKS)
+	if err != nil {
+		return nil, err
+	}
+
+	claimToHeaders := make([]*jwtauthnv3.JwtClaimToHeader, 0, len(claims.ClaimToHeaders))
+	for _, claimToHeader := range claims.ClaimToHeaders {
+		claimToHeaders = append(claimToHeaders, &jwtauthnv3.JwtClaimToHeader{
+			HeaderName: claimToHeader.Header,
+			ClaimName:  claimToHeader.Claim,
+		})
+	}
+
+	return &jwtauthnv3.JwtProvider{
+		Issuer:              claims.Issuer,
+		Audiences:           []string{oidc.ClientID},
+		ClaimToHeaders:      claimToHeaders,
+		Forward:             true,
+		FromCookies:         []string{oidc.IDTokenCookieName()},
+		JwksSourceSpecifier: remote,
+	}, nil
+}
+
+// allowMissingJWTRequirement returns a requirement that is satisfied when the JWT is missing.
+func allowMissingJWTRequirement() *jwtauthnv3.JwtRequirement {
+	return &jwtauthnv3.JwtRequirement{
+		RequiresType: &jwtauthnv3.JwtRequirement_AllowMissing{
+			AllowMissing: &emptypb.Empty{},
+		},
+	}
+}
+
 // buildXdsUpstreamTLSSocket returns an xDS TransportSocket that uses envoyTrustBundle
 // as the CA to authenticate server certificates.
 // TODO huabing: add support for custom CA and client certificate.
@@ -300,23 +395,19 @@ func (*jwt) patchResources(tCtx *types.ResourceVersionTable, routes []*ir.HTTPRo
 			continue
 		}
 
-		for i := range route.Security.JWT.Providers {
+		for i := range routeJWTProviders(route) {
 			jwks := route.Security.JWT.Providers[i].RemoteJWKS
 			if jwks == nil {
 				continue
 			}
+			if err := createJWKSCluster(jwks, tCtx); err != nil {
+				errs = errors.Join(errs, err)
+			}
+		}
 
-			// If the rmote JWKS has a destination, use it.
-			if jwks.Destination != nil && len(jwks.Destination.Settings) > 0 {
-				if err := createExtServiceXDSCluster(
-					jwks.Destination, jwks.Traffic, tCtx); err != nil {
-					errs = errors.Join(errs, err)
-				}
-			} else {
-				// Create a cluster with the token endpoint url.
-				if err := addClusterFromURL(jwks.URI, tCtx); err != nil {
-					errs = errors.Join(errs, err)
-				}
+		if routeContainsOIDCIDTokenClaims(route) {
+			if err := createJWKSCluster(&route.Security.OIDC.IDTokenClaims.RemoteJWKS, tCtx); err != nil {
+				errs = errors.Join(errs, err)
 			}
 		}
 	}
@@ -324,6 +415,16 @@ func (*jwt) patchResources(tCtx *types.ResourceVersionTable, routes []*ir.HTTPRo
 	return errs
 }
 
+// createJWKSCluster creates a cluster for fetching the provided remote JWKS.
+func createJWKSCluster(jwks *ir.RemoteJWKS, tCtx *types.ResourceVersionTable) error {
+	// If the remote JWKS has a destination, use it.
+	if jwks.Destination != nil && len(jwks.Destination.Settings) > 0 {
+		return createExtServiceXDSCluster(jwks.Destination, jwks.Traffic, tCtx)
+	}
+	// Create a cluster with the JWKS url.
+	return addClusterFromURL(jwks.URI, tCtx)
+}
+
 // listenerContainsJWTAuthn returns true if JWT authentication exists for the
 // provided listener.
 func listenerContainsJWTAuthn(irListener *ir.HTTPListener) bool {
@@ -341,16 +442,28 @@ func listenerContainsJWTAuthn(irListener *ir.HTTPListener) bool {
 }
 
 // routeContainsJWTAuthn returns true if JWT authentication exists for the
-// provided route.
+// provided route, or the claims of the OIDC ID token need to be extracted.
 func routeContainsJWTAuthn(irRoute *ir.HTTPRoute) bool {
+	return len(routeJWTProviders(irRoute)) > 0 || routeContainsOIDCIDTokenClaims(irRoute)
+}
+
+// routeJWTProviders returns the JWT providers of the provided route.
+func routeJWTProviders(irRoute *ir.HTTPRoute) []ir.JWTProvider {
 	if irRoute != nil &&
 		irRoute.Security != nil &&
-		irRoute.Security.JWT != nil &&
-		irRoute.Security.JWT.Providers != nil &&
-		len(irRoute.Security.JWT.Providers) > 0 {
-		return true
+		irRoute.Security.JWT != nil {
+		return irRoute.Security.JWT.Providers
 	}
-	return false
+	return nil
+}
+
+// routeContainsOIDCIDTokenClaims returns true if the claims of the OIDC ID token
+// need to be extracted into headers for the provided route.
+func routeContainsOIDCIDTokenClaims(irRoute *ir.HTTPRoute) bool {
+	return irRoute != nil &&
+		irRoute.Security != nil &&
+		irRoute.Security.OIDC != nil &&
+		irRoute.Security.OIDC.IDTokenClaims != nil
 }
 
 // buildJwtFromHeaders returns a list of JwtHeader transformed from JWTFromHeader struct
@@ -9,17 +9,20 @@ import (
 	"errors"
 	"fmt"
 
+	mutation_rulesv3 "github.com/envoyproxy/go-control-plane/envoy/config/common/mutation_rules/v3"
 	configv3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	rlv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
 	localrlv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
 	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	early_header_mutationv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/early_header_mutation/header_mutation/v3"
 	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/utils/proto"
 	"github.com/envoyproxy/gateway/internal/utils/ratelimit"
 	"github.com/envoyproxy/gateway/internal/xds/types"
 )
@@ -28,6 +31,11 @@ const (
 	localRateLimitFilterStatPrefix = "http_local_rate_limiter"
 	descriptorMaskedRemoteAddress  = "masked_remote_address"
 	descriptorRemoteAddress        = "remote_address"
+	// localRateLimitShadowHeader is added to the requests over the limit in shadow mode.
+	localRateLimitShadowHeader = "x-envoy-ratelimited-shadow"
+	// localRateLimitShadowHeaderMutation is the name of the early header mutation removing the
+	// shadow mode header sent by the client.
+	localRateLimitShadowHeaderMutation = "envoy.http.early_header_mutation.header_mutation/local-ratelimit-shadow"
 )
 
 func init() {
@@ -51,6 +59,12 @@ func (*localRateLimit) patchHCM(mgr *hcmv3.HttpConnectionManager, irListener *ir
 		return nil
 	}
 
+	if listenerContainsLocalRateLimitShadowMode(irListener) {
+		if err := patchHCMWithLocalRateLimitShadowHeader(mgr); err != nil {
+			return err
+		}
+	}
+
 	// Return early if filter already exists.
 	for _, httpFilter := range mgr.HttpFilters {
 		if httpFilter.Name == egv1a1.EnvoyFilterLocalRateLimit.String() {
@@ -100,6 +114,48 @@ func listenerContainsLocalRateLimit(irListener *ir.HTTPListener) bool {
 	return false
 }
 
+// listenerContainsLocalRateLimitShadowMode returns true if any local rate limit of the provided
+// listener is in shadow mode.
+func listenerContainsLocalRateLimitShadowMode(irListener *ir.HTTPListener) bool {
+	for _, route := range irListener.Routes {
+		if routeContainsLocalRateLimit(route) && route.Traffic.RateLimit.Local.ShadowMode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// patchHCMWithLocalRateLimitShadowHeader adds the early header mutation removing the shadow mode
+// header sent by the client to the HTTP Connection Manager, so the requests can't be marked as
+// over the limit by the client.
+func patchHCMWithLocalRateLimitShadowHeader(mgr *hcmv3.HttpConnectionManager) error {
+	for _, ext := range mgr.EarlyHeaderMutationExtensions {
+		if ext.Name == localRateLimitShadowHeaderMutation {
+			return nil
+		}
+	}
+
+	mutationAny, err := proto.ToAnyWithValidation(&early_header_mutationv3.HeaderMutation{
+		Mutations: []*mutation_rulesv3.HeaderMutation{
+			{
+				Action: &mutation_rulesv3.HeaderMutation_Remove{
+					Remove: localRateLimitShadowHeader,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	mgr.EarlyHeaderMutationExtensions = append(mgr.EarlyHeaderMutationExtensions, &configv3.TypedExtensionConfig{
+		Name:        localRateLimitShadowHeaderMutation,
+		TypedConfig: mutationAny,
+	})
+	return nil
+}
+
 func routeContainsLocalRateLimit(irRoute *ir.HTTPRoute) bool {
 	if irRoute == nil ||
 		irRoute.Traffic == nil ||
@@ -135,7 +191,10 @@ func (*localRateLimit) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute, h
 
 	local := irRoute.Traffic.RateLimit.Local
 
-	rateLimits, descriptors := buildRouteLocalRateLimits(local)
+	rateLimits, descriptors, err := buildRouteLocalRateLimits(local)
+	if err != nil {
+		return err
+	}
 	routeAction.RateLimits = rateLimits
 
 	filterCfg := route.GetTypedPerFilterConfig()
@@ -164,7 +223,7 @@ func (*localRateLimit) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute, h
 		},
 		FilterEnforced: &configv3.RuntimeFractionalPercent{
 			DefaultValue: &typev3.FractionalPercent{
-				Numerator:   100,
+				Numerator:   localRateLimitEnforcedPercent(local),
 				Denominator: typev3.FractionalPercent_HUNDRED,
 			},
 		},
@@ -181,6 +240,18 @@ func (*localRateLimit) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute, h
 	if httpListener.Headers != nil && httpListener.Headers.DisableRateLimitHeaders {
 		localRl.EnableXRatelimitHeaders = rlv3.XRateLimitHeadersRFCVersion_OFF
 	}
+	// In shadow mode, mark the requests over the limit so that they can be logged.
+	if local.ShadowMode {
+		localRl.RequestHeadersToAddWhenNotEnforced = []*configv3.HeaderValueOption{
+			{
+				Header: &configv3.HeaderValue{
+					Key:   localRateLimitShadowHeader,
+					Value: "true",
+				},
+				AppendAction: configv3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+			},
+		}
+	}
 
 	localRlAny, err := anypb.New(localRl)
 	if err != nil {
@@ -195,8 +266,17 @@ func (*localRateLimit) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute, h
 	return nil
 }
 
+// localRateLimitEnforcedPercent returns the percentage of the requests the local rate limit
+// is enforced for. In shadow mode, the requests are counted but never rejected.
+func localRateLimitEnforcedPercent(local *ir.LocalRateLimit) uint32 {
+	if local.ShadowMode {
+		return 0
+	}
+	return 100
+}
+
 func buildRouteLocalRateLimits(local *ir.LocalRateLimit) (
-	[]*routev3.RateLimit, []*rlv3.LocalRateLimitDescriptor,
+	[]*routev3.RateLimit, []*rlv3.LocalRateLimitDescriptor, error,
 ) {
 	var rateLimits []*routev3.RateLimit
 	var descriptors []*rlv3.LocalRateLimitDescriptor
@@ -264,6 +344,51 @@ func buildRouteLocalRateLimits(local *ir.LocalRateLimit) (
 			descriptorEntries = append(descriptorEntries, entry)
 		}
 
+		// QueryParamMatches, MetadataMatches and ExpressionMatches, their match indexes follow the header matches.
+		mIdx := len(rule.HeaderMatches)
+		for _, match := range rule.QueryParamMatches {
+			descriptorKey := getRouteRuleDescriptor(rIdx, mIdx)
+			entry := &rlv3.RateLimitDescriptor_Entry{
+				Key: descriptorKey,
+			}
+			// The descriptor entry value is not set for distinct matches, which means that each distinct
+			// value of the matched query parameter will be counted separately.
+			if !match.Distinct {
+				entry.Value = descriptorKey
+			}
+			rlActions = append(rlActions, buildQueryParamMatchAction(match, descriptorKey))
+			descriptorEntries = append(descriptorEntries, entry)
+			mIdx++
+		}
+		for _, match := range rule.MetadataMatches {
+			descriptorKey := getRouteRuleDescriptor(rIdx, mIdx)
+			entry := &rlv3.RateLimitDescriptor_Entry{
+				Key: descriptorKey,
+			}
+			// The descriptor entry value is the metadata value itself, it's not set for distinct matches.
+			if match.Exact != nil {
+				entry.Value = *match.Exact
+			}
+			rlActions = append(rlActions, buildMetadataMatchAction(match, descriptorKey))
+			descriptorEntries = append(descriptorEntries, entry)
+			mIdx++
+		}
+		for _, expr := range rule.ExpressionMatches {
+			descriptorKey := getRouteRuleDescriptor(rIdx, mIdx)
+			action, err := buildExpressionMatchAction(expr, descriptorKey)
+			if err != nil {
+				return nil, nil, err
+			}
+			// The descriptor entry value is the result of the expression.
+			entry := &rlv3.RateLimitDescriptor_Entry{
+				Key:   descriptorKey,
+				Value: expressionMatchDescriptorValue,
+			}
+			rlActions = append(rlActions, action)
+			descriptorEntries = append(descriptorEntries, entry)
+			mIdx++
+		}
+
 		// Source IP CIDRMatch
 		if rule.CIDRMatch != nil {
 			// For CIDR matches, we first need to check if the source IP matches the CIDR range using
@@ -321,5 +446,5 @@ func buildRouteLocalRateLimits(local *ir.LocalRateLimit) (
 		descriptors = append(descriptors, descriptor)
 	}
 
-	return rateLimits, descriptors
+	return rateLimits, descriptors, nil
 }
@@ -8,23 +8,38 @@ package translator
 import (
 	"errors"
 	"fmt"
+	"strings"
 
+	mutation_rulesv3 "github.com/envoyproxy/go-control-plane/envoy/config/common/mutation_rules/v3"
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	extauthv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
 	oauth2v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/oauth2/v3"
 	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	early_header_mutationv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/early_header_mutation/header_mutation/v3"
 	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/basicauth"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/utils/proto"
 	"github.com/envoyproxy/gateway/internal/xds/types"
 )
 
+const (
+	// oidcClaimHeadersMutation is the name of the early header mutation removing the headers of the OIDC claims.
+	oidcClaimHeadersMutation = "envoy.http.early_header_mutation.header_mutation/oidc-claim-headers"
+	// oidcSessionMaxRequestBytes is the maximum size of the request bodies sent to the session service of
+	// Envoy Gateway, which only needs the body of the back-channel logout requests.
+	oidcSessionMaxRequestBytes = 8192
+)
+
 func init() {
 	registerHTTPFilter(&oidc{})
 }
@@ -48,6 +63,10 @@ func (*oidc) patchHCM(mgr *hcmv3.HttpConnectionManager, irListener *ir.HTTPListe
 		return errors.New("ir listener is nil")
 	}
 
+	if err := patchHCMWithOIDCClaimHeaders(mgr, irListener); err != nil {
+		return err
+	}
+
 	for _, route := range irListener.Routes {
 		if !routeContainsOIDC(route) {
 			continue
@@ -67,11 +86,115 @@ func (*oidc) patchHCM(mgr *hcmv3.HttpConnectionManager, irListener *ir.HTTPListe
 		}
 
 		mgr.HttpFilters = append(mgr.HttpFilters, filter)
+
+		if route.Security.OIDC.Session != nil {
+			filter, err = buildHCMOIDCSessionFilter(route.Security.OIDC)
+			if err != nil {
+				errs = errors.Join(errs, err)
+				continue
+			}
+			mgr.HttpFilters = append(mgr.HttpFilters, filter)
+		}
 	}
 
 	return errs
 }
 
+// patchHCMWithOIDCClaimHeaders adds an early header mutation to the HTTP Connection Manager removing the headers
+// of the ID token and UserInfo claims from the requests received by the listener.
+// The JWT authn filter and the session service of Envoy Gateway only set a claim header when the claim exists,
+// and allow the requests the oauth filter passes through without a session, so the claim headers sent by the
+// client would otherwise reach the backend.
+func patchHCMWithOIDCClaimHeaders(mgr *hcmv3.HttpConnectionManager, irListener *ir.HTTPListener) error {
+	var headers []string
+	for _, route := range irListener.Routes {
+		if !routeContainsOIDC(route) {
+			continue
+		}
+		oidc := route.Security.OIDC
+		if oidc.IDTokenClaims != nil {
+			for _, claimToHeader := range oidc.IDTokenClaims.ClaimToHeaders {
+				headers = append(headers, strings.ToLower(claimToHeader.Header))
+			}
+		}
+		if oidc.Session != nil {
+			for _, claimToHeader := range oidc.Session.UserInfoToHeaders {
+				headers = append(headers, strings.ToLower(claimToHeader.Header))
+			}
+		}
+	}
+	if len(headers) == 0 {
+		return nil
+	}
+
+	// Multiple IR listeners may share the HTTP Connection Manager, so the mutation is merged with the existing one.
+	mutation := &early_header_mutationv3.HeaderMutation{}
+	var ext *corev3.TypedExtensionConfig
+	for _, e := range mgr.EarlyHeaderMutationExtensions {
+		if e.Name == oidcClaimHeadersMutation {
+			ext = e
+			if err := e.TypedConfig.UnmarshalTo(mutation); err != nil {
+				return err
+			}
+		}
+	}
+
+	removed := sets.New[string]()
+	for _, m := range mutation.Mutations {
+		removed.Insert(m.GetRemove())
+	}
+	for _, header := range headers {
+		if removed.Has(header) {
+			continue
+		}
+		removed.Insert(header)
+		mutation.Mutations = append(mutation.Mutations, &mutation_rulesv3.HeaderMutation{
+			Action: &mutation_rulesv3.HeaderMutation_Remove{
+				Remove: header,
+			},
+		})
+	}
+
+	mutationAny, err := proto.ToAnyWithValidation(mutation)
+	if err != nil {
+		return err
+	}
+	if ext != nil {
+		ext.TypedConfig = mutationAny
+		return nil
+	}
+	mgr.EarlyHeaderMutationExtensions = append(mgr.EarlyHeaderMutationExtensions, &corev3.TypedExtensionConfig{
+		Name:        oidcClaimHeadersMutation,
+		TypedConfig: mutationAny,
+	})
+	return nil
+}
+
+// buildHCMOIDCSessionFilter returns the ext_authz filter calling the session service of Envoy Gateway,
+// which checks the sessions of the OIDC config and handles its back-channel logout requests.
+// The filter is named after the oauth2 filter, so that it runs right after it.
+func buildHCMOIDCSessionFilter(oidc *ir.OIDC) (*hcmv3.HttpFilter, error) {
+	extAuthzProto := verifierConfig("cookie")
+	if oidc.Session.BackchannelLogoutPath != "" {
+		extAuthzProto.WithRequestBody = &extauthv3.BufferSettings{
+			MaxRequestBytes:     oidcSessionMaxRequestBytes,
+			AllowPartialMessage: true,
+		}
+	}
+	extAuthzAny, err := proto.ToAnyWithValidation(extAuthzProto)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcmv3.HttpFilter{
+		Name:     oidcSessionFilterName(oidc),
+		Disabled: true,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: extAuthzAny,
+		},
+	}, nil
+}
+
 // buildHCMOAuth2Filter returns an OAuth2 HTTP filter from the provided IR HTTPRoute.
 func buildHCMOAuth2Filter(securityFeatures *ir.SecurityFeatures) (*hcmv3.HttpFilter, error) {
 	oauth2Proto, err := oauth2Config(securityFeatures)
@@ -97,6 +220,10 @@ func oauth2FilterName(oidc *ir.OIDC) string {
 	return perRouteFilterName(egv1a1.EnvoyFilterOAuth2, oidc.Name)
 }
 
+func oidcSessionFilterName(oidc *ir.OIDC) string {
+	return perRouteFilterName(egv1a1.EnvoyFilterOAuth2, oidc.Name+"/session")
+}
+
 func oauth2Config(securityFeatures *ir.SecurityFeatures) (*oauth2v3.OAuth2, error) {
 	var (
 		tokenEndpointCluster string
@@ -172,10 +299,10 @@ func oauth2Config(securityFeatures *ir.SecurityFeatures) (*oauth2v3.OAuth2, erro
 					},
 				},
 				CookieNames: &oauth2v3.OAuth2Credentials_CookieNames{
-					BearerToken:  fmt.Sprintf("AccessToken-%s", oidc.CookieSuffix),
+					BearerToken:  oidc.AccessTokenCookieName(),
 					OauthHmac:    fmt.Sprintf("OauthHMAC-%s", oidc.CookieSuffix),
 					OauthExpires: fmt.Sprintf("OauthExpires-%s", oidc.CookieSuffix),
-					IdToken:      fmt.Sprintf("IdToken-%s", oidc.CookieSuffix),
+					IdToken:      oidc.IDTokenCookieName(),
 					RefreshToken: fmt.Sprintf("RefreshToken-%s", oidc.CookieSuffix),
 					OauthNonce:   fmt.Sprintf("OauthNonce-%s", oidc.CookieSuffix),
 				},
@@ -201,16 +328,6 @@ func oauth2Config(securityFeatures *ir.SecurityFeatures) (*oauth2v3.OAuth2, erro
 		}
 	}
 
-	if oidc.CookieNameOverrides != nil &&
-		oidc.CookieNameOverrides.AccessToken != nil {
-		oauth2.Config.Credentials.CookieNames.BearerToken = *oidc.CookieNameOverrides.AccessToken
-	}
-
-	if oidc.CookieNameOverrides != nil &&
-		oidc.CookieNameOverrides.IDToken != nil {
-		oauth2.Config.Credentials.CookieNames.IdToken = *oidc.CookieNameOverrides.IDToken
-	}
-
 	if oidc.CookieDomain != nil {
 		oauth2.Config.Credentials.CookieDomain = *oidc.CookieDomain
 	}
@@ -228,6 +345,19 @@ func oauth2Config(securityFeatures *ir.SecurityFeatures) (*oauth2v3.OAuth2, erro
 		oauth2.Config.PassThroughMatcher = buildHeaderMatchers(securityFeatures.JWT)
 	}
 
+	// The back-channel logout requests of the OIDC Provider don't have a session, they are
+	// handled by the session service of Envoy Gateway.
+	if oidc.Session != nil && oidc.Session.BackchannelLogoutPath != "" {
+		oauth2.Config.PassThroughMatcher = append(oauth2.Config.PassThroughMatcher, &routev3.HeaderMatcher{
+			Name: ":path",
+			HeaderMatchSpecifier: &routev3.HeaderMatcher_StringMatch{
+				StringMatch: &matcherv3.StringMatcher{
+					MatchPattern: &matcherv3.StringMatcher_Exact{Exact: oidc.Session.BackchannelLogoutPath},
+				},
+			},
+		})
+	}
+
 	if oidc.DenyRedirect != nil {
 		oauth2.Config.DenyRedirectMatcher = buildDenyRedirectMatcher(oidc)
 	}
@@ -578,5 +708,23 @@ func (*oidc) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute, _ *ir.HTTPL
 	if err := enableFilterOnRoute(route, filterName); err != nil {
 		return err
 	}
+
+	if irRoute.Security.OIDC.Session == nil {
+		return nil
+	}
+	// The session service of Envoy Gateway looks up the config by its name, which is sent in
+	// the context extensions.
+	sessionFilterName := oidcSessionFilterName(irRoute.Security.OIDC)
+	if _, ok := route.GetTypedPerFilterConfig()[sessionFilterName]; ok {
+		return fmt.Errorf("route already contains filter config: %s, %+v", sessionFilterName, route)
+	}
+	sessionAny, err := verifierPerRouteConfig(basicauth.OIDCContextExtensionKey, irRoute.Security.OIDC.Name)
+	if err != nil {
+		return err
+	}
+	if route.TypedPerFilterConfig == nil {
+		route.TypedPerFilterConfig = make(map[string]*anypb.Any)
+	}
+	route.TypedPerFilterConfig[sessionFilterName] = sessionAny
 	return nil
 }
@@ -16,8 +16,11 @@ import (
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	ratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/config/ratelimit/v3"
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	luafilterv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
 	ratelimitfilterv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ratelimit/v3"
 	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	exprdescriptorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/rate_limit_descriptors/expr/v3"
+	metadatav3 "github.com/envoyproxy/go-control-plane/envoy/type/metadata/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	rlsconfv3 "github.com/envoyproxy/go-control-plane/ratelimit/config/ratelimit/v3"
 	"github.com/envoyproxy/ratelimit/src/config"
@@ -29,6 +32,17 @@ import (
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/utils/cel"
+	"github.com/envoyproxy/gateway/internal/utils/proto"
+)
+
+const (
+	// expressionMatchDescriptorValue is the descriptor entry value of the CEL expression matches
+	// for which the expression evaluates to true.
+	expressionMatchDescriptorValue = "true"
+	// rateLimitCostMetadataNamespace is the dynamic metadata namespace of the rate limit costs
+	// read from the response headers.
+	rateLimitCostMetadataNamespace = "envoy-gateway.ratelimit.cost"
 )
 
 // patchHCMWithRateLimit builds and appends the Rate Limit Filter to the HTTP connection manager
@@ -51,6 +65,16 @@ func (t *Translator) patchHCMWithRateLimit(mgr *hcmv3.HttpConnectionManager, irL
 	if len(rateLimitFilters) > 0 {
 		mgr.HttpFilters = append(rateLimitFilters, mgr.HttpFilters...)
 	}
+
+	// The costs read from the response headers are capped by a Lua filter, which is enabled on the routes using them.
+	for _, route := range irListener.Routes {
+		if routeContainsRateLimitCostHeader(route) {
+			if filter := buildRateLimitCostFilter(); filter != nil {
+				mgr.HttpFilters = append(mgr.HttpFilters, filter)
+			}
+			break
+		}
+	}
 }
 
 // isRateLimitPresent returns true if rate limit config exists for the listener.
@@ -155,13 +179,21 @@ func createRateLimitFilter(t *Translator, irListener *ir.HTTPListener, domain, f
 }
 
 // patchRouteWithRateLimit builds rate limit actions and appends to the route.
-func patchRouteWithRateLimit(route *routev3.Route, irRoute *ir.HTTPRoute) error { //nolint:unparam
+func patchRouteWithRateLimit(route *routev3.Route, irRoute *ir.HTTPRoute) error {
 	// Return early if no rate limit config exists.
 	xdsRouteAction := route.GetRoute()
 	if !isValidGlobalRateLimit(irRoute) || xdsRouteAction == nil {
 		return nil
 	}
-	rateLimits, costSpecified := buildRouteRateLimits(irRoute)
+	rateLimits, costSpecified, err := buildRouteRateLimits(irRoute)
+	if err != nil {
+		return err
+	}
+	if routeContainsRateLimitCostHeader(irRoute) {
+		if err := patchRouteWithRateLimitCostFilter(route, irRoute); err != nil {
+			return err
+		}
+	}
 	if costSpecified {
 		return patchRouteWithRateLimitOnTypedFilterConfig(route, rateLimits, irRoute)
 	}
@@ -196,10 +228,10 @@ func patchRouteWithRateLimitOnTypedFilterConfig(route *routev3.Route, rateLimits
 }
 
 // buildRouteRateLimits constructs rate limit actions for a given route based on the global rate limit configuration.
-func buildRouteRateLimits(route *ir.HTTPRoute) (rateLimits []*routev3.RateLimit, costSpecified bool) {
+func buildRouteRateLimits(route *ir.HTTPRoute) (rateLimits []*routev3.RateLimit, costSpecified bool, err error) {
 	// Ensure route has rate limit config
 	if !isValidGlobalRateLimit(route) {
-		return nil, false
+		return nil, false, nil
 	}
 
 	// Get the global rate limit configuration
@@ -285,6 +317,25 @@ func buildRouteRateLimits(route *ir.HTTPRoute) (rateLimits []*routev3.RateLimit,
 			rlActions = append(rlActions, action)
 		}
 
+		// Process each query parameter, metadata and expression match in the rule, their match indexes follow the header matches.
+		mIdx := len(rule.HeaderMatches)
+		for _, match := range rule.QueryParamMatches {
+			rlActions = append(rlActions, buildQueryParamMatchAction(match, getRouteRuleDescriptor(domainRuleIdx, mIdx)))
+			mIdx++
+		}
+		for _, match := range rule.MetadataMatches {
+			rlActions = append(rlActions, buildMetadataMatchAction(match, getRouteRuleDescriptor(domainRuleIdx, mIdx)))
+			mIdx++
+		}
+		for _, expr := range rule.ExpressionMatches {
+			action, err := buildExpressionMatchAction(expr, getRouteRuleDescriptor(domainRuleIdx, mIdx))
+			if err != nil {
+				return nil, false, err
+			}
+			rlActions = append(rlActions, action)
+			mIdx++
+		}
+
 		// To be able to rate limit each individual IP, we need to use a nested descriptors structure in the configuration
 		// of the rate limit server:
 		// * the outer layer is a masked_remote_address descriptor that catches all the source IPs inside a specified CIDR.
@@ -350,7 +401,7 @@ func buildRouteRateLimits(route *ir.HTTPRoute) (rateLimits []*routev3.RateLimit,
 
 		if c := rule.RequestCost; c != nil {
 			// Set the hits addend for the request cost if specified.
-			rateLimit.HitsAddend = rateLimitCostToHitsAddend(c)
+			rateLimit.HitsAddend = rateLimitCostToHitsAddend(c, rIdx)
 			costSpecified = true
 		}
 		// Add the rate limit to the list of rate limits.
@@ -359,15 +410,108 @@ func buildRouteRateLimits(route *ir.HTTPRoute) (rateLimits []*routev3.RateLimit,
 		// Handle response cost by creating a separate rate limit object.
 		if c := rule.ResponseCost; c != nil {
 			responseRule := &routev3.RateLimit{Actions: rlActions, ApplyOnStreamDone: true}
-			responseRule.HitsAddend = rateLimitCostToHitsAddend(c)
+			responseRule.HitsAddend = rateLimitCostToHitsAddend(c, rIdx)
 			rateLimits = append(rateLimits, responseRule)
 			costSpecified = true
 		}
 	}
-	return
+	return rateLimits, costSpecified, nil
+}
+
+// buildQueryParamMatchAction returns the rate limit action of a query parameter match.
+func buildQueryParamMatchAction(match *ir.StringMatch, descriptorKey string) *routev3.RateLimit_Action {
+	// Handle distinct matches by setting up query parameters actions.
+	if match.Distinct {
+		return &routev3.RateLimit_Action{
+			ActionSpecifier: &routev3.RateLimit_Action_QueryParameters_{
+				QueryParameters: &routev3.RateLimit_Action_QueryParameters{
+					QueryParameterName: match.Name,
+					DescriptorKey:      descriptorKey,
+				},
+			},
+		}
+	}
+
+	// Handle non-distinct matches by setting up query parameter value match actions.
+	expectMatch := true
+	if match.Invert != nil && *match.Invert {
+		expectMatch = false
+	}
+	return &routev3.RateLimit_Action{
+		ActionSpecifier: &routev3.RateLimit_Action_QueryParameterValueMatch_{
+			QueryParameterValueMatch: &routev3.RateLimit_Action_QueryParameterValueMatch{
+				DescriptorKey:   descriptorKey,
+				DescriptorValue: descriptorKey,
+				ExpectMatch: &wrapperspb.BoolValue{
+					Value: expectMatch,
+				},
+				QueryParameters: []*routev3.QueryParameterMatcher{
+					{
+						Name: match.Name,
+						QueryParameterMatchSpecifier: &routev3.QueryParameterMatcher_StringMatch{
+							StringMatch: buildXdsStringMatcher(match),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildMetadataMatchAction returns the rate limit action of a dynamic metadata match.
+// The descriptor entry value is the metadata value, so the exact matches are done by the descriptors
+// of the rate limit configuration, and the requests without the metadata are not rate limited by the rule.
+func buildMetadataMatchAction(match *ir.MetadataMatch, descriptorKey string) *routev3.RateLimit_Action {
+	path := make([]*metadatav3.MetadataKey_PathSegment, 0, len(match.Path))
+	for _, key := range match.Path {
+		path = append(path, &metadatav3.MetadataKey_PathSegment{
+			Segment: &metadatav3.MetadataKey_PathSegment_Key{Key: key},
+		})
+	}
+	return &routev3.RateLimit_Action{
+		ActionSpecifier: &routev3.RateLimit_Action_Metadata{
+			Metadata: &routev3.RateLimit_Action_MetaData{
+				DescriptorKey: descriptorKey,
+				MetadataKey: &metadatav3.MetadataKey{
+					Key:  match.Namespace,
+					Path: path,
+				},
+				Source: routev3.RateLimit_Action_MetaData_DYNAMIC,
+			},
+		},
+	}
 }
 
-func rateLimitCostToHitsAddend(c *ir.RateLimitCost) *routev3.RateLimit_HitsAddend {
+// buildExpressionMatchAction returns the rate limit action of a CEL expression match.
+// The descriptor entry value is the result of the expression, so the requests for which it evaluates to true
+// match the descriptors of the rate limit configuration, and the requests for which the evaluation fails are
+// not rate limited by the rule.
+func buildExpressionMatchAction(expr egv1a1.CELExpression, descriptorKey string) (*routev3.RateLimit_Action, error) {
+	parsedExpr, err := cel.Compile(string(expr))
+	if err != nil {
+		return nil, err
+	}
+	descriptor, err := proto.ToAnyWithValidation(&exprdescriptorv3.Descriptor{
+		DescriptorKey: descriptorKey,
+		SkipIfError:   true,
+		ExprSpecifier: &exprdescriptorv3.Descriptor_Parsed{
+			Parsed: parsedExpr.GetExpr(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &routev3.RateLimit_Action{
+		ActionSpecifier: &routev3.RateLimit_Action_Extension{
+			Extension: &corev3.TypedExtensionConfig{
+				Name:        "envoy.rate_limit_descriptors.expr",
+				TypedConfig: descriptor,
+			},
+		},
+	}, nil
+}
+
+func rateLimitCostToHitsAddend(c *ir.RateLimitCost, ruleIdx int) *routev3.RateLimit_HitsAddend {
 	ret := &routev3.RateLimit_HitsAddend{}
 	if c.Number != nil {
 		ret.Number = &wrapperspb.UInt64Value{Value: *c.Number}
@@ -375,9 +519,90 @@ func rateLimitCostToHitsAddend(c *ir.RateLimitCost) *routev3.RateLimit_HitsAdden
 	if c.Format != nil {
 		ret.Format = *c.Format
 	}
+	if c.Header != nil {
+		// The cost is read from the dynamic metadata set by the rate limit cost Lua filter.
+		ret.Format = fmt.Sprintf("%%DYNAMIC_METADATA(%s:%s)%%", rateLimitCostMetadataNamespace, getRateLimitCostMetadataKey(ruleIdx))
+	}
 	return ret
 }
 
+// routeContainsRateLimitCostHeader returns true if a global rate limit rule of the route reads its cost from a response header.
+func routeContainsRateLimitCostHeader(irRoute *ir.HTTPRoute) bool {
+	if !isValidGlobalRateLimit(irRoute) {
+		return false
+	}
+	for _, rule := range irRoute.Traffic.RateLimit.Global.Rules {
+		if rule.ResponseCost != nil && rule.ResponseCost.Header != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func getRateLimitCostMetadataKey(ruleIdx int) string {
+	return "rule-" + strconv.Itoa(ruleIdx)
+}
+
+func getRateLimitCostFilterName() string {
+	return perRouteFilterName(egv1a1.EnvoyFilterLua, "ratelimit-cost/0")
+}
+
+// buildRateLimitCostFilter returns the Lua filter that caps the rate limit costs read from the response headers.
+// The filter is disabled by default, and the code is set on the routes using it.
+func buildRateLimitCostFilter() *hcmv3.HttpFilter {
+	luaAny, err := anypb.New(&luafilterv3.Lua{})
+	if err != nil {
+		return nil
+	}
+	return &hcmv3.HttpFilter{
+		Name:     getRateLimitCostFilterName(),
+		Disabled: true,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: luaAny,
+		},
+	}
+}
+
+// patchRouteWithRateLimitCostFilter enables the rate limit cost Lua filter on the route.
+// For each rule reading its cost from a response header, the Lua code sets the dynamic metadata
+// read by the hits addend of the rule to the value of the header, capped to its maximum.
+func patchRouteWithRateLimitCostFilter(route *routev3.Route, irRoute *ir.HTTPRoute) error {
+	var code strings.Builder
+	code.WriteString("function envoy_on_response(response_handle)\n")
+	code.WriteString("  local metadata = response_handle:streamInfo():dynamicMetadata()\n")
+	for rIdx, rule := range irRoute.Traffic.RateLimit.Global.Rules {
+		if rule.ResponseCost == nil || rule.ResponseCost.Header == nil {
+			continue
+		}
+		header := rule.ResponseCost.Header
+		fmt.Fprintf(&code, "  local cost%d = response_handle:headers():get(%q)\n", rIdx, strings.ToLower(header.Name))
+		fmt.Fprintf(&code, "  if cost%d ~= nil and string.match(cost%d, \"^%%d+$\") then\n", rIdx, rIdx)
+		fmt.Fprintf(&code, "    metadata:set(%q, %q, string.format(\"%%d\", math.min(tonumber(cost%d), %d)))\n",
+			rateLimitCostMetadataNamespace, getRateLimitCostMetadataKey(rIdx), rIdx, header.Max)
+		code.WriteString("  end\n")
+	}
+	code.WriteString("end\n")
+
+	luaPerRoute := &luafilterv3.LuaPerRoute{
+		Override: &luafilterv3.LuaPerRoute_SourceCode{
+			SourceCode: &corev3.DataSource{
+				Specifier: &corev3.DataSource_InlineString{
+					InlineString: code.String(),
+				},
+			},
+		},
+	}
+	luaAny, err := proto.ToAnyWithValidation(luaPerRoute)
+	if err != nil {
+		return err
+	}
+	if route.TypedPerFilterConfig == nil {
+		route.TypedPerFilterConfig = make(map[string]*anypb.Any)
+	}
+	route.TypedPerFilterConfig[getRateLimitCostFilterName()] = luaAny
+	return nil
+}
+
 // GetRateLimitServiceConfigStr returns the PB string for the rate limit service configuration.
 func GetRateLimitServiceConfigStr(pbCfg *rlsconfv3.RateLimitConfig) (string, error) {
 	var buf bytes.Buffer
@@ -591,8 +816,11 @@ func buildRateLimitServiceDescriptors(route *ir.HTTPRoute) []*rlsconfv3.RateLimi
 	// The order in which matching descriptors are built is consistent with
 	// the order in which ratelimit actions are built:
 	//  1) Header Matches
-	//  2) CIDR Match
-	//  3) No Match
+	//  2) Query Parameter Matches
+	//  3) Metadata Matches
+	//  4) Expression Matches
+	//  5) CIDR Match
+	//  6) No Match
 
 	for rIdx, rule := range global.Rules {
 		rateLimitPolicy := &rlsconfv3.RateLimitPolicy{
@@ -632,6 +860,45 @@ func buildRateLimitServiceDescriptors(route *ir.HTTPRoute) []*rlsconfv3.RateLimi
 			// as it is also possible that CIDR match descriptor also exist.
 		}
 
+		// 2) Query Parameter Matches, 3) Metadata Matches and 4) Expression Matches
+		// Their match indexes follow the header matches, in the same way as the ratelimit actions.
+		mIdx := len(rule.HeaderMatches)
+		appendDescriptor := func(pbDesc *rlsconfv3.RateLimitDescriptor) {
+			if cur != nil {
+				cur.Descriptors = []*rlsconfv3.RateLimitDescriptor{pbDesc}
+			} else {
+				head = pbDesc
+			}
+			cur = pbDesc
+			mIdx++
+		}
+		for _, match := range rule.QueryParamMatches {
+			pbDesc := new(rlsconfv3.RateLimitDescriptor)
+			pbDesc.Key = getRouteRuleDescriptor(domainRuleIdx, mIdx)
+			// QueryParameters case
+			if !match.Distinct {
+				// QueryParameterValueMatch case
+				pbDesc.Value = getRouteRuleDescriptor(domainRuleIdx, mIdx)
+			}
+			appendDescriptor(pbDesc)
+		}
+		for _, match := range rule.MetadataMatches {
+			pbDesc := new(rlsconfv3.RateLimitDescriptor)
+			pbDesc.Key = getRouteRuleDescriptor(domainRuleIdx, mIdx)
+			// The descriptor value is the metadata value itself, it's left empty for distinct matches.
+			if match.Exact != nil {
+				pbDesc.Value = *match.Exact
+			}
+			appendDescriptor(pbDesc)
+		}
+		for range rule.ExpressionMatches {
+			pbDesc := new(rlsconfv3.RateLimitDescriptor)
+			pbDesc.Key = getRouteRuleDescriptor(domainRuleIdx, mIdx)
+			// The descriptor value is the result of the expression.
+			pbDesc.Value = expressionMatchDescriptorValue
+			appendDescriptor(pbDesc)
+		}
+
 		// EG supports two kinds of rate limit descriptors for the source IP: exact and distinct.
 		// * exact means that all IP Addresses within the specified Source IP CIDR share the same rate limit bucket.
 		// * distinct means that each IP Address within the specified Source IP CIDR has its own rate limit bucket.
@@ -653,7 +920,7 @@ func buildRateLimitServiceDescriptors(route *ir.HTTPRoute) []*rlsconfv3.RateLimi
 		//            requests_per_unit: 100
 		//
 		// Please refer to [Rate Limit Service Descriptor list definition](https://github.com/envoyproxy/ratelimit#descriptor-list-definition) for details.
-		// 2) CIDR Match
+		// 5) CIDR Match
 		if rule.CIDRMatch != nil {
 			// MaskedRemoteAddress case
 			pbDesc := new(rlsconfv3.RateLimitDescriptor)
@@ -678,7 +945,7 @@ func buildRateLimitServiceDescriptors(route *ir.HTTPRoute) []*rlsconfv3.RateLimi
 		}
 		// Case when both header and cidr match are not set and the ratelimit
 		// will be applied to all traffic.
-		// 3) No Match (apply to all traffic)
+		// 6) No Match (apply to all traffic)
 		if !rule.IsMatchSet() {
 			pbDesc := new(rlsconfv3.RateLimitDescriptor)
 			pbDesc.Key = getRouteRuleDescriptor(domainRuleIdx, -1)
@@ -687,8 +954,11 @@ func buildRateLimitServiceDescriptors(route *ir.HTTPRoute) []*rlsconfv3.RateLimi
 			cur = head
 		}
 
-		// Finalize rate-limit policy on the last descriptor in the chain
+		// Finalize rate-limit policy on the last descriptor in the chain.
+		// In shadow mode, the rate limit service counts the requests but doesn't reject them,
+		// and reports the requests over the limit in its stats and the response dynamic metadata.
 		cur.RateLimit = rateLimitPolicy
+		cur.ShadowMode = rule.ShadowMode
 		pbDescriptors = append(pbDescriptors, head)
 	}
 
@@ -20,6 +20,7 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/utils/proto"
 	"github.com/envoyproxy/gateway/internal/xds/utils/fractionalpercent"
@@ -116,6 +117,7 @@ func buildXdsRoute(httpRoute *ir.HTTPRoute, httpListener *ir.HTTPListener) (*rou
 		} else {
 			return nil, err
 		}
+		router.GetRoute().HedgePolicy = buildHedgePolicy(httpRoute)
 	}
 
 	// Telemetry
@@ -733,11 +735,45 @@ func buildRetryPolicy(route *ir.HTTPRoute) (*routev3.RetryPolicy, error) {
 			if bbo {
 				rp.RetryBackOff = rbo
 			}
+
+			if rr.PerRetry.BackOff.RateLimited != nil {
+				rp.RateLimitedRetryBackOff = buildRateLimitedRetryBackOff(rr.PerRetry.BackOff.RateLimited)
+			}
 		}
 	}
 	return rp, nil
 }
 
+func buildRateLimitedRetryBackOff(rlb *ir.RateLimitedBackOff) *routev3.RetryPolicy_RateLimitedRetryBackOff {
+	rrlb := &routev3.RetryPolicy_RateLimitedRetryBackOff{}
+	for _, h := range rlb.ResetHeaders {
+		format := routev3.RetryPolicy_SECONDS
+		if h.Format == egv1a1.RetryResetHeaderFormatUnixTimestamp {
+			format = routev3.RetryPolicy_UNIX_TIMESTAMP
+		}
+		rrlb.ResetHeaders = append(rrlb.ResetHeaders, &routev3.RetryPolicy_ResetHeader{
+			Name:   h.Name,
+			Format: format,
+		})
+	}
+	if rlb.MaxInterval != nil {
+		rrlb.MaxInterval = durationpb.New(rlb.MaxInterval.Duration)
+	}
+	return rrlb
+}
+
+// buildHedgePolicy returns the hedge policy of the route, which sends a retry without
+// canceling the attempt whose per try timeout has been reached.
+func buildHedgePolicy(route *ir.HTTPRoute) *routev3.HedgePolicy {
+	rr := route.GetRetry()
+	if rr == nil || rr.PerRetry == nil || !rr.PerRetry.HedgeOnTimeout {
+		return nil
+	}
+	return &routev3.HedgePolicy{
+		HedgeOnPerTryTimeout: true,
+	}
+}
+
 func buildRouteTracing(httpRoute *ir.HTTPRoute) (*routev3.Tracing, error) {
 	if httpRoute == nil || httpRoute.Traffic == nil ||
 		httpRoute.Traffic.Telemetry == nil ||
@@ -91,6 +91,11 @@ type GlobalRateLimitSettings struct {
 	// FailClosed is a switch used to control the flow of traffic
 	// when the response from the ratelimit server cannot be obtained.
 	FailClosed bool
+
+	// CACertFilepath is the path of the CA certificate the proxy uses
+	// to verify the rate limit service.
+	// If not set, "/certs/ca.crt" is used.
+	CACertFilepath string
 }
 
 // Translate translates the XDS IR into xDS resources
@@ -1060,8 +1065,7 @@ func addXdsCluster(tCtx *types.ResourceVersionTable, args *xdsClusterArgs) error
 		return err
 	}
 	xdsCluster := result.cluster
-	preferLocal := ptr.Deref(args.loadBalancer, ir.LoadBalancer{}).PreferLocal
-	xdsEndpoints := buildXdsClusterLoadAssignment(args.name, args.settings, preferLocal)
+	xdsEndpoints := buildXdsClusterLoadAssignment(args.name, args.settings, args.loadBalancer, args.failover)
 	for _, ds := range args.settings {
 		shouldValidateTLS := ds.TLS != nil && !ds.TLS.InsecureSkipVerify
 		if shouldValidateTLS {
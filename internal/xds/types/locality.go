@@ -0,0 +1,76 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package types
+
+import (
+	"sort"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ProxyLocalityOriginMetadataNamespace is the namespace of the filter metadata which marks the
+// localities ordered by their distance to the locality of the proxy they are sent to.
+const ProxyLocalityOriginMetadataNamespace = "envoy-gateway.proxy_locality_origin"
+
+// LocalityDistance returns the distance of the locality to the origin: 0 for the same zone,
+// 1 for the same region and 2 for the other regions.
+func LocalityDistance(origin, l *corev3.Locality) uint32 {
+	switch {
+	case origin == nil:
+		return 0
+	case origin.Region != "" && origin.Region != l.GetRegion():
+		return 2
+	case origin.Zone != "" && origin.Zone != l.GetZone():
+		return 1
+	default:
+		return 0
+	}
+}
+
+// CompactLocalityPriorities renumbers the priorities of the localities, so that
+// there's no gap between the priority levels.
+func CompactLocalityPriorities(localities []*endpointv3.LocalityLbEndpoints) {
+	levels := sets.New[uint32]()
+	for _, l := range localities {
+		levels.Insert(l.Priority)
+	}
+	sortedLevels := sets.List(levels)
+	for _, l := range localities {
+		l.Priority = uint32(sort.Search(len(sortedLevels), func(i int) bool {
+			return sortedLevels[i] >= l.Priority
+		}))
+	}
+}
+
+// LocalizeLoadAssignment returns a copy of the load assignment where the localities marked with
+// ProxyLocalityOriginMetadataNamespace are prioritized by their distance to the origin, which is
+// the locality of the proxy. The load assignment is returned as is if no locality is marked.
+func LocalizeLoadAssignment(cla *endpointv3.ClusterLoadAssignment, origin *corev3.Locality) *endpointv3.ClusterLoadAssignment {
+	if !hasProxyLocalityOrigin(cla) {
+		return cla
+	}
+
+	localized := proto.Clone(cla).(*endpointv3.ClusterLoadAssignment)
+	for _, l := range localized.Endpoints {
+		if _, ok := l.GetMetadata().GetFilterMetadata()[ProxyLocalityOriginMetadataNamespace]; ok {
+			l.Priority = LocalityDistance(origin, l.Locality)
+		}
+	}
+	CompactLocalityPriorities(localized.Endpoints)
+	return localized
+}
+
+func hasProxyLocalityOrigin(cla *endpointv3.ClusterLoadAssignment) bool {
+	for _, l := range cla.GetEndpoints() {
+		if _, ok := l.GetMetadata().GetFilterMetadata()[ProxyLocalityOriginMetadataNamespace]; ok {
+			return true
+		}
+	}
+	return false
+}
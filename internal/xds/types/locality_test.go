@@ -0,0 +1,91 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package types
+
+import (
+	"testing"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestLocalizeLoadAssignment(t *testing.T) {
+	marker := &corev3.Metadata{
+		FilterMetadata: map[string]*structpb.Struct{
+			ProxyLocalityOriginMetadataNamespace: {},
+		},
+	}
+	newCLA := func(metadata *corev3.Metadata) *endpointv3.ClusterLoadAssignment {
+		return &endpointv3.ClusterLoadAssignment{
+			ClusterName: "test-cluster",
+			Endpoints: []*endpointv3.LocalityLbEndpoints{
+				{Locality: &corev3.Locality{Region: "us-east-1", Zone: "us-east-1a"}, Metadata: metadata},
+				{Locality: &corev3.Locality{Region: "us-east-1", Zone: "us-east-1b"}, Metadata: metadata},
+				{Locality: &corev3.Locality{Region: "us-west-2", Zone: "us-west-2a"}, Metadata: metadata},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		cla        *endpointv3.ClusterLoadAssignment
+		origin     *corev3.Locality
+		priorities []uint32
+		unchanged  bool
+	}{
+		{
+			name:       "same zone, same region and other region",
+			cla:        newCLA(marker),
+			origin:     &corev3.Locality{Region: "us-east-1", Zone: "us-east-1b"},
+			priorities: []uint32{1, 0, 2},
+		},
+		{
+			name:       "no locality in the same region",
+			cla:        newCLA(marker),
+			origin:     &corev3.Locality{Region: "eu-west-1", Zone: "eu-west-1a"},
+			priorities: []uint32{0, 0, 0},
+		},
+		{
+			name:       "origin with only a zone",
+			cla:        newCLA(marker),
+			origin:     &corev3.Locality{Zone: "us-west-2a"},
+			priorities: []uint32{1, 1, 0},
+		},
+		{
+			name:       "proxy without locality",
+			cla:        newCLA(marker),
+			priorities: []uint32{0, 0, 0},
+		},
+		{
+			name:       "localities without marker",
+			cla:        newCLA(nil),
+			origin:     &corev3.Locality{Region: "us-east-1", Zone: "us-east-1b"},
+			priorities: []uint32{0, 0, 0},
+			unchanged:  true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := LocalizeLoadAssignment(tc.cla, tc.origin)
+			if tc.unchanged {
+				require.Same(t, tc.cla, got)
+			} else {
+				require.NotSame(t, tc.cla, got)
+			}
+			priorities := make([]uint32, 0, len(got.Endpoints))
+			for _, l := range got.Endpoints {
+				priorities = append(priorities, l.Priority)
+			}
+			require.Equal(t, tc.priorities, priorities)
+			// The load assignment shared by the proxies must not be modified.
+			for _, l := range tc.cla.Endpoints {
+				require.Zero(t, l.Priority)
+			}
+		})
+	}
+}
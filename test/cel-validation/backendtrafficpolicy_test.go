@@ -415,6 +415,38 @@ func TestBackendTrafficPolicyTarget(t *testing.T) {
 			},
 			wantErrors: []string{},
 		},
+		{
+			desc: "ZoneAware with both preferLocal and localityWeighted set",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					ClusterSettings: egv1a1.ClusterSettings{
+						LoadBalancer: &egv1a1.LoadBalancer{
+							Type: egv1a1.RoundRobinLoadBalancerType,
+							ZoneAware: &egv1a1.ZoneAware{
+								PreferLocal: &egv1a1.PreferLocalZone{},
+								LocalityWeighted: &egv1a1.LocalityWeighted{
+									Origin: &egv1a1.Locality{
+										Region: ptr.To("us-east-1"),
+									},
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.loadBalancer.zoneAware: Invalid value: \"object\": only one of preferLocal or localityWeighted can be set.",
+			},
+		},
 		{
 			desc: "leastRequest with SlowStar is set",
 			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
@@ -713,6 +745,212 @@ func TestBackendTrafficPolicyTarget(t *testing.T) {
 				"spec.circuitBreaker.maxConnections: Invalid value: 4294967296: spec.circuitBreaker.maxConnections in body should be less than or equal to 4294967295",
 			},
 		},
+		{
+			desc: "hedgeOnTimeout without timeout",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					ClusterSettings: egv1a1.ClusterSettings{
+						Retry: &egv1a1.Retry{
+							PerRetry: &egv1a1.PerRetryPolicy{
+								HedgeOnTimeout: ptr.To(true),
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.retry.perRetry: Invalid value: \"object\": timeout must be set when hedgeOnTimeout is enabled.",
+			},
+		},
+		{
+			desc: "invalid retry budget percent",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					ClusterSettings: egv1a1.ClusterSettings{
+						CircuitBreaker: &egv1a1.CircuitBreaker{
+							RetryBudget: &egv1a1.RetryBudget{
+								Percent: ptr.To[int32](101),
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.circuitBreaker.retryBudget.percent: Invalid value: 101: spec.circuitBreaker.retryBudget.percent in body should be less than or equal to 100",
+			},
+		},
+		{
+			desc: "adaptive concurrency with a non error status code",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					AdaptiveConcurrency: &egv1a1.AdaptiveConcurrency{
+						ConcurrencyLimitExceededStatus: ptr.To(egv1a1.HTTPStatus(200)),
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.adaptiveConcurrency: Invalid value: \"object\": concurrencyLimitExceededStatus must be an error status code.",
+			},
+		},
+		{
+			desc: "adaptive concurrency with both minRTT interval and fixedValue",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					AdaptiveConcurrency: &egv1a1.AdaptiveConcurrency{
+						MinRTT: &egv1a1.AdaptiveConcurrencyMinRTT{
+							Interval:   ptr.To(gwapiv1.Duration("30s")),
+							FixedValue: ptr.To(gwapiv1.Duration("50ms")),
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.adaptiveConcurrency.minRTT: Invalid value: \"object\": only one of interval or fixedValue can be set.",
+			},
+		},
+		{
+			desc: "invalid outlier detection percentages",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					ClusterSettings: egv1a1.ClusterSettings{
+						HealthCheck: &egv1a1.HealthCheck{
+							Passive: &egv1a1.PassiveHealthCheck{
+								SuccessRate: &egv1a1.PassiveHealthCheckSuccessRate{
+									EnforcingPercent: ptr.To[uint32](101),
+								},
+								FailurePercentage: &egv1a1.PassiveHealthCheckFailurePercentage{
+									Threshold: ptr.To[uint32](101),
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.HealthCheck.passive.successRate.enforcingPercent: Invalid value: 101: spec.HealthCheck.passive.successRate.enforcingPercent in body should be less than or equal to 100",
+				"spec.HealthCheck.passive.failurePercentage.threshold: Invalid value: 101: spec.HealthCheck.passive.failurePercentage.threshold in body should be less than or equal to 100",
+			},
+		},
+		{
+			desc: "invalid failover",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					Failover: &egv1a1.Failover{
+						Priorities:             []egv1a1.FailoverPriority{},
+						OverprovisioningFactor: ptr.To[uint32](0),
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.failover.priorities in body should have at least 1 items",
+				"spec.failover.overprovisioningFactor: Invalid value: 0: spec.failover.overprovisioningFactor in body should be greater than or equal to 1",
+			},
+		},
+		{
+			desc: "maxEjectionTime lower than baseEjectionTime",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					ClusterSettings: egv1a1.ClusterSettings{
+						HealthCheck: &egv1a1.HealthCheck{
+							Passive: &egv1a1.PassiveHealthCheck{
+								BaseEjectionTime: ptr.To(gwapiv1.Duration("1m")),
+								MaxEjectionTime:  ptr.To(gwapiv1.Duration("30s")),
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.HealthCheck.passive: Invalid value: \"object\": maxEjectionTime must be greater than or equal to baseEjectionTime.",
+			},
+		},
+		{
+			desc: "maxEjectionTime equal to baseEjectionTime",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					ClusterSettings: egv1a1.ClusterSettings{
+						HealthCheck: &egv1a1.HealthCheck{
+							Passive: &egv1a1.PassiveHealthCheck{
+								MaxEjectionTime: ptr.To(gwapiv1.Duration("30s")),
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
 		{
 			desc: "invalid path of http health checker",
 			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
@@ -1583,6 +1821,60 @@ func TestBackendTrafficPolicyTarget(t *testing.T) {
 				"spec.responseOverride[0].match.statusCodes[0]: Invalid value: \"object\": range must be set for type Range",
 			},
 		},
+		{
+			desc: "expression in response override",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					ResponseOverride: []*egv1a1.ResponseOverride{
+						{
+							Match: egv1a1.CustomResponseMatch{
+								Expression: ptr.To(egv1a1.CELExpression("response.headers['x-reason'] == 'quota'")),
+							},
+							Response: &egv1a1.CustomResponse{
+								ContentType: ptr.To("text/plain"),
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "empty match in response override",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					ResponseOverride: []*egv1a1.ResponseOverride{
+						{
+							Match: egv1a1.CustomResponseMatch{},
+							Response: &egv1a1.CustomResponse{
+								ContentType: ptr.To("text/plain"),
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"at least one of statusCodes or expression must be specified",
+			},
+		},
 		{
 			desc: "status range invalid in response override",
 			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
@@ -1839,6 +2131,179 @@ func TestBackendTrafficPolicyTarget(t *testing.T) {
 				`spec.rateLimit.global.rules[0].cost.request: Invalid value: "object": only one of number or metadata can be specified`,
 			},
 		},
+		{
+			desc: "valid Global rate limit rules with response cost from a header",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					RateLimit: &egv1a1.RateLimitSpec{
+						Type: egv1a1.GlobalRateLimitType,
+						Global: &egv1a1.GlobalRateLimit{
+							Rules: []egv1a1.RateLimitRule{
+								{
+									Limit: egv1a1.RateLimitValue{Requests: 10, Unit: "Minute"},
+									Cost: &egv1a1.RateLimitCost{
+										Response: &egv1a1.RateLimitCostSpecifier{
+											From:   egv1a1.RateLimitCostFromHeader,
+											Header: &egv1a1.RateLimitCostHeader{Name: "x-usage-tokens", Max: 1000},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "invalid Global rate limit rules with request cost from a header",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					RateLimit: &egv1a1.RateLimitSpec{
+						Type: egv1a1.GlobalRateLimitType,
+						Global: &egv1a1.GlobalRateLimit{
+							Rules: []egv1a1.RateLimitRule{
+								{
+									Limit: egv1a1.RateLimitValue{Requests: 10, Unit: "Minute"},
+									Cost: &egv1a1.RateLimitCost{
+										Request: &egv1a1.RateLimitCostSpecifier{
+											From:   egv1a1.RateLimitCostFromHeader,
+											Header: &egv1a1.RateLimitCostHeader{Name: "x-usage-tokens", Max: 1000},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				`spec.rateLimit.global.rules[0].cost: Invalid value: "object": header is only supported for the response cost`,
+			},
+		},
+		{
+			desc: "invalid Global rate limit rules with response cost specifying both header and number fields",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					RateLimit: &egv1a1.RateLimitSpec{
+						Type: egv1a1.GlobalRateLimitType,
+						Global: &egv1a1.GlobalRateLimit{
+							Rules: []egv1a1.RateLimitRule{
+								{
+									Limit: egv1a1.RateLimitValue{Requests: 10, Unit: "Minute"},
+									Cost: &egv1a1.RateLimitCost{
+										Response: &egv1a1.RateLimitCostSpecifier{
+											From:   egv1a1.RateLimitCostFromHeader,
+											Header: &egv1a1.RateLimitCostHeader{Name: "x-usage-tokens", Max: 1000},
+											Number: ptr.To[uint64](200),
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				`spec.rateLimit.global.rules[0].cost.response: Invalid value: "object": header cannot be specified with number or metadata`,
+			},
+		},
+		{
+			desc: "invalid Global rate limit rules with response cost from a header without the header",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					RateLimit: &egv1a1.RateLimitSpec{
+						Type: egv1a1.GlobalRateLimitType,
+						Global: &egv1a1.GlobalRateLimit{
+							Rules: []egv1a1.RateLimitRule{
+								{
+									Limit: egv1a1.RateLimitValue{Requests: 10, Unit: "Minute"},
+									Cost: &egv1a1.RateLimitCost{
+										Response: &egv1a1.RateLimitCostSpecifier{
+											From: egv1a1.RateLimitCostFromHeader,
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				`spec.rateLimit.global.rules[0].cost.response: Invalid value: "object": header must be specified when from is Header`,
+			},
+		},
+		{
+			desc: "invalid Global rate limit rules with response cost from a header exceeding the maximum cost",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					RateLimit: &egv1a1.RateLimitSpec{
+						Type: egv1a1.GlobalRateLimitType,
+						Global: &egv1a1.GlobalRateLimit{
+							Rules: []egv1a1.RateLimitRule{
+								{
+									Limit: egv1a1.RateLimitValue{Requests: 10, Unit: "Minute"},
+									Cost: &egv1a1.RateLimitCost{
+										Response: &egv1a1.RateLimitCostSpecifier{
+											From:   egv1a1.RateLimitCostFromHeader,
+											Header: &egv1a1.RateLimitCostHeader{Name: "x-usage-tokens", Max: 2000000000},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				`spec.rateLimit.global.rules[0].cost.response.header.max: Invalid value: 2000000000: spec.rateLimit.global.rules[0].cost.response.header.max in body should be less than or equal to 1000000000`,
+			},
+		},
 		{
 			desc: "invalid count of local rate limit rules specifying costPerResponse",
 			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
@@ -1870,6 +2335,159 @@ func TestBackendTrafficPolicyTarget(t *testing.T) {
 			},
 			wantErrors: []string{`response cost is not supported for Local Rate Limits`},
 		},
+		{
+			desc: "local rate limit rules specifying shadowMode",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					RateLimit: &egv1a1.RateLimitSpec{
+						Type: egv1a1.LocalRateLimitType,
+						Local: &egv1a1.LocalRateLimit{
+							Rules: []egv1a1.RateLimitRule{
+								{
+									Limit: egv1a1.RateLimitValue{Requests: 10, Unit: "Minute"},
+									// This is not supported for LocalRateLimit.
+									ShadowMode: ptr.To(true),
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{`shadowMode is not supported for Local Rate Limit rules, set it on the rateLimit instead`},
+		},
+		{
+			desc: "local rate limit specifying shadowMode",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					RateLimit: &egv1a1.RateLimitSpec{
+						Type:       egv1a1.LocalRateLimitType,
+						ShadowMode: ptr.To(true),
+						Local: &egv1a1.LocalRateLimit{
+							Rules: []egv1a1.RateLimitRule{
+								{
+									Limit: egv1a1.RateLimitValue{Requests: 10, Unit: "Minute"},
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "global rate limit with jwtClaims and queryParams selectors",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					RateLimit: &egv1a1.RateLimitSpec{
+						Type: egv1a1.GlobalRateLimitType,
+						Global: &egv1a1.GlobalRateLimit{
+							Rules: []egv1a1.RateLimitRule{
+								{
+									ClientSelectors: []egv1a1.RateLimitSelectCondition{
+										{
+											JWTClaims: []egv1a1.JWTClaimMatch{
+												{
+													Provider: "example",
+													Name:     "tenant",
+													Type:     ptr.To(egv1a1.JWTClaimMatchDistinct),
+												},
+											},
+											QueryParams: []egv1a1.QueryParamMatch{
+												{
+													Name:  "debug",
+													Value: ptr.To("true"),
+												},
+											},
+											Path: &gwapiv1.HTTPPathMatch{
+												Type:  ptr.To(gwapiv1.PathMatchPathPrefix),
+												Value: ptr.To("/api"),
+											},
+											Methods: []gwapiv1.HTTPMethod{gwapiv1.HTTPMethodPost},
+										},
+									},
+									Limit: egv1a1.RateLimitValue{Requests: 10, Unit: "Minute"},
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "global rate limit with invalid jwtClaims and queryParams selectors",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					RateLimit: &egv1a1.RateLimitSpec{
+						Type: egv1a1.GlobalRateLimitType,
+						Global: &egv1a1.GlobalRateLimit{
+							Rules: []egv1a1.RateLimitRule{
+								{
+									ClientSelectors: []egv1a1.RateLimitSelectCondition{
+										{
+											JWTClaims: []egv1a1.JWTClaimMatch{
+												{
+													Provider: "example",
+													Name:     "tenant",
+												},
+											},
+											QueryParams: []egv1a1.QueryParamMatch{
+												{
+													Name:  "api_key",
+													Type:  ptr.To(egv1a1.QueryParamMatchDistinct),
+													Value: ptr.To("foo"),
+												},
+											},
+										},
+									},
+									Limit: egv1a1.RateLimitValue{Requests: 10, Unit: "Minute"},
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"value must be set for Exact type, and must not be set for Distinct type",
+				"value must be set for Exact and RegularExpression types, and must not be set for Distinct type",
+			},
+		},
 		{
 			desc: "panicThreshold is set",
 			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
@@ -16,6 +16,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 )
@@ -139,6 +140,60 @@ func TestHTTPRouteFilter(t *testing.T) {
 			},
 			wantErrors: []string{"spec.urlRewrite.hostname: Invalid value: \"object\": header must be nil if the type is not Header"},
 		},
+		{
+			desc: "valid oauth2 credential injection",
+			mutate: func(httproutefilter *egv1a1.HTTPRouteFilter) {
+				httproutefilter.Spec = egv1a1.HTTPRouteFilterSpec{
+					CredentialInjection: &egv1a1.HTTPCredentialInjectionFilter{
+						Credential: egv1a1.InjectedCredential{
+							OAuth2: &egv1a1.OAuth2ClientCredentials{
+								TokenEndpoint:        "https://oauth.example.com/token",
+								ClientID:             "client",
+								ClientSecret:         gwapiv1.SecretObjectReference{Name: "client-secret"},
+								ClientAuthentication: ptr.To(egv1a1.OAuth2ClientSecretPost),
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "both valueRef and oauth2 in credential injection",
+			mutate: func(httproutefilter *egv1a1.HTTPRouteFilter) {
+				httproutefilter.Spec = egv1a1.HTTPRouteFilterSpec{
+					CredentialInjection: &egv1a1.HTTPCredentialInjectionFilter{
+						Credential: egv1a1.InjectedCredential{
+							ValueRef: gwapiv1.SecretObjectReference{Name: "credential"},
+							OAuth2: &egv1a1.OAuth2ClientCredentials{
+								TokenEndpoint: "https://oauth.example.com/token",
+								ClientID:      "client",
+								ClientSecret:  gwapiv1.SecretObjectReference{Name: "client-secret"},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{"spec.credentialInjection.credential: Invalid value: \"object\": exactly one of valueRef or oauth2 must be set"},
+		},
+		{
+			desc: "header with oauth2 credential injection",
+			mutate: func(httproutefilter *egv1a1.HTTPRouteFilter) {
+				httproutefilter.Spec = egv1a1.HTTPRouteFilterSpec{
+					CredentialInjection: &egv1a1.HTTPCredentialInjectionFilter{
+						Header: ptr.To("x-credential"),
+						Credential: egv1a1.InjectedCredential{
+							OAuth2: &egv1a1.OAuth2ClientCredentials{
+								TokenEndpoint: "https://oauth.example.com/token",
+								ClientID:      "client",
+								ClientSecret:  gwapiv1.SecretObjectReference{Name: "client-secret"},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{"spec.credentialInjection: Invalid value: \"object\": header cannot be set with an oauth2 credential, the access token is always injected into the Authorization header"},
+		},
 	}
 
 	for _, tc := range cases {
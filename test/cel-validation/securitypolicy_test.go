@@ -1176,6 +1176,226 @@ func TestSecurityPolicyTarget(t *testing.T) {
 				"Exactly one of inline or valueRef must be set with correct type.",
 			},
 		},
+		{
+			desc: "basic auth with htpasswd users",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					BasicAuth: &egv1a1.BasicAuth{
+						Users: gwapiv1.SecretObjectReference{
+							Name: "users-secret",
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "basic auth with ldap",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					BasicAuth: &egv1a1.BasicAuth{
+						LDAP: &egv1a1.LDAPUserStore{
+							URL:    "ldaps://ldap.example.com:636",
+							BindDN: "cn=envoy-gateway,ou=services,dc=example,dc=com",
+							BindPassword: gwapiv1.SecretObjectReference{
+								Name: "ldap-bind-password",
+							},
+							BaseDN:       "ou=users,dc=example,dc=com",
+							SearchFilter: ptr.To("(&(objectClass=person)(uid=%s))"),
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "basic auth with both htpasswd users and ldap",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					BasicAuth: &egv1a1.BasicAuth{
+						Users: gwapiv1.SecretObjectReference{
+							Name: "users-secret",
+						},
+						LDAP: &egv1a1.LDAPUserStore{
+							URL:    "ldaps://ldap.example.com:636",
+							BindDN: "cn=envoy-gateway,ou=services,dc=example,dc=com",
+							BindPassword: gwapiv1.SecretObjectReference{
+								Name: "ldap-bind-password",
+							},
+							BaseDN:       "ou=users,dc=example,dc=com",
+							SearchFilter: ptr.To("(uid=%s)"),
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"exactly one of users or ldap must be specified",
+			},
+		},
+		{
+			desc: "basic auth without htpasswd users or ldap",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					BasicAuth: &egv1a1.BasicAuth{},
+				}
+			},
+			wantErrors: []string{
+				"exactly one of users or ldap must be specified",
+			},
+		},
+		{
+			desc: "basic auth with ldap search filter without username",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					BasicAuth: &egv1a1.BasicAuth{
+						LDAP: &egv1a1.LDAPUserStore{
+							URL:    "ldaps://ldap.example.com:636",
+							BindDN: "cn=envoy-gateway,ou=services,dc=example,dc=com",
+							BindPassword: gwapiv1.SecretObjectReference{
+								Name: "ldap-bind-password",
+							},
+							BaseDN:       "ou=users,dc=example,dc=com",
+							SearchFilter: ptr.To("(objectClass=person)"),
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"searchFilter must contain %s",
+			},
+		},
+		{
+			desc: "basic auth with ldap invalid url",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					BasicAuth: &egv1a1.BasicAuth{
+						LDAP: &egv1a1.LDAPUserStore{
+							URL:    "https://ldap.example.com",
+							BindDN: "cn=envoy-gateway,ou=services,dc=example,dc=com",
+							BindPassword: gwapiv1.SecretObjectReference{
+								Name: "ldap-bind-password",
+							},
+							BaseDN:       "ou=users,dc=example,dc=com",
+							SearchFilter: ptr.To("(uid=%s)"),
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.basicAuth.ldap.url: Invalid value",
+			},
+		},
+		{
+			desc: "basic auth with ldap url without startTLS",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					BasicAuth: &egv1a1.BasicAuth{
+						LDAP: &egv1a1.LDAPUserStore{
+							URL:    "ldap://ldap.example.com",
+							BindDN: "cn=envoy-gateway,ou=services,dc=example,dc=com",
+							BindPassword: gwapiv1.SecretObjectReference{
+								Name: "ldap-bind-password",
+							},
+							BaseDN: "ou=users,dc=example,dc=com",
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"the connection to the LDAP server must use TLS: an ldaps:// url or startTLS must be specified",
+			},
+		},
+		{
+			desc: "basic auth with ldap url and startTLS",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					BasicAuth: &egv1a1.BasicAuth{
+						LDAP: &egv1a1.LDAPUserStore{
+							URL:      "ldap://ldap.example.com",
+							StartTLS: ptr.To(true),
+							BindDN:   "cn=envoy-gateway,ou=services,dc=example,dc=com",
+							BindPassword: gwapiv1.SecretObjectReference{
+								Name: "ldap-bind-password",
+							},
+							BaseDN: "ou=users,dc=example,dc=com",
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
 		{
 			desc: "target selectors without targetRefs or targetRef",
 			mutate: func(sp *egv1a1.SecurityPolicy) {
@@ -1290,7 +1510,7 @@ func TestSecurityPolicyTarget(t *testing.T) {
 					},
 				}
 			},
-			wantErrors: []string{"at least one of clientCIDRs, jwt, or headers must be specified"},
+			wantErrors: []string{"at least one of clientCIDRs, jwt, headers, clientCertificate, or expression must be specified"},
 		},
 		{
 			desc: "authorization-jwt-claims-without-jwt-authn",
@@ -1357,6 +1577,234 @@ func TestSecurityPolicyTarget(t *testing.T) {
 			},
 			wantErrors: []string{"at least one of claims or scopes must be specified"},
 		},
+		{
+			desc: "authorization-operation-paths-hosts-headers",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					Authorization: &egv1a1.Authorization{
+						Rules: []egv1a1.AuthorizationRule{
+							{
+								Action: egv1a1.AuthorizationActionDeny,
+								Operation: &egv1a1.Operation{
+									Paths: []egv1a1.StringMatch{
+										{
+											Type:  ptr.To(egv1a1.StringMatchPrefix),
+											Value: "/admin/",
+										},
+									},
+									Hosts: []gwapiv1.Hostname{"*.internal.example.com"},
+									Headers: []gwapiv1.HTTPHeaderMatch{
+										{
+											Name:  "x-debug",
+											Value: "true",
+										},
+									},
+								},
+								Principal: egv1a1.Principal{
+									ClientCIDRs: []egv1a1.CIDR{"0.0.0.0/0"},
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "authorization-empty-operation",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					Authorization: &egv1a1.Authorization{
+						Rules: []egv1a1.AuthorizationRule{
+							{
+								Action:    egv1a1.AuthorizationActionDeny,
+								Operation: &egv1a1.Operation{},
+								Principal: egv1a1.Principal{
+									ClientCIDRs: []egv1a1.CIDR{"0.0.0.0/0"},
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{"at least one of methods, paths, hosts, headers, or expression must be specified"},
+		},
+		{
+			desc: "authorization-expression",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					Authorization: &egv1a1.Authorization{
+						Rules: []egv1a1.AuthorizationRule{
+							{
+								Action: egv1a1.AuthorizationActionAllow,
+								Operation: &egv1a1.Operation{
+									Expression: ptr.To(egv1a1.CELExpression("request.size < 1024")),
+								},
+								Principal: egv1a1.Principal{
+									Expression: ptr.To(egv1a1.CELExpression("request.headers['x-trusted'] == 'true'")),
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "authorization-client-certificate",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					Authorization: &egv1a1.Authorization{
+						Rules: []egv1a1.AuthorizationRule{
+							{
+								Action: egv1a1.AuthorizationActionAllow,
+								Principal: egv1a1.Principal{
+									ClientCertificate: &egv1a1.ClientCertificatePrincipal{
+										URIs: []egv1a1.StringMatch{
+											{
+												Type:  ptr.To(egv1a1.StringMatchPrefix),
+												Value: "spiffe://cluster.local/ns/payments/",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "authorization-empty-client-certificate",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					Authorization: &egv1a1.Authorization{
+						Rules: []egv1a1.AuthorizationRule{
+							{
+								Action: egv1a1.AuthorizationActionAllow,
+								Principal: egv1a1.Principal{
+									ClientCertificate: &egv1a1.ClientCertificatePrincipal{},
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{"at least one of subjects, issuers, uris, dnsNames, or emails must be specified"},
+		},
+		{
+			desc: "policy-inline",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					Policy: &egv1a1.PolicyAuth{
+						Inline: ptr.To(egv1a1.CELExpression("request.headers['x-tenant'] == 'foo'")),
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "policy-inline-and-value-ref",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					Policy: &egv1a1.PolicyAuth{
+						Inline: ptr.To(egv1a1.CELExpression("request.headers['x-tenant'] == 'foo'")),
+						ValueRef: &gwapiv1.LocalObjectReference{
+							Kind: gwapiv1.Kind("ConfigMap"),
+							Name: gwapiv1.ObjectName("policy"),
+						},
+					},
+				}
+			},
+			wantErrors: []string{"exactly one of inline or valueRef must be set"},
+		},
+		{
+			desc: "policy-value-ref-not-configmap",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					Policy: &egv1a1.PolicyAuth{
+						ValueRef: &gwapiv1.LocalObjectReference{
+							Kind: gwapiv1.Kind("Secret"),
+							Name: gwapiv1.ObjectName("policy"),
+						},
+					},
+				}
+			},
+			wantErrors: []string{"Only a reference to an object of kind ConfigMap belonging to default v1 API group is supported."},
+		},
 		{
 			desc: "oidc-retry",
 			mutate: func(sp *egv1a1.SecurityPolicy) {